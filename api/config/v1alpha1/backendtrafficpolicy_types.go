@@ -0,0 +1,842 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// KindBackendTrafficPolicy is the name of the BackendTrafficPolicy kind.
+	KindBackendTrafficPolicy = "BackendTrafficPolicy"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// BackendTrafficPolicy configures the traffic between Envoy and the backends of
+// the targeted Gateway or HTTPRoute. A policy targeting an HTTPRoute takes
+// precedence over a policy targeting the Gateway the HTTPRoute is attached to.
+type BackendTrafficPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackendTrafficPolicySpec   `json:"spec,omitempty"`
+	Status BackendTrafficPolicyStatus `json:"status,omitempty"`
+}
+
+// BackendTrafficPolicySpec defines the desired state of BackendTrafficPolicy.
+type BackendTrafficPolicySpec struct {
+	// TargetRef identifies the Gateway or HTTPRoute the policy applies to. The
+	// target must be in the same namespace as the policy. When multiple policies
+	// target the same resource, the oldest policy takes precedence.
+	TargetRef gwapiv1a2.PolicyTargetReference `json:"targetRef"`
+
+	// Retry defines the retry policy of requests to the backends. If unspecified,
+	// requests are not retried.
+	//
+	// +optional
+	Retry *Retry `json:"retry,omitempty"`
+
+	// LoadBalancer defines the load balancing policy of requests to the backends.
+	// If unspecified, requests are load balanced round robin.
+	//
+	// +optional
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+
+	// CircuitBreaker defines the limits of the connections and requests from
+	// Envoy to the backends, beyond which requests fail fast instead of queueing.
+	// If unspecified, the Envoy default limits apply.
+	//
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+
+	// HealthCheck defines how the health of backend endpoints is determined.
+	// Unhealthy endpoints are removed from load balancing.
+	//
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+
+	// TLS defines how Envoy originates TLS connections to the backends. If
+	// unspecified, connections to the backends are not encrypted.
+	//
+	// +optional
+	TLS *BackendTLS `json:"tls,omitempty"`
+
+	// TCPKeepalive enables TCP keepalive on the connections from Envoy to the
+	// backends. If unspecified, TCP keepalive is disabled.
+	//
+	// +optional
+	TCPKeepalive *TCPKeepalive `json:"tcpKeepalive,omitempty"`
+
+	// RateLimit defines the rate limits of the requests to the backends. When
+	// the policy targets a Gateway, each route of the Gateway is limited
+	// separately. If unspecified, requests are not rate limited.
+	//
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// IPAccessControl restricts the clients allowed to send requests to the
+	// backends by address, in addition to the IPAccessControl of the
+	// ClientTrafficPolicy of the Gateway. Denied requests are rejected with a
+	// 403 status code. If unspecified, all clients are allowed.
+	//
+	// +optional
+	IPAccessControl *IPAccessControl `json:"ipAccessControl,omitempty"`
+
+	// FaultInjection injects faults into the requests to the backends, e.g. to
+	// run chaos experiments. If unspecified, no faults are injected.
+	//
+	// +optional
+	FaultInjection *FaultInjection `json:"faultInjection,omitempty"`
+
+	// Buffer limits the size of the request bodies forwarded to the backends.
+	// Requests are buffered until complete before being forwarded, and requests
+	// with larger bodies are rejected with a 413 status code. If unspecified,
+	// requests are streamed to the backends regardless of their size.
+	//
+	// +optional
+	Buffer *Buffer `json:"buffer,omitempty"`
+
+	// Upgrade defines the protocol upgrades allowed for the requests of the
+	// targeted routes. If unspecified, upgrades are not allowed.
+	//
+	// +optional
+	Upgrade *ProtocolUpgrade `json:"upgrade,omitempty"`
+
+	// ResponseHeaders modifies the headers of the responses of the backends of
+	// the targeted routes, like the ResponseHeaderModifier filter of newer
+	// Gateway API versions. If unspecified, the response headers are not
+	// modified.
+	//
+	// +optional
+	ResponseHeaders *HeaderModifier `json:"responseHeaders,omitempty"`
+
+	// Timeouts defines the timeouts of the requests of the targeted routes,
+	// like the timeouts of the HTTPRoute rules of newer Gateway API versions.
+	// If unspecified, the Envoy defaults are used.
+	//
+	// +optional
+	Timeouts *RouteTimeouts `json:"timeouts,omitempty"`
+}
+
+// RouteTimeouts defines the timeouts of the requests of a route. A timeout of 0s
+// disables the timeout, while negative timeouts are ignored.
+type RouteTimeouts struct {
+	// Request is the time allowed to respond to a request, from the request
+	// being received until the response is fully sent, including the retries.
+	// If unspecified, defaults to 15s.
+	//
+	// +optional
+	Request *metav1.Duration `json:"request,omitempty"`
+
+	// BackendRequest is the time allowed to each attempt of a request sent to
+	// the backends, i.e. the first attempt and each retry. It is capped to the
+	// Request timeout, and the PerTryTimeout of the Retry takes precedence over
+	// it. If unspecified, the Request timeout applies to all the attempts.
+	//
+	// +optional
+	BackendRequest *metav1.Duration `json:"backendRequest,omitempty"`
+}
+
+// HeaderModifier defines the headers set, added and removed. Header names are
+// case-insensitive, and only the first of the entries with the same name is
+// used.
+type HeaderModifier struct {
+	// Set overwrites the headers with the given names with the given values.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Set []gwapiv1a2.HTTPHeader `json:"set,omitempty"`
+
+	// Add appends the given values to the headers with the given names.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Add []gwapiv1a2.HTTPHeader `json:"add,omitempty"`
+
+	// Remove removes the headers with the given names.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Remove []gwapiv1a2.HTTPHeaderName `json:"remove,omitempty"`
+}
+
+// ProtocolUpgrade defines the protocol upgrades allowed for the requests of a route.
+type ProtocolUpgrade struct {
+	// WebSocket allows the requests to be upgraded to the WebSocket protocol,
+	// over HTTP/1.1 or HTTP/2. If unspecified, defaults to false.
+	//
+	// +optional
+	WebSocket *bool `json:"webSocket,omitempty"`
+
+	// Connect allows CONNECT requests, whose payload is tunneled to the
+	// backends over TCP connections. The CONNECT requests are routed by their
+	// headers, regardless of the path matches of the routes. If unspecified,
+	// defaults to false.
+	//
+	// +optional
+	Connect *bool `json:"connect,omitempty"`
+}
+
+// Buffer defines the buffering of request bodies.
+type Buffer struct {
+	// MaxRequestBytes is the maximum size of the request bodies, in bytes.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	MaxRequestBytes int64 `json:"maxRequestBytes"`
+}
+
+// FaultInjection defines the faults injected into requests. At least one of the
+// Delay or Abort fields must be specified.
+type FaultInjection struct {
+	// Delay delays requests before forwarding them to the backends.
+	//
+	// +optional
+	Delay *FaultInjectionDelay `json:"delay,omitempty"`
+
+	// Abort rejects requests with an HTTP status code instead of forwarding
+	// them to the backends.
+	//
+	// +optional
+	Abort *FaultInjectionAbort `json:"abort,omitempty"`
+}
+
+// FaultInjectionDelay defines the delay injected into requests.
+type FaultInjectionDelay struct {
+	// FixedDelay is the delay of the requests. It must be greater than zero.
+	FixedDelay metav1.Duration `json:"fixedDelay"`
+
+	// Percentage is the percentage of the requests delayed. If unspecified,
+	// defaults to 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+// FaultInjectionAbort defines the abort injected into requests.
+type FaultInjectionAbort struct {
+	// HTTPStatus is the status code of the responses of the aborted requests.
+	//
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	HTTPStatus int32 `json:"httpStatus"`
+
+	// Percentage is the percentage of the requests aborted. If unspecified,
+	// defaults to 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+// RateLimit defines the rate limits of requests.
+type RateLimit struct {
+	// Local limits the rate of requests independently in each Envoy proxy,
+	// using a token bucket per route. Requests above the limit are rejected
+	// with a 429 status code.
+	//
+	// +optional
+	Local *LocalRateLimit `json:"local,omitempty"`
+
+	// Global limits the rate of requests across all Envoy proxies, using the
+	// counters of the global rate limit service. Requests above the limit are
+	// rejected with a 429 status code. Global rate limits are ignored unless
+	// the rate limit service is enabled in the Envoy Gateway configuration.
+	//
+	// +optional
+	Global *GlobalRateLimit `json:"global,omitempty"`
+}
+
+// GlobalRateLimit defines the rate limits of requests enforced by the global rate
+// limit service.
+type GlobalRateLimit struct {
+	// Rules are the rate limits of the requests matching specific headers. A
+	// request matching several rules counts against each of them.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Rules []GlobalRateLimitRule `json:"rules"`
+}
+
+// GlobalRateLimitRule defines the rate limit of the requests matching all of the
+// headers.
+type GlobalRateLimitRule struct {
+	// Headers are the headers the requests must match. If unspecified, all
+	// requests match the rule.
+	//
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Headers []GlobalRateLimitHeaderMatch `json:"headers,omitempty"`
+
+	// Limit is the rate limit of the matching requests.
+	Limit RateLimitValue `json:"limit"`
+}
+
+// GlobalRateLimitHeaderMatch defines a header of a global rate limit rule.
+type GlobalRateLimitHeaderMatch struct {
+	// Name of the header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Value of the header. If unspecified, any value matches and each
+	// distinct value of the header is limited separately.
+	//
+	// +optional
+	Value *string `json:"value,omitempty"`
+}
+
+// LocalRateLimit defines the token buckets limiting the rate of requests in each
+// Envoy proxy.
+type LocalRateLimit struct {
+	// Limit is the rate limit of the requests not matching any rule.
+	Limit RateLimitValue `json:"limit"`
+
+	// Burst is the maximum number of requests allowed at once. If unspecified,
+	// defaults to the requests of the limit.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *uint32 `json:"burst,omitempty"`
+
+	// Rules are rate limits of the requests matching specific headers, in
+	// addition to the limit. The unit of a rule must not be shorter than the
+	// unit of the limit, otherwise the rule is ignored and the policy is not
+	// accepted.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Rules []LocalRateLimitRule `json:"rules,omitempty"`
+}
+
+// LocalRateLimitRule defines the rate limit of the requests matching all of the
+// headers.
+type LocalRateLimitRule struct {
+	// Headers are the headers the requests must match.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	Headers []RateLimitHeaderMatch `json:"headers"`
+
+	// Limit is the rate limit of the matching requests.
+	Limit RateLimitValue `json:"limit"`
+}
+
+// RateLimitHeaderMatch defines a header whose value is exactly matched.
+type RateLimitHeaderMatch struct {
+	// Name of the header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Value of the header.
+	Value string `json:"value"`
+}
+
+// RateLimitValue defines the number of requests allowed per unit of time.
+type RateLimitValue struct {
+	// Requests is the number of requests allowed per unit.
+	//
+	// +kubebuilder:validation:Minimum=1
+	Requests uint32 `json:"requests"`
+
+	// Unit is the unit of time of the limit.
+	Unit RateLimitUnit `json:"unit"`
+}
+
+// RateLimitUnit is the unit of time of a rate limit.
+//
+// +kubebuilder:validation:Enum=Second;Minute;Hour
+type RateLimitUnit string
+
+const (
+	// RateLimitUnitSecond limits the requests per second.
+	RateLimitUnitSecond RateLimitUnit = "Second"
+	// RateLimitUnitMinute limits the requests per minute.
+	RateLimitUnitMinute RateLimitUnit = "Minute"
+	// RateLimitUnitHour limits the requests per hour.
+	RateLimitUnitHour RateLimitUnit = "Hour"
+)
+
+// RetryOn is a condition under which a request to a backend is retried.
+//
+// +kubebuilder:validation:Enum="5xx";"gateway-error";"reset";"connect-failure";"retriable-4xx";"refused-stream";"retriable-status-codes";"cancelled";"deadline-exceeded";"internal";"resource-exhausted";"unavailable"
+type RetryOn string
+
+// Retry defines how requests to a backend are retried.
+type Retry struct {
+	// NumRetries is the number of retries of a request. If unspecified, defaults
+	// to 2.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NumRetries *int32 `json:"numRetries,omitempty"`
+
+	// RetryOn is the list of conditions under which a request is retried. If
+	// unspecified, requests are retried on connect failures, refused streams,
+	// and for gRPC, the "cancelled" and "unavailable" status codes.
+	//
+	// +optional
+	RetryOn []RetryOn `json:"retryOn,omitempty"`
+
+	// HTTPStatusCodes is the list of HTTP response status codes for which a
+	// request is retried, in addition to the RetryOn conditions. If unspecified,
+	// defaults to 503 when RetryOn is also unspecified.
+	//
+	// +optional
+	HTTPStatusCodes []HTTPStatus `json:"httpStatusCodes,omitempty"`
+
+	// PerTryTimeout is the timeout of each attempt of a request, including the
+	// first one. If unspecified, the route timeout applies to all attempts.
+	//
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+
+	// BackOff defines the exponential back-off between retries. If unspecified,
+	// the Envoy defaults of a 25ms base interval and a 250ms max interval apply.
+	//
+	// +optional
+	BackOff *BackOffPolicy `json:"backOff,omitempty"`
+}
+
+// HTTPStatus is an HTTP response status code.
+//
+// +kubebuilder:validation:Minimum=100
+// +kubebuilder:validation:Maximum=599
+type HTTPStatus int32
+
+// BackOffPolicy defines the exponential back-off between retries.
+type BackOffPolicy struct {
+	// BaseInterval is the base interval between retries.
+	BaseInterval metav1.Duration `json:"baseInterval"`
+
+	// MaxInterval is the maximum interval between retries. If unspecified,
+	// defaults to 10 times the BaseInterval.
+	//
+	// +optional
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+}
+
+// LoadBalancerType is the type of a load balancer.
+type LoadBalancerType string
+
+const (
+	// RoundRobinLoadBalancerType selects backend endpoints in turn.
+	RoundRobinLoadBalancerType LoadBalancerType = "RoundRobin"
+	// LeastRequestLoadBalancerType selects the backend endpoint with the fewest
+	// active requests out of a random sample of endpoints.
+	LeastRequestLoadBalancerType LoadBalancerType = "LeastRequest"
+	// RandomLoadBalancerType selects a random backend endpoint.
+	RandomLoadBalancerType LoadBalancerType = "Random"
+	// ConsistentHashLoadBalancerType sends requests with the same hash key to
+	// the same backend endpoint, e.g. for session affinity to stateful backends.
+	ConsistentHashLoadBalancerType LoadBalancerType = "ConsistentHash"
+)
+
+// LoadBalancer defines the load balancing policy of requests to the backends.
+type LoadBalancer struct {
+	// Type is the type of the load balancer. Supported types are "RoundRobin",
+	// "LeastRequest", "Random" and "ConsistentHash".
+	//
+	// +kubebuilder:validation:Enum=RoundRobin;LeastRequest;Random;ConsistentHash
+	Type LoadBalancerType `json:"type"`
+
+	// LeastRequest defines the configuration of the LeastRequest load balancer.
+	//
+	// +optional
+	LeastRequest *LeastRequest `json:"leastRequest,omitempty"`
+
+	// ConsistentHash defines the configuration of the ConsistentHash load
+	// balancer. Required when the type is "ConsistentHash".
+	//
+	// +optional
+	ConsistentHash *ConsistentHash `json:"consistentHash,omitempty"`
+}
+
+// LeastRequest defines the configuration of a least request load balancer.
+type LeastRequest struct {
+	// ChoiceCount is the number of random backend endpoints compared to select
+	// the one with the fewest active requests. If unspecified, defaults to 2.
+	//
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	ChoiceCount *int32 `json:"choiceCount,omitempty"`
+}
+
+// ConsistentHashType is the source of the hash key of a consistent hash load balancer.
+type ConsistentHashType string
+
+const (
+	// SourceIPConsistentHashType hashes the IP address of the client.
+	SourceIPConsistentHashType ConsistentHashType = "SourceIP"
+	// HeaderConsistentHashType hashes the value of a request header.
+	HeaderConsistentHashType ConsistentHashType = "Header"
+	// CookieConsistentHashType hashes the value of a cookie. The cookie is
+	// generated by Envoy if the request doesn't carry it.
+	CookieConsistentHashType ConsistentHashType = "Cookie"
+)
+
+// ConsistentHashAlgorithm is the hashing algorithm of a consistent hash load balancer.
+type ConsistentHashAlgorithm string
+
+const (
+	// RingHashConsistentHashAlgorithm is the Ketama ring hash algorithm.
+	RingHashConsistentHashAlgorithm ConsistentHashAlgorithm = "RingHash"
+	// MaglevConsistentHashAlgorithm is the Maglev algorithm, which has faster
+	// lookups and table builds than the ring hash at the cost of stability when
+	// endpoints are added or removed.
+	MaglevConsistentHashAlgorithm ConsistentHashAlgorithm = "Maglev"
+)
+
+// ConsistentHash defines the configuration of a consistent hash load balancer.
+type ConsistentHash struct {
+	// Type is the source of the hash key. Supported types are "SourceIP",
+	// "Header" and "Cookie".
+	//
+	// +kubebuilder:validation:Enum=SourceIP;Header;Cookie
+	Type ConsistentHashType `json:"type"`
+
+	// Algorithm is the hashing algorithm. Supported algorithms are "RingHash"
+	// and "Maglev". If unspecified, defaults to "RingHash".
+	//
+	// +kubebuilder:validation:Enum=RingHash;Maglev
+	// +optional
+	Algorithm *ConsistentHashAlgorithm `json:"algorithm,omitempty"`
+
+	// Header defines the request header to hash. Required when the type is "Header".
+	//
+	// +optional
+	Header *HeaderHash `json:"header,omitempty"`
+
+	// Cookie defines the cookie to hash. Required when the type is "Cookie".
+	//
+	// +optional
+	Cookie *CookieHash `json:"cookie,omitempty"`
+}
+
+// HeaderHash defines the request header used as the hash key.
+type HeaderHash struct {
+	// Name is the name of the request header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// CookieHash defines the cookie used as the hash key.
+type CookieHash struct {
+	// Name is the name of the cookie.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// TTL is the lifetime of the cookie generated by Envoy when a request
+	// doesn't carry it. If unspecified, no cookie is generated and requests
+	// without the cookie are not hashed.
+	//
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Path is the path of the generated cookie. If unspecified, the cookie
+	// applies to all paths.
+	//
+	// +optional
+	Path *string `json:"path,omitempty"`
+}
+
+// CircuitBreaker defines the limits of the connections and requests to the
+// backends. Unless stated otherwise, each limit defaults to 1024 if
+// unspecified.
+type CircuitBreaker struct {
+	// MaxConnections is the maximum number of connections to the backends.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+
+	// MaxPendingRequests is the maximum number of requests queued while waiting
+	// for a connection to the backends.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	MaxPendingRequests *int64 `json:"maxPendingRequests,omitempty"`
+
+	// MaxParallelRequests is the maximum number of parallel requests to the backends.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	MaxParallelRequests *int64 `json:"maxParallelRequests,omitempty"`
+
+	// MaxParallelRetries is the maximum number of parallel retries to the
+	// backends. If unspecified, defaults to 3.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	MaxParallelRetries *int64 `json:"maxParallelRetries,omitempty"`
+}
+
+// HealthCheck defines how the health of backend endpoints is determined.
+type HealthCheck struct {
+	// Active defines the active health check, which periodically probes the
+	// backend endpoints. If unspecified, endpoints are not actively probed.
+	//
+	// +optional
+	Active *ActiveHealthCheck `json:"active,omitempty"`
+
+	// Passive defines the passive health check, also known as outlier
+	// detection, which ejects endpoints based on the responses of the requests
+	// sent to them. If unspecified, endpoints returning 5 consecutive 5xx
+	// responses are ejected, per the Envoy defaults.
+	//
+	// +optional
+	Passive *PassiveHealthCheck `json:"passive,omitempty"`
+}
+
+// ActiveHealthCheckerType is the type of an active health checker.
+type ActiveHealthCheckerType string
+
+const (
+	// HTTPActiveHealthCheckerType probes endpoints with HTTP requests.
+	HTTPActiveHealthCheckerType ActiveHealthCheckerType = "HTTP"
+	// TCPActiveHealthCheckerType probes endpoints by opening TCP connections.
+	TCPActiveHealthCheckerType ActiveHealthCheckerType = "TCP"
+)
+
+// ActiveHealthCheck defines the active health check of backend endpoints.
+type ActiveHealthCheck struct {
+	// Type is the type of the health checker. Supported types are "HTTP" and "TCP".
+	//
+	// +kubebuilder:validation:Enum=HTTP;TCP
+	Type ActiveHealthCheckerType `json:"type"`
+
+	// Timeout is the time to wait for a health check response. If unspecified,
+	// defaults to 1s.
+	//
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Interval is the time between health checks. If unspecified, defaults to 3s.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successful health checks
+	// after which an unhealthy endpoint is marked healthy. If unspecified,
+	// defaults to 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HealthyThreshold *int32 `json:"healthyThreshold,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed health checks
+	// after which a healthy endpoint is marked unhealthy. If unspecified,
+	// defaults to 3.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UnhealthyThreshold *int32 `json:"unhealthyThreshold,omitempty"`
+
+	// HTTP defines the configuration of the HTTP health checker. Required when
+	// the type is "HTTP".
+	//
+	// +optional
+	HTTP *HTTPActiveHealthChecker `json:"http,omitempty"`
+}
+
+// HTTPActiveHealthChecker defines the configuration of an HTTP health checker.
+type HTTPActiveHealthChecker struct {
+	// Path is the path of the health check requests.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// ExpectedStatuses is the list of response status codes of healthy
+	// endpoints. If unspecified, defaults to 200.
+	//
+	// +optional
+	ExpectedStatuses []HTTPStatus `json:"expectedStatuses,omitempty"`
+}
+
+// PassiveHealthCheck defines the outlier detection of backend endpoints.
+type PassiveHealthCheck struct {
+	// Consecutive5xxErrors is the number of consecutive 5xx responses, including
+	// locally originated errors such as connection failures, after which an
+	// endpoint is ejected. If unspecified, defaults to 5.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Consecutive5xxErrors *int32 `json:"consecutive5xxErrors,omitempty"`
+
+	// Interval is the time between ejection analysis sweeps. If unspecified,
+	// defaults to 10s.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// BaseEjectionTime is the base duration of an ejection. An endpoint is
+	// ejected for the base duration multiplied by the number of times it has
+	// been ejected. If unspecified, defaults to 30s.
+	//
+	// +optional
+	BaseEjectionTime *metav1.Duration `json:"baseEjectionTime,omitempty"`
+
+	// MaxEjectionPercent is the maximum percentage of the endpoints of a backend
+	// that can be ejected at the same time. If unspecified, defaults to 10.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// BackendTLS defines the TLS connections originated by Envoy to the backends.
+type BackendTLS struct {
+	// SNI is the server name sent to the backends in the TLS handshake. If
+	// unspecified, no server name is sent.
+	//
+	// +optional
+	SNI *gwapiv1a2.PreciseHostname `json:"sni,omitempty"`
+
+	// SubjectAltNames is the list of subject alternative names, one of which
+	// the certificate presented by the backends must match. If unspecified,
+	// the subject alternative names of the certificate are not verified.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	SubjectAltNames []string `json:"subjectAltNames,omitempty"`
+
+	// CACertificateRef references a ConfigMap or Secret in the namespace of
+	// the policy containing the CA bundle used to verify the certificate
+	// presented by the backends, under the "ca.crt" key. If unspecified, the
+	// certificate is verified using the system CA bundle of the Envoy proxy.
+	//
+	// +optional
+	CACertificateRef *CACertificateReference `json:"caCertificateRef,omitempty"`
+}
+
+// CACertificateReference references a ConfigMap or Secret containing a CA bundle.
+type CACertificateReference struct {
+	// Kind is the kind of the referent.
+	//
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +kubebuilder:default=ConfigMap
+	// +optional
+	Kind *string `json:"kind,omitempty"`
+
+	// Name is the name of the referent.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// TCPKeepalive defines the TCP keepalive of connections. Unspecified fields use
+// the operating system defaults.
+type TCPKeepalive struct {
+	// Probes is the number of unacknowledged probes sent before the connection
+	// is considered dead.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Probes *int32 `json:"probes,omitempty"`
+
+	// IdleTime is the duration a connection must be idle before the first
+	// probe is sent. The duration is rounded up to whole seconds.
+	//
+	// +optional
+	IdleTime *metav1.Duration `json:"idleTime,omitempty"`
+
+	// Interval is the duration between probes. The duration is rounded up to
+	// whole seconds.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// IPAccessControl defines access control based on the address of the client. For
+// HTTP requests, the address is the one detected by the client IP detection
+// settings of the ClientTrafficPolicy. A client is allowed if its address is not
+// in Deny and, if Allow is specified, is in Allow. If a CIDR is invalid, all
+// clients are denied.
+type IPAccessControl struct {
+	// Allow is the list of address ranges of the clients allowed. If
+	// specified, clients outside of these ranges are denied.
+	//
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Allow []CIDR `json:"allow,omitempty"`
+
+	// Deny is the list of address ranges of the clients denied, even if they
+	// are allowed.
+	//
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Deny []CIDR `json:"deny,omitempty"`
+}
+
+// CIDR is an IPv4 or IPv6 address range in CIDR notation, e.g. "10.0.0.0/8" or
+// "2001:db8::/32".
+//
+// +kubebuilder:validation:MinLength=1
+// +kubebuilder:validation:MaxLength=64
+// +kubebuilder:validation:Pattern=`^(([0-9]{1,3}\.){3}[0-9]{1,3}/([0-9]|[12][0-9]|3[0-2])|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*/([0-9]|[1-9][0-9]|1[01][0-9]|12[0-8]))$`
+type CIDR string
+
+// BackendTrafficPolicyStatus defines the observed state of BackendTrafficPolicy.
+type BackendTrafficPolicyStatus struct {
+	// Conditions describe the current conditions of the BackendTrafficPolicy.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted"
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// BackendTrafficPolicyConditionType is a type of condition of a BackendTrafficPolicy.
+type BackendTrafficPolicyConditionType string
+
+// BackendTrafficPolicyConditionReason is a reason of a condition of a BackendTrafficPolicy.
+type BackendTrafficPolicyConditionReason string
+
+const (
+	// BackendTrafficPolicyConditionAccepted indicates whether the
+	// BackendTrafficPolicy is valid and applied as specified.
+	BackendTrafficPolicyConditionAccepted BackendTrafficPolicyConditionType = "Accepted"
+
+	// BackendTrafficPolicyReasonAccepted is used with the "Accepted" condition
+	// when the policy is valid.
+	BackendTrafficPolicyReasonAccepted BackendTrafficPolicyConditionReason = "Accepted"
+
+	// BackendTrafficPolicyReasonInvalid is used with the "Accepted" condition
+	// when a setting of the policy is invalid. The message of the condition
+	// describes how the invalid setting is applied.
+	BackendTrafficPolicyReasonInvalid BackendTrafficPolicyConditionReason = "Invalid"
+)
+
+//+kubebuilder:object:root=true
+
+// BackendTrafficPolicyList contains a list of BackendTrafficPolicy
+type BackendTrafficPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackendTrafficPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackendTrafficPolicy{}, &BackendTrafficPolicyList{})
+}
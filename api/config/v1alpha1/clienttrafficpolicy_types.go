@@ -0,0 +1,684 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// KindClientTrafficPolicy is the name of the ClientTrafficPolicy kind.
+	KindClientTrafficPolicy = "ClientTrafficPolicy"
+)
+
+//+kubebuilder:object:root=true
+
+// ClientTrafficPolicy configures the traffic between the clients and the
+// listeners of the targeted Gateway.
+type ClientTrafficPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClientTrafficPolicySpec `json:"spec,omitempty"`
+}
+
+// ClientTrafficPolicySpec defines the desired state of ClientTrafficPolicy.
+type ClientTrafficPolicySpec struct {
+	// TargetRef identifies the Gateway the policy applies to. The target must
+	// be in the same namespace as the policy. When multiple policies target the
+	// same Gateway, the oldest policy takes precedence.
+	TargetRef gwapiv1a2.PolicyTargetReference `json:"targetRef"`
+
+	// TLS defines the TLS settings of the HTTPS listeners of the Gateway,
+	// including the accepted TLS versions and cipher suites.
+	//
+	// +optional
+	TLS *ClientTLS `json:"tls,omitempty"`
+
+	// HTTP3 enables HTTP/3 on the HTTPS listeners of the Gateway. Each HTTPS
+	// listener is also served over QUIC on the same UDP port, and advertised
+	// to clients in the alt-svc response header. Exposing both TCP and UDP
+	// ports on a LoadBalancer Service requires a cluster supporting
+	// mixed-protocol load balancers. If unspecified, HTTP/3 is disabled.
+	//
+	// +optional
+	HTTP3 *HTTP3Settings `json:"http3,omitempty"`
+
+	// HTTP1 defines the HTTP/1.1 settings of the HTTP and HTTPS listeners of
+	// the Gateway, which also apply to the connections to the backends of
+	// their routes. If unspecified, the Envoy defaults are used.
+	//
+	// +optional
+	HTTP1 *HTTP1Settings `json:"http1,omitempty"`
+
+	// ClientIPDetection defines how the address of the client is detected
+	// for requests received by the HTTP and HTTPS listeners of the Gateway,
+	// e.g. when the Gateway is deployed behind a CDN or load balancer. If
+	// unspecified, the address of the client is the last address of the
+	// X-Forwarded-For header, which is not appended to.
+	//
+	// +optional
+	ClientIPDetection *ClientIPDetectionSettings `json:"clientIPDetection,omitempty"`
+
+	// Timeouts defines the timeouts of the connections and requests of the
+	// clients of the HTTP and HTTPS listeners of the Gateway. If unspecified,
+	// the Envoy defaults are used.
+	//
+	// +optional
+	Timeouts *ClientTimeouts `json:"timeouts,omitempty"`
+
+	// MaxRequestHeadersKB is the maximum size of the request headers in KiB
+	// accepted by the HTTP and HTTPS listeners of the Gateway. Requests with
+	// larger headers are rejected with a 431 status code. If unspecified,
+	// defaults to 60.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8192
+	// +optional
+	MaxRequestHeadersKB *int32 `json:"maxRequestHeadersKB,omitempty"`
+
+	// TCPKeepalive enables TCP keepalive on the connections of the clients of
+	// the listeners of the Gateway, so that idle long-lived connections are
+	// kept open by NAT devices and dead peers are detected. If unspecified,
+	// TCP keepalive is disabled.
+	//
+	// +optional
+	TCPKeepalive *TCPKeepalive `json:"tcpKeepalive,omitempty"`
+
+	// SocketOptions are additional socket options set on the listening sockets
+	// of the listeners of the Gateway. The options are passed to setsockopt(2)
+	// as is, so they are only portable across proxies running on the same
+	// operating system.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	SocketOptions []SocketOption `json:"socketOptions,omitempty"`
+
+	// Compression compresses the responses sent to the clients of the HTTP
+	// and HTTPS listeners of the Gateway, using an algorithm accepted by the
+	// client in the Accept-Encoding request header. If unspecified, responses
+	// are not compressed.
+	//
+	// +optional
+	Compression *Compression `json:"compression,omitempty"`
+
+	// IPAccessControl restricts the clients of the listeners of the Gateway by
+	// address. Denied requests of HTTP and HTTPS listeners are rejected with a
+	// 403 status code, and denied connections of TCP and TLS listeners are
+	// closed. If unspecified, all clients are allowed.
+	//
+	// +optional
+	IPAccessControl *IPAccessControl `json:"ipAccessControl,omitempty"`
+
+	// LocalReply customizes the responses generated by Envoy for the requests
+	// of the HTTP and HTTPS listeners of the Gateway, e.g. the 503 responses
+	// when no backend is healthy or the 404 responses when no route matches.
+	// If unspecified, Envoy generates plain text responses.
+	//
+	// +optional
+	LocalReply *LocalReply `json:"localReply,omitempty"`
+
+	// HSTS adds the Strict-Transport-Security header to the responses of the
+	// HTTPS listeners of the Gateway, instructing browsers to only connect to
+	// the hostnames of the listeners over HTTPS. If unspecified, the header is
+	// not added.
+	//
+	// +optional
+	HSTS *HSTS `json:"hsts,omitempty"`
+
+	// ResponseHeaders defines the handling of the headers of the responses
+	// sent to the clients by the HTTP and HTTPS listeners of the Gateway, e.g.
+	// to hide the implementation details of Envoy and the backends. It takes
+	// precedence over the ResponseHeaders of the EnvoyProxy, except that the
+	// removed headers of both are removed. If unspecified, the response
+	// headers are not modified.
+	//
+	// +optional
+	ResponseHeaders *ResponseHeaderSettings `json:"responseHeaders,omitempty"`
+
+	// RequestHeaders defines the headers set and added to the requests
+	// received by the HTTP and HTTPS listeners of the Gateway, before they are
+	// routed. If unspecified, the request headers are not modified.
+	//
+	// +optional
+	RequestHeaders *RequestHeaderSettings `json:"requestHeaders,omitempty"`
+}
+
+// RequestHeaderSettings defines the headers set and added to the requests
+// received from the clients. Header names are case-insensitive, and only the
+// first of the entries with the same name is used.
+type RequestHeaderSettings struct {
+	// Set overwrites the headers with the given names with the given values,
+	// e.g. to prevent the clients from setting them.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Set []gwapiv1a2.HTTPHeader `json:"set,omitempty"`
+
+	// Add appends the given values to the headers with the given names.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Add []gwapiv1a2.HTTPHeader `json:"add,omitempty"`
+}
+
+// ResponseHeaderSettings defines the handling of the headers of the responses sent
+// to the clients.
+type ResponseHeaderSettings struct {
+	// ServerHeader defines the Server header of the responses. If unspecified,
+	// Envoy sets the Server header to "envoy".
+	//
+	// +optional
+	ServerHeader *ServerHeader `json:"serverHeader,omitempty"`
+
+	// RemoveHeaders are the names of the headers removed from the responses,
+	// e.g. "x-powered-by". Names are case-insensitive.
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	RemoveHeaders []string `json:"removeHeaders,omitempty"`
+}
+
+// ServerHeaderTransformation defines how Envoy handles the Server header of the
+// responses of the backends.
+//
+// +kubebuilder:validation:Enum=Overwrite;AppendIfAbsent;PassThrough
+type ServerHeaderTransformation string
+
+const (
+	// ServerHeaderTransformationOverwrite overwrites the Server header of the
+	// responses with the Value.
+	ServerHeaderTransformationOverwrite ServerHeaderTransformation = "Overwrite"
+	// ServerHeaderTransformationAppendIfAbsent sets the Server header to the
+	// Value for the responses without a Server header.
+	ServerHeaderTransformationAppendIfAbsent ServerHeaderTransformation = "AppendIfAbsent"
+	// ServerHeaderTransformationPassThrough passes the Server header of the
+	// responses through, and doesn't add one to the responses without it.
+	ServerHeaderTransformationPassThrough ServerHeaderTransformation = "PassThrough"
+)
+
+// ServerHeader defines the Server header of the responses.
+type ServerHeader struct {
+	// Transformation defines how the Server header of the responses of the
+	// backends is handled. If unspecified, defaults to "Overwrite".
+	//
+	// +optional
+	Transformation *ServerHeaderTransformation `json:"transformation,omitempty"`
+
+	// Value is the value of the Server header set by Envoy. If unspecified,
+	// defaults to "envoy".
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Value *string `json:"value,omitempty"`
+}
+
+// HSTS defines the Strict-Transport-Security header, see
+// https://www.rfc-editor.org/rfc/rfc6797.
+type HSTS struct {
+	// MaxAge is the time, in seconds, browsers only connect over HTTPS after
+	// receiving the header. A MaxAge of 0 instructs browsers to stop enforcing
+	// HTTPS.
+	//
+	// +kubebuilder:validation:Minimum=0
+	MaxAge int64 `json:"maxAge"`
+
+	// IncludeSubdomains also enforces HTTPS for the subdomains of the hostnames.
+	// If unspecified, defaults to false.
+	//
+	// +optional
+	IncludeSubdomains *bool `json:"includeSubdomains,omitempty"`
+
+	// Preload allows the hostnames to be included in the HSTS preload lists of
+	// browsers. If unspecified, defaults to false.
+	//
+	// +optional
+	Preload *bool `json:"preload,omitempty"`
+}
+
+// LocalReply defines the customization of the responses generated by Envoy.
+type LocalReply struct {
+	// Mappers rewrite the status code and body of the responses matching
+	// their status codes. The first matching mapper applies.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Mappers []LocalReplyMapper `json:"mappers,omitempty"`
+
+	// BodyFormat formats the bodies of all the responses, after the mappers
+	// apply. If unspecified, the bodies are returned as plain text.
+	//
+	// +optional
+	BodyFormat *LocalReplyBodyFormat `json:"bodyFormat,omitempty"`
+}
+
+// LocalReplyMapper rewrites the responses generated by Envoy matching its status
+// codes.
+type LocalReplyMapper struct {
+	// StatusCodes is the list of status codes of the responses rewritten.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	StatusCodes []HTTPStatus `json:"statusCodes"`
+
+	// StatusCode replaces the status code of the responses. If unspecified,
+	// the status code is kept.
+	//
+	// +optional
+	StatusCode *HTTPStatus `json:"statusCode,omitempty"`
+
+	// Body replaces the body of the responses. If unspecified, the body is
+	// kept.
+	//
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	Body *string `json:"body,omitempty"`
+}
+
+// LocalReplyBodyFormatType is the type of the format of the bodies of the
+// responses generated by Envoy.
+//
+// +kubebuilder:validation:Enum=Text;JSON
+type LocalReplyBodyFormatType string
+
+const (
+	// LocalReplyBodyFormatTypeText formats the bodies as text.
+	LocalReplyBodyFormatTypeText LocalReplyBodyFormatType = "Text"
+	// LocalReplyBodyFormatTypeJSON formats the bodies as JSON objects.
+	LocalReplyBodyFormatTypeJSON LocalReplyBodyFormatType = "JSON"
+)
+
+// LocalReplyBodyFormat defines the format of the bodies of the responses generated
+// by Envoy. The formats may contain the command operators of the Envoy access log
+// formats, e.g. %LOCAL_REPLY_BODY% or %RESPONSE_CODE%, see
+// https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#command-operators.
+// Only the format matching the Type may be specified.
+type LocalReplyBodyFormat struct {
+	// Type is the type of the format.
+	Type LocalReplyBodyFormatType `json:"type"`
+
+	// Text is the format of the text bodies, e.g. "error: %LOCAL_REPLY_BODY%".
+	//
+	// +optional
+	Text *string `json:"text,omitempty"`
+
+	// JSON is the format of the fields of the JSON bodies, e.g.
+	// {"message": "%LOCAL_REPLY_BODY%", "code": "%RESPONSE_CODE%"}.
+	//
+	// +kubebuilder:validation:MaxProperties=32
+	// +optional
+	JSON map[string]string `json:"json,omitempty"`
+
+	// ContentType replaces the content type of the responses. If unspecified,
+	// defaults to "text/plain" for text bodies and "application/json" for JSON
+	// bodies.
+	//
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+}
+
+// Compression defines the compression of the responses sent to the clients.
+type Compression struct {
+	// Algorithms is the list of compression algorithms supported, in order of
+	// preference when a client accepts several of them equally.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	Algorithms []CompressionAlgorithm `json:"algorithms"`
+
+	// ContentTypes is the list of content types of the responses compressed,
+	// e.g. "application/json". If unspecified, the Envoy defaults are used,
+	// covering the common text, JSON, JavaScript and XML content types.
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	ContentTypes []string `json:"contentTypes,omitempty"`
+
+	// MinContentLength is the minimum length in bytes of the responses
+	// compressed. If unspecified, defaults to 30.
+	//
+	// +optional
+	MinContentLength *uint32 `json:"minContentLength,omitempty"`
+
+	// Level is the trade-off between the speed and the ratio of the
+	// compression. If unspecified, defaults to Default.
+	//
+	// +kubebuilder:default=Default
+	// +optional
+	Level *CompressionLevel `json:"level,omitempty"`
+}
+
+// CompressionAlgorithm is an algorithm compressing the responses.
+//
+// +kubebuilder:validation:Enum=Gzip;Brotli
+type CompressionAlgorithm string
+
+const (
+	// CompressionAlgorithmGzip is the gzip algorithm.
+	CompressionAlgorithmGzip CompressionAlgorithm = "Gzip"
+	// CompressionAlgorithmBrotli is the brotli algorithm.
+	CompressionAlgorithmBrotli CompressionAlgorithm = "Brotli"
+)
+
+// CompressionLevel is the trade-off between the speed and the ratio of the
+// compression.
+//
+// +kubebuilder:validation:Enum=BestSpeed;Default;BestCompression
+type CompressionLevel string
+
+const (
+	// CompressionLevelBestSpeed favors the speed of the compression.
+	CompressionLevelBestSpeed CompressionLevel = "BestSpeed"
+	// CompressionLevelDefault is the default level of each algorithm.
+	CompressionLevelDefault CompressionLevel = "Default"
+	// CompressionLevelBestCompression favors the ratio of the compression.
+	CompressionLevelBestCompression CompressionLevel = "BestCompression"
+)
+
+// SocketOption defines an integer socket option.
+type SocketOption struct {
+	// Level is the protocol level of the option, e.g. 1 for SOL_SOCKET or 6
+	// for IPPROTO_TCP on Linux.
+	Level int64 `json:"level"`
+
+	// Name is the name of the option, e.g. 9 for SO_KEEPALIVE on Linux.
+	Name int64 `json:"name"`
+
+	// Value is the integer value of the option.
+	Value int64 `json:"value"`
+
+	// State is the state of the socket in which the option is set.
+	//
+	// +kubebuilder:default=Prebind
+	// +optional
+	State *SocketOptionState `json:"state,omitempty"`
+}
+
+// SocketOptionState is the state of a socket in which a socket option is set.
+//
+// +kubebuilder:validation:Enum=Prebind;Bound;Listening
+type SocketOptionState string
+
+const (
+	// SocketOptionStatePrebind sets the option before the socket is bound.
+	SocketOptionStatePrebind SocketOptionState = "Prebind"
+	// SocketOptionStateBound sets the option after the socket is bound.
+	SocketOptionStateBound SocketOptionState = "Bound"
+	// SocketOptionStateListening sets the option after the socket is listening.
+	SocketOptionStateListening SocketOptionState = "Listening"
+)
+
+// ClientTimeouts defines the timeouts of the connections and requests of the
+// clients. A timeout of 0s disables the timeout.
+type ClientTimeouts struct {
+	// IdleTimeout is the time after which a connection without active
+	// requests is closed. If unspecified, defaults to 1h.
+	//
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// StreamIdleTimeout is the time after which a request without activity,
+	// i.e. without data received or sent, is reset. If unspecified, defaults
+	// to 5m.
+	//
+	// +optional
+	StreamIdleTimeout *metav1.Duration `json:"streamIdleTimeout,omitempty"`
+
+	// RequestTimeout is the time allowed to receive a complete request,
+	// guarding against slow clients. If unspecified, requests are not timed
+	// out.
+	//
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+}
+
+// ClientIPDetectionSettings defines how the address of the client is detected.
+// Only one of XForwardedFor or CustomHeader may be specified.
+type ClientIPDetectionSettings struct {
+	// XForwardedFor detects the address of the client from the
+	// X-Forwarded-For header, trusting the provided number of proxies in
+	// front of the Gateway.
+	//
+	// +optional
+	XForwardedFor *XForwardedForSettings `json:"xForwardedFor,omitempty"`
+
+	// CustomHeader detects the address of the client from a custom header,
+	// e.g. the header set by a CDN.
+	//
+	// +optional
+	CustomHeader *CustomHeaderExtensionSettings `json:"customHeader,omitempty"`
+}
+
+// XForwardedForSettings defines how the address of the client is detected from
+// the X-Forwarded-For header.
+type XForwardedForSettings struct {
+	// NumTrustedHops is the number of proxies in front of the Gateway that
+	// append their peer address to the X-Forwarded-For header. The address
+	// of the client is the address at this position from the end of the
+	// header, or the peer address of the connection if it's 0. If
+	// unspecified, defaults to 0.
+	//
+	// +optional
+	NumTrustedHops *uint32 `json:"numTrustedHops,omitempty"`
+
+	// Append appends the peer address of the connection to the
+	// X-Forwarded-For header of requests forwarded to the backends. If
+	// unspecified, defaults to true.
+	//
+	// +optional
+	Append *bool `json:"append,omitempty"`
+}
+
+// CustomHeaderExtensionSettings defines how the address of the client is detected
+// from a custom header.
+type CustomHeaderExtensionSettings struct {
+	// Name is the name of the header containing the address of the client.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+
+	// FailClosed rejects requests without a valid address in the header with
+	// a 403 response. If unspecified, the peer address of the connection is
+	// used for such requests.
+	//
+	// +optional
+	FailClosed *bool `json:"failClosed,omitempty"`
+}
+
+// HTTP3Settings defines the HTTP/3 settings of the HTTPS listeners.
+type HTTP3Settings struct {
+}
+
+// HTTP1Settings defines the HTTP/1.1 settings of the HTTP and HTTPS listeners.
+type HTTP1Settings struct {
+	// HeaderCase is the casing of the header names sent to the clients and
+	// the backends, for legacy applications failing on lowercase header names.
+	// If unspecified, the header names are lowercased.
+	//
+	// +optional
+	HeaderCase *HeaderCase `json:"headerCase,omitempty"`
+}
+
+// HeaderCase is the casing of HTTP/1.1 header names.
+//
+// +kubebuilder:validation:Enum=PreserveCase;ProperCase
+type HeaderCase string
+
+const (
+	// HeaderCasePreserve preserves the casing of the header names as received
+	// from the clients and the backends. Header names added by Envoy are
+	// lowercase.
+	HeaderCasePreserve HeaderCase = "PreserveCase"
+	// HeaderCaseProper capitalizes the first letter of the words of the header
+	// names, e.g. "Content-Type".
+	HeaderCaseProper HeaderCase = "ProperCase"
+)
+
+// ClientTLS defines the TLS settings of the connections from the clients.
+type ClientTLS struct {
+	// ClientValidation defines how the certificates presented by the clients
+	// are verified. If unspecified, clients are not asked for certificates.
+	//
+	// +optional
+	ClientValidation *ClientValidation `json:"clientValidation,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted from the clients. If
+	// unspecified, the Envoy default (1.2) is used.
+	//
+	// +optional
+	MinVersion *TLSVersion `json:"minVersion,omitempty"`
+
+	// MaxVersion is the maximum TLS version accepted from the clients. If
+	// unspecified, the Envoy default (1.3) is used.
+	//
+	// +optional
+	MaxVersion *TLSVersion `json:"maxVersion,omitempty"`
+
+	// Ciphers is the list of cipher suites supported for TLS 1.2 and below,
+	// e.g. "ECDHE-RSA-AES128-GCM-SHA256". The cipher suites of TLS 1.3 are not
+	// configurable. If unspecified, the Envoy defaults are used.
+	//
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Ciphers []string `json:"ciphers,omitempty"`
+
+	// ECDHCurves is the list of supported ECDH curves, e.g. "X25519" or
+	// "P-256". If unspecified, the Envoy defaults are used.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	ECDHCurves []string `json:"ecdhCurves,omitempty"`
+
+	// ALPNProtocols is the list of application protocols advertised to the
+	// clients over ALPN, in order of preference. If unspecified, no
+	// application protocol is negotiated and clients use HTTP/1.1.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	ALPNProtocols []ALPNProtocol `json:"alpnProtocols,omitempty"`
+}
+
+// TLSVersion is a TLS protocol version.
+//
+// +kubebuilder:validation:Enum="1.0";"1.1";"1.2";"1.3"
+type TLSVersion string
+
+const (
+	// TLSv10 is TLS 1.0.
+	TLSv10 TLSVersion = "1.0"
+	// TLSv11 is TLS 1.1.
+	TLSv11 TLSVersion = "1.1"
+	// TLSv12 is TLS 1.2.
+	TLSv12 TLSVersion = "1.2"
+	// TLSv13 is TLS 1.3.
+	TLSv13 TLSVersion = "1.3"
+)
+
+// ALPNProtocol is an application protocol negotiated over ALPN.
+//
+// +kubebuilder:validation:Enum=h2;http/1.1
+type ALPNProtocol string
+
+const (
+	// ALPNProtocolHTTP2 is HTTP/2.
+	ALPNProtocolHTTP2 ALPNProtocol = "h2"
+	// ALPNProtocolHTTP11 is HTTP/1.1.
+	ALPNProtocolHTTP11 ALPNProtocol = "http/1.1"
+)
+
+// ClientValidation defines how client certificates are verified.
+type ClientValidation struct {
+	// CACertificateRef references a ConfigMap or Secret in the namespace of
+	// the policy containing the CA bundle used to verify client certificates,
+	// under the "ca.crt" key. If the referent also contains a "ca.crl" key, it
+	// is used as the certificate revocation list of the CA bundle.
+	CACertificateRef CACertificateReference `json:"caCertificateRef"`
+
+	// Optional allows clients that don't present a certificate to connect.
+	// Certificates presented by clients are verified either way.
+	//
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+
+	// ForwardClientCertDetails defines how the details of the client
+	// certificate are forwarded to the backends in the
+	// x-forwarded-client-cert header. If unspecified, the header is removed
+	// from requests.
+	//
+	// +optional
+	ForwardClientCertDetails *ForwardClientCertDetails `json:"forwardClientCertDetails,omitempty"`
+}
+
+// ForwardClientCertMode is the handling of the x-forwarded-client-cert header.
+//
+// +kubebuilder:validation:Enum=Sanitize;ForwardOnly;AppendForward;SanitizeSet;AlwaysForwardOnly
+type ForwardClientCertMode string
+
+const (
+	// ForwardClientCertModeSanitize removes the header from requests.
+	ForwardClientCertModeSanitize ForwardClientCertMode = "Sanitize"
+	// ForwardClientCertModeForwardOnly forwards the header of mutual TLS
+	// requests unchanged.
+	ForwardClientCertModeForwardOnly ForwardClientCertMode = "ForwardOnly"
+	// ForwardClientCertModeAppendForward appends the details of the client
+	// certificate to the header of mutual TLS requests.
+	ForwardClientCertModeAppendForward ForwardClientCertMode = "AppendForward"
+	// ForwardClientCertModeSanitizeSet replaces the header of mutual TLS
+	// requests with the details of the client certificate.
+	ForwardClientCertModeSanitizeSet ForwardClientCertMode = "SanitizeSet"
+	// ForwardClientCertModeAlwaysForwardOnly forwards the header of all
+	// requests unchanged.
+	ForwardClientCertModeAlwaysForwardOnly ForwardClientCertMode = "AlwaysForwardOnly"
+)
+
+// ClientCertDetail is a detail of the client certificate set in the
+// x-forwarded-client-cert header.
+//
+// +kubebuilder:validation:Enum=Subject;Cert;Chain;DNS;URI
+type ClientCertDetail string
+
+const (
+	// ClientCertDetailSubject is the subject of the client certificate.
+	ClientCertDetailSubject ClientCertDetail = "Subject"
+	// ClientCertDetailCert is the PEM encoded client certificate.
+	ClientCertDetailCert ClientCertDetail = "Cert"
+	// ClientCertDetailChain is the PEM encoded client certificate chain.
+	ClientCertDetailChain ClientCertDetail = "Chain"
+	// ClientCertDetailDNS is the DNS subject alternative names of the client
+	// certificate.
+	ClientCertDetailDNS ClientCertDetail = "DNS"
+	// ClientCertDetailURI is the URI subject alternative name of the client
+	// certificate.
+	ClientCertDetailURI ClientCertDetail = "URI"
+)
+
+// ForwardClientCertDetails defines the x-forwarded-client-cert header of
+// requests forwarded to the backends.
+type ForwardClientCertDetails struct {
+	// Mode is the handling of the header.
+	Mode ForwardClientCertMode `json:"mode"`
+
+	// CertDetails is the list of details of the client certificate set in the
+	// header in the AppendForward and SanitizeSet modes, in addition to the
+	// hash of the certificate. If unspecified, only the hash is set.
+	//
+	// +optional
+	CertDetails []ClientCertDetail `json:"certDetails,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClientTrafficPolicyList contains a list of ClientTrafficPolicy
+type ClientTrafficPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClientTrafficPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClientTrafficPolicy{}, &ClientTrafficPolicyList{})
+}
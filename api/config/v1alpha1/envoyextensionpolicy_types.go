@@ -0,0 +1,182 @@
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// KindEnvoyExtensionPolicy is the name of the EnvoyExtensionPolicy kind.
+	KindEnvoyExtensionPolicy = "EnvoyExtensionPolicy"
+)
+
+//+kubebuilder:object:root=true
+
+// EnvoyExtensionPolicy extends the processing of the requests of the targeted
+// Gateway or HTTPRoute by Envoy with custom logic.
+type EnvoyExtensionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EnvoyExtensionPolicySpec `json:"spec,omitempty"`
+}
+
+// EnvoyExtensionPolicySpec defines the desired state of EnvoyExtensionPolicy.
+type EnvoyExtensionPolicySpec struct {
+	// TargetRef identifies the Gateway or HTTPRoute the policy applies to. The
+	// target must be in the same namespace as the policy. When multiple policies
+	// target the same resource, the oldest policy takes precedence.
+	TargetRef gwapiv1a2.PolicyTargetReference `json:"targetRef"`
+
+	// Wasm is the list of Wasm extensions processing the requests of the HTTP
+	// and HTTPS listeners of the targeted Gateway, in order. Wasm extensions
+	// apply to all the routes of the listeners, so they are ignored for
+	// policies targeting an HTTPRoute.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Wasm []Wasm `json:"wasm,omitempty"`
+
+	// Lua is the Lua script processing the requests of the targeted HTTPRoute,
+	// or of the routes attached to the targeted Gateway that aren't targeted
+	// by a policy themselves. Lua scripts are ignored unless enabled in the
+	// extension APIs settings of Envoy Gateway.
+	//
+	// +optional
+	Lua *Lua `json:"lua,omitempty"`
+
+	// GRPCJSONTranscoder transcodes the JSON requests of REST clients into
+	// gRPC requests to the backends of the targeted HTTPRoute, or of the
+	// routes attached to the targeted Gateway that aren't targeted by a
+	// policy themselves. The backends must be connected to using HTTP/2, e.g.
+	// through Service ports with the grpc appProtocol.
+	//
+	// +optional
+	GRPCJSONTranscoder *GRPCJSONTranscoder `json:"grpcJSONTranscoder,omitempty"`
+}
+
+// GRPCJSONTranscoder defines the transcoding of JSON requests into gRPC requests,
+// following the HTTP annotations of the gRPC methods, see
+// https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/grpc_json_transcoder_filter.
+type GRPCJSONTranscoder struct {
+	// ProtoDescriptorRef references the ConfigMap holding the protobuf
+	// descriptor set of the gRPC services, as generated by protoc with the
+	// --include_imports and --descriptor_set_out flags. The ConfigMap must be
+	// in the namespace of the policy.
+	ProtoDescriptorRef ProtoDescriptorReference `json:"protoDescriptorRef"`
+
+	// Services is the list of fully qualified names of the gRPC services
+	// transcoded, e.g. "helloworld.Greeter".
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	Services []string `json:"services"`
+}
+
+// ProtoDescriptorReference references a protobuf descriptor set in the binary data
+// of a ConfigMap.
+type ProtoDescriptorReference struct {
+	// Name is the name of the ConfigMap.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key is the key of the descriptor set in the binary data of the
+	// ConfigMap. If unspecified, defaults to "descriptor.pb".
+	//
+	// +optional
+	Key *string `json:"key,omitempty"`
+}
+
+// Lua defines a Lua script run by the Lua filter of Envoy. The script defines
+// the envoy_on_request and/or envoy_on_response functions, see
+// https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/lua_filter.
+type Lua struct {
+	// Inline is the source code of the script.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=65536
+	Inline string `json:"inline"`
+}
+
+// Wasm defines a Wasm extension, running a plugin of a Wasm module in the V8
+// runtime of Envoy.
+type Wasm struct {
+	// Name identifies the extension. It must be unique within the policy.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Name string `json:"name"`
+
+	// RootID is the root ID of the plugin within the module. If unspecified,
+	// the module must contain a single plugin.
+	//
+	// +optional
+	RootID *string `json:"rootID,omitempty"`
+
+	// Code defines the source of the Wasm module.
+	Code WasmCodeSource `json:"code"`
+
+	// Config is the configuration passed to the plugin, serialized as JSON.
+	//
+	// +optional
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// FailOpen allows the requests when the plugin fails, e.g. when the
+	// module can't be fetched. If unspecified, such requests are rejected
+	// with a 503 status code.
+	//
+	// +optional
+	FailOpen *bool `json:"failOpen,omitempty"`
+}
+
+// WasmCodeSourceType is the type of the source of a Wasm module.
+//
+// +kubebuilder:validation:Enum=HTTP
+type WasmCodeSourceType string
+
+const (
+	// WasmCodeSourceTypeHTTP fetches the module from an HTTP URL.
+	WasmCodeSourceTypeHTTP WasmCodeSourceType = "HTTP"
+)
+
+// WasmCodeSource defines the source of a Wasm module. Only the source matching
+// the Type may be specified.
+type WasmCodeSource struct {
+	// Type is the type of the source of the module.
+	Type WasmCodeSourceType `json:"type"`
+
+	// HTTP fetches the module from an HTTP URL.
+	//
+	// +optional
+	HTTP *HTTPWasmCodeSource `json:"http,omitempty"`
+}
+
+// HTTPWasmCodeSource defines a Wasm module fetched by Envoy from an HTTP URL.
+type HTTPWasmCodeSource struct {
+	// URL is the http or https URL of the module. The certificate of an https
+	// server is verified against the system CA bundle of Envoy.
+	//
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the module, verified
+	// before the module is loaded.
+	//
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	SHA256 string `json:"sha256"`
+}
+
+//+kubebuilder:object:root=true
+
+// EnvoyExtensionPolicyList contains a list of EnvoyExtensionPolicy
+type EnvoyExtensionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnvoyExtensionPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EnvoyExtensionPolicy{}, &EnvoyExtensionPolicyList{})
+}
@@ -35,6 +35,235 @@ type EnvoyGatewaySpec struct {
 	//
 	// +optional
 	Provider *Provider `json:"provider,omitempty"`
+
+	// RateLimit defines the configuration of the global rate limit service
+	// deployed and managed by Envoy Gateway. If unspecified, global rate
+	// limits of BackendTrafficPolicies are ignored.
+	//
+	// +optional
+	RateLimit *RateLimitService `json:"rateLimit,omitempty"`
+
+	// ExtensionAPIs defines the settings of the extension APIs requiring an
+	// opt-in. If unspecified, all of them are disabled.
+	//
+	// +optional
+	ExtensionAPIs *ExtensionAPISettings `json:"extensionApis,omitempty"`
+
+	// Admin defines the settings of the admin server of Envoy Gateway. If
+	// unspecified, the admin server is disabled.
+	//
+	// +optional
+	Admin *EnvoyGatewayAdmin `json:"admin,omitempty"`
+
+	// Logging defines the logging settings of Envoy Gateway. If unspecified,
+	// all the components log at the info level with the console encoding.
+	//
+	// +optional
+	Logging *EnvoyGatewayLogging `json:"logging,omitempty"`
+
+	// Translation defines the settings of the translation of the resources of
+	// the provider. If unspecified, default settings are used.
+	//
+	// +optional
+	Translation *EnvoyGatewayTranslation `json:"translation,omitempty"`
+
+	// Tracing defines the OpenTelemetry tracing settings of Envoy Gateway,
+	// exporting a span per reconcile of the provider, per translation of the
+	// Gateway API resources and per xDS snapshot generated. If unspecified,
+	// tracing is disabled.
+	//
+	// +optional
+	Tracing *EnvoyGatewayTracing `json:"tracing,omitempty"`
+}
+
+// EnvoyGatewayTracing defines the settings of the exporter of the traces of
+// Envoy Gateway to an OpenTelemetry collector over OTLP/HTTP.
+type EnvoyGatewayTracing struct {
+	// Endpoint is the host and port of the OTLP/HTTP receiver of the
+	// collector, e.g. "otel-collector.monitoring:4318".
+	Endpoint string `json:"endpoint"`
+
+	// Insecure exports the traces over HTTP instead of HTTPS.
+	//
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// SamplingRate is the percentage of the traces that are sampled. Defaults
+	// to 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingRate *uint32 `json:"samplingRate,omitempty"`
+}
+
+// EnvoyGatewayTranslation defines the settings of the translation of the
+// resources of the provider to the intermediate representation.
+type EnvoyGatewayTranslation struct {
+	// DebounceWindow is the time to wait for further changes of the resources
+	// after a change before translating them, restarted by each change, so that
+	// a burst of changes, e.g. applying many HTTPRoutes at once, triggers a
+	// single translation and xDS push. Defaults to 100ms. A window of zero
+	// disables debouncing.
+	//
+	// +optional
+	DebounceWindow *metav1.Duration `json:"debounceWindow,omitempty"`
+
+	// MaxDelay is the maximum time a translation is delayed by the debounce
+	// window after the first change, bounding the translation latency during
+	// a continuous stream of changes. Defaults to 1s.
+	//
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+}
+
+// EnvoyGatewayLogging defines the logging settings of Envoy Gateway.
+type EnvoyGatewayLogging struct {
+	// Level is the log level of each component of Envoy Gateway. The level of
+	// the "default" component applies to the components that aren't listed,
+	// and defaults to "info".
+	//
+	// +optional
+	Level map[EnvoyGatewayLogComponent]EnvoyGatewayLogLevel `json:"level,omitempty"`
+
+	// Encoding is the encoding of the logs. Defaults to "console".
+	//
+	// +optional
+	Encoding EnvoyGatewayLogEncoding `json:"encoding,omitempty"`
+}
+
+// EnvoyGatewayLogComponent defines a component of Envoy Gateway with its own
+// log level.
+//
+// +kubebuilder:validation:Enum=default;provider;gateway-api;xds;infra
+type EnvoyGatewayLogComponent string
+
+const (
+	// LogComponentDefault is the component whose level applies to the
+	// components without a level of their own.
+	LogComponentDefault EnvoyGatewayLogComponent = "default"
+
+	// LogComponentProvider is the provider of the resources of Envoy Gateway.
+	LogComponentProvider EnvoyGatewayLogComponent = "provider"
+
+	// LogComponentGatewayAPI is the translator of the Gateway API resources
+	// into the IR.
+	LogComponentGatewayAPI EnvoyGatewayLogComponent = "gateway-api"
+
+	// LogComponentXds is the translator of the IR into xDS resources and the
+	// xDS server configuring Envoy.
+	LogComponentXds EnvoyGatewayLogComponent = "xds"
+
+	// LogComponentInfra is the manager of the Envoy infrastructure.
+	LogComponentInfra EnvoyGatewayLogComponent = "infra"
+)
+
+// EnvoyGatewayLogLevel defines a log level of Envoy Gateway.
+//
+// +kubebuilder:validation:Enum=debug;info;warn;error
+type EnvoyGatewayLogLevel string
+
+const (
+	// EnvoyGatewayLogLevelDebug logs the debug and all more severe messages.
+	EnvoyGatewayLogLevelDebug EnvoyGatewayLogLevel = "debug"
+
+	// EnvoyGatewayLogLevelInfo logs the info and all more severe messages.
+	EnvoyGatewayLogLevelInfo EnvoyGatewayLogLevel = "info"
+
+	// EnvoyGatewayLogLevelWarn logs the warning and error messages.
+	EnvoyGatewayLogLevelWarn EnvoyGatewayLogLevel = "warn"
+
+	// EnvoyGatewayLogLevelError logs the error messages only.
+	EnvoyGatewayLogLevelError EnvoyGatewayLogLevel = "error"
+)
+
+// EnvoyGatewayLogEncoding defines an encoding of the logs of Envoy Gateway.
+//
+// +kubebuilder:validation:Enum=console;json
+type EnvoyGatewayLogEncoding string
+
+const (
+	// EnvoyGatewayLogEncodingConsole encodes the logs as human-readable text.
+	EnvoyGatewayLogEncodingConsole EnvoyGatewayLogEncoding = "console"
+
+	// EnvoyGatewayLogEncodingJSON encodes the logs as JSON objects.
+	EnvoyGatewayLogEncodingJSON EnvoyGatewayLogEncoding = "json"
+)
+
+// EnvoyGatewayAdmin defines the settings of the admin server of Envoy Gateway.
+type EnvoyGatewayAdmin struct {
+	// Address is the address the admin server listens on. If unspecified,
+	// defaults to "127.0.0.1:19000", so that the admin server is only reachable
+	// from within the Envoy Gateway pod, e.g. with "kubectl port-forward".
+	//
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// EnableDumpConfig enables the /debug/xds, /debug/ir and /debug/provider
+	// endpoints dumping the xDS snapshots of the connected Envoy nodes, the
+	// intermediate representation and the resources of the provider. Private
+	// keys and the data of Secrets are redacted.
+	//
+	// +optional
+	EnableDumpConfig bool `json:"enableDumpConfig,omitempty"`
+
+	// EnablePprof enables the /debug/pprof endpoints profiling Envoy Gateway and
+	// the /debug/vars endpoint exposing its runtime variables.
+	//
+	// +optional
+	EnablePprof bool `json:"enablePprof,omitempty"`
+}
+
+// ExtensionAPISettings defines the settings of the extension APIs requiring an
+// opt-in, since they run arbitrary logic in Envoy.
+type ExtensionAPISettings struct {
+	// EnableLua enables the Lua scripts of EnvoyExtensionPolicies.
+	//
+	// +optional
+	EnableLua bool `json:"enableLua,omitempty"`
+
+	// EnableEnvoyPatchPolicy enables the EnvoyPatchPolicies patching the xDS
+	// resources generated for the Gateways.
+	//
+	// +optional
+	EnableEnvoyPatchPolicy bool `json:"enableEnvoyPatchPolicy,omitempty"`
+}
+
+// RateLimitService defines the configuration of the global rate limit service.
+type RateLimitService struct {
+	// Backend holds the configuration of the database backend storing the
+	// rate limit counters.
+	Backend RateLimitDatabaseBackend `json:"backend"`
+}
+
+// RateLimitDatabaseBackend defines the configuration of the database backend of
+// the global rate limit service.
+// +union
+type RateLimitDatabaseBackend struct {
+	// Type is the type of database backend to use.
+	//
+	// +unionDiscriminator
+	Type RateLimitDatabaseBackendType `json:"type"`
+
+	// Redis defines the configuration of the Redis database backend.
+	//
+	// +optional
+	Redis *RateLimitRedisSettings `json:"redis,omitempty"`
+}
+
+// RateLimitDatabaseBackendType defines the types of database backends supported
+// by the global rate limit service.
+type RateLimitDatabaseBackendType string
+
+const (
+	// RateLimitDatabaseBackendTypeRedis defines the "Redis" database backend.
+	RateLimitDatabaseBackendTypeRedis RateLimitDatabaseBackendType = "Redis"
+)
+
+// RateLimitRedisSettings defines the configuration of the Redis database backend.
+type RateLimitRedisSettings struct {
+	// URL of the Redis database, e.g. "redis.redis-system.svc.cluster.local:6379".
+	URL string `json:"url"`
 }
 
 // Gateway defines the desired Gateway API configuration of Envoy Gateway.
@@ -87,7 +316,47 @@ type KubernetesProvider struct {
 
 // FileProvider defines configuration for the File provider.
 type FileProvider struct {
-	// TODO: Add config as use cases are better understood.
+	// Paths are the paths of the YAML or JSON files holding the resources of
+	// Envoy Gateway, e.g. GatewayClasses, Gateways, HTTPRoutes and EnvoyProxies.
+	// A path can also be a directory, whose ".yaml", ".yml" and ".json" files
+	// are loaded, non-recursively. The files are watched and the resources are
+	// reloaded when they change. Namespaced resources without a namespace are
+	// in the "default" namespace.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Paths []string `json:"paths"`
+
+	// Infrastructure defines the settings of the Envoy proxies managed with the
+	// File provider, which run as processes on the host of Envoy Gateway. If
+	// unspecified, default settings are used.
+	//
+	// +optional
+	Infrastructure *HostInfrastructure `json:"infrastructure,omitempty"`
+}
+
+// HostInfrastructure defines the settings of the Envoy proxies running as
+// processes on the host of Envoy Gateway. An Envoy process is started for each
+// Gateway, and restarted when it exits or its bootstrap configuration changes.
+type HostInfrastructure struct {
+	// EnvoyPath is the path of the Envoy binary. If unspecified, defaults to
+	// "envoy", looked up in the PATH.
+	//
+	// +optional
+	EnvoyPath string `json:"envoyPath,omitempty"`
+
+	// HomeDir is the directory holding the bootstrap configurations of the
+	// Envoy processes. If unspecified, defaults to "/tmp/envoy-gateway".
+	//
+	// +optional
+	HomeDir string `json:"homeDir,omitempty"`
+
+	// CertsDir is the directory holding the "ca.crt", "tls.crt" and "tls.key"
+	// files of the certificate authenticating the Envoy processes to the xDS
+	// server, e.g. the files of the "envoy" Secret created by certgen. If
+	// unspecified, defaults to "/certs/envoy".
+	//
+	// +optional
+	CertsDir string `json:"certsDir,omitempty"`
 }
 
 func init() {
@@ -0,0 +1,166 @@
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// KindEnvoyPatchPolicy is the name of the EnvoyPatchPolicy kind.
+	KindEnvoyPatchPolicy = "EnvoyPatchPolicy"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// EnvoyPatchPolicy patches the xDS resources generated by Envoy Gateway for the
+// targeted Gateway before they are served to Envoy. It is an escape hatch for
+// Envoy features not exposed by the other APIs, and is ignored unless enabled
+// in the extension APIs settings of Envoy Gateway.
+type EnvoyPatchPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnvoyPatchPolicySpec   `json:"spec,omitempty"`
+	Status EnvoyPatchPolicyStatus `json:"status,omitempty"`
+}
+
+// EnvoyPatchPolicySpec defines the desired state of EnvoyPatchPolicy.
+type EnvoyPatchPolicySpec struct {
+	// TargetRef identifies the Gateway the policy applies to. The Gateway must
+	// be in the same namespace as the policy. When multiple policies target
+	// the same Gateway, they are applied from the oldest to the newest.
+	TargetRef gwapiv1a2.PolicyTargetReference `json:"targetRef"`
+
+	// JSONPatches is the list of JSON patches applied to the xDS resources, in
+	// order. The patches of a policy are applied atomically: if one of them
+	// fails, none of them is applied.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	JSONPatches []EnvoyJSONPatchConfig `json:"jsonPatches"`
+}
+
+// EnvoyResourceType is the type URL of an xDS resource.
+// +kubebuilder:validation:Enum=type.googleapis.com/envoy.config.listener.v3.Listener;type.googleapis.com/envoy.config.route.v3.RouteConfiguration;type.googleapis.com/envoy.config.cluster.v3.Cluster
+type EnvoyResourceType string
+
+const (
+	// ListenerEnvoyResourceType is the type of the xDS Listeners.
+	ListenerEnvoyResourceType EnvoyResourceType = "type.googleapis.com/envoy.config.listener.v3.Listener"
+
+	// RouteConfigurationEnvoyResourceType is the type of the xDS RouteConfigurations.
+	RouteConfigurationEnvoyResourceType EnvoyResourceType = "type.googleapis.com/envoy.config.route.v3.RouteConfiguration"
+
+	// ClusterEnvoyResourceType is the type of the xDS Clusters.
+	ClusterEnvoyResourceType EnvoyResourceType = "type.googleapis.com/envoy.config.cluster.v3.Cluster"
+)
+
+// EnvoyJSONPatchConfig defines a JSON patch applied to an xDS resource.
+type EnvoyJSONPatchConfig struct {
+	// Type is the type of the patched xDS resource.
+	Type EnvoyResourceType `json:"type"`
+
+	// Name is the name of the patched xDS resource.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Operation is the JSON patch operation applied to the JSON representation
+	// of the resource, see https://www.rfc-editor.org/rfc/rfc6902.
+	Operation JSONPatchOperation `json:"operation"`
+}
+
+// JSONPatchOperationType is the type of a JSON patch operation.
+// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+type JSONPatchOperationType string
+
+// JSONPatchOperation defines a JSON patch operation, see
+// https://www.rfc-editor.org/rfc/rfc6902#section-4.
+type JSONPatchOperation struct {
+	// Op is the type of the operation.
+	Op JSONPatchOperationType `json:"op"`
+
+	// Path is the JSON pointer to the location of the resource the operation
+	// applies to, e.g. "/filter_chains/0/filters/0/typed_config/use_remote_address".
+	Path string `json:"path"`
+
+	// From is the JSON pointer to the source location of the move and copy
+	// operations.
+	//
+	// +optional
+	From *string `json:"from,omitempty"`
+
+	// Value is the value of the add, replace and test operations.
+	//
+	// +optional
+	Value *apiextensionsv1.JSON `json:"value,omitempty"`
+}
+
+// EnvoyPatchPolicyStatus defines the observed state of EnvoyPatchPolicy.
+type EnvoyPatchPolicyStatus struct {
+	// Conditions describe the current conditions of the EnvoyPatchPolicy.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted"
+	// * "Programmed"
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// EnvoyPatchPolicyConditionType is a type of condition of an EnvoyPatchPolicy.
+type EnvoyPatchPolicyConditionType string
+
+// EnvoyPatchPolicyConditionReason is a reason of a condition of an EnvoyPatchPolicy.
+type EnvoyPatchPolicyConditionReason string
+
+const (
+	// EnvoyPatchPolicyConditionAccepted indicates whether the EnvoyPatchPolicy
+	// applies to a Gateway managed by Envoy Gateway.
+	EnvoyPatchPolicyConditionAccepted EnvoyPatchPolicyConditionType = "Accepted"
+
+	// EnvoyPatchPolicyReasonAccepted is used with the "Accepted" condition when
+	// the policy applies to the targeted Gateway.
+	EnvoyPatchPolicyReasonAccepted EnvoyPatchPolicyConditionReason = "Accepted"
+
+	// EnvoyPatchPolicyReasonDisabled is used with the "Accepted" condition when
+	// EnvoyPatchPolicies aren't enabled in the settings of Envoy Gateway.
+	EnvoyPatchPolicyReasonDisabled EnvoyPatchPolicyConditionReason = "Disabled"
+
+	// EnvoyPatchPolicyReasonTargetNotFound is used with the "Accepted" condition
+	// when the targeted Gateway doesn't exist or isn't managed by Envoy Gateway.
+	EnvoyPatchPolicyReasonTargetNotFound EnvoyPatchPolicyConditionReason = "TargetNotFound"
+
+	// EnvoyPatchPolicyConditionProgrammed indicates whether the patches of the
+	// EnvoyPatchPolicy are applied to the xDS resources served to Envoy.
+	EnvoyPatchPolicyConditionProgrammed EnvoyPatchPolicyConditionType = "Programmed"
+
+	// EnvoyPatchPolicyReasonProgrammed is used with the "Programmed" condition
+	// when all the patches of the policy are applied.
+	EnvoyPatchPolicyReasonProgrammed EnvoyPatchPolicyConditionReason = "Programmed"
+
+	// EnvoyPatchPolicyReasonInvalid is used with the "Programmed" condition when
+	// a patch of the policy can't be applied, e.g. because the patched resource
+	// doesn't exist or the patched resource is invalid. None of the patches of
+	// the policy are applied then.
+	EnvoyPatchPolicyReasonInvalid EnvoyPatchPolicyConditionReason = "Invalid"
+)
+
+//+kubebuilder:object:root=true
+
+// EnvoyPatchPolicyList contains a list of EnvoyPatchPolicy
+type EnvoyPatchPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnvoyPatchPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EnvoyPatchPolicy{}, &EnvoyPatchPolicyList{})
+}
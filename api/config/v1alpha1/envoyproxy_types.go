@@ -1,7 +1,18 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// KindEnvoyProxy is the name of the EnvoyProxy kind.
+	KindEnvoyProxy = "EnvoyProxy"
 )
 
 //+kubebuilder:object:root=true
@@ -18,16 +29,1254 @@ type EnvoyProxy struct {
 
 // EnvoyProxySpec defines the desired state of EnvoyProxy.
 type EnvoyProxySpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - define desired state of cluster.
-	// Important: Run "make" to regenerate code after modifying this file.
+	// Provider defines the desired resource provider and provider-specific configuration.
+	// If unspecified, the "Kubernetes" resource provider is used with default configuration
+	// parameters.
+	//
+	// +optional
+	Provider *ProxyProvider `json:"provider,omitempty"`
+
+	// Bootstrap defines an override of the Envoy bootstrap configuration generated
+	// by Envoy Gateway, e.g. to configure the overload manager or stats sinks. If
+	// unspecified, the generated bootstrap configuration is used.
+	//
+	// +optional
+	Bootstrap *ProxyBootstrap `json:"bootstrap,omitempty"`
+
+	// HTTPSRedirect enables an HTTP listener redirecting requests to the HTTPS
+	// listeners of a Gateway, generated for every Gateway with HTTPS listeners.
+	// If unspecified, no redirect listener is generated.
+	//
+	// +optional
+	HTTPSRedirect *HTTPSRedirect `json:"httpsRedirect,omitempty"`
+
+	// ConnectionLimit limits the number of active connections of each listener
+	// of a Gateway. Connections above the limit are closed. If unspecified, the
+	// connections of the listeners are not limited.
+	//
+	// +optional
+	ConnectionLimit *ConnectionLimit `json:"connectionLimit,omitempty"`
+
+	// Overload defines the overload protection of the Envoy proxies, which
+	// sheds load when a proxy runs short of memory or connections instead of
+	// being OOM-killed. If unspecified, no overload protection is configured.
+	//
+	// +optional
+	Overload *ProxyOverload `json:"overload,omitempty"`
+
+	// AccessLog defines the access logs of the listeners of a Gateway. If
+	// unspecified, no access logs are written.
+	//
+	// +optional
+	AccessLog *ProxyAccessLog `json:"accessLog,omitempty"`
+
+	// Tracing defines the tracing of the requests of the HTTP listeners of a
+	// Gateway. If unspecified, requests are not traced.
+	//
+	// +optional
+	Tracing *ProxyTracing `json:"tracing,omitempty"`
+
+	// Telemetry defines the telemetry of the Envoy proxies. If unspecified,
+	// the Envoy defaults are used.
+	//
+	// +optional
+	Telemetry *ProxyTelemetry `json:"telemetry,omitempty"`
+
+	// Logging defines the logging of the Envoy proxies. If unspecified, the
+	// proxies log at the "info" level.
+	//
+	// +optional
+	Logging *ProxyLogging `json:"logging,omitempty"`
+
+	// ResponseHeaders defines the handling of the headers of the responses sent
+	// to the clients by the HTTP and HTTPS listeners of every Gateway, e.g. to
+	// hide the implementation details of Envoy and the backends. The
+	// ResponseHeaders of a ClientTrafficPolicy take precedence. If unspecified,
+	// the response headers are not modified.
+	//
+	// +optional
+	ResponseHeaders *ResponseHeaderSettings `json:"responseHeaders,omitempty"`
+}
+
+// ProxyLogging defines the logging of the Envoy proxies.
+type ProxyLogging struct {
+	// Level is the log level of the Envoy proxies. If unspecified, defaults
+	// to "info".
+	//
+	// +optional
+	Level *LogLevel `json:"level,omitempty"`
+
+	// ComponentLevels are the log levels of specific Envoy components, keyed by
+	// component name, e.g. "http" or "upstream", overriding Level for these
+	// components. See the --component-log-level option of
+	// https://www.envoyproxy.io/docs/envoy/latest/operations/cli.
+	//
+	// +optional
+	ComponentLevels map[string]LogLevel `json:"componentLevels,omitempty"`
+}
+
+// LogLevel is the log level of an Envoy proxy or Envoy component.
+//
+// +kubebuilder:validation:Enum=trace;debug;info;warning;error;critical;off
+type LogLevel string
+
+const (
+	// LogLevelTrace logs the trace and all more severe messages.
+	LogLevelTrace LogLevel = "trace"
+	// LogLevelDebug logs the debug and all more severe messages.
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelInfo logs the info and all more severe messages.
+	LogLevelInfo LogLevel = "info"
+	// LogLevelWarning logs the warning and all more severe messages.
+	LogLevelWarning LogLevel = "warning"
+	// LogLevelError logs the error and critical messages.
+	LogLevelError LogLevel = "error"
+	// LogLevelCritical logs the critical messages.
+	LogLevelCritical LogLevel = "critical"
+	// LogLevelOff disables logging.
+	LogLevelOff LogLevel = "off"
+)
+
+// ProxyTelemetry defines the telemetry of the Envoy proxies.
+type ProxyTelemetry struct {
+	// Metrics defines the metrics of the Envoy proxies. If unspecified, all the
+	// metrics are served by the Prometheus endpoint of the readiness listener.
+	//
+	// +optional
+	Metrics *ProxyMetrics `json:"metrics,omitempty"`
+}
+
+// ProxyMetrics defines the metrics of the Envoy proxies.
+type ProxyMetrics struct {
+	// Prometheus defines a dedicated listener serving the Prometheus metrics
+	// endpoint. If unspecified, the endpoint is served by the readiness listener
+	// on port 19001.
+	//
+	// +optional
+	Prometheus *ProxyPrometheus `json:"prometheus,omitempty"`
+
+	// Matcher defines the metrics created by the Envoy proxies, e.g. to reduce
+	// the cardinality of the metrics. If unspecified, all the metrics are
+	// created.
+	//
+	// +optional
+	Matcher *ProxyStatsMatcher `json:"matcher,omitempty"`
+
+	// StatsTags are the tags extracted from the names of the metrics, in
+	// addition to the default Envoy tags.
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	StatsTags []ProxyStatsTag `json:"statsTags,omitempty"`
+
+	// Sinks are the sinks the metrics are flushed to, in addition to the
+	// Prometheus endpoint.
+	//
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Sinks []ProxyMetricSink `json:"sinks,omitempty"`
+}
+
+// ProxyPrometheus defines the listener serving the Prometheus metrics endpoint.
+type ProxyPrometheus struct {
+	// Port is the container port of the listener. It must not conflict with
+	// the container ports of the Gateway listeners or the readiness listener.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// ProxyStatsMatcher defines the metrics created by the Envoy proxies, by prefix of
+// their names, e.g. "cluster.outbound". Only one of InclusionPrefixes or
+// ExclusionPrefixes may be specified.
+type ProxyStatsMatcher struct {
+	// InclusionPrefixes are the prefixes of the names of the metrics created.
+	// Other metrics are not created.
+	//
+	// +optional
+	InclusionPrefixes []string `json:"inclusionPrefixes,omitempty"`
+
+	// ExclusionPrefixes are the prefixes of the names of the metrics not
+	// created.
+	//
+	// +optional
+	ExclusionPrefixes []string `json:"exclusionPrefixes,omitempty"`
+}
+
+// ProxyStatsTag defines a tag extracted from the names of the metrics.
+type ProxyStatsTag struct {
+	// Name is the name of the tag.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Regex is the regular expression extracting the value of the tag from the
+	// names of the metrics. The first capture group is removed from the names,
+	// and its first sub-group is the value of the tag, see
+	// https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/metrics/v3/stats.proto#envoy-v3-api-msg-config-metrics-v3-tagspecifier.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Regex string `json:"regex"`
+}
+
+// ProxyMetricSinkType is the type of a metric sink.
+//
+// +kubebuilder:validation:Enum=Statsd
+type ProxyMetricSinkType string
+
+const (
+	// ProxyMetricSinkTypeStatsd flushes the metrics to a statsd server over UDP.
+	ProxyMetricSinkTypeStatsd ProxyMetricSinkType = "Statsd"
+)
+
+// ProxyMetricSink defines a sink the metrics are flushed to. Only the sink matching
+// the Type may be specified.
+type ProxyMetricSink struct {
+	// Type is the type of the sink.
+	Type ProxyMetricSinkType `json:"type"`
+
+	// Statsd flushes the metrics to a statsd server.
+	//
+	// +optional
+	Statsd *StatsdMetricSink `json:"statsd,omitempty"`
+}
+
+// StatsdMetricSink defines the statsd server the metrics are flushed to.
+type StatsdMetricSink struct {
+	// Address is the IP address of the statsd server.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Port is the UDP port of the statsd server.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Prefix is the prefix added to the names of the metrics. If unspecified,
+	// defaults to "envoy".
+	//
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// ProxyTracing defines the tracing of the requests of the HTTP listeners of a Gateway.
+type ProxyTracing struct {
+	// Provider defines the tracing provider the spans are sent to.
+	Provider TracingProvider `json:"provider"`
+
+	// SamplingRate is the percentage of the requests traced. Requests traced
+	// by the clients are traced regardless of the sampling rate. If
+	// unspecified, defaults to 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingRate *int32 `json:"samplingRate,omitempty"`
+
+	// CustomTags are the tags added to the spans, in addition to the tags
+	// added by Envoy.
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	CustomTags []CustomTag `json:"customTags,omitempty"`
+}
+
+// TracingProviderType is the type of a tracing provider.
+//
+// +kubebuilder:validation:Enum=OpenTelemetry;Zipkin;Datadog
+type TracingProviderType string
+
+const (
+	// TracingProviderTypeOpenTelemetry sends the spans to an OpenTelemetry
+	// collector using the OTLP gRPC protocol.
+	TracingProviderTypeOpenTelemetry TracingProviderType = "OpenTelemetry"
+	// TracingProviderTypeZipkin sends the spans to a Zipkin collector using the
+	// Zipkin v2 JSON API.
+	TracingProviderTypeZipkin TracingProviderType = "Zipkin"
+	// TracingProviderTypeDatadog sends the spans to a Datadog agent.
+	TracingProviderTypeDatadog TracingProviderType = "Datadog"
+)
+
+// TracingProvider defines the tracing provider the spans are sent to. The spans of
+// the Zipkin and Datadog providers are named after the Gateway.
+type TracingProvider struct {
+	// Type is the type of the tracing provider.
+	Type TracingProviderType `json:"type"`
+
+	// Host is the hostname of the collector of the tracing provider, e.g.
+	// otel-collector.monitoring.svc.cluster.local.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Port is the port of the collector of the tracing provider.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// CustomTagType is the type of the value of a custom tag.
+//
+// +kubebuilder:validation:Enum=Literal;Environment;RequestHeader;Metadata
+type CustomTagType string
+
+const (
+	// CustomTagTypeLiteral sets the tag to a literal value.
+	CustomTagTypeLiteral CustomTagType = "Literal"
+	// CustomTagTypeEnvironment sets the tag to the value of an environment
+	// variable of the Envoy container.
+	CustomTagTypeEnvironment CustomTagType = "Environment"
+	// CustomTagTypeRequestHeader sets the tag to the value of a request header.
+	CustomTagTypeRequestHeader CustomTagType = "RequestHeader"
+	// CustomTagTypeMetadata sets the tag to the value of a metadata field.
+	CustomTagTypeMetadata CustomTagType = "Metadata"
+)
+
+// CustomTag defines a tag added to the spans. Only the value source matching the
+// Type may be specified.
+type CustomTag struct {
+	// Name is the name of the tag.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type is the type of the value of the tag.
+	Type CustomTagType `json:"type"`
+
+	// Literal is the literal value of the tag.
+	//
+	// +optional
+	Literal *LiteralCustomTag `json:"literal,omitempty"`
+
+	// Environment is the environment variable the value of the tag is read
+	// from.
+	//
+	// +optional
+	Environment *EnvironmentCustomTag `json:"environment,omitempty"`
+
+	// RequestHeader is the request header the value of the tag is read from.
+	//
+	// +optional
+	RequestHeader *RequestHeaderCustomTag `json:"requestHeader,omitempty"`
+
+	// Metadata is the metadata field the value of the tag is read from.
+	//
+	// +optional
+	Metadata *MetadataCustomTag `json:"metadata,omitempty"`
+}
+
+// LiteralCustomTag defines the literal value of a custom tag.
+type LiteralCustomTag struct {
+	// Value of the tag.
+	Value string `json:"value"`
+}
+
+// EnvironmentCustomTag defines the environment variable the value of a custom tag
+// is read from.
+type EnvironmentCustomTag struct {
+	// Name of the environment variable.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// DefaultValue is the value of the tag if the environment variable is
+	// unset. If unspecified, the tag is omitted.
+	//
+	// +optional
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+// RequestHeaderCustomTag defines the request header the value of a custom tag is
+// read from.
+type RequestHeaderCustomTag struct {
+	// Name of the request header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// DefaultValue is the value of the tag if the request header is missing.
+	// If unspecified, the tag is omitted.
+	//
+	// +optional
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+// MetadataCustomTagKind is the kind of the metadata the value of a custom tag is
+// read from.
+//
+// +kubebuilder:validation:Enum=Request;Route
+type MetadataCustomTagKind string
+
+const (
+	// MetadataCustomTagKindRequest reads the dynamic metadata of the request,
+	// e.g. set by a Lua script.
+	MetadataCustomTagKindRequest MetadataCustomTagKind = "Request"
+	// MetadataCustomTagKindRoute reads the metadata of the route.
+	MetadataCustomTagKindRoute MetadataCustomTagKind = "Route"
+)
+
+// MetadataCustomTag defines the metadata field the value of a custom tag is read
+// from.
+type MetadataCustomTag struct {
+	// Kind is the kind of the metadata.
+	Kind MetadataCustomTagKind `json:"kind"`
+
+	// Key is the namespace of the metadata, usually the name of the filter
+	// setting it, e.g. envoy.filters.http.lua.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Path is the path of the field within the namespace of the metadata.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Path []string `json:"path"`
+
+	// DefaultValue is the value of the tag if the metadata field is missing.
+	// If unspecified, the tag is omitted.
+	//
+	// +optional
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+// ProxyAccessLog defines the access logs of the listeners of a Gateway.
+type ProxyAccessLog struct {
+	// Format defines the format of the access logs written to files. If
+	// unspecified, the default Envoy format is used, see
+	// https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#default-format-string.
+	//
+	// +optional
+	Format *ProxyAccessLogFormat `json:"format,omitempty"`
+
+	// Sinks are the destinations the access logs are written to.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	Sinks []ProxyAccessLogSink `json:"sinks"`
+}
+
+// ProxyAccessLogFormatType is the type of the format of the access logs.
+//
+// +kubebuilder:validation:Enum=Text;JSON
+type ProxyAccessLogFormatType string
+
+const (
+	// ProxyAccessLogFormatTypeText formats the access logs as text lines.
+	ProxyAccessLogFormatTypeText ProxyAccessLogFormatType = "Text"
+	// ProxyAccessLogFormatTypeJSON formats the access logs as JSON objects.
+	ProxyAccessLogFormatTypeJSON ProxyAccessLogFormatType = "JSON"
+)
+
+// ProxyAccessLogFormat defines the format of the access logs. The formats contain
+// the command operators of the Envoy access log formats, e.g. %REQ(:PATH)% or
+// %RESPONSE_CODE%, see
+// https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#command-operators.
+// Only the format matching the Type may be specified.
+type ProxyAccessLogFormat struct {
+	// Type is the type of the format.
+	Type ProxyAccessLogFormatType `json:"type"`
+
+	// Text is the format of the text lines, e.g.
+	// "[%START_TIME%] %REQ(:METHOD)% %REQ(:PATH)% %RESPONSE_CODE%\n".
+	//
+	// +optional
+	Text *string `json:"text,omitempty"`
+
+	// JSON is the format of the fields of the JSON objects, e.g.
+	// {"path": "%REQ(:PATH)%", "status": "%RESPONSE_CODE%"}.
+	//
+	// +kubebuilder:validation:MaxProperties=64
+	// +optional
+	JSON map[string]string `json:"json,omitempty"`
+}
+
+// ProxyAccessLogSinkType is the type of the destination of the access logs.
+//
+// +kubebuilder:validation:Enum=File;ALS
+type ProxyAccessLogSinkType string
+
+const (
+	// ProxyAccessLogSinkTypeFile writes the access logs to a file.
+	ProxyAccessLogSinkTypeFile ProxyAccessLogSinkType = "File"
+	// ProxyAccessLogSinkTypeALS streams the access logs to an access log
+	// service over gRPC.
+	ProxyAccessLogSinkTypeALS ProxyAccessLogSinkType = "ALS"
+)
+
+// ProxyAccessLogSink defines a destination of the access logs. Only the sink
+// matching the Type may be specified.
+type ProxyAccessLogSink struct {
+	// Type is the type of the sink.
+	Type ProxyAccessLogSinkType `json:"type"`
+
+	// File writes the access logs to a file of the Envoy container.
+	//
+	// +optional
+	File *FileAccessLogSink `json:"file,omitempty"`
+
+	// ALS streams the access logs to an access log service implementing the
+	// Envoy gRPC access log service API.
+	//
+	// +optional
+	ALS *ALSAccessLogSink `json:"als,omitempty"`
+}
+
+// FileAccessLogSink defines the file the access logs are written to.
+type FileAccessLogSink struct {
+	// Path is the path of the file, e.g. /dev/stdout to write the access logs
+	// to the standard output of the Envoy container.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+}
+
+// ALSAccessLogSink defines the access log service the access logs are streamed to.
+// The access logs of HTTP listeners are streamed as HTTP access log entries, and
+// the access logs of TCP listeners as TCP access log entries. The format of the
+// access logs doesn't apply, since the entries are structured.
+type ALSAccessLogSink struct {
+	// Host is the hostname of the access log service, e.g.
+	// als.monitoring.svc.cluster.local.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Port is the gRPC port of the access log service.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// LogName identifies the access logs within the access log service. If
+	// unspecified, defaults to the namespace and name of the Gateway, e.g.
+	// "default/eg".
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	LogName *string `json:"logName,omitempty"`
+
+	// BufferSize is the size of the buffer of the access logs, in bytes, which
+	// are streamed when the buffer is full. If unspecified, defaults to 16384.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+
+	// BufferFlushInterval is the interval at which the buffered access logs are
+	// streamed. If unspecified, defaults to 1s.
+	//
+	// +optional
+	BufferFlushInterval *metav1.Duration `json:"bufferFlushInterval,omitempty"`
+}
+
+// ConnectionLimit defines the limit of active connections of a listener.
+type ConnectionLimit struct {
+	// Value is the maximum number of active connections of the listener.
+	//
+	// +kubebuilder:validation:Minimum=1
+	Value int64 `json:"value"`
+
+	// CloseDelay is the delay before closing connections above the limit,
+	// which slows down clients retrying aggressively. If unspecified,
+	// connections are closed immediately.
+	//
+	// +optional
+	CloseDelay *metav1.Duration `json:"closeDelay,omitempty"`
+}
+
+// ProxyOverload defines the overload protection of the Envoy proxies.
+type ProxyOverload struct {
+	// MaxActiveDownstreamConnections is the maximum number of active
+	// connections of a proxy across all its listeners. If unspecified, the
+	// connections of a proxy are not limited.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActiveDownstreamConnections *int64 `json:"maxActiveDownstreamConnections,omitempty"`
+
+	// Heap defines the overload actions triggered by the heap usage of a
+	// proxy. If unspecified, the heap usage doesn't trigger overload actions.
+	//
+	// +optional
+	Heap *HeapOverload `json:"heap,omitempty"`
+}
+
+// HeapOverload defines the overload actions triggered by the heap usage of a proxy.
+// Thresholds are percentages of MaxHeapSizeBytes.
+type HeapOverload struct {
+	// MaxHeapSizeBytes is the maximum heap size of a proxy, which should be
+	// lower than the memory limit of the Envoy container.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxHeapSizeBytes int64 `json:"maxHeapSizeBytes"`
+
+	// ShrinkHeapThreshold is the heap usage at which a proxy releases free
+	// memory to the system. If unspecified, defaults to 95.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ShrinkHeapThreshold *int32 `json:"shrinkHeapThreshold,omitempty"`
+
+	// StopAcceptingRequestsThreshold is the heap usage at which a proxy
+	// responds to new requests with a 503 status code. If unspecified,
+	// defaults to 98.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StopAcceptingRequestsThreshold *int32 `json:"stopAcceptingRequestsThreshold,omitempty"`
+}
+
+// HTTPSRedirect defines the configuration of the HTTP listener redirecting
+// requests to the HTTPS listeners of a Gateway.
+type HTTPSRedirect struct {
+	// Port is the port of the redirect listener. A Gateway listener on the same
+	// port takes precedence over the redirect listener. If unspecified, defaults
+	// to 80.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// StatusCode is the HTTP status code of the redirect response. Supported
+	// status codes are 301 and 302. If unspecified, defaults to 301.
+	//
+	// +kubebuilder:validation:Enum=301;302
+	// +optional
+	StatusCode *int32 `json:"statusCode,omitempty"`
+}
+
+// ProxyBootstrap defines an override of the Envoy bootstrap configuration.
+type ProxyBootstrap struct {
+	// Type is the type of the bootstrap override. Supported types are "Replace"
+	// and "JSONPatch". If unspecified, defaults to "Replace".
+	//
+	// +kubebuilder:validation:Enum=Replace;JSONPatch
+	// +optional
+	Type *BootstrapType `json:"type,omitempty"`
+
+	// Value is the bootstrap override in YAML or JSON format. When Type is "Replace",
+	// Value is the complete bootstrap configuration replacing the generated one, and
+	// must include the xDS cluster and dynamic resources of the generated bootstrap
+	// for Envoy to be managed by Envoy Gateway. When Type is "JSONPatch", Value is a
+	// list of RFC 6902 JSON Patch operations applied to the generated bootstrap.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value"`
+}
+
+// BootstrapType is the type of an Envoy bootstrap override.
+type BootstrapType string
+
+const (
+	// BootstrapTypeReplace replaces the generated bootstrap configuration.
+	BootstrapTypeReplace BootstrapType = "Replace"
+
+	// BootstrapTypeJSONPatch patches the generated bootstrap configuration.
+	BootstrapTypeJSONPatch BootstrapType = "JSONPatch"
+)
+
+// ProxyProvider defines the desired configuration of a resource provider.
+// +union
+type ProxyProvider struct {
+	// Type is the type of resource provider to use. A resource provider provides
+	// infrastructure resources for running the data plane, e.g. Envoy proxy.
+	// Supported types are "Kubernetes".
+	//
+	// +unionDiscriminator
+	Type ProviderType `json:"type"`
+
+	// Kubernetes defines the desired configuration of the Kubernetes resource provider.
+	// If unspecified, default configuration parameters will apply.
+	//
+	// +optional
+	Kubernetes *ProxyKubeProvider `json:"kubernetes,omitempty"`
+}
+
+// ProxyKubeProvider defines configuration for the Kubernetes resource provider.
+type ProxyKubeProvider struct {
+	// Namespace is the namespace of the managed Envoy resources. If unspecified,
+	// the resources are created in the namespace of Envoy Gateway. The namespace
+	// is created if it doesn't exist, and the certificates Secret of Envoy is
+	// copied to it. Envoy Gateway is only permitted to manage the resources in
+	// its own namespace, so the Role and RoleBinding granting it the permissions
+	// in the namespace must be created at install time, e.g. with the
+	// PROXY_NAMESPACES variable of "make generate-manifests". Changing the
+	// namespace does not remove the resources from the previous namespace.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// WorkloadType determines the kind of workload resource used to run the Envoy
+	// fleet. Valid options are "Deployment" and "DaemonSet". If unspecified, defaults
+	// to "Deployment". A DaemonSet runs one Envoy pod on every eligible node, which
+	// is the common pattern on clusters without a cloud load balancer.
+	//
+	// +kubebuilder:validation:Enum=Deployment;DaemonSet
+	// +optional
+	WorkloadType *KubeWorkloadType `json:"workloadType,omitempty"`
+
+	// Deployment defines the desired configuration of the Envoy Deployment resource.
+	// If unspecified, default configuration parameters will apply. When WorkloadType
+	// is "DaemonSet", the configuration applies to the Envoy DaemonSet resource.
+	//
+	// +optional
+	Deployment *KubeDeployment `json:"deployment,omitempty"`
+
+	// Service defines the desired configuration of the Envoy Service resource.
+	// If unspecified, default configuration parameters will apply.
+	//
+	// +optional
+	Service *KubeService `json:"service,omitempty"`
+
+	// ServiceAccount defines the desired configuration of the Envoy ServiceAccount
+	// resource. If unspecified, default configuration parameters will apply.
+	//
+	// +optional
+	ServiceAccount *KubeServiceAccount `json:"serviceAccount,omitempty"`
+
+	// EnvoyHPA defines the desired configuration of a HorizontalPodAutoscaler
+	// that scales the Envoy Deployment. If unspecified, no HorizontalPodAutoscaler
+	// is created and the Envoy Deployment runs a single replica. Ignored when
+	// WorkloadType is "DaemonSet".
+	//
+	// +optional
+	EnvoyHPA *KubeHorizontalPodAutoscaler `json:"envoyHpa,omitempty"`
+
+	// EnvoyPDB defines the desired configuration of a PodDisruptionBudget for the
+	// Envoy pods. If unspecified, no PodDisruptionBudget is created.
+	//
+	// +optional
+	EnvoyPDB *KubePodDisruptionBudget `json:"envoyPdb,omitempty"`
+
+	// NetworkPolicy defines the desired configuration of a NetworkPolicy for the
+	// Envoy pods. If specified, ingress to the Envoy pods is only allowed on the
+	// listener ports, and egress from the Envoy pods is only allowed to the Envoy
+	// Gateway xDS server, DNS and the destinations of NetworkPolicy.Egress. If
+	// unspecified, no NetworkPolicy is created.
+	//
+	// +optional
+	NetworkPolicy *KubeNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Prometheus defines the desired configuration of Prometheus metrics scraping
+	// of the Envoy pods. If specified, a PodMonitor is created when the Prometheus
+	// Operator CRDs are installed, otherwise the pods are annotated with the
+	// "prometheus.io" scrape annotations. If unspecified, neither is configured.
+	//
+	// +optional
+	Prometheus *KubePrometheus `json:"prometheus,omitempty"`
+}
+
+// KubeDeployment defines the desired configuration of a Kubernetes Deployment resource.
+type KubeDeployment struct {
+	// Labels are labels added to the Deployment. Labels managed by Envoy Gateway
+	// take precedence over labels with the same key.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are annotations added to the Deployment.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Strategy is the deployment strategy used to replace existing Envoy pods with
+	// new ones, e.g. RollingUpdate with maxSurge/maxUnavailable or Recreate. If
+	// unspecified, the Kubernetes default RollingUpdate strategy applies. Ignored
+	// when WorkloadType is "DaemonSet".
+	//
+	// +optional
+	Strategy *appsv1.DeploymentStrategy `json:"strategy,omitempty"`
+
+	// Pod defines the desired configuration of the Envoy pods.
+	// If unspecified, default configuration parameters will apply.
+	//
+	// +optional
+	Pod *KubePod `json:"pod,omitempty"`
+
+	// Container defines the desired configuration of the Envoy container.
+	// If unspecified, default configuration parameters will apply.
+	//
+	// +optional
+	Container *KubeContainer `json:"container,omitempty"`
+}
+
+// KubePod defines the desired configuration of a Kubernetes pod.
+type KubePod struct {
+	// Labels are labels added to the pods. Labels managed by Envoy Gateway
+	// take precedence over labels with the same key.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are annotations added to the pods, e.g. to configure metrics
+	// scraping or to exclude the pods from service mesh injection.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// SecurityContext holds pod-level security attributes and common container
+	// settings. If unspecified, the pods run as a non-root user.
+	//
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// NodeSelector is a selector which must match a node's labels for the pod
+	// to be scheduled on that node. More info:
+	// https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity defines the scheduling constraints of the pod.
+	//
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations allow the pod to be scheduled on nodes with matching taints.
+	//
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints describe how pods ought to spread across
+	// topology domains, e.g. zones.
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the pods, e.g.
+	// "system-cluster-critical" to protect the pods from preemption and eviction.
+	//
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the RuntimeClass used to run the pods,
+	// e.g. to run the pods in a sandbox such as gVisor or Kata Containers.
+	//
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// HostNetwork runs the pods in the network namespace of the node, exposing the
+	// listeners on the node addresses through the container ports of the listeners.
+	// Listeners on privileged ports are shifted to unprivileged container ports, e.g.
+	// 80 to 10080. HostNetwork and HostPorts are mutually exclusive.
+	//
+	// +optional
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// HostPorts exposes the listeners on the node addresses through host ports
+	// matching the listener ports, e.g. a listener on port 80 is reachable on port
+	// 80 of the node. HostNetwork and HostPorts are mutually exclusive.
+	//
+	// +optional
+	HostPorts *bool `json:"hostPorts,omitempty"`
+
+	// Volumes are additional volumes of the pod, e.g. to provide a custom CA bundle
+	// to the Envoy container through Container.VolumeMounts. Volume names must not
+	// conflict with the volumes managed by Envoy Gateway, i.e. "certs", "sds" and
+	// "bootstrap".
+	//
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// InitContainers are containers run to completion before the Envoy container
+	// is started. Container names must not conflict with "envoy".
+	//
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// SidecarContainers are containers run alongside the Envoy container, e.g. a
+	// metrics exporter. Container names must not conflict with "envoy".
+	//
+	// +optional
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 }
 
+// KubeContainer defines the desired configuration of a Kubernetes container.
+type KubeContainer struct {
+	// Image is the container image of the Envoy container. If unspecified, the
+	// default Envoy proxy image is used.
+	//
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// Args are additional command-line arguments passed to Envoy, appended to the
+	// arguments managed by Envoy Gateway.
+	//
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are additional environment variables set in the Envoy container.
+	// Environment variables managed by Envoy Gateway take precedence over
+	// variables with the same name.
+	//
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// VolumeMounts are additional volumes to mount into the Envoy container,
+	// e.g. volumes defined by Pod.Volumes.
+	//
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// ReadinessProbe defines the readiness probe of the Envoy container. The
+	// default probe checks the Envoy readiness endpoint, which succeeds once Envoy
+	// has received its initial xDS configuration. Unset thresholds and handler
+	// are taken from the default probe.
+	//
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// LivenessProbe defines the liveness probe of the Envoy container. The default
+	// probe checks the Envoy readiness endpoint. Unset thresholds and handler are
+	// taken from the default probe.
+	//
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// Resources defines the compute resource requests and limits of the container.
+	// If unspecified, no requests or limits are set. More info:
+	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// SecurityContext defines the security options the container should be run with.
+	// If unspecified, the container runs as a non-root user with a read-only root
+	// filesystem, without privilege escalation and with all capabilities dropped
+	// except NET_BIND_SERVICE, so that listeners may bind to ports below 1024.
+	//
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+// KubeService defines the desired configuration of a Kubernetes Service resource.
+type KubeService struct {
+	// Type determines how the Service is exposed. Valid options are "ClusterIP",
+	// "LoadBalancer" and "NodePort". If unspecified, defaults to "LoadBalancer".
+	//
+	// +kubebuilder:validation:Enum=ClusterIP;LoadBalancer;NodePort
+	// +optional
+	Type *KubeServiceType `json:"type,omitempty"`
+
+	// Labels are labels added to the Service. Labels managed by Envoy Gateway
+	// take precedence over labels with the same key.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are annotations added to the Service, e.g. to configure the
+	// behavior of a cloud provider load balancer.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// LoadBalancerIP requests a specific IP address from the load balancer
+	// implementation. Only applies to Services of type "LoadBalancer" and is
+	// ignored if the cloud provider does not support the feature.
+	//
+	// +optional
+	LoadBalancerIP *string `json:"loadBalancerIP,omitempty"`
+
+	// LoadBalancerClass is the class of the load balancer implementation the
+	// Service belongs to. Only applies to Services of type "LoadBalancer". If
+	// unspecified, the default load balancer implementation is used.
+	//
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// AllocateLoadBalancerNodePorts defines whether node ports are automatically
+	// allocated for the Service. Only applies to Services of type "LoadBalancer".
+	// If unspecified, node ports are allocated.
+	//
+	// +optional
+	AllocateLoadBalancerNodePorts *bool `json:"allocateLoadBalancerNodePorts,omitempty"`
+
+	// ExternalTrafficPolicy determines how the Service routes external traffic.
+	// Valid options are "Local" and "Cluster". "Local" preserves the client
+	// source IP and avoids a second hop for "LoadBalancer" and "NodePort"
+	// Services. Only applies to Services of type "LoadBalancer" and "NodePort".
+	// If unspecified, defaults to "Local".
+	//
+	// +kubebuilder:validation:Enum=Local;Cluster
+	// +optional
+	ExternalTrafficPolicy *KubeServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// IPFamilies is the list of IP families, e.g. "IPv4" and "IPv6", assigned to
+	// the Service. The first family is the primary family of the Service. If
+	// unspecified, the family is chosen based on IPFamilyPolicy and the cluster
+	// configuration. Envoy listeners bind on the IPv6 unspecified address when
+	// the IPv6 family is requested.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	IPFamilies []KubeIPFamily `json:"ipFamilies,omitempty"`
+
+	// IPFamilyPolicy represents the dual-stack-ness requested or required by the
+	// Service. Valid options are "SingleStack", "PreferDualStack" and
+	// "RequireDualStack". If unspecified, defaults to "SingleStack".
+	//
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy *KubeIPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+}
+
+// KubeIPFamily represents the IP family of a Service.
+//
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type KubeIPFamily string
+
+const (
+	// KubeIPFamilyIPv4 represents the IPv4 family.
+	KubeIPFamilyIPv4 KubeIPFamily = "IPv4"
+
+	// KubeIPFamilyIPv6 represents the IPv6 family.
+	KubeIPFamilyIPv6 KubeIPFamily = "IPv6"
+)
+
+// KubeIPFamilyPolicy represents the dual-stack-ness of a Service.
+type KubeIPFamilyPolicy string
+
+const (
+	// KubeIPFamilyPolicySingleStack assigns a single IP family to the Service.
+	KubeIPFamilyPolicySingleStack KubeIPFamilyPolicy = "SingleStack"
+
+	// KubeIPFamilyPolicyPreferDualStack assigns both IP families to the Service
+	// if the cluster is configured for dual-stack, or a single family otherwise.
+	KubeIPFamilyPolicyPreferDualStack KubeIPFamilyPolicy = "PreferDualStack"
+
+	// KubeIPFamilyPolicyRequireDualStack requires both IP families to be assigned
+	// to the Service.
+	KubeIPFamilyPolicyRequireDualStack KubeIPFamilyPolicy = "RequireDualStack"
+)
+
+// KubeServiceExternalTrafficPolicy determines how a Service routes external traffic.
+type KubeServiceExternalTrafficPolicy string
+
+const (
+	// KubeServiceExternalTrafficPolicyLocal routes external traffic to node-local
+	// endpoints only, preserving the client source IP.
+	KubeServiceExternalTrafficPolicyLocal KubeServiceExternalTrafficPolicy = "Local"
+
+	// KubeServiceExternalTrafficPolicyCluster routes external traffic to all
+	// endpoints of the Service.
+	KubeServiceExternalTrafficPolicyCluster KubeServiceExternalTrafficPolicy = "Cluster"
+)
+
+// KubeServiceAccount defines the desired configuration of a Kubernetes ServiceAccount resource.
+type KubeServiceAccount struct {
+	// Labels are labels added to the ServiceAccount. Labels managed by Envoy
+	// Gateway take precedence over labels with the same key.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are annotations added to the ServiceAccount, e.g. to bind the
+	// Envoy pods to a cloud identity through "eks.amazonaws.com/role-arn" (EKS IAM
+	// roles for service accounts) or "iam.gke.io/gcp-service-account" (GKE Workload
+	// Identity).
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Rules are the permissions granted to the ServiceAccount in the namespace of
+	// the Envoy pods, e.g. for sidecar containers accessing the Kubernetes API.
+	// Envoy itself doesn't access the Kubernetes API, so if unspecified, no Role
+	// and RoleBinding are created and no ServiceAccount token is mounted in the
+	// pods. The rules may only grant the get, list and watch verbs on configmaps,
+	// endpoints, pods and services of the core API group.
+	//
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+}
+
+// KubeHorizontalPodAutoscaler defines the desired configuration of a Kubernetes
+// HorizontalPodAutoscaler resource.
+type KubeHorizontalPodAutoscaler struct {
+	// MinReplicas is the lower limit for the number of replicas to which the
+	// autoscaler can scale down. If unspecified, defaults to 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas to which the
+	// autoscaler can scale up. It cannot be less than MinReplicas.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Metrics contains the specifications used to calculate the desired replica
+	// count, e.g. CPU, memory or custom metric targets. If unspecified, defaults
+	// to a target average CPU utilization of 80%. More info:
+	// https://kubernetes.io/docs/tasks/run-application/horizontal-pod-autoscale/
+	//
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+
+	// Behavior configures the scaling behavior of the autoscaler in both the up
+	// and down directions. If unspecified, the default Kubernetes scaling
+	// behavior applies.
+	//
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// KubePodDisruptionBudget defines the desired configuration of a Kubernetes
+// PodDisruptionBudget resource. At most one of MinAvailable and MaxUnavailable
+// may be specified. If neither is specified, MinAvailable defaults to 1.
+type KubePodDisruptionBudget struct {
+	// MinAvailable is the number or percentage of Envoy pods that must remain
+	// available after an eviction.
+	//
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of Envoy pods that can be
+	// unavailable after an eviction.
+	//
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// KubeNetworkPolicy defines the desired configuration of a Kubernetes NetworkPolicy
+// resource.
+type KubeNetworkPolicy struct {
+	// Egress are additional egress rules of the Envoy pods. Since egress is denied
+	// by default, rules must be added to allow traffic to the backends of routes.
+	//
+	// +optional
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// KubePrometheus defines the desired configuration of Prometheus metrics scraping
+// of the Envoy pods.
+type KubePrometheus struct {
+	// Labels are labels added to the PodMonitor, e.g. to match the podMonitorSelector
+	// of a Prometheus resource.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Interval is the interval at which metrics are scraped, e.g. "30s". If
+	// unspecified, the scrape interval of Prometheus applies. Only applies to the
+	// PodMonitor.
+	//
+	// +kubebuilder:validation:Pattern="^(0|([0-9]+(\\.[0-9]+)?(ms|s|m|h))+)$"
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+}
+
+// KubeWorkloadType determines the kind of workload resource used to run Envoy.
+type KubeWorkloadType string
+
+const (
+	// KubeWorkloadTypeDeployment means Envoy is run by a Deployment.
+	KubeWorkloadTypeDeployment KubeWorkloadType = "Deployment"
+
+	// KubeWorkloadTypeDaemonSet means Envoy is run by a DaemonSet.
+	KubeWorkloadTypeDaemonSet KubeWorkloadType = "DaemonSet"
+)
+
+// KubeServiceType determines how a Service is exposed.
+type KubeServiceType string
+
+const (
+	// KubeServiceTypeClusterIP means a Service will only be accessible inside the
+	// cluster, via the cluster IP.
+	KubeServiceTypeClusterIP KubeServiceType = "ClusterIP"
+
+	// KubeServiceTypeLoadBalancer means a Service will be exposed via an external
+	// load balancer, if the cloud provider supports it.
+	KubeServiceTypeLoadBalancer KubeServiceType = "LoadBalancer"
+
+	// KubeServiceTypeNodePort means a Service will be exposed on a port of every
+	// node, in addition to the cluster IP.
+	KubeServiceTypeNodePort KubeServiceType = "NodePort"
+)
+
 // EnvoyProxyStatus defines the observed state of EnvoyProxy
 type EnvoyProxyStatus struct {
-	// INSERT ADDITIONAL STATUS FIELDS - define observed state of cluster.
-	// Important: Run "make" to regenerate code after modifying this file.
+	// Conditions describe the current conditions of the EnvoyProxy.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted"
+	// * "Available"
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// GatewayClasses are the names of the GatewayClasses managed by Envoy Gateway
+	// that reference the EnvoyProxy through their parametersRef.
+	//
+	// +optional
+	GatewayClasses []string `json:"gatewayClasses,omitempty"`
+
+	// Replicas is the total number of Envoy replicas of the Gateways using the
+	// EnvoyProxy.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// AvailableReplicas is the number of available Envoy replicas of the Gateways
+	// using the EnvoyProxy.
+	//
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
 }
 
+// EnvoyProxyConditionType is a type of condition of an EnvoyProxy.
+type EnvoyProxyConditionType string
+
+// EnvoyProxyConditionReason is a reason of a condition of an EnvoyProxy.
+type EnvoyProxyConditionReason string
+
+const (
+	// EnvoyProxyConditionAccepted indicates whether the EnvoyProxy is used by the
+	// accepted GatewayClass of Envoy Gateway.
+	EnvoyProxyConditionAccepted EnvoyProxyConditionType = "Accepted"
+
+	// EnvoyProxyReasonAccepted is used with the "Accepted" condition when the
+	// EnvoyProxy is referenced by the accepted GatewayClass.
+	EnvoyProxyReasonAccepted EnvoyProxyConditionReason = "Accepted"
+
+	// EnvoyProxyReasonNotReferenced is used with the "Accepted" condition when the
+	// EnvoyProxy is not referenced by a GatewayClass managed by Envoy Gateway.
+	EnvoyProxyReasonNotReferenced EnvoyProxyConditionReason = "NotReferenced"
+
+	// EnvoyProxyReasonGatewayClassNotAccepted is used with the "Accepted" condition
+	// when none of the GatewayClasses referencing the EnvoyProxy are accepted.
+	EnvoyProxyReasonGatewayClassNotAccepted EnvoyProxyConditionReason = "GatewayClassNotAccepted"
+
+	// EnvoyProxyConditionAvailable indicates whether every Envoy workload of the
+	// Gateways using the EnvoyProxy has available replicas.
+	EnvoyProxyConditionAvailable EnvoyProxyConditionType = "Available"
+
+	// EnvoyProxyReasonAvailable is used with the "Available" condition when every
+	// Envoy workload has available replicas.
+	EnvoyProxyReasonAvailable EnvoyProxyConditionReason = "Available"
+
+	// EnvoyProxyReasonUnavailable is used with the "Available" condition when an
+	// Envoy workload has no available replicas.
+	EnvoyProxyReasonUnavailable EnvoyProxyConditionReason = "Unavailable"
+
+	// EnvoyProxyReasonNoResources is used with the "Available" condition when no
+	// Envoy workloads exist for the EnvoyProxy.
+	EnvoyProxyReasonNoResources EnvoyProxyConditionReason = "NoResources"
+)
+
 //+kubebuilder:object:root=true
 
 // EnvoyProxyList contains a list of EnvoyProxy
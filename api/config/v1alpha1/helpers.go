@@ -1,7 +1,52 @@
 package v1alpha1
 
 import (
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// defaultProxyUID is the user and group ID used to run the Envoy proxy. It
+	// matches the "envoy" user of the upstream Envoy image.
+	defaultProxyUID = int64(101)
+	// defaultHPAMinReplicas is the default lower limit for the number of Envoy replicas.
+	defaultHPAMinReplicas = int32(1)
+	// defaultHPACPUUtilization is the default target average CPU utilization of the Envoy pods.
+	defaultHPACPUUtilization = int32(80)
+	// defaultPDBMinAvailable is the default number of Envoy pods that must remain
+	// available after an eviction.
+	defaultPDBMinAvailable = 1
+	// defaultHTTPSRedirectPort is the default port of the HTTPS redirect listener.
+	defaultHTTPSRedirectPort = int32(80)
+	// defaultHTTPSRedirectStatusCode is the default status code of HTTPS redirect responses.
+	defaultHTTPSRedirectStatusCode = int32(301)
+	// defaultShrinkHeapThreshold is the default heap usage percentage at which
+	// Envoy releases free memory.
+	defaultShrinkHeapThreshold = int32(95)
+	// defaultStopAcceptingRequestsThreshold is the default heap usage percentage at
+	// which Envoy stops accepting requests.
+	defaultStopAcceptingRequestsThreshold = int32(98)
+	// defaultAdminAddress is the default address of the admin server of Envoy Gateway.
+	defaultAdminAddress = "127.0.0.1:19000"
+	// defaultHostEnvoyPath is the default path of the Envoy binary run on the host.
+	defaultHostEnvoyPath = "envoy"
+	// defaultHostHomeDir is the default directory of the bootstrap configurations
+	// of the Envoy processes run on the host.
+	defaultHostHomeDir = "/tmp/envoy-gateway"
+	// defaultHostCertsDir is the default directory of the xDS client certificate
+	// of the Envoy processes run on the host.
+	defaultHostCertsDir = "/certs/envoy"
+	// defaultDebounceWindow is the default debounce window of the translation.
+	defaultDebounceWindow = 100 * time.Millisecond
+	// defaultTranslationMaxDelay is the default maximum delay of the translation.
+	defaultTranslationMaxDelay = time.Second
+	// defaultTracingSamplingRate is the default percentage of the traces of Envoy
+	// Gateway that are sampled.
+	defaultTracingSamplingRate = uint32(100)
 )
 
 // DefaultEnvoyGateway returns a new EnvoyGateway with default configuration parameters.
@@ -56,3 +101,314 @@ func (e *EnvoyGateway) GetProvider() *Provider {
 	}
 	return DefaultProvider()
 }
+
+// GetAdmin returns a copy of the admin server configuration of Envoy Gateway with
+// defaults set for unspecified fields, or nil if the admin server is disabled.
+func (e *EnvoyGateway) GetAdmin() *EnvoyGatewayAdmin {
+	if e == nil || e.Admin == nil {
+		return nil
+	}
+
+	a := e.Admin.DeepCopy()
+	if a.Address == "" {
+		a.Address = defaultAdminAddress
+	}
+	return a
+}
+
+// GetTranslation returns a copy of the translation settings of Envoy Gateway,
+// with the defaults of the unspecified settings.
+func (e *EnvoyGateway) GetTranslation() *EnvoyGatewayTranslation {
+	t := new(EnvoyGatewayTranslation)
+	if e != nil && e.Translation != nil {
+		t = e.Translation.DeepCopy()
+	}
+	if t.DebounceWindow == nil {
+		t.DebounceWindow = &metav1.Duration{Duration: defaultDebounceWindow}
+	}
+	if t.MaxDelay == nil {
+		t.MaxDelay = &metav1.Duration{Duration: defaultTranslationMaxDelay}
+	}
+	return t
+}
+
+// GetHostInfrastructure returns a copy of the settings of the Envoy processes
+// managed with the File provider, with defaults set for unspecified fields.
+func (f *FileProvider) GetHostInfrastructure() *HostInfrastructure {
+	host := new(HostInfrastructure)
+	if f != nil && f.Infrastructure != nil {
+		host = f.Infrastructure.DeepCopy()
+	}
+	if host.EnvoyPath == "" {
+		host.EnvoyPath = defaultHostEnvoyPath
+	}
+	if host.HomeDir == "" {
+		host.HomeDir = defaultHostHomeDir
+	}
+	if host.CertsDir == "" {
+		host.CertsDir = defaultHostCertsDir
+	}
+	return host
+}
+
+// GetLevel returns the log level of the provided component, falling back to the
+// level of the default component and then to "info".
+func (l *EnvoyGatewayLogging) GetLevel(component EnvoyGatewayLogComponent) EnvoyGatewayLogLevel {
+	if l == nil {
+		return EnvoyGatewayLogLevelInfo
+	}
+	if level, ok := l.Level[component]; ok {
+		return level
+	}
+	if level, ok := l.Level[LogComponentDefault]; ok {
+		return level
+	}
+	return EnvoyGatewayLogLevelInfo
+}
+
+// GetEncoding returns the encoding of the logs, defaulting to "console".
+func (l *EnvoyGatewayLogging) GetEncoding() EnvoyGatewayLogEncoding {
+	if l == nil || l.Encoding == "" {
+		return EnvoyGatewayLogEncodingConsole
+	}
+	return l.Encoding
+}
+
+// GetSamplingRate returns the percentage of the traces that are sampled,
+// defaulting to 100.
+func (t *EnvoyGatewayTracing) GetSamplingRate() uint32 {
+	if t == nil || t.SamplingRate == nil {
+		return defaultTracingSamplingRate
+	}
+	return *t.SamplingRate
+}
+
+// DefaultProxyKubeProvider returns a new ProxyKubeProvider with default configuration parameters.
+func DefaultProxyKubeProvider() *ProxyKubeProvider {
+	workloadType := KubeWorkloadTypeDeployment
+	return &ProxyKubeProvider{
+		WorkloadType:   &workloadType,
+		Deployment:     DefaultKubeDeployment(),
+		Service:        DefaultKubeService(),
+		ServiceAccount: DefaultKubeServiceAccount(),
+	}
+}
+
+// DefaultKubeDeployment returns a new KubeDeployment with default configuration parameters.
+func DefaultKubeDeployment() *KubeDeployment {
+	return &KubeDeployment{
+		Pod:       DefaultKubePod(),
+		Container: DefaultKubeContainer(),
+	}
+}
+
+// DefaultKubePod returns a new KubePod with default configuration parameters.
+func DefaultKubePod() *KubePod {
+	return &KubePod{
+		SecurityContext: DefaultKubePodSecurityContext(),
+	}
+}
+
+// DefaultKubePodSecurityContext returns a new PodSecurityContext that runs
+// the pod as a non-root user.
+func DefaultKubePodSecurityContext() *corev1.PodSecurityContext {
+	uid := defaultProxyUID
+	nonRoot := true
+	return &corev1.PodSecurityContext{
+		RunAsUser:    &uid,
+		RunAsGroup:   &uid,
+		RunAsNonRoot: &nonRoot,
+	}
+}
+
+// DefaultKubeContainer returns a new KubeContainer with default configuration parameters.
+func DefaultKubeContainer() *KubeContainer {
+	return &KubeContainer{
+		SecurityContext: DefaultKubeContainerSecurityContext(),
+	}
+}
+
+// DefaultKubeContainerSecurityContext returns a new SecurityContext that runs the
+// container with a read-only root filesystem, without privilege escalation and
+// with all capabilities dropped except NET_BIND_SERVICE.
+func DefaultKubeContainerSecurityContext() *corev1.SecurityContext {
+	nonRoot := true
+	readOnly := true
+	escalation := false
+	return &corev1.SecurityContext{
+		RunAsNonRoot:             &nonRoot,
+		ReadOnlyRootFilesystem:   &readOnly,
+		AllowPrivilegeEscalation: &escalation,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+			Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+		},
+	}
+}
+
+// DefaultKubeService returns a new KubeService with default configuration parameters.
+func DefaultKubeService() *KubeService {
+	svcType := KubeServiceTypeLoadBalancer
+	policy := KubeServiceExternalTrafficPolicyLocal
+	return &KubeService{
+		Type:                  &svcType,
+		ExternalTrafficPolicy: &policy,
+	}
+}
+
+// DefaultKubeServiceAccount returns a new KubeServiceAccount with default configuration parameters.
+func DefaultKubeServiceAccount() *KubeServiceAccount {
+	return &KubeServiceAccount{}
+}
+
+// DefaultKubeHPAMetrics returns the default metrics used to scale the Envoy Deployment.
+func DefaultKubeHPAMetrics() []autoscalingv2.MetricSpec {
+	utilization := defaultHPACPUUtilization
+	return []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		},
+	}
+}
+
+// GetProxyProviderType returns the resource provider type of the EnvoyProxy,
+// defaulting to "Kubernetes" if unspecified.
+func (e *EnvoyProxy) GetProxyProviderType() ProviderType {
+	if e == nil || e.Spec.Provider == nil {
+		return ProviderTypeKubernetes
+	}
+	return e.Spec.Provider.Type
+}
+
+// GetKubeProvider returns a copy of the Kubernetes resource provider configuration
+// of the EnvoyProxy with default configuration parameters set for unspecified fields.
+// The EnvoyProxy is not modified.
+func (e *EnvoyProxy) GetKubeProvider() *ProxyKubeProvider {
+	if e == nil || e.Spec.Provider == nil || e.Spec.Provider.Kubernetes == nil {
+		return DefaultProxyKubeProvider()
+	}
+
+	kp := e.Spec.Provider.Kubernetes.DeepCopy()
+	if kp.WorkloadType == nil {
+		kp.WorkloadType = DefaultProxyKubeProvider().WorkloadType
+	}
+	if kp.Deployment == nil {
+		kp.Deployment = DefaultKubeDeployment()
+	}
+	if kp.Deployment.Pod == nil {
+		kp.Deployment.Pod = DefaultKubePod()
+	}
+	if kp.Deployment.Pod.SecurityContext == nil {
+		kp.Deployment.Pod.SecurityContext = DefaultKubePodSecurityContext()
+	}
+	if kp.Deployment.Container == nil {
+		kp.Deployment.Container = DefaultKubeContainer()
+	}
+	if kp.Deployment.Container.SecurityContext == nil {
+		kp.Deployment.Container.SecurityContext = DefaultKubeContainerSecurityContext()
+	}
+	if kp.Service == nil {
+		kp.Service = DefaultKubeService()
+	}
+	if kp.Service.Type == nil {
+		kp.Service.Type = DefaultKubeService().Type
+	}
+	if kp.Service.ExternalTrafficPolicy == nil {
+		kp.Service.ExternalTrafficPolicy = DefaultKubeService().ExternalTrafficPolicy
+	}
+	if kp.ServiceAccount == nil {
+		kp.ServiceAccount = DefaultKubeServiceAccount()
+	}
+	if kp.EnvoyHPA != nil {
+		if kp.EnvoyHPA.MinReplicas == nil {
+			minReplicas := defaultHPAMinReplicas
+			kp.EnvoyHPA.MinReplicas = &minReplicas
+		}
+		if len(kp.EnvoyHPA.Metrics) == 0 {
+			kp.EnvoyHPA.Metrics = DefaultKubeHPAMetrics()
+		}
+	}
+	if kp.EnvoyPDB != nil && kp.EnvoyPDB.MinAvailable == nil && kp.EnvoyPDB.MaxUnavailable == nil {
+		minAvailable := intstr.FromInt(defaultPDBMinAvailable)
+		kp.EnvoyPDB.MinAvailable = &minAvailable
+	}
+
+	return kp
+}
+
+// IPv6Enabled returns true if the KubeService is assigned the IPv6 family
+// or requests dual-stack.
+func (s *KubeService) IPv6Enabled() bool {
+	if s == nil {
+		return false
+	}
+	if s.IPFamilyPolicy != nil && *s.IPFamilyPolicy != KubeIPFamilyPolicySingleStack {
+		return true
+	}
+	for _, family := range s.IPFamilies {
+		if family == KubeIPFamilyIPv6 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHTTPSRedirect returns a copy of the HTTPS redirect configuration of the
+// EnvoyProxy with defaults set for unspecified fields, or nil if the HTTPS
+// redirect is not enabled. The EnvoyProxy is not modified.
+func (e *EnvoyProxy) GetHTTPSRedirect() *HTTPSRedirect {
+	if e == nil || e.Spec.HTTPSRedirect == nil {
+		return nil
+	}
+
+	r := e.Spec.HTTPSRedirect.DeepCopy()
+	if r.Port == nil {
+		port := defaultHTTPSRedirectPort
+		r.Port = &port
+	}
+	if r.StatusCode == nil {
+		code := defaultHTTPSRedirectStatusCode
+		r.StatusCode = &code
+	}
+
+	return r
+}
+
+// GetOverload returns a copy of the overload protection configuration of the
+// EnvoyProxy with defaults set for unspecified fields, or nil if overload
+// protection is not enabled. The EnvoyProxy is not modified.
+func (e *EnvoyProxy) GetOverload() *ProxyOverload {
+	if e == nil || e.Spec.Overload == nil {
+		return nil
+	}
+
+	o := e.Spec.Overload.DeepCopy()
+	if o.Heap != nil {
+		if o.Heap.ShrinkHeapThreshold == nil {
+			threshold := defaultShrinkHeapThreshold
+			o.Heap.ShrinkHeapThreshold = &threshold
+		}
+		if o.Heap.StopAcceptingRequestsThreshold == nil {
+			threshold := defaultStopAcceptingRequestsThreshold
+			o.Heap.StopAcceptingRequestsThreshold = &threshold
+		}
+	}
+
+	return o
+}
+
+// GetMetrics returns the metrics configuration of the EnvoyProxy, or nil if
+// unspecified.
+func (e *EnvoyProxy) GetMetrics() *ProxyMetrics {
+	if e == nil || e.Spec.Telemetry == nil {
+		return nil
+	}
+	return e.Spec.Telemetry.Metrics
+}
@@ -0,0 +1,205 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// xdsResourceTypes are the types of the xDS resources dumped by /debug/xds.
+var xdsResourceTypes = []string{
+	resourcev3.ListenerType,
+	resourcev3.RouteType,
+	resourcev3.ClusterType,
+	resourcev3.EndpointType,
+	resourcev3.SecretType,
+	resourcev3.RuntimeType,
+}
+
+// registerDebugHandlers registers the handlers of the /debug endpoints dumping the
+// state of Envoy Gateway.
+func (r *Runner) registerDebugHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/xds", r.handleXdsDump)
+	mux.HandleFunc("/debug/ir", r.handleIRDump)
+	mux.HandleFunc("/debug/provider", r.handleProviderDump)
+}
+
+// xdsNodeDump is the dump of the xDS snapshot of an Envoy node.
+type xdsNodeDump struct {
+	ID        string                       `json:"id"`
+	Cluster   string                       `json:"cluster"`
+	Resources map[string]*xdsResourcesDump `json:"resources"`
+}
+
+// xdsResourcesDump is the dump of the xDS resources of a type.
+type xdsResourcesDump struct {
+	Version   string            `json:"version"`
+	Resources []json.RawMessage `json:"resources"`
+}
+
+func (r *Runner) handleXdsDump(w http.ResponseWriter, _ *http.Request) {
+	var nodes []*xdsNodeDump
+	for _, snapshot := range r.NodeSnapshots() {
+		node := &xdsNodeDump{
+			ID:        snapshot.Node.Id,
+			Cluster:   snapshot.Node.Cluster,
+			Resources: map[string]*xdsResourcesDump{},
+		}
+		for _, typ := range xdsResourceTypes {
+			resources := snapshot.Snapshot.GetResourcesAndTTL(typ)
+			if len(resources) == 0 {
+				continue
+			}
+			dump := &xdsResourcesDump{Version: snapshot.Snapshot.GetVersion(typ)}
+			for _, name := range sortedKeys(resources) {
+				data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(redactXdsResource(resources[name].Resource))
+				if err != nil {
+					http.Error(w, fmt.Sprintf("failed to marshal resource %s: %v", name, err), http.StatusInternalServerError)
+					return
+				}
+				dump.Resources = append(dump.Resources, data)
+			}
+			node.Resources[typ] = dump
+		}
+		nodes = append(nodes, node)
+	}
+
+	r.writeJSON(w, map[string]interface{}{"nodes": nodes})
+}
+
+// redactXdsResource returns the provided resource without its private key, if any.
+func redactXdsResource(res cachetypes.Resource) cachetypes.Resource {
+	secret, ok := res.(*tlsv3.Secret)
+	if !ok || secret.GetTlsCertificate() == nil {
+		return res
+	}
+	secret = proto.Clone(secret).(*tlsv3.Secret)
+	secret.GetTlsCertificate().PrivateKey = nil
+	return secret
+}
+
+// irDump is the dump of the intermediate representation, keyed by IR key.
+type irDump struct {
+	Xds   map[string]*ir.Xds   `json:"xds"`
+	Infra map[string]*ir.Infra `json:"infra"`
+}
+
+func (r *Runner) handleIRDump(w http.ResponseWriter, _ *http.Request) {
+	dump := &irDump{
+		Xds:   map[string]*ir.Xds{},
+		Infra: r.InfraIR.LoadAll(),
+	}
+	for key, xds := range r.XdsIR.LoadAll() {
+		xds = xds.DeepCopy()
+		for _, listener := range xds.HTTP {
+			if listener.TLS != nil {
+				listener.TLS.PrivateKey = nil
+			}
+		}
+		dump.Xds[key] = xds
+	}
+
+	r.writeJSON(w, dump)
+}
+
+// providerDump is the dump of the resources of the provider.
+type providerDump struct {
+	GatewayClasses         []*gwapiv1b1.GatewayClass        `json:"gatewayClasses"`
+	Gateways               []*gwapiv1b1.Gateway             `json:"gateways"`
+	HTTPRoutes             []*gwapiv1b1.HTTPRoute           `json:"httpRoutes"`
+	TLSRoutes              []*gwapiv1a2.TLSRoute            `json:"tlsRoutes"`
+	ReferenceGrants        []*gwapiv1a2.ReferenceGrant      `json:"referenceGrants"`
+	Namespaces             []*corev1.Namespace              `json:"namespaces"`
+	Services               []*corev1.Service                `json:"services"`
+	Secrets                []*corev1.Secret                 `json:"secrets"`
+	ConfigMaps             []*corev1.ConfigMap              `json:"configMaps"`
+	EnvoyProxies           []*v1alpha1.EnvoyProxy           `json:"envoyProxies"`
+	BackendTrafficPolicies []*v1alpha1.BackendTrafficPolicy `json:"backendTrafficPolicies"`
+	ClientTrafficPolicies  []*v1alpha1.ClientTrafficPolicy  `json:"clientTrafficPolicies"`
+	EnvoyExtensionPolicies []*v1alpha1.EnvoyExtensionPolicy `json:"envoyExtensionPolicies"`
+	EnvoyPatchPolicies     []*v1alpha1.EnvoyPatchPolicy     `json:"envoyPatchPolicies"`
+}
+
+func (r *Runner) handleProviderDump(w http.ResponseWriter, _ *http.Request) {
+	res := r.ProviderResources
+	dump := &providerDump{
+		GatewayClasses:         sortedValues(res.GatewayClasses.LoadAll()),
+		Gateways:               sortedValues(res.Gateways.LoadAll()),
+		HTTPRoutes:             sortedValues(res.HTTPRoutes.LoadAll()),
+		TLSRoutes:              sortedValues(res.TLSRoutes.LoadAll()),
+		ReferenceGrants:        sortedValues(res.ReferenceGrants.LoadAll()),
+		Namespaces:             sortedValues(res.Namespaces.LoadAll()),
+		Services:               sortedValues(res.Services.LoadAll()),
+		ConfigMaps:             sortedValues(res.ConfigMaps.LoadAll()),
+		EnvoyProxies:           sortedValues(res.EnvoyProxies.LoadAll()),
+		BackendTrafficPolicies: sortedValues(res.BackendTrafficPolicies.LoadAll()),
+		ClientTrafficPolicies:  sortedValues(res.ClientTrafficPolicies.LoadAll()),
+		EnvoyExtensionPolicies: sortedValues(res.EnvoyExtensionPolicies.LoadAll()),
+		EnvoyPatchPolicies:     sortedValues(res.EnvoyPatchPolicies.LoadAll()),
+	}
+	// Only the keys of the data of the Secrets are dumped.
+	for _, secret := range sortedValues(res.Secrets.LoadAll()) {
+		secret = secret.DeepCopy()
+		for key := range secret.Data {
+			secret.Data[key] = nil
+		}
+		secret.StringData = nil
+		dump.Secrets = append(dump.Secrets, secret)
+	}
+
+	r.writeJSON(w, dump)
+}
+
+// writeJSON writes the JSON representation of v to w.
+func (r *Runner) writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to marshal dump: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		r.Logger.Error(err, "failed to write dump")
+	}
+}
+
+// sortedKeys returns the keys of the provided map, sorted.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedValues returns the values of the provided map, sorted by the string
+// representation of their key.
+func sortedValues[K comparable, V any](m map[K]V) []V {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	values := make([]V, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, m[key])
+	}
+	return values
+}
@@ -0,0 +1,135 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	cfg, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	return New(&Config{
+		Server:            *cfg,
+		ProviderResources: new(message.ProviderResources),
+		XdsIR:             new(message.XdsIR),
+		InfraIR:           new(message.InfraIR),
+		NodeSnapshots:     func() []cache.NodeSnapshot { return nil },
+	})
+}
+
+// requireDump requests the provided debug endpoint and decodes the dump into out.
+func requireDump(t *testing.T, r *Runner, path string, out interface{}) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	r.registerDebugHandlers(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out))
+	return rec.Body.String()
+}
+
+func TestXdsDump(t *testing.T) {
+	r := newTestRunner(t)
+	snapshot, err := cachev3.NewSnapshot("1", map[resourcev3.Type][]cachetypes.Resource{
+		resourcev3.ClusterType: {&clusterv3.Cluster{Name: "cluster-b"}, &clusterv3.Cluster{Name: "cluster-a"}},
+		resourcev3.SecretType: {&tlsv3.Secret{
+			Name: "secret",
+			Type: &tlsv3.Secret_TlsCertificate{TlsCertificate: &tlsv3.TlsCertificate{
+				CertificateChain: &corev3.DataSource{Specifier: &corev3.DataSource_InlineString{InlineString: "cert-data"}},
+				PrivateKey:       &corev3.DataSource{Specifier: &corev3.DataSource_InlineString{InlineString: "key-data"}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	r.NodeSnapshots = func() []cache.NodeSnapshot {
+		return []cache.NodeSnapshot{{
+			Node:     &corev3.Node{Id: "envoy-1", Cluster: "envoy-gateway-gateway-1"},
+			Snapshot: snapshot,
+		}}
+	}
+
+	var dump struct {
+		Nodes []*xdsNodeDump `json:"nodes"`
+	}
+	body := requireDump(t, r, "/debug/xds", &dump)
+	require.Len(t, dump.Nodes, 1)
+	require.Equal(t, "envoy-1", dump.Nodes[0].ID)
+	require.Equal(t, "envoy-gateway-gateway-1", dump.Nodes[0].Cluster)
+
+	clusters := dump.Nodes[0].Resources[resourcev3.ClusterType]
+	require.Equal(t, "1", clusters.Version)
+	require.Len(t, clusters.Resources, 2)
+	require.JSONEq(t, `{"name":"cluster-a"}`, string(clusters.Resources[0]))
+
+	// The private keys are redacted.
+	require.Contains(t, body, "cert-data")
+	require.NotContains(t, body, "key-data")
+}
+
+func TestIRDump(t *testing.T) {
+	r := newTestRunner(t)
+	r.XdsIR.Store("envoy-gateway-gateway-1", &ir.Xds{
+		HTTP: []*ir.HTTPListener{{
+			Name: "listener",
+			TLS: &ir.TLSListenerConfig{
+				ServerCertificate: []byte("cert-data"),
+				PrivateKey:        []byte("key-data"),
+			},
+		}},
+	})
+	r.InfraIR.Store("envoy-gateway-gateway-1", ir.NewInfra())
+
+	var dump irDump
+	requireDump(t, r, "/debug/ir", &dump)
+	require.Contains(t, dump.Infra, "envoy-gateway-gateway-1")
+	tls := dump.Xds["envoy-gateway-gateway-1"].HTTP[0].TLS
+	require.Equal(t, []byte("cert-data"), tls.ServerCertificate)
+	require.Empty(t, tls.PrivateKey)
+
+	// The stored IR isn't modified.
+	stored, _ := r.XdsIR.Load("envoy-gateway-gateway-1")
+	require.Equal(t, []byte("key-data"), stored.HTTP[0].TLS.PrivateKey)
+}
+
+func TestProviderDump(t *testing.T) {
+	r := newTestRunner(t)
+	for _, name := range []string{"svc-b", "svc-a"} {
+		r.ProviderResources.Services.Store(types.NamespacedName{Namespace: "default", Name: name},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}})
+	}
+	r.ProviderResources.Secrets.Store(types.NamespacedName{Namespace: "default", Name: "secret"}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "secret"},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: []byte("key-data")},
+	})
+
+	var dump providerDump
+	body := requireDump(t, r, "/debug/provider", &dump)
+	require.Len(t, dump.Services, 2)
+	require.Equal(t, "svc-a", dump.Services[0].Name)
+
+	// Only the keys of the data of the Secrets are dumped.
+	require.Len(t, dump.Secrets, 1)
+	require.Contains(t, dump.Secrets[0].Data, corev1.TLSPrivateKeyKey)
+	require.NotContains(t, body, "a2V5LWRhdGE=")
+}
@@ -0,0 +1,19 @@
+package admin
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+)
+
+// registerPprofHandlers registers the handlers of the /debug/pprof endpoints
+// profiling Envoy Gateway and of the /debug/vars endpoint exposing its runtime
+// variables.
+func registerPprofHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+const (
+	// readHeaderTimeout is the time allowed to read the headers of the requests
+	// to the admin server.
+	readHeaderTimeout = 10 * time.Second
+	// shutdownTimeout is the time allowed for the admin server to complete the
+	// pending requests once Envoy Gateway shuts down.
+	shutdownTimeout = 5 * time.Second
+)
+
+type Config struct {
+	config.Server
+	ProviderResources *message.ProviderResources
+	XdsIR             *message.XdsIR
+	InfraIR           *message.InfraIR
+	// NodeSnapshots returns the xDS snapshots of the connected Envoy nodes.
+	NodeSnapshots func() []cache.NodeSnapshot
+}
+
+type Runner struct {
+	Config
+	server *http.Server
+}
+
+func New(cfg *Config) *Runner {
+	return &Runner{Config: *cfg}
+}
+
+func (r *Runner) Name() string {
+	return "admin"
+}
+
+// Start starts the admin runner, if the admin server is enabled.
+func (r *Runner) Start(ctx context.Context) error {
+	r.Logger = r.Logger.WithValues("runner", r.Name())
+	admin := r.EnvoyGateway.GetAdmin()
+	if admin == nil {
+		r.Logger.Info("admin server is disabled")
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	if admin.EnableDumpConfig {
+		r.registerDebugHandlers(mux)
+	}
+	if admin.EnablePprof {
+		registerPprofHandlers(mux)
+	}
+	r.server = &http.Server{
+		Addr:              admin.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go r.serve(ctx)
+	r.Logger.Info("started", "address", admin.Address)
+	return nil
+}
+
+// serve serves the admin server until ctx is done.
+func (r *Runner) serve(ctx context.Context) {
+	go func() {
+		if err := r.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			r.Logger.Error(err, "failed to start admin server")
+		}
+	}()
+
+	<-ctx.Done()
+	r.Logger.Info("admin server shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := r.server.Shutdown(shutdownCtx); err != nil {
+		r.Logger.Error(err, "failed to shut down admin server")
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
@@ -10,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
@@ -29,26 +31,48 @@ func getCertGenCommand() *cobra.Command {
 	return cmd
 }
 
-// certGen generates control plane certificates.
+// certGen generates control plane certificates, or rotates them when they are about
+// to expire.
 func certGen() error {
 	cfg, err := getConfig()
 	if err != nil {
 		return err
 	}
-	log := cfg.Logger
 
-	certs, err := crypto.GenerateCerts(cfg.EnvoyGateway)
+	cli, err := client.New(clicfg.GetConfigOrDie(), client.Options{Scheme: envoygateway.GetScheme()})
 	if err != nil {
-		return fmt.Errorf("failed to generate certificates: %v", err)
+		return fmt.Errorf("failed to create controller-runtime client: %v", err)
 	}
-	log.Info("generated certificates")
 
-	cli, err := client.New(clicfg.GetConfigOrDie(), client.Options{Scheme: envoygateway.GetScheme()})
+	return generateOrRotateCerts(ctrl.SetupSignalHandler(), cfg.Logger, cli, cfg.EnvoyGateway, time.Now())
+}
+
+// generateOrRotateCerts generates the control plane certificates if they don't exist
+// or expire within the renewal threshold. The rotated certificates are issued by a
+// new CA, and the previous CA remains trusted until the next rotation.
+func generateOrRotateCerts(ctx context.Context, log logr.Logger, cli client.Client, egCfg *v1alpha1.EnvoyGateway, now time.Time) error {
+	current, err := kubernetes.GetCerts(ctx, cli, config.EnvoyGatewayNamespace)
 	if err != nil {
-		return fmt.Errorf("failed to create controller-runtime client: %v", err)
+		return fmt.Errorf("failed to get certificates: %v", err)
+	}
+	renewalDeadline := now.Add(24 * time.Duration(crypto.DefaultCertificateRenewalThreshold) * time.Hour)
+	if current != nil && !crypto.CertificatesExpireBefore(current, renewalDeadline) {
+		log.Info("certificates are up to date")
+		return nil
+	}
+
+	certs, err := crypto.GenerateCerts(egCfg)
+	if err != nil {
+		return fmt.Errorf("failed to generate certificates: %v", err)
+	}
+	if current != nil {
+		crypto.TrustPreviousCA(certs, current.CACertificate, now)
+		log.Info("rotated certificates")
+	} else {
+		log.Info("generated certificates")
 	}
 
-	if err := outputCerts(ctrl.SetupSignalHandler(), log, cli, certs); err != nil {
+	if err := outputCerts(ctx, log, cli, certs); err != nil {
 		return fmt.Errorf("failed to output certificates: %v", err)
 	}
 
@@ -67,5 +91,17 @@ func outputCerts(ctx context.Context, log logr.Logger, cli client.Client, certs
 		log.Info("created secret", "namespace", s.Namespace, "name", s.Name)
 	}
 
+	// The copies of the Envoy certificates in the namespaces of the managed Envoy
+	// resources are updated with the certificates.
+	copies, err := kubernetes.UpdateEnvoySecretCopies(ctx, cli, certs)
+	if err != nil {
+		return fmt.Errorf("failed to update secret copies: %v", err)
+	}
+
+	for i := range copies {
+		s := copies[i]
+		log.Info("updated secret", "namespace", s.Namespace, "name", s.Name)
+	}
+
 	return nil
 }
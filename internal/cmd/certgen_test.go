@@ -1,12 +1,68 @@
 package cmd
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
 )
 
 func TestGetCertgenCommand(t *testing.T) {
 	got := getCertGenCommand()
 	assert.Equal(t, "certgen", got.Use)
 }
+
+func TestGenerateOrRotateCerts(t *testing.T) {
+	ctx := context.Background()
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	// A copy of the Envoy certificates made in the namespace of managed Envoy
+	// resources.
+	envoyCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "edge",
+			Name:      "envoy",
+			Labels:    map[string]string{config.EnvoyCertsCopyLabel: "true"},
+		},
+	}
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(envoyCopy).Build()
+	now := time.Now()
+
+	// The certificates are generated when they don't exist.
+	require.NoError(t, generateOrRotateCerts(ctx, logger, cli, nil, now))
+	generated, err := kubernetes.GetCerts(ctx, cli, config.EnvoyGatewayNamespace)
+	require.NoError(t, err)
+	require.NotNil(t, generated)
+
+	// The certificates are kept until they expire within the renewal threshold.
+	require.NoError(t, generateOrRotateCerts(ctx, logger, cli, nil, now.AddDate(0, 0, 300)))
+	current, err := kubernetes.GetCerts(ctx, cli, config.EnvoyGatewayNamespace)
+	require.NoError(t, err)
+	require.Equal(t, generated, current)
+
+	// The rotated certificates are issued by a new CA, and the previous CA remains
+	// trusted.
+	require.NoError(t, generateOrRotateCerts(ctx, logger, cli, nil, now.AddDate(0, 0, 340)))
+	rotated, err := kubernetes.GetCerts(ctx, cli, config.EnvoyGatewayNamespace)
+	require.NoError(t, err)
+	require.NotEqual(t, generated.EnvoyCertificate, rotated.EnvoyCertificate)
+	require.Contains(t, string(rotated.CACertificate), string(generated.CACertificate))
+	require.NotEqual(t, generated.CACertificate, rotated.CACertificate)
+
+	// The copy of the Envoy certificates is updated with the rotated certificates.
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(envoyCopy), envoyCopy))
+	require.Equal(t, rotated.CACertificate, envoyCopy.Data["ca.crt"])
+	require.Equal(t, rotated.EnvoyCertificate, envoyCopy.Data[corev1.TLSCertKey])
+	require.Equal(t, rotated.EnvoyPrivateKey, envoyCopy.Data[corev1.TLSPrivateKeyKey])
+}
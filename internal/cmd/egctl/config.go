@@ -0,0 +1,238 @@
+package egctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	infrakube "github.com/envoyproxy/gateway/internal/infrastructure/kubernetes"
+)
+
+const (
+	// jsonOutput prints the config dumps as JSON.
+	jsonOutput = "json"
+	// yamlOutput prints the config dumps as YAML.
+	yamlOutput = "yaml"
+	// adminRequestTimeout is the timeout of the requests to the Envoy admin
+	// interface.
+	adminRequestTimeout = 10 * time.Second
+)
+
+// configDumpTypes are the types of the configs of the config dump printed by
+// each command, or nil for all the configs.
+var configDumpTypes = []struct {
+	name  string
+	types []string
+}{
+	{name: "listener", types: []string{"type.googleapis.com/envoy.admin.v3.ListenersConfigDump"}},
+	{name: "route", types: []string{"type.googleapis.com/envoy.admin.v3.RoutesConfigDump"}},
+	{name: "cluster", types: []string{"type.googleapis.com/envoy.admin.v3.ClustersConfigDump"}},
+	{name: "all"},
+}
+
+// envoyProxyConfigOptions are the options of the envoy-proxy config commands.
+type envoyProxyConfigOptions struct {
+	namespace string
+	output    string
+}
+
+// getConfigCommand returns the config cobra command to be executed.
+func getConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Retrieve the configuration of the managed proxies",
+	}
+
+	cmd.AddCommand(getEnvoyProxyConfigCommand())
+
+	return cmd
+}
+
+// getEnvoyProxyConfigCommand returns the envoy-proxy config cobra command to be
+// executed, with a subcommand per config dump type.
+func getEnvoyProxyConfigCommand() *cobra.Command {
+	opts := new(envoyProxyConfigOptions)
+
+	cmd := &cobra.Command{
+		Use:   "envoy-proxy",
+		Short: "Retrieve the configuration of a managed Envoy proxy from its admin interface",
+		Long: `Retrieve the configuration of a pod of a managed Envoy proxy from the config
+dump of its admin interface, which is port-forwarded to a local port.`,
+	}
+
+	cmd.PersistentFlags().StringVarP(&opts.namespace, "namespace", "n", config.EnvoyGatewayNamespace,
+		"The namespace of the pod.")
+	cmd.PersistentFlags().StringVarP(&opts.output, "output", "o", jsonOutput,
+		"The output format, either json or yaml.")
+
+	for _, dumpType := range configDumpTypes {
+		dumpType := dumpType
+		cmd.AddCommand(&cobra.Command{
+			Use:     dumpType.name + " <pod>",
+			Short:   fmt.Sprintf("Retrieve the %s configuration of a managed Envoy proxy", dumpType.name),
+			Example: fmt.Sprintf("  egctl config envoy-proxy %s envoy-default-eg-64656661-5d9c8b5d6c-2xq4z", dumpType.name),
+			Args:    cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return envoyProxyConfig(cmd.Context(), cmd.OutOrStdout(), opts, args[0], dumpType.types)
+			},
+		})
+	}
+
+	return cmd
+}
+
+// envoyProxyConfig writes the configs of the provided types of the config dump
+// of the provided managed Envoy proxy pod to w.
+func envoyProxyConfig(ctx context.Context, w io.Writer, opts *envoyProxyConfigOptions, podName string, types []string) error {
+	if opts.output != jsonOutput && opts.output != yamlOutput {
+		return fmt.Errorf("unsupported output format %q, must be %s or %s", opts.output, jsonOutput, yamlOutput)
+	}
+
+	restCfg, err := clicfg.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	cli, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	pod, err := cli.CoreV1().Pods(opts.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := checkEnvoyProxyPod(pod); err != nil {
+		return err
+	}
+
+	localPort, stop, err := portForwardAdmin(restCfg, cli, pod)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	return writeConfigDump(ctx, w, fmt.Sprintf("http://127.0.0.1:%d", localPort), types, opts.output)
+}
+
+// checkEnvoyProxyPod returns an error if the provided pod isn't a running pod of
+// a managed Envoy proxy.
+func checkEnvoyProxyPod(pod *corev1.Pod) error {
+	if !labels.SelectorFromSet(infrakube.EnvoyAppLabel()).Matches(labels.Set(pod.Labels)) {
+		return fmt.Errorf("pod %s/%s is not a managed Envoy proxy", pod.Namespace, pod.Name)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Errorf("pod %s/%s is not running", pod.Namespace, pod.Name)
+	}
+	return nil
+}
+
+// portForwardAdmin forwards a random local port to the admin interface of the
+// provided pod, and returns the local port and a function stopping the
+// forwarding.
+func portForwardAdmin(restCfg *rest.Config, cli kubernetes.Interface, pod *corev1.Pod) (uint16, func(), error) {
+	transport, upgrader, err := spdy.RoundTripperFor(restCfg)
+	if err != nil {
+		return 0, nil, err
+	}
+	url := cli.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).
+		SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopCh, readyCh := make(chan struct{}), make(chan struct{})
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", infrakube.EnvoyAdminPort)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return 0, nil, err
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case err := <-errCh:
+		return 0, nil, fmt.Errorf("failed to port-forward to pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	case <-readyCh:
+	}
+	ports, err := fw.GetPorts()
+	if err != nil {
+		close(stopCh)
+		return 0, nil, err
+	}
+	return ports[0].Local, func() { close(stopCh) }, nil
+}
+
+// writeConfigDump writes the configs of the provided types of the config dump
+// of the Envoy admin interface at adminURL to w, or all of them if types is
+// empty.
+func writeConfigDump(ctx context.Context, w io.Writer, adminURL string, types []string, output string) error {
+	ctx, cancel := context.WithTimeout(ctx, adminRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, adminURL+"/config_dump", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get config dump: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get config dump: unexpected status %s", resp.Status)
+	}
+
+	// The configs are filtered without decoding them, since the config dump may
+	// hold extensions that Envoy Gateway doesn't know.
+	var dump struct {
+		Configs []json.RawMessage `json:"configs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&dump); err != nil {
+		return fmt.Errorf("failed to decode config dump: %w", err)
+	}
+	if len(types) > 0 {
+		var configs []json.RawMessage
+		for _, cfg := range dump.Configs {
+			var typed struct {
+				Type string `json:"@type"`
+			}
+			if err := json.Unmarshal(cfg, &typed); err != nil {
+				return fmt.Errorf("failed to decode config dump: %w", err)
+			}
+			for _, typ := range types {
+				if typed.Type == typ {
+					configs = append(configs, cfg)
+				}
+			}
+		}
+		dump.Configs = configs
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return err
+	}
+	if output == yamlOutput {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,98 @@
+package egctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testConfigDump = `{
+  "configs": [
+    {"@type": "type.googleapis.com/envoy.admin.v3.BootstrapConfigDump", "bootstrap": {}},
+    {"@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump", "dynamic_active_clusters": [{"cluster": {"name": "cluster-1"}}]},
+    {"@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump", "dynamic_listeners": [{"name": "listener-1"}]},
+    {"@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump", "dynamic_route_configs": [{"route_config": {"name": "route-1"}}]}
+  ]
+}`
+
+func TestWriteConfigDump(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config_dump" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testConfigDump))
+	}))
+	defer srv.Close()
+
+	configTypes := func(t *testing.T, out []byte) []string {
+		var dump struct {
+			Configs []struct {
+				Type string `json:"@type"`
+			} `json:"configs"`
+		}
+		require.NoError(t, json.Unmarshal(out, &dump))
+		var types []string
+		for _, cfg := range dump.Configs {
+			types = append(types, cfg.Type)
+		}
+		return types
+	}
+
+	// Each command only prints the configs of its type.
+	for _, dumpType := range configDumpTypes {
+		dumpType := dumpType
+		t.Run(dumpType.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			require.NoError(t, writeConfigDump(context.Background(), out, srv.URL, dumpType.types, jsonOutput))
+			if dumpType.types == nil {
+				require.Len(t, configTypes(t, out.Bytes()), 4)
+			} else {
+				require.Equal(t, dumpType.types, configTypes(t, out.Bytes()))
+			}
+		})
+	}
+
+	out := new(bytes.Buffer)
+	require.NoError(t, writeConfigDump(context.Background(), out, srv.URL, configDumpTypes[0].types, yamlOutput))
+	require.Equal(t, `configs:
+- '@type': type.googleapis.com/envoy.admin.v3.ListenersConfigDump
+  dynamic_listeners:
+  - name: listener-1
+`, out.String())
+
+	// The errors of the admin interface are returned.
+	require.Error(t, writeConfigDump(context.Background(), new(bytes.Buffer), srv.URL+"/unknown", nil, jsonOutput))
+}
+
+func TestEnvoyProxyConfigInvalidOutput(t *testing.T) {
+	opts := &envoyProxyConfigOptions{namespace: "envoy-gateway-system", output: "xml"}
+	err := envoyProxyConfig(context.Background(), new(bytes.Buffer), opts, "envoy", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unsupported output format "xml"`)
+}
+
+func TestCheckEnvoyProxyPod(t *testing.T) {
+	pod := func(lbls map[string]string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway-system", Name: "envoy", Labels: lbls},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	envoyLabels := map[string]string{
+		"app.gateway.envoyproxy.io/name":                 "envoy",
+		"gateway.envoyproxy.io/owning-gateway-name":      "eg",
+		"gateway.envoyproxy.io/owning-gateway-namespace": "default",
+	}
+
+	require.NoError(t, checkEnvoyProxyPod(pod(envoyLabels, corev1.PodRunning)))
+	require.Error(t, checkEnvoyProxyPod(pod(envoyLabels, corev1.PodPending)))
+	require.Error(t, checkEnvoyProxyPod(pod(map[string]string{"app": "backend"}, corev1.PodRunning)))
+}
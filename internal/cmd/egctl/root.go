@@ -0,0 +1,36 @@
+package egctl
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// GetRootCommand returns the root cobra command of egctl to be executed by main.
+func GetRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "egctl",
+		Short: "A command line utility for operating Envoy Gateway",
+		// The errors are printed by main, without the usage.
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.AddCommand(getConfigCommand())
+	cmd.AddCommand(getStatusCommand())
+	cmd.AddCommand(getExperimentalCommand())
+
+	return cmd
+}
+
+// getExperimentalCommand returns the cobra command grouping the experimental
+// commands, whose flags and output may change.
+func getExperimentalCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "experimental",
+		Aliases: []string{"x"},
+		Short:   "Experimental features",
+	}
+
+	cmd.AddCommand(getTranslateCommand())
+
+	return cmd
+}
@@ -0,0 +1,257 @@
+package egctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+)
+
+// statusOptions are the options of the status commands.
+type statusOptions struct {
+	namespace  string
+	configPath string
+}
+
+// statusKinds are the kinds of the resources whose status is summarized, with
+// the function writing their summary.
+var statusKinds = []struct {
+	name    string
+	aliases []string
+	write   func(ctx context.Context, w io.Writer, cli client.Client, namespace string, controller gwapiv1b1.GatewayController) error
+}{
+	{name: "gatewayclass", aliases: []string{"gatewayclasses", "gc"}, write: writeGatewayClassStatus},
+	{name: "gateway", aliases: []string{"gateways", "gtw"}, write: writeGatewayStatus},
+	{name: "httproute", aliases: []string{"httproutes"}, write: writeHTTPRouteStatus},
+}
+
+// getStatusCommand returns the status cobra command to be executed, with a
+// subcommand per resource kind.
+func getStatusCommand() *cobra.Command {
+	opts := new(statusOptions)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Summarize the status of the Gateway API resources managed by Envoy Gateway",
+		Long: `Summarize the conditions of the Gateway API resources managed by Envoy Gateway.
+The conditions that aren't met are listed with their reason and message.`,
+	}
+
+	cmd.PersistentFlags().StringVarP(&opts.namespace, "namespace", "n", "",
+		"The namespace of the resources. The resources of all namespaces are listed if unset.")
+	cmd.PersistentFlags().StringVarP(&opts.configPath, "config-path", "c", "",
+		"The path to the Envoy Gateway configuration file, setting the controller name of the resources.")
+
+	for _, kind := range statusKinds {
+		kind := kind
+		cmd.AddCommand(&cobra.Command{
+			Use:     kind.name,
+			Aliases: kind.aliases,
+			Short:   fmt.Sprintf("Summarize the status of the %s resources", kind.name),
+			Args:    cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				eg, err := loadEnvoyGateway(opts.configPath)
+				if err != nil {
+					return err
+				}
+				cli, err := newClient()
+				if err != nil {
+					return err
+				}
+				controller := gwapiv1b1.GatewayController(eg.Gateway.ControllerName)
+				return kind.write(cmd.Context(), cmd.OutOrStdout(), cli, opts.namespace, controller)
+			},
+		})
+	}
+
+	return cmd
+}
+
+// newClient returns a client of the cluster of the kubeconfig.
+func newClient() (client.Client, error) {
+	restCfg, err := clicfg.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	cli, err := client.New(restCfg, client.Options{Scheme: envoygateway.GetScheme()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create controller-runtime client: %v", err)
+	}
+	return cli, nil
+}
+
+// writeGatewayClassStatus writes the status of the GatewayClasses of the
+// provided controller to w.
+func writeGatewayClassStatus(ctx context.Context, w io.Writer, cli client.Client, _ string, controller gwapiv1b1.GatewayController) error {
+	gcs, err := managedGatewayClasses(ctx, cli, controller)
+	if err != nil {
+		return err
+	}
+
+	tw := newStatusWriter(w, "NAME", "CONDITIONS", "ISSUES")
+	for _, gc := range gcs {
+		tw.row([]string{gc.Name}, gc.Status.Conditions, nil)
+	}
+	return tw.Flush()
+}
+
+// writeGatewayStatus writes the status of the Gateways of the GatewayClasses of
+// the provided controller to w, including the issues of their listeners.
+func writeGatewayStatus(ctx context.Context, w io.Writer, cli client.Client, namespace string, controller gwapiv1b1.GatewayController) error {
+	gcs, err := managedGatewayClasses(ctx, cli, controller)
+	if err != nil {
+		return err
+	}
+	managed := map[gwapiv1b1.ObjectName]bool{}
+	for _, gc := range gcs {
+		managed[gwapiv1b1.ObjectName(gc.Name)] = true
+	}
+
+	gateways := new(gwapiv1b1.GatewayList)
+	if err := cli.List(ctx, gateways, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list gateways: %w", err)
+	}
+	sort.Slice(gateways.Items, func(i, j int) bool {
+		return namespacedName(&gateways.Items[i]) < namespacedName(&gateways.Items[j])
+	})
+
+	tw := newStatusWriter(w, "NAMESPACE", "NAME", "CONDITIONS", "ISSUES")
+	for i := range gateways.Items {
+		gateway := &gateways.Items[i]
+		if !managed[gateway.Spec.GatewayClassName] {
+			continue
+		}
+		var listenerIssues []string
+		for _, listener := range gateway.Status.Listeners {
+			for _, issue := range conditionIssues(listener.Conditions) {
+				listenerIssues = append(listenerIssues, fmt.Sprintf("listener %s: %s", listener.Name, issue))
+			}
+		}
+		tw.row([]string{gateway.Namespace, gateway.Name}, gateway.Status.Conditions, listenerIssues)
+	}
+	return tw.Flush()
+}
+
+// writeHTTPRouteStatus writes the status of the HTTPRoutes for each of their
+// parents managed by the provided controller to w.
+func writeHTTPRouteStatus(ctx context.Context, w io.Writer, cli client.Client, namespace string, controller gwapiv1b1.GatewayController) error {
+	routes := new(gwapiv1b1.HTTPRouteList)
+	if err := cli.List(ctx, routes, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list httproutes: %w", err)
+	}
+	sort.Slice(routes.Items, func(i, j int) bool {
+		return namespacedName(&routes.Items[i]) < namespacedName(&routes.Items[j])
+	})
+
+	tw := newStatusWriter(w, "NAMESPACE", "NAME", "PARENT", "CONDITIONS", "ISSUES")
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		for _, parent := range route.Status.Parents {
+			if parent.ControllerName != controller {
+				continue
+			}
+			tw.row([]string{route.Namespace, route.Name, parentRefString(route.Namespace, parent.ParentRef)}, parent.Conditions, nil)
+		}
+	}
+	return tw.Flush()
+}
+
+// managedGatewayClasses returns the GatewayClasses of the provided controller,
+// sorted by name.
+func managedGatewayClasses(ctx context.Context, cli client.Client, controller gwapiv1b1.GatewayController) ([]gwapiv1b1.GatewayClass, error) {
+	gcs := new(gwapiv1b1.GatewayClassList)
+	if err := cli.List(ctx, gcs); err != nil {
+		return nil, fmt.Errorf("failed to list gatewayclasses: %w", err)
+	}
+	var managed []gwapiv1b1.GatewayClass
+	for _, gc := range gcs.Items {
+		if gc.Spec.ControllerName == controller {
+			managed = append(managed, gc)
+		}
+	}
+	sort.Slice(managed, func(i, j int) bool {
+		return managed[i].Name < managed[j].Name
+	})
+	return managed, nil
+}
+
+// namespacedName returns the namespace/name key of the provided object.
+func namespacedName(obj client.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// parentRefString returns the namespace/name of the provided parent reference
+// of a route of the provided namespace, with its section name if any.
+func parentRefString(routeNamespace string, ref gwapiv1b1.ParentReference) string {
+	namespace := routeNamespace
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	out := namespace + "/" + string(ref.Name)
+	if ref.SectionName != nil {
+		out += "/" + string(*ref.SectionName)
+	}
+	return out
+}
+
+// negativeConditionTypes are the types of the conditions signaling an issue
+// when they are true.
+var negativeConditionTypes = map[string]bool{
+	string(gwapiv1b1.ListenerConditionConflicted): true,
+	string(gwapiv1b1.ListenerConditionDetached):   true,
+}
+
+// conditionIssues returns the type, reason and message of the provided
+// conditions that signal an issue.
+func conditionIssues(conditions []metav1.Condition) []string {
+	var issues []string
+	for _, cond := range conditions {
+		ok := cond.Status == metav1.ConditionTrue
+		if negativeConditionTypes[cond.Type] {
+			ok = cond.Status == metav1.ConditionFalse
+		}
+		if !ok {
+			issues = append(issues, fmt.Sprintf("%s: %s: %s", cond.Type, cond.Reason, cond.Message))
+		}
+	}
+	return issues
+}
+
+// statusWriter writes the status of resources as a table.
+type statusWriter struct {
+	*tabwriter.Writer
+}
+
+// newStatusWriter returns a statusWriter writing to w, and writes the provided
+// header.
+func newStatusWriter(w io.Writer, header ...string) *statusWriter {
+	tw := &statusWriter{Writer: tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	return tw
+}
+
+// row writes a row of the provided columns, followed by the provided conditions
+// and the issues of the resource. The issues of the conditions come first,
+// followed by the provided extra issues.
+func (tw *statusWriter) row(columns []string, conditions []metav1.Condition, extraIssues []string) {
+	conds := make([]string, 0, len(conditions))
+	for _, cond := range conditions {
+		conds = append(conds, fmt.Sprintf("%s=%s", cond.Type, cond.Status))
+	}
+	condsColumn := strings.Join(conds, ",")
+	if condsColumn == "" {
+		condsColumn = "<none>"
+	}
+	issues := append(conditionIssues(conditions), extraIssues...)
+	fmt.Fprintln(tw, strings.Join(append(columns, condsColumn, strings.Join(issues, "; ")), "\t"))
+}
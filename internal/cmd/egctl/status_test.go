@@ -0,0 +1,149 @@
+package egctl
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+)
+
+const testController = gwapiv1b1.GatewayController("gateway.envoyproxy.io/gatewayclass-controller")
+
+func testCondition(typ string, status metav1.ConditionStatus, reason, msg string) metav1.Condition {
+	return metav1.Condition{Type: typ, Status: status, Reason: reason, Message: msg}
+}
+
+func testStatusClient() client.Client {
+	sectionName := gwapiv1b1.SectionName("http")
+	objs := []client.Object{
+		&gwapiv1b1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "eg"},
+			Spec:       gwapiv1b1.GatewayClassSpec{ControllerName: testController},
+			Status: gwapiv1b1.GatewayClassStatus{Conditions: []metav1.Condition{
+				testCondition("Accepted", metav1.ConditionTrue, "Accepted", "Valid GatewayClass"),
+			}},
+		},
+		&gwapiv1b1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec:       gwapiv1b1.GatewayClassSpec{ControllerName: "example.com/other-controller"},
+		},
+		&gwapiv1b1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eg"},
+			Spec:       gwapiv1b1.GatewaySpec{GatewayClassName: "eg"},
+			Status: gwapiv1b1.GatewayStatus{
+				Conditions: []metav1.Condition{
+					testCondition("Scheduled", metav1.ConditionTrue, "Scheduled", "Deployment replicas available"),
+					testCondition("Ready", metav1.ConditionFalse, "AddressNotAssigned", "No addresses have been assigned"),
+				},
+				Listeners: []gwapiv1b1.ListenerStatus{{
+					Name: "http",
+					Conditions: []metav1.Condition{
+						testCondition("Conflicted", metav1.ConditionTrue, "HostnameConflict", "All listeners must have a unique hostname"),
+						testCondition("Ready", metav1.ConditionTrue, "Ready", "Listener is ready"),
+					},
+				}},
+			},
+		},
+		&gwapiv1b1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "other"},
+			Spec:       gwapiv1b1.GatewaySpec{GatewayClassName: "other"},
+		},
+		&gwapiv1b1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backend"},
+			Status: gwapiv1b1.HTTPRouteStatus{RouteStatus: gwapiv1b1.RouteStatus{Parents: []gwapiv1b1.RouteParentStatus{
+				{
+					ParentRef:      gwapiv1b1.ParentReference{Name: "eg", SectionName: &sectionName},
+					ControllerName: testController,
+					Conditions: []metav1.Condition{
+						testCondition("Accepted", metav1.ConditionTrue, "Accepted", "Route is accepted"),
+						testCondition("ResolvedRefs", metav1.ConditionFalse, "BackendNotFound", "Service default/backend not found"),
+					},
+				},
+				{
+					ParentRef:      gwapiv1b1.ParentReference{Name: "other"},
+					ControllerName: "example.com/other-controller",
+				},
+			}}},
+		},
+		&gwapiv1b1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "frontend"},
+		},
+	}
+	return fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(objs...).Build()
+}
+
+// statusLines returns the lines of out, with the columns separated by a single
+// space.
+func statusLines(out string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	return lines
+}
+
+func TestWriteStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		write    func(ctx context.Context, w *bytes.Buffer, cli client.Client) error
+		expected []string
+	}{
+		{
+			name: "gatewayclass",
+			write: func(ctx context.Context, w *bytes.Buffer, cli client.Client) error {
+				return writeGatewayClassStatus(ctx, w, cli, "", testController)
+			},
+			expected: []string{
+				"NAME CONDITIONS ISSUES",
+				"eg Accepted=True",
+			},
+		},
+		{
+			name: "gateway",
+			write: func(ctx context.Context, w *bytes.Buffer, cli client.Client) error {
+				return writeGatewayStatus(ctx, w, cli, "", testController)
+			},
+			expected: []string{
+				"NAMESPACE NAME CONDITIONS ISSUES",
+				"default eg Scheduled=True,Ready=False Ready: AddressNotAssigned: No addresses have been assigned; " +
+					"listener http: Conflicted: HostnameConflict: All listeners must have a unique hostname",
+			},
+		},
+		{
+			name: "httproute",
+			write: func(ctx context.Context, w *bytes.Buffer, cli client.Client) error {
+				return writeHTTPRouteStatus(ctx, w, cli, "", testController)
+			},
+			expected: []string{
+				"NAMESPACE NAME PARENT CONDITIONS ISSUES",
+				"default backend default/eg/http Accepted=True,ResolvedRefs=False ResolvedRefs: BackendNotFound: Service default/backend not found",
+			},
+		},
+		{
+			name: "httproute without managed parents",
+			write: func(ctx context.Context, w *bytes.Buffer, cli client.Client) error {
+				return writeHTTPRouteStatus(ctx, w, cli, "apps", testController)
+			},
+			expected: []string{
+				"NAMESPACE NAME PARENT CONDITIONS ISSUES",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			require.NoError(t, tc.write(context.Background(), out, testStatusClient()))
+			require.Equal(t, tc.expected, statusLines(out.String()))
+		})
+	}
+}
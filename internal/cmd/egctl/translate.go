@@ -0,0 +1,166 @@
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes"
+	"github.com/envoyproxy/gateway/internal/provider/file"
+	"github.com/envoyproxy/gateway/internal/xds/translator"
+)
+
+const (
+	// gatewayAPIType translates from the Gateway API resources.
+	gatewayAPIType = "gateway-api"
+	// xdsType translates to the xDS resources of the Envoy proxies.
+	xdsType = "xds"
+)
+
+// xdsOutputTypes are the xDS resource types of the output, with their name.
+var xdsOutputTypes = []struct {
+	name string
+	typ  string
+}{
+	{name: "listeners", typ: resource.ListenerType},
+	{name: "routes", typ: resource.RouteType},
+	{name: "clusters", typ: resource.ClusterType},
+}
+
+// translateOptions are the options of the translate command.
+type translateOptions struct {
+	from       string
+	to         string
+	files      []string
+	configPath string
+}
+
+// getTranslateCommand returns the translate cobra command to be executed.
+func getTranslateCommand() *cobra.Command {
+	opts := new(translateOptions)
+
+	cmd := &cobra.Command{
+		Use:   "translate",
+		Short: "Translate the resources of the provided files, without a cluster",
+		Long: `Translate the Gateway API resources of the provided files into the xDS
+resources of the Envoy proxies of each Gateway, like Envoy Gateway does. The files
+are loaded like the File provider loads them.`,
+		Example: `  egctl x translate --from gateway-api --to xds -f manifests.yaml`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return translate(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", gatewayAPIType,
+		"The type of the resources to translate. Only gateway-api is supported.")
+	cmd.Flags().StringVar(&opts.to, "to", xdsType,
+		"The type of the translated resources. Only xds is supported.")
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
+		"The files, or directories of files, holding the resources to translate.")
+	cmd.Flags().StringVarP(&opts.configPath, "config-path", "c", "",
+		"The path to the Envoy Gateway configuration file, enabling the extensions of the translation.")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// translate translates the resources of the provided files, and writes the
+// listeners, routes and clusters of each Gateway to w, as YAML.
+func translate(w io.Writer, opts *translateOptions) error {
+	if opts.from != gatewayAPIType {
+		return fmt.Errorf("unsupported type %q to translate from, only %q is supported", opts.from, gatewayAPIType)
+	}
+	if opts.to != xdsType {
+		return fmt.Errorf("unsupported type %q to translate to, only %q is supported", opts.to, xdsType)
+	}
+
+	eg, err := loadEnvoyGateway(opts.configPath)
+	if err != nil {
+		return err
+	}
+
+	in, gc, err := file.LoadGatewayAPIResources(opts.files, gwapiv1b1.GatewayController(eg.Gateway.ControllerName))
+	if err != nil {
+		return err
+	}
+	if gc == nil {
+		return fmt.Errorf("no GatewayClass with controller name %s", eg.Gateway.ControllerName)
+	}
+
+	t := &gatewayapi.Translator{
+		GatewayClassName:        gwapiv1b1.ObjectName(gc.Name),
+		GlobalRateLimitEnabled:  eg.RateLimit != nil,
+		LuaEnabled:              eg.ExtensionAPIs != nil && eg.ExtensionAPIs.EnableLua,
+		EnvoyPatchPolicyEnabled: eg.ExtensionAPIs != nil && eg.ExtensionAPIs.EnableEnvoyPatchPolicy,
+	}
+	result := t.Translate(in)
+
+	xdsTranslator := new(translator.Translator)
+	if eg.RateLimit != nil {
+		xdsTranslator.GlobalRateLimit = &translator.GlobalRateLimitSettings{
+			ServiceHost: kubernetes.RateLimitServiceHost(config.EnvoyGatewayNamespace),
+			ServicePort: uint32(kubernetes.RateLimitGRPCPort),
+		}
+	}
+
+	out := map[string]map[string][]json.RawMessage{}
+	for key, xdsIR := range result.XdsIR {
+		if err := xdsIR.Validate(); err != nil {
+			return fmt.Errorf("invalid xds ir of %s: %w", key, err)
+		}
+		tCtx, err := xdsTranslator.Translate(xdsIR)
+		if err != nil {
+			return fmt.Errorf("failed to translate the xds ir of %s: %w", key, err)
+		}
+
+		out[key] = map[string][]json.RawMessage{}
+		for _, outputType := range xdsOutputTypes {
+			resources := make([]json.RawMessage, 0, len(tCtx.XdsResources[outputType.typ]))
+			for _, res := range tCtx.XdsResources[outputType.typ] {
+				data, err := protojson.Marshal(res.(proto.Message))
+				if err != nil {
+					return err
+				}
+				resources = append(resources, data)
+			}
+			out[key][outputType.name] = resources
+		}
+	}
+
+	// The keys of the maps are sorted by the JSON encoding.
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// loadEnvoyGateway returns the EnvoyGateway of the provided config file, or the
+// default one if the path is empty.
+func loadEnvoyGateway(configPath string) (*v1alpha1.EnvoyGateway, error) {
+	if configPath == "" {
+		return v1alpha1.DefaultEnvoyGateway(), nil
+	}
+	eg, err := config.Decode(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config file %s: %w", configPath, err)
+	}
+	eg.SetDefaults()
+	return eg, nil
+}
@@ -0,0 +1,70 @@
+package egctl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	want, err := os.ReadFile("testdata/translate.out.yaml")
+	require.NoError(t, err)
+
+	cmd := GetRootCommand()
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"x", "translate", "--from", "gateway-api", "--to", "xds", "-f", "testdata/translate.in.yaml"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, string(want), out.String())
+}
+
+func TestTranslateInvalid(t *testing.T) {
+	// The Gateway of a GatewayClass that isn't managed by Envoy Gateway.
+	otherController := filepath.Join(t.TempDir(), "other.yaml")
+	require.NoError(t, os.WriteFile(otherController, []byte(`apiVersion: gateway.networking.k8s.io/v1beta1
+kind: GatewayClass
+metadata:
+  name: other
+spec:
+  controllerName: example.com/gatewayclass-controller
+`), 0o600))
+
+	testCases := []struct {
+		name string
+		opts *translateOptions
+		err  string
+	}{
+		{
+			name: "unsupported from type",
+			opts: &translateOptions{from: "xds", to: xdsType, files: []string{"testdata/translate.in.yaml"}},
+			err:  `unsupported type "xds" to translate from`,
+		},
+		{
+			name: "unsupported to type",
+			opts: &translateOptions{from: gatewayAPIType, to: "gateway-api", files: []string{"testdata/translate.in.yaml"}},
+			err:  `unsupported type "gateway-api" to translate to`,
+		},
+		{
+			name: "missing file",
+			opts: &translateOptions{from: gatewayAPIType, to: xdsType, files: []string{"testdata/missing.yaml"}},
+			err:  "no such file or directory",
+		},
+		{
+			name: "no gateway class",
+			opts: &translateOptions{from: gatewayAPIType, to: xdsType, files: []string{otherController}},
+			err:  "no GatewayClass with controller name gateway.envoyproxy.io/gatewayclass-controller",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := translate(new(bytes.Buffer), tc.opts)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -1,18 +1,31 @@
 package cmd
 
 import (
+	"context"
+	"time"
+
 	"github.com/spf13/cobra"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	adminrunner "github.com/envoyproxy/gateway/internal/admin"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	gatewayapirunner "github.com/envoyproxy/gateway/internal/gatewayapi/runner"
 	infrarunner "github.com/envoyproxy/gateway/internal/infrastructure/runner"
 	"github.com/envoyproxy/gateway/internal/message"
 	providerrunner "github.com/envoyproxy/gateway/internal/provider/runner"
+	"github.com/envoyproxy/gateway/internal/tracing"
 	xdsserverrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
 	xdstranslatorrunner "github.com/envoyproxy/gateway/internal/xds/translator/runner"
 )
 
+const (
+	// tracingShutdownTimeout is the maximum time to flush the pending spans on
+	// shutdown.
+	tracingShutdownTimeout = 5 * time.Second
+)
+
 var (
 	// cfgPath is the path to the EnvoyGateway configuration file.
 	cfgPath string
@@ -71,6 +84,12 @@ func getConfig() (*config.Server, error) {
 		// Set defaults for unset fields
 		eg.SetDefaults()
 		cfg.EnvoyGateway = eg
+		// Use the logging settings of the config file.
+		cfg.Logger, err = cfg.ComponentLogger(v1alpha1.LogComponentDefault)
+		if err != nil {
+			log.Error(err, "failed to create logger", "name", cfgPath)
+			return nil, err
+		}
 	}
 	return cfg, nil
 }
@@ -82,6 +101,20 @@ func setupRunners(cfg *config.Server) error {
 	// https://github.com/envoyproxy/gateway/issues/43
 	ctx := ctrl.SetupSignalHandler()
 
+	// Set up the tracing of the control plane, if enabled.
+	shutdownTracing, err := tracing.Setup(ctx, cfg.EnvoyGateway.Tracing)
+	if err != nil {
+		return err
+	}
+
+	xds := new(message.Xds)
+	// The xDS Server is created first, so that its health checks are served by
+	// the provider.
+	xdsServerRunner := xdsserverrunner.New(&xdsserverrunner.Config{
+		Server: *cfg,
+		Xds:    xds,
+	})
+
 	pResources := new(message.ProviderResources)
 	// Start the Provider Service
 	// It fetches the resources from the configured provider type
@@ -89,6 +122,8 @@ func setupRunners(cfg *config.Server) error {
 	providerRunner := providerrunner.New(&providerrunner.Config{
 		Server:            *cfg,
 		ProviderResources: pResources,
+		HealthzChecks:     map[string]healthz.Checker{"xds-server": xdsServerRunner.HealthzCheck},
+		ReadyzChecks:      map[string]healthz.Checker{"xds-server": xdsServerRunner.ReadyzCheck},
 	})
 	if err := providerRunner.Start(ctx); err != nil {
 		return err
@@ -109,13 +144,13 @@ func setupRunners(cfg *config.Server) error {
 		return err
 	}
 
-	xds := new(message.Xds)
 	// Start the Xds Translator Service
 	// It subscribes to the xdsIR, translates it into xds Resources and publishes it.
 	xdsTranslatorRunner := xdstranslatorrunner.New(&xdstranslatorrunner.Config{
-		Server: *cfg,
-		XdsIR:  xdsIR,
-		Xds:    xds,
+		Server:            *cfg,
+		ProviderResources: pResources,
+		XdsIR:             xdsIR,
+		Xds:               xds,
 	})
 	if err := xdsTranslatorRunner.Start(ctx); err != nil {
 		return err
@@ -123,10 +158,12 @@ func setupRunners(cfg *config.Server) error {
 
 	// Start the Infra Manager Runner
 	// It subscribes to the infraIR, translates it into Envoy Proxy infrastructure
-	// resources such as K8s deployment and services.
+	// resources such as K8s deployment and services, and publishes their status.
 	infraRunner := infrarunner.New(&infrarunner.Config{
-		Server:  *cfg,
-		InfraIR: infraIR,
+		Server:            *cfg,
+		ProviderResources: pResources,
+		InfraIR:           infraIR,
+		XdsIR:             xdsIR,
 	})
 	if err := infraRunner.Start(ctx); err != nil {
 		return err
@@ -135,14 +172,23 @@ func setupRunners(cfg *config.Server) error {
 	// Start the xDS Server
 	// It subscribes to the xds Resources and configures the remote Envoy Proxy
 	// via the xDS Protocol
-	xdsServerRunner := xdsserverrunner.New(&xdsserverrunner.Config{
-		Server: *cfg,
-		Xds:    xds,
-	})
 	if err := xdsServerRunner.Start(ctx); err != nil {
 		return err
 	}
 
+	// Start the Admin Server, if enabled
+	// It serves the debug endpoints dumping the state of Envoy Gateway.
+	adminRunner := adminrunner.New(&adminrunner.Config{
+		Server:            *cfg,
+		ProviderResources: pResources,
+		XdsIR:             xdsIR,
+		InfraIR:           infraIR,
+		NodeSnapshots:     xdsServerRunner.NodeSnapshots,
+	})
+	if err := adminRunner.Start(ctx); err != nil {
+		return err
+	}
+
 	// Wait until done
 	<-ctx.Done()
 	// Close messages
@@ -157,10 +203,21 @@ func setupRunners(cfg *config.Server) error {
 	pResources.HTTPRouteStatuses.Close()
 	pResources.TLSRoutes.Close()
 	pResources.TLSRouteStatuses.Close()
+	pResources.BackendTrafficPolicyStatuses.Close()
+	pResources.EnvoyPatchPolicies.Close()
+	pResources.EnvoyPatchPolicyStatuses.Close()
+	pResources.InfraStatuses.Close()
 	xdsIR.Close()
 	infraIR.Close()
 	xds.Close()
 
+	// Flush the pending spans.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		cfg.Logger.Error(err, "failed to shut down tracing")
+	}
+
 	cfg.Logger.Info("shutting down")
 
 	return nil
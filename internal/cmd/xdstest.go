@@ -139,17 +139,18 @@ func xDSTest() error {
 	// Now, we do the translation because everything is static.
 	// Normally, we'd do this in response to updates on the
 	// message bus.
-	cacheVersion1, err := translator.Translate(ir1)
+	tr := new(translator.Translator)
+	cacheVersion1, err := tr.Translate(ir1)
 	if err != nil {
 		return err
 	}
 
-	cacheVersion2, err := translator.Translate(ir2)
+	cacheVersion2, err := tr.Translate(ir2)
 	if err != nil {
 		return err
 	}
 
-	cacheVersion3, err := translator.Translate(ir3)
+	cacheVersion3, err := tr.Translate(ir3)
 	if err != nil {
 		return err
 	}
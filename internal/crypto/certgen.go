@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -29,6 +30,10 @@ const (
 	// DefaultCertificateLifetime holds the default certificate lifetime (in days).
 	DefaultCertificateLifetime = 365
 
+	// DefaultCertificateRenewalThreshold holds the remaining certificate lifetime
+	// (in days) under which certificates are renewed.
+	DefaultCertificateRenewalThreshold = 30
+
 	// DefaultDNSSuffix is the default DNS suffix name.
 	DefaultDNSSuffix = "cluster.local"
 
@@ -157,6 +162,46 @@ func GenerateCerts(egCfg *v1alpha1.EnvoyGateway) (*Certificates, error) {
 	}
 }
 
+// CertificatesExpireBefore returns true if one of the provided certificates, or the
+// CA certificate issuing them, expires before the provided deadline or can't be
+// parsed.
+func CertificatesExpireBefore(certs *Certificates, deadline time.Time) bool {
+	for _, certPEM := range [][]byte{certs.CACertificate, certs.EnvoyGatewayCertificate, certs.EnvoyCertificate} {
+		cert, err := parseCertificate(certPEM)
+		if err != nil || cert.NotAfter.Before(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrustPreviousCA appends the CA certificate issuing the previous certificates to
+// the CA bundle of certs, unless it has expired. Both Envoy Gateway and Envoy trust
+// the certificates issued by either CA, so that the xDS connections keep working
+// while the rotated certificates are propagated to the pods.
+func TrustPreviousCA(certs *Certificates, previousCABundle []byte, now time.Time) {
+	previousCA, err := parseCertificate(previousCABundle)
+	if err != nil || previousCA.NotAfter.Before(now) {
+		return
+	}
+	bundle := make([]byte, 0, len(certs.CACertificate)+len(previousCABundle))
+	bundle = append(bundle, certs.CACertificate...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: previousCA.Raw,
+	})...)
+	certs.CACertificate = bundle
+}
+
+// parseCertificate parses the first certificate of the provided PEM data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed to decode PEM certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
 // newCert generates a new keypair based on the given the request.
 // The return values are cert, key, err.
 func newCert(request *certificateRequest) ([]byte, []byte, error) {
@@ -131,3 +131,47 @@ func verifyCert(certPEM []byte, roots *x509.CertPool, dnsname string, currentTim
 
 	return nil
 }
+
+func TestCertificatesExpireBefore(t *testing.T) {
+	certs, err := GenerateCerts(nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.False(t, CertificatesExpireBefore(certs, now))
+	require.True(t, CertificatesExpireBefore(certs, now.AddDate(0, 0, DefaultCertificateLifetime+1)))
+
+	invalid := *certs
+	invalid.EnvoyCertificate = []byte("invalid")
+	require.True(t, CertificatesExpireBefore(&invalid, now))
+}
+
+func TestTrustPreviousCA(t *testing.T) {
+	previous, err := GenerateCerts(nil)
+	require.NoError(t, err)
+	certs, err := GenerateCerts(nil)
+	require.NoError(t, err)
+	now := time.Now()
+
+	// The CA bundle of the rotated certificates trusts the certificates issued by
+	// both CAs.
+	rotated := *certs
+	TrustPreviousCA(&rotated, previous.CACertificate, now)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(rotated.CACertificate))
+	require.NoError(t, verifyCert(certs.EnvoyCertificate, roots, "*.envoy-gateway-system", now))
+	require.NoError(t, verifyCert(previous.EnvoyCertificate, roots, "*.envoy-gateway-system", now))
+
+	// Only the CA issuing the previous certificates is kept by the next rotation.
+	next, err := GenerateCerts(nil)
+	require.NoError(t, err)
+	TrustPreviousCA(next, rotated.CACertificate, now)
+	roots = x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(next.CACertificate))
+	require.NoError(t, verifyCert(certs.EnvoyCertificate, roots, "*.envoy-gateway-system", now))
+	require.Error(t, verifyCert(previous.EnvoyCertificate, roots, "*.envoy-gateway-system", now))
+
+	// An expired CA isn't trusted anymore.
+	expired := *certs
+	TrustPreviousCA(&expired, previous.CACertificate, now.AddDate(0, 0, DefaultCertificateLifetime+1))
+	require.Equal(t, certs.CACertificate, expired.CACertificate)
+}
@@ -14,6 +14,10 @@ const (
 	EnvoyGatewayServiceName = "envoy-gateway"
 	// EnvoyPrefix is the prefix applied to the Envoy ConfigMap, Service, Deployment, and ServiceAccount.
 	EnvoyPrefix = "envoy"
+	// EnvoyCertsCopyLabel labels the copies of the Envoy certificates Secret made in the
+	// namespaces of the managed Envoy resources, which are updated when the certificates
+	// are rotated.
+	EnvoyCertsCopyLabel = "gateway.envoyproxy.io/envoy-certs-copy"
 )
 
 // Server wraps the EnvoyGateway configuration and additional parameters
@@ -36,3 +40,9 @@ func NewDefaultServer() (*Server, error) {
 		Logger:       logger,
 	}, nil
 }
+
+// ComponentLogger returns a logger for the provided component of Envoy Gateway,
+// as set by the logging settings of Envoy Gateway.
+func (s *Server) ComponentLogger(component v1alpha1.EnvoyGatewayLogComponent) (logr.Logger, error) {
+	return log.NewComponentLogger(s.EnvoyGateway.Logging, component)
+}
@@ -0,0 +1,691 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// defaultRetryNumRetries is the default number of retries of a request.
+	defaultRetryNumRetries = uint32(2)
+	// defaultRetryStatusCode is the default status code for which a request is
+	// retried when no retry conditions are specified.
+	defaultRetryStatusCode = uint32(503)
+
+	// defaultActiveHealthCheckTimeout is the default time to wait for a health check response.
+	defaultActiveHealthCheckTimeout = time.Second
+	// defaultActiveHealthCheckInterval is the default time between health checks.
+	defaultActiveHealthCheckInterval = 3 * time.Second
+	// defaultActiveHealthCheckHealthyThreshold is the default number of successful
+	// health checks after which an endpoint is marked healthy.
+	defaultActiveHealthCheckHealthyThreshold = uint32(1)
+	// defaultActiveHealthCheckUnhealthyThreshold is the default number of failed
+	// health checks after which an endpoint is marked unhealthy.
+	defaultActiveHealthCheckUnhealthyThreshold = uint32(3)
+	// defaultActiveHealthCheckExpectedStatus is the default response status code
+	// of healthy endpoints.
+	defaultActiveHealthCheckExpectedStatus = uint32(200)
+)
+
+// defaultRetryOn is the list of retry conditions used when no retry conditions
+// are specified.
+var defaultRetryOn = []string{"connect-failure", "refused-stream", "unavailable", "cancelled", "retriable-status-codes"}
+
+// ProcessBackendTrafficPolicies returns copies of the provided policies with an
+// Accepted condition, set to false if a setting of the policy is invalid. The
+// invalid settings are still applied as described by the message of the
+// condition.
+func (t *Translator) ProcessBackendTrafficPolicies(policies []*v1alpha1.BackendTrafficPolicy) []*v1alpha1.BackendTrafficPolicy {
+	var processed []*v1alpha1.BackendTrafficPolicy
+	for _, policy := range sortByCreationTimestamp(policies) {
+		policy = policy.DeepCopy()
+		cond := metav1.Condition{
+			Type:               string(v1alpha1.BackendTrafficPolicyConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(v1alpha1.BackendTrafficPolicyReasonAccepted),
+			Message:            "The policy is valid",
+			ObservedGeneration: policy.Generation,
+		}
+		if issues := validateBackendTrafficPolicy(policy); len(issues) > 0 {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = string(v1alpha1.BackendTrafficPolicyReasonInvalid)
+			cond.Message = strings.Join(issues, "; ")
+		}
+		meta.SetStatusCondition(&policy.Status.Conditions, cond)
+		processed = append(processed, policy)
+	}
+	return processed
+}
+
+// validateBackendTrafficPolicy returns the issues of the invalid settings of the
+// provided policy, describing how they are applied.
+func validateBackendTrafficPolicy(policy *v1alpha1.BackendTrafficPolicy) []string {
+	var issues []string
+	if accessControl := policy.Spec.IPAccessControl; accessControl != nil {
+		for _, cidrs := range [][]v1alpha1.CIDR{accessControl.Allow, accessControl.Deny} {
+			if _, err := buildIRCIDRs(cidrs); err != nil {
+				issues = append(issues, fmt.Sprintf("ipAccessControl: %v, so all the clients are denied", err))
+				break
+			}
+		}
+	}
+	if rateLimit := policy.Spec.RateLimit; rateLimit != nil && rateLimit.Local != nil {
+		unit := rateLimit.Local.Limit.Unit
+		for i, rule := range rateLimit.Local.Rules {
+			if ir.RateLimitUnit(rule.Limit.Unit).Duration() < ir.RateLimitUnit(unit).Duration() {
+				issues = append(issues, fmt.Sprintf("rateLimit.local.rules[%d]: the unit %s is shorter than the unit %s of the limit, so the rule is ignored",
+					i, rule.Limit.Unit, unit))
+			}
+		}
+	}
+	return issues
+}
+
+// backendTrafficPolicyForRoute returns the BackendTrafficPolicy that applies to the
+// traffic of httpRoute attached to gateway, or nil if no policy applies. A policy
+// targeting the HTTPRoute takes precedence over a policy targeting the Gateway.
+func backendTrafficPolicyForRoute(policies []*v1alpha1.BackendTrafficPolicy, httpRoute *v1beta1.HTTPRoute, gateway *v1beta1.Gateway) *v1alpha1.BackendTrafficPolicy {
+	if policy := backendTrafficPolicyForTarget(policies, KindHTTPRoute, httpRoute.Namespace, httpRoute.Name); policy != nil {
+		return policy
+	}
+	return backendTrafficPolicyForTarget(policies, KindGateway, gateway.Namespace, gateway.Name)
+}
+
+// backendTrafficPolicyForTarget returns the oldest BackendTrafficPolicy targeting the
+// Gateway API resource of the provided kind, namespace and name, or nil if the resource
+// isn't targeted.
+func backendTrafficPolicyForTarget(policies []*v1alpha1.BackendTrafficPolicy, kind, namespace, name string) *v1alpha1.BackendTrafficPolicy {
+	return policyForTarget(policies, func(policy *v1alpha1.BackendTrafficPolicy) v1alpha2.PolicyTargetReference {
+		return policy.Spec.TargetRef
+	}, kind, namespace, name)
+}
+
+// policyForTarget returns the oldest of the provided policies targeting the Gateway API
+// resource of the provided kind, namespace and name, or the zero value if the resource
+// isn't targeted. Policies are only able to target resources in their own namespace.
+func policyForTarget[P metav1.Object](policies []P, targetRef func(P) v1alpha2.PolicyTargetReference, kind, namespace, name string) P {
+	var targeting []P
+	for _, policy := range policies {
+		ref := targetRef(policy)
+		if string(ref.Group) != v1beta1.GroupName || string(ref.Kind) != kind || string(ref.Name) != name {
+			continue
+		}
+		if policy.GetNamespace() != namespace || (ref.Namespace != nil && string(*ref.Namespace) != namespace) {
+			continue
+		}
+		targeting = append(targeting, policy)
+	}
+	if len(targeting) == 0 {
+		var none P
+		return none
+	}
+
+	sort.Slice(targeting, func(i, j int) bool {
+		iCreated, jCreated := targeting[i].GetCreationTimestamp(), targeting[j].GetCreationTimestamp()
+		if iCreated.Equal(&jCreated) {
+			return targeting[i].GetName() < targeting[j].GetName()
+		}
+		return iCreated.Before(&jCreated)
+	})
+	return targeting[0]
+}
+
+// applyBackendTrafficPolicy sets the backend traffic configuration of the IR route
+// from the BackendTrafficPolicy that applies to it, if any. Global rate limits are
+// only applied if globalRateLimit is true.
+func applyBackendTrafficPolicy(irRoute *ir.HTTPRoute, policy *v1alpha1.BackendTrafficPolicy, resources *Resources, globalRateLimit bool) {
+	if policy == nil {
+		return
+	}
+
+	irRoute.Retry = buildIRRetry(policy.Spec.Retry)
+	irRoute.LoadBalancer = buildIRLoadBalancer(policy.Spec.LoadBalancer)
+	irRoute.CircuitBreaker = buildIRCircuitBreaker(policy.Spec.CircuitBreaker)
+	irRoute.HealthCheck = buildIRHealthCheck(policy.Spec.HealthCheck)
+	irRoute.TCPKeepalive = buildIRTCPKeepalive(policy.Spec.TCPKeepalive)
+	irRoute.RateLimit = buildIRRateLimit(policy.Spec.RateLimit, globalRateLimit)
+	irRoute.IPAccessControl = buildIRIPAccessControl(policy.Spec.IPAccessControl)
+	irRoute.FaultInjection = buildIRFaultInjection(policy.Spec.FaultInjection)
+	irRoute.Buffer = buildIRBuffer(policy.Spec.Buffer)
+	irRoute.Upgrade = buildIRUpgrade(policy.Spec.Upgrade)
+	irRoute.Timeout = buildIRTimeout(policy.Spec.Timeouts)
+	if responseHeaders := policy.Spec.ResponseHeaders; responseHeaders != nil {
+		// The headers are prepended to fresh slices, since the headers of the
+		// route are shared with the routes of its other hostnames.
+		irRoute.AddResponseHeaders = append(buildIRAddHeaders(responseHeaders.Set, responseHeaders.Add), irRoute.AddResponseHeaders...)
+		irRoute.RemoveResponseHeaders = append(buildIRRemoveHeaders(responseHeaders.Remove), irRoute.RemoveResponseHeaders...)
+	}
+
+	backendTLS, ok := buildIRBackendTLS(policy.Spec.TLS, policy.Namespace, resources)
+	if !ok && len(irRoute.Destinations) > 0 {
+		// The CA bundle can't be resolved, so fail closed instead of
+		// connecting to the backends without verifying their certificates.
+		irRoute.Destinations = nil
+		irRoute.BackendWeights = ir.BackendWeights{}
+		irRoute.DirectResponse = &ir.DirectResponse{
+			StatusCode: 500,
+		}
+	}
+	irRoute.BackendTLS = backendTLS
+}
+
+// buildIRRetry translates the retry configuration of a BackendTrafficPolicy
+// into the IR, setting defaults for unspecified fields.
+func buildIRRetry(retry *v1alpha1.Retry) *ir.Retry {
+	if retry == nil {
+		return nil
+	}
+
+	numRetries := defaultRetryNumRetries
+	if retry.NumRetries != nil {
+		numRetries = uint32(*retry.NumRetries)
+	}
+	irRetry := &ir.Retry{
+		NumRetries:    &numRetries,
+		PerTryTimeout: retry.PerTryTimeout,
+	}
+
+	for _, retryOn := range retry.RetryOn {
+		irRetry.RetryOn = append(irRetry.RetryOn, string(retryOn))
+	}
+	for _, code := range retry.HTTPStatusCodes {
+		irRetry.RetriableStatusCodes = append(irRetry.RetriableStatusCodes, uint32(code))
+	}
+	switch {
+	case len(irRetry.RetryOn) == 0 && len(irRetry.RetriableStatusCodes) == 0:
+		irRetry.RetryOn = append([]string(nil), defaultRetryOn...)
+		irRetry.RetriableStatusCodes = []uint32{defaultRetryStatusCode}
+	case len(irRetry.RetryOn) == 0:
+		irRetry.RetryOn = []string{"retriable-status-codes"}
+	case len(irRetry.RetriableStatusCodes) > 0 && !slices.Contains(irRetry.RetryOn, "retriable-status-codes"):
+		// Status codes are only retried with the retriable-status-codes condition.
+		irRetry.RetryOn = append(irRetry.RetryOn, "retriable-status-codes")
+	}
+
+	if retry.BackOff != nil {
+		irRetry.BackOff = &ir.BackOff{
+			BaseInterval: retry.BackOff.BaseInterval,
+			MaxInterval:  retry.BackOff.MaxInterval,
+		}
+	}
+
+	return irRetry
+}
+
+// buildIRLoadBalancer translates the load balancer of a BackendTrafficPolicy into
+// the IR, or returns nil for round robin load balancing, including when the load
+// balancer is missing the configuration of its type.
+func buildIRLoadBalancer(lb *v1alpha1.LoadBalancer) *ir.LoadBalancer {
+	if lb == nil {
+		return nil
+	}
+
+	switch lb.Type {
+	case v1alpha1.RoundRobinLoadBalancerType:
+		return &ir.LoadBalancer{RoundRobin: &ir.RoundRobin{}}
+	case v1alpha1.LeastRequestLoadBalancerType:
+		leastRequest := &ir.LeastRequest{}
+		if lb.LeastRequest != nil && lb.LeastRequest.ChoiceCount != nil {
+			choiceCount := uint32(*lb.LeastRequest.ChoiceCount)
+			leastRequest.ChoiceCount = &choiceCount
+		}
+		return &ir.LoadBalancer{LeastRequest: leastRequest}
+	case v1alpha1.RandomLoadBalancerType:
+		return &ir.LoadBalancer{Random: &ir.Random{}}
+	case v1alpha1.ConsistentHashLoadBalancerType:
+		if consistentHash := buildIRConsistentHash(lb.ConsistentHash); consistentHash != nil {
+			return &ir.LoadBalancer{ConsistentHash: consistentHash}
+		}
+	}
+
+	return nil
+}
+
+func buildIRConsistentHash(consistentHash *v1alpha1.ConsistentHash) *ir.ConsistentHash {
+	if consistentHash == nil {
+		return nil
+	}
+
+	irConsistentHash := &ir.ConsistentHash{
+		Algorithm: ir.RingHashAlgorithm,
+	}
+	if consistentHash.Algorithm != nil && *consistentHash.Algorithm == v1alpha1.MaglevConsistentHashAlgorithm {
+		irConsistentHash.Algorithm = ir.MaglevAlgorithm
+	}
+
+	switch consistentHash.Type {
+	case v1alpha1.SourceIPConsistentHashType:
+		irConsistentHash.SourceIP = true
+	case v1alpha1.HeaderConsistentHashType:
+		if consistentHash.Header == nil {
+			return nil
+		}
+		irConsistentHash.Header = &ir.HeaderHash{
+			Name: consistentHash.Header.Name,
+		}
+	case v1alpha1.CookieConsistentHashType:
+		if consistentHash.Cookie == nil {
+			return nil
+		}
+		irConsistentHash.Cookie = &ir.CookieHash{
+			Name: consistentHash.Cookie.Name,
+			TTL:  consistentHash.Cookie.TTL,
+			Path: consistentHash.Cookie.Path,
+		}
+	default:
+		return nil
+	}
+
+	return irConsistentHash
+}
+
+// buildIRCircuitBreaker translates the circuit breaker of a BackendTrafficPolicy
+// into the IR.
+func buildIRCircuitBreaker(circuitBreaker *v1alpha1.CircuitBreaker) *ir.CircuitBreaker {
+	if circuitBreaker == nil {
+		return nil
+	}
+
+	return &ir.CircuitBreaker{
+		MaxConnections:      uint32PtrFromInt64(circuitBreaker.MaxConnections),
+		MaxPendingRequests:  uint32PtrFromInt64(circuitBreaker.MaxPendingRequests),
+		MaxParallelRequests: uint32PtrFromInt64(circuitBreaker.MaxParallelRequests),
+		MaxParallelRetries:  uint32PtrFromInt64(circuitBreaker.MaxParallelRetries),
+	}
+}
+
+// buildIRHealthCheck translates the health check of a BackendTrafficPolicy
+// into the IR.
+func buildIRHealthCheck(healthCheck *v1alpha1.HealthCheck) *ir.HealthCheck {
+	if healthCheck == nil {
+		return nil
+	}
+
+	irHealthCheck := &ir.HealthCheck{
+		Active: buildIRActiveHealthCheck(healthCheck.Active),
+	}
+	if passive := healthCheck.Passive; passive != nil {
+		irHealthCheck.Passive = &ir.OutlierDetection{
+			Consecutive5xxErrors: uint32PtrFromInt32(passive.Consecutive5xxErrors),
+			Interval:             passive.Interval,
+			BaseEjectionTime:     passive.BaseEjectionTime,
+			MaxEjectionPercent:   uint32PtrFromInt32(passive.MaxEjectionPercent),
+		}
+	}
+
+	return irHealthCheck
+}
+
+// buildIRActiveHealthCheck translates an active health check into the IR, setting
+// defaults for unspecified fields, or returns nil if the health check is missing
+// the configuration of its type.
+func buildIRActiveHealthCheck(healthCheck *v1alpha1.ActiveHealthCheck) *ir.ActiveHealthCheck {
+	if healthCheck == nil {
+		return nil
+	}
+
+	irHealthCheck := &ir.ActiveHealthCheck{
+		Timeout:            metav1.Duration{Duration: defaultActiveHealthCheckTimeout},
+		Interval:           metav1.Duration{Duration: defaultActiveHealthCheckInterval},
+		HealthyThreshold:   defaultActiveHealthCheckHealthyThreshold,
+		UnhealthyThreshold: defaultActiveHealthCheckUnhealthyThreshold,
+	}
+	if healthCheck.Timeout != nil {
+		irHealthCheck.Timeout = *healthCheck.Timeout
+	}
+	if healthCheck.Interval != nil {
+		irHealthCheck.Interval = *healthCheck.Interval
+	}
+	if healthCheck.HealthyThreshold != nil {
+		irHealthCheck.HealthyThreshold = uint32(*healthCheck.HealthyThreshold)
+	}
+	if healthCheck.UnhealthyThreshold != nil {
+		irHealthCheck.UnhealthyThreshold = uint32(*healthCheck.UnhealthyThreshold)
+	}
+
+	switch healthCheck.Type {
+	case v1alpha1.HTTPActiveHealthCheckerType:
+		if healthCheck.HTTP == nil {
+			return nil
+		}
+		irHealthCheck.HTTP = &ir.HTTPHealthChecker{
+			Path: healthCheck.HTTP.Path,
+		}
+		for _, status := range healthCheck.HTTP.ExpectedStatuses {
+			irHealthCheck.HTTP.ExpectedStatuses = append(irHealthCheck.HTTP.ExpectedStatuses, uint32(status))
+		}
+		if len(irHealthCheck.HTTP.ExpectedStatuses) == 0 {
+			irHealthCheck.HTTP.ExpectedStatuses = []uint32{defaultActiveHealthCheckExpectedStatus}
+		}
+	case v1alpha1.TCPActiveHealthCheckerType:
+		irHealthCheck.TCP = &ir.TCPHealthChecker{}
+	default:
+		return nil
+	}
+
+	return irHealthCheck
+}
+
+// buildIRBackendTLS translates the TLS configuration of a BackendTrafficPolicy in
+// namespace into the IR, resolving the referenced CA bundle from resources. False
+// is returned if the CA bundle can't be resolved.
+func buildIRBackendTLS(backendTLS *v1alpha1.BackendTLS, namespace string, resources *Resources) (*ir.BackendTLSConfig, bool) {
+	if backendTLS == nil {
+		return nil, true
+	}
+
+	irBackendTLS := &ir.BackendTLSConfig{
+		SubjectAltNames: backendTLS.SubjectAltNames,
+	}
+	if backendTLS.SNI != nil {
+		irBackendTLS.SNI = string(*backendTLS.SNI)
+	}
+
+	if ref := backendTLS.CACertificateRef; ref != nil {
+		caCertificate := resources.getCACertificateRefData(namespace, ref, caCertificateKey)
+		if len(caCertificate) == 0 {
+			return nil, false
+		}
+		irBackendTLS.CACertificate = caCertificate
+	}
+
+	return irBackendTLS, true
+}
+
+// buildIRRateLimit translates the rate limits of a BackendTrafficPolicy into the
+// IR. Global rate limits are ignored unless global is true.
+func buildIRRateLimit(rateLimit *v1alpha1.RateLimit, global bool) *ir.RateLimit {
+	if rateLimit == nil {
+		return nil
+	}
+
+	irRateLimit := &ir.RateLimit{
+		Local: buildIRLocalRateLimit(rateLimit.Local),
+	}
+	if global {
+		irRateLimit.Global = buildIRGlobalRateLimit(rateLimit.Global)
+	}
+	if irRateLimit.Local == nil && irRateLimit.Global == nil {
+		return nil
+	}
+	return irRateLimit
+}
+
+// buildIRLocalRateLimit translates a local rate limit into the IR. Rules with a unit
+// shorter than the unit of the limit are not supported by Envoy, so they are ignored.
+func buildIRLocalRateLimit(local *v1alpha1.LocalRateLimit) *ir.LocalRateLimit {
+	if local == nil {
+		return nil
+	}
+
+	irLocal := &ir.LocalRateLimit{
+		Default: buildIRRateLimitValue(local.Limit),
+	}
+	if local.Burst != nil {
+		irLocal.Burst = *local.Burst
+	}
+	for _, rule := range local.Rules {
+		irRule := &ir.LocalRateLimitRule{
+			Limit: buildIRRateLimitValue(rule.Limit),
+		}
+		if irRule.Limit.Unit.Duration() < irLocal.Default.Unit.Duration() {
+			continue
+		}
+		for _, header := range rule.Headers {
+			irRule.HeaderMatches = append(irRule.HeaderMatches, &ir.RateLimitHeaderMatch{
+				Name:  header.Name,
+				Value: header.Value,
+			})
+		}
+		irLocal.Rules = append(irLocal.Rules, irRule)
+	}
+
+	return irLocal
+}
+
+// buildIRGlobalRateLimit translates a global rate limit into the IR.
+func buildIRGlobalRateLimit(global *v1alpha1.GlobalRateLimit) *ir.GlobalRateLimit {
+	if global == nil || len(global.Rules) == 0 {
+		return nil
+	}
+
+	irGlobal := &ir.GlobalRateLimit{}
+	for _, rule := range global.Rules {
+		irRule := &ir.GlobalRateLimitRule{
+			Limit: buildIRRateLimitValue(rule.Limit),
+		}
+		for _, header := range rule.Headers {
+			match := &ir.RateLimitHeaderMatch{Name: header.Name}
+			if header.Value != nil {
+				match.Value = *header.Value
+			}
+			irRule.HeaderMatches = append(irRule.HeaderMatches, match)
+		}
+		irGlobal.Rules = append(irGlobal.Rules, irRule)
+	}
+
+	return irGlobal
+}
+
+func buildIRRateLimitValue(value v1alpha1.RateLimitValue) ir.RateLimitValue {
+	return ir.RateLimitValue{
+		Requests: value.Requests,
+		Unit:     ir.RateLimitUnit(value.Unit),
+	}
+}
+
+// buildIRTCPKeepalive translates the TCP keepalive configuration of a policy into
+// the IR. Durations are rounded up to whole seconds, and non-positive durations
+// are left to the operating system defaults.
+func buildIRTCPKeepalive(keepalive *v1alpha1.TCPKeepalive) *ir.TCPKeepalive {
+	if keepalive == nil {
+		return nil
+	}
+
+	irKeepalive := &ir.TCPKeepalive{
+		IdleTime: secondsPtrFromDuration(keepalive.IdleTime),
+		Interval: secondsPtrFromDuration(keepalive.Interval),
+	}
+	if keepalive.Probes != nil && *keepalive.Probes > 0 {
+		irKeepalive.Probes = uint32PtrFromInt32(keepalive.Probes)
+	}
+	return irKeepalive
+}
+
+// buildIRIPAccessControl translates the IP access control configuration of a policy
+// into the IR. If any CIDR is invalid, the access control fails closed by denying
+// all clients.
+func buildIRIPAccessControl(accessControl *v1alpha1.IPAccessControl) *ir.IPAccessControl {
+	if accessControl == nil || (len(accessControl.Allow) == 0 && len(accessControl.Deny) == 0) {
+		return nil
+	}
+
+	allow, allowErr := buildIRCIDRs(accessControl.Allow)
+	deny, denyErr := buildIRCIDRs(accessControl.Deny)
+	if allowErr != nil || denyErr != nil {
+		return &ir.IPAccessControl{
+			Deny: []*ir.CIDR{
+				{Prefix: "0.0.0.0", PrefixLen: 0},
+				{Prefix: "::", PrefixLen: 0},
+			},
+		}
+	}
+
+	return &ir.IPAccessControl{Allow: allow, Deny: deny}
+}
+
+func buildIRCIDRs(cidrs []v1alpha1.CIDR) ([]*ir.CIDR, error) {
+	var irCIDRs []*ir.CIDR
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(string(cidr))
+		if err != nil {
+			return nil, err
+		}
+		prefixLen, _ := ipNet.Mask.Size()
+		irCIDRs = append(irCIDRs, &ir.CIDR{
+			Prefix:    ipNet.IP.String(),
+			PrefixLen: uint32(prefixLen),
+		})
+	}
+	return irCIDRs, nil
+}
+
+func secondsPtrFromDuration(d *metav1.Duration) *uint32 {
+	if d == nil || d.Duration <= 0 {
+		return nil
+	}
+	seconds := uint32((d.Duration + time.Second - 1) / time.Second)
+	return &seconds
+}
+
+func uint32PtrFromInt32(i *int32) *uint32 {
+	if i == nil {
+		return nil
+	}
+	u := uint32(*i)
+	return &u
+}
+
+func uint32PtrFromInt64(i *int64) *uint32 {
+	if i == nil {
+		return nil
+	}
+	u := uint32(*i)
+	return &u
+}
+
+// buildIRFaultInjection translates the fault injection of a BackendTrafficPolicy
+// into the IR, setting defaults for unspecified fields. Fault injections without
+// a delay or an abort are ignored.
+func buildIRFaultInjection(faultInjection *v1alpha1.FaultInjection) *ir.FaultInjection {
+	if faultInjection == nil || (faultInjection.Delay == nil && faultInjection.Abort == nil) {
+		return nil
+	}
+
+	irFaultInjection := &ir.FaultInjection{}
+	if delay := faultInjection.Delay; delay != nil {
+		irFaultInjection.Delay = &ir.FaultInjectionDelay{
+			FixedDelay: delay.FixedDelay,
+			Percentage: faultInjectionPercentage(delay.Percentage),
+		}
+	}
+	if abort := faultInjection.Abort; abort != nil {
+		irFaultInjection.Abort = &ir.FaultInjectionAbort{
+			HTTPStatus: uint32(abort.HTTPStatus),
+			Percentage: faultInjectionPercentage(abort.Percentage),
+		}
+	}
+	return irFaultInjection
+}
+
+func faultInjectionPercentage(percentage *int32) uint32 {
+	if percentage == nil {
+		return 100
+	}
+	return uint32(*percentage)
+}
+
+// buildIRBuffer translates the buffering of a BackendTrafficPolicy into the IR.
+func buildIRBuffer(buffer *v1alpha1.Buffer) *ir.Buffer {
+	if buffer == nil {
+		return nil
+	}
+	return &ir.Buffer{MaxRequestBytes: uint32(buffer.MaxRequestBytes)}
+}
+
+// buildIRUpgrade translates the protocol upgrades of a BackendTrafficPolicy into the
+// IR, or returns nil if no upgrade is allowed.
+func buildIRUpgrade(upgrade *v1alpha1.ProtocolUpgrade) *ir.Upgrade {
+	if upgrade == nil {
+		return nil
+	}
+	irUpgrade := &ir.Upgrade{
+		WebSocket: upgrade.WebSocket != nil && *upgrade.WebSocket,
+		Connect:   upgrade.Connect != nil && *upgrade.Connect,
+	}
+	if !irUpgrade.WebSocket && !irUpgrade.Connect {
+		return nil
+	}
+	return irUpgrade
+}
+
+// buildIRTimeout translates the timeouts of a BackendTrafficPolicy into the IR.
+// Negative timeouts are ignored, and the backend request timeout is capped to the
+// request timeout.
+func buildIRTimeout(timeouts *v1alpha1.RouteTimeouts) *ir.HTTPTimeout {
+	if timeouts == nil {
+		return nil
+	}
+
+	irTimeout := new(ir.HTTPTimeout)
+	if timeouts.Request != nil && timeouts.Request.Duration >= 0 {
+		irTimeout.Request = timeouts.Request
+	}
+	if timeouts.BackendRequest != nil && timeouts.BackendRequest.Duration >= 0 {
+		irTimeout.BackendRequest = timeouts.BackendRequest
+		// A zero request timeout disables it.
+		if irTimeout.Request != nil && irTimeout.Request.Duration > 0 &&
+			irTimeout.BackendRequest.Duration > irTimeout.Request.Duration {
+			irTimeout.BackendRequest = irTimeout.Request
+		}
+	}
+
+	if irTimeout.Request == nil && irTimeout.BackendRequest == nil {
+		return nil
+	}
+	return irTimeout
+}
+
+// buildIRAddHeaders translates the provided headers to set and add into the IR.
+// Header names are case-insensitive, so only the first of the headers with the
+// same name is used, the headers to set coming first.
+func buildIRAddHeaders(set, add []v1alpha2.HTTPHeader) []ir.AddHeader {
+	var irHeaders []ir.AddHeader
+	seen := map[string]bool{}
+	for _, headers := range []struct {
+		headers []v1alpha2.HTTPHeader
+		append  bool
+	}{{headers: set}, {headers: add, append: true}} {
+		for _, header := range headers.headers {
+			name := strings.ToLower(string(header.Name))
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			irHeaders = append(irHeaders, ir.AddHeader{
+				Name:   string(header.Name),
+				Value:  header.Value,
+				Append: headers.append,
+			})
+		}
+	}
+	return irHeaders
+}
+
+// buildIRRemoveHeaders translates the provided names of the headers to remove into
+// the IR, lowercased and deduplicated.
+func buildIRRemoveHeaders(names []v1alpha2.HTTPHeaderName) []string {
+	var irNames []string
+	seen := map[string]bool{}
+	for _, header := range names {
+		name := strings.ToLower(string(header))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		irNames = append(irNames, name)
+	}
+	return irNames
+}
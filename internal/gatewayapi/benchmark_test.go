@@ -0,0 +1,173 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdstranslator "github.com/envoyproxy/gateway/internal/xds/translator"
+)
+
+// BenchmarkTranslate measures the translation of a Gateway with an increasing
+// number of HTTPRoutes. Run it with:
+//
+//	make benchmark
+func BenchmarkTranslate(b *testing.B) {
+	for _, routes := range []int{100, 1000, 10000} {
+		routes := routes
+		b.Run(fmt.Sprintf("routes-%d", routes), func(b *testing.B) {
+			resources := benchmarkResources(1, routes)
+			translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+			result := translator.Translate(benchmarkResourcesCopy(resources))
+			if len(result.XdsIR) != 1 {
+				b.Fatalf("translated %d xds irs, expected 1", len(result.XdsIR))
+			}
+			for _, xdsIR := range result.XdsIR {
+				if got := len(xdsIR.HTTP[0].Routes); got != routes {
+					b.Fatalf("translated %d routes, expected %d", got, routes)
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// The translation sets the statuses of the resources.
+				b.StopTimer()
+				in := benchmarkResourcesCopy(resources)
+				b.StartTimer()
+
+				translator.Translate(in)
+			}
+		})
+	}
+}
+
+// BenchmarkTranslateStages measures the translation of the Gateway API resources
+// into the xds IR and the translation of the xds IR into xDS resources, for a
+// Gateway with an increasing number of HTTP listeners, to compare the cost of
+// both stages. Run it with:
+//
+//	make benchmark
+func BenchmarkTranslateStages(b *testing.B) {
+	const routes = 5000
+	for _, listeners := range []int{1, 10, 100} {
+		listeners := listeners
+		resources := benchmarkResources(listeners, routes)
+		translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+		result := translator.Translate(benchmarkResourcesCopy(resources))
+		if len(result.XdsIR) != 1 {
+			b.Fatalf("translated %d xds irs, expected 1", len(result.XdsIR))
+		}
+		var xdsIR *ir.Xds
+		for _, x := range result.XdsIR {
+			xdsIR = x
+		}
+		if got := len(xdsIR.HTTP); got != listeners {
+			b.Fatalf("translated %d listeners, expected %d", got, listeners)
+		}
+
+		b.Run(fmt.Sprintf("gatewayapi/listeners-%d", listeners), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				in := benchmarkResourcesCopy(resources)
+				b.StartTimer()
+
+				translator.Translate(in)
+			}
+		})
+
+		b.Run(fmt.Sprintf("xds/listeners-%d", listeners), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := new(xdstranslator.Translator).Translate(xdsIR); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkResources returns a Gateway with the provided number of HTTP listeners,
+// and the provided number of HTTPRoutes attached to them in turn, each with its
+// own hostname and path.
+func benchmarkResources(listeners, routes int) *Resources {
+	gateway := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "envoy-gateway",
+			Name:      "gateway-1",
+		},
+		Spec: v1beta1.GatewaySpec{
+			GatewayClassName: "envoy-gateway-class",
+		},
+	}
+	for i := 0; i < listeners; i++ {
+		gateway.Spec.Listeners = append(gateway.Spec.Listeners, v1beta1.Listener{
+			Name:     v1beta1.SectionName(fmt.Sprintf("http-%d", i)),
+			Protocol: v1beta1.HTTPProtocolType,
+			Port:     v1beta1.PortNumber(80 + i),
+			AllowedRoutes: &v1beta1.AllowedRoutes{
+				Namespaces: &v1beta1.RouteNamespaces{
+					From: FromNamespacesPtr(v1beta1.NamespacesFromAll),
+				},
+			},
+		})
+	}
+
+	resources := &Resources{Gateways: []*v1beta1.Gateway{gateway}}
+	for i := 0; i < routes; i++ {
+		resources.HTTPRoutes = append(resources.HTTPRoutes, &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      fmt.Sprintf("httproute-%d", i),
+			},
+			Spec: v1beta1.HTTPRouteSpec{
+				CommonRouteSpec: v1beta1.CommonRouteSpec{
+					ParentRefs: []v1beta1.ParentReference{{
+						Namespace:   NamespacePtr("envoy-gateway"),
+						Name:        "gateway-1",
+						SectionName: SectionNamePtr(fmt.Sprintf("http-%d", i%listeners)),
+					}},
+				},
+				Hostnames: []v1beta1.Hostname{v1beta1.Hostname(fmt.Sprintf("route-%d.example.com", i))},
+				Rules: []v1beta1.HTTPRouteRule{{
+					Matches: []v1beta1.HTTPRouteMatch{{
+						Path: &v1beta1.HTTPPathMatch{
+							Type:  PathMatchTypePtr(v1beta1.PathMatchPathPrefix),
+							Value: StringPtr(fmt.Sprintf("/route-%d", i)),
+						},
+					}},
+					BackendRefs: []v1beta1.HTTPBackendRef{{
+						BackendRef: v1beta1.BackendRef{
+							BackendObjectReference: v1beta1.BackendObjectReference{
+								Name: "service-1",
+								Port: PortNumPtr(8080),
+							},
+						},
+					}},
+				}},
+			},
+		})
+	}
+	addTestFixtures(resources)
+	return resources
+}
+
+// benchmarkResourcesCopy returns a copy of the Gateways and HTTPRoutes of the
+// provided resources.
+func benchmarkResourcesCopy(resources *Resources) *Resources {
+	out := *resources
+	out.Gateways = make([]*v1beta1.Gateway, 0, len(resources.Gateways))
+	for _, gateway := range resources.Gateways {
+		out.Gateways = append(out.Gateways, gateway.DeepCopy())
+	}
+	out.HTTPRoutes = make([]*v1beta1.HTTPRoute, 0, len(resources.HTTPRoutes))
+	for _, route := range resources.HTTPRoutes {
+		out.HTTPRoutes = append(out.HTTPRoutes, route.DeepCopy())
+	}
+	return &out
+}
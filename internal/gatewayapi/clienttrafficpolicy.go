@@ -0,0 +1,368 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// clientTrafficPolicyForGateway returns the oldest ClientTrafficPolicy targeting
+// gateway, or nil if no policy targets it.
+func clientTrafficPolicyForGateway(policies []*v1alpha1.ClientTrafficPolicy, gateway *v1beta1.Gateway) *v1alpha1.ClientTrafficPolicy {
+	return policyForTarget(policies, func(policy *v1alpha1.ClientTrafficPolicy) v1alpha2.PolicyTargetReference {
+		return policy.Spec.TargetRef
+	}, KindGateway, gateway.Namespace, gateway.Name)
+}
+
+// buildIRClientTLS translates the TLS configuration of a ClientTrafficPolicy into
+// a TLSListenerConfig holding only the client settings, which are applied to the
+// TLS configuration of each HTTPS listener of the targeted Gateway. An error is
+// returned if the CA bundle used to verify client certificates can't be resolved.
+func buildIRClientTLS(policy *v1alpha1.ClientTrafficPolicy, resources *Resources) (*ir.TLSListenerConfig, error) {
+	if policy == nil || policy.Spec.TLS == nil {
+		return nil, nil
+	}
+	tls := policy.Spec.TLS
+
+	clientTLS := &ir.TLSListenerConfig{
+		Ciphers:    tls.Ciphers,
+		ECDHCurves: tls.ECDHCurves,
+	}
+	if tls.MinVersion != nil {
+		clientTLS.MinVersion = ir.TLSVersion(*tls.MinVersion)
+	}
+	if tls.MaxVersion != nil {
+		clientTLS.MaxVersion = ir.TLSVersion(*tls.MaxVersion)
+	}
+	for _, protocol := range tls.ALPNProtocols {
+		clientTLS.ALPNProtocols = append(clientTLS.ALPNProtocols, string(protocol))
+	}
+
+	validation := tls.ClientValidation
+	if validation == nil {
+		return clientTLS, nil
+	}
+
+	ref := &validation.CACertificateRef
+	caCertificate := resources.getCACertificateRefData(policy.Namespace, ref, caCertificateKey)
+	if len(caCertificate) == 0 {
+		kind := KindConfigMap
+		if ref.Kind != nil {
+			kind = *ref.Kind
+		}
+		return nil, fmt.Errorf("client CA certificate %s %s/%s referenced by ClientTrafficPolicy %s/%s does not exist or does not contain %s",
+			kind, policy.Namespace, ref.Name, policy.Namespace, policy.Name, caCertificateKey)
+	}
+
+	clientTLS.ClientCACertificate = caCertificate
+	clientTLS.ClientCRL = resources.getCACertificateRefData(policy.Namespace, ref, caCRLKey)
+	clientTLS.RequireClientCertificate = !validation.Optional
+
+	if details := validation.ForwardClientCertDetails; details != nil {
+		clientTLS.ForwardClientCertDetails = &ir.ForwardClientCertDetails{
+			Mode: ir.ForwardClientCertMode(details.Mode),
+		}
+		for _, detail := range details.CertDetails {
+			switch detail {
+			case v1alpha1.ClientCertDetailSubject:
+				clientTLS.ForwardClientCertDetails.Subject = true
+			case v1alpha1.ClientCertDetailCert:
+				clientTLS.ForwardClientCertDetails.Cert = true
+			case v1alpha1.ClientCertDetailChain:
+				clientTLS.ForwardClientCertDetails.Chain = true
+			case v1alpha1.ClientCertDetailDNS:
+				clientTLS.ForwardClientCertDetails.DNS = true
+			case v1alpha1.ClientCertDetailURI:
+				clientTLS.ForwardClientCertDetails.URI = true
+			}
+		}
+	}
+
+	return clientTLS, nil
+}
+
+// applyClientTLS sets the client settings of clientTLS on tlsConfig.
+func applyClientTLS(tlsConfig, clientTLS *ir.TLSListenerConfig) {
+	if tlsConfig == nil || clientTLS == nil {
+		return
+	}
+	tlsConfig.ClientCACertificate = clientTLS.ClientCACertificate
+	tlsConfig.ClientCRL = clientTLS.ClientCRL
+	tlsConfig.RequireClientCertificate = clientTLS.RequireClientCertificate
+	tlsConfig.ForwardClientCertDetails = clientTLS.ForwardClientCertDetails
+	tlsConfig.MinVersion = clientTLS.MinVersion
+	tlsConfig.MaxVersion = clientTLS.MaxVersion
+	tlsConfig.Ciphers = clientTLS.Ciphers
+	tlsConfig.ECDHCurves = clientTLS.ECDHCurves
+	tlsConfig.ALPNProtocols = clientTLS.ALPNProtocols
+}
+
+// buildIRHTTP3 returns the HTTP/3 settings of an HTTPS listener of the Gateway
+// targeted by the provided policy, exposed to clients on servicePort, or nil if
+// HTTP/3 isn't enabled.
+func buildIRHTTP3(policy *v1alpha1.ClientTrafficPolicy, servicePort int32) *ir.HTTP3Settings {
+	if policy == nil || policy.Spec.HTTP3 == nil {
+		return nil
+	}
+	return &ir.HTTP3Settings{AdvertisedPort: uint32(servicePort)}
+}
+
+// buildIRHTTP1 returns the HTTP/1.1 settings configured by the provided policy for the
+// HTTP and HTTPS listeners of the targeted Gateway, or nil if the Envoy defaults are
+// used.
+func buildIRHTTP1(policy *v1alpha1.ClientTrafficPolicy) *ir.HTTP1Settings {
+	if policy == nil || policy.Spec.HTTP1 == nil || policy.Spec.HTTP1.HeaderCase == nil {
+		return nil
+	}
+	return &ir.HTTP1Settings{HeaderCase: ir.HeaderCase(*policy.Spec.HTTP1.HeaderCase)}
+}
+
+// buildIRClientIPDetection returns the detection of the client address configured by
+// the provided policy for the HTTP and HTTPS listeners of the targeted Gateway, or
+// nil if the Envoy defaults are used. Policies specifying both detection methods
+// are ignored, since Envoy can't combine them.
+func buildIRClientIPDetection(policy *v1alpha1.ClientTrafficPolicy) *ir.ClientIPDetection {
+	if policy == nil || policy.Spec.ClientIPDetection == nil {
+		return nil
+	}
+	detection := policy.Spec.ClientIPDetection
+	if (detection.XForwardedFor == nil) == (detection.CustomHeader == nil) {
+		return nil
+	}
+
+	irDetection := new(ir.ClientIPDetection)
+	if xff := detection.XForwardedFor; xff != nil {
+		irDetection.XForwardedFor = &ir.XForwardedForIPDetection{
+			SkipAppend: xff.Append != nil && !*xff.Append,
+		}
+		if xff.NumTrustedHops != nil {
+			irDetection.XForwardedFor.NumTrustedHops = *xff.NumTrustedHops
+		}
+	}
+	if header := detection.CustomHeader; header != nil {
+		irDetection.CustomHeader = &ir.CustomHeaderIPDetection{
+			Name:       header.Name,
+			FailClosed: header.FailClosed != nil && *header.FailClosed,
+		}
+	}
+
+	return irDetection
+}
+
+// buildIRClientTimeouts returns the timeouts of the clients configured by the provided
+// policy for the HTTP and HTTPS listeners of the targeted Gateway, or nil if the
+// Envoy defaults are used.
+func buildIRClientTimeouts(policy *v1alpha1.ClientTrafficPolicy) *ir.ClientTimeouts {
+	if policy == nil || policy.Spec.Timeouts == nil {
+		return nil
+	}
+	timeouts := policy.Spec.Timeouts
+	return &ir.ClientTimeouts{
+		Idle:       timeouts.IdleTimeout,
+		StreamIdle: timeouts.StreamIdleTimeout,
+		Request:    timeouts.RequestTimeout,
+	}
+}
+
+// buildIRMaxRequestHeadersKB returns the maximum size of the request headers configured
+// by the provided policy for the HTTP and HTTPS listeners of the targeted Gateway, or
+// zero if the Envoy default is used.
+func buildIRMaxRequestHeadersKB(policy *v1alpha1.ClientTrafficPolicy) uint32 {
+	if policy == nil || policy.Spec.MaxRequestHeadersKB == nil {
+		return 0
+	}
+	return uint32(*policy.Spec.MaxRequestHeadersKB)
+}
+
+// buildIRSocketOptions translates the socket options configured by the provided
+// policy for the listeners of the targeted Gateway into the IR.
+func buildIRSocketOptions(policy *v1alpha1.ClientTrafficPolicy) []*ir.SocketOption {
+	if policy == nil || len(policy.Spec.SocketOptions) == 0 {
+		return nil
+	}
+
+	options := make([]*ir.SocketOption, 0, len(policy.Spec.SocketOptions))
+	for _, option := range policy.Spec.SocketOptions {
+		state := ir.SocketOptionStatePrebind
+		if option.State != nil {
+			state = ir.SocketOptionState(*option.State)
+		}
+		options = append(options, &ir.SocketOption{
+			Level: option.Level,
+			Name:  option.Name,
+			Value: option.Value,
+			State: state,
+		})
+	}
+	return options
+}
+
+// buildIRCompression translates the compression configured by the provided policy for
+// the HTTP and HTTPS listeners of the targeted Gateway into the IR. Duplicate
+// algorithms are ignored.
+func buildIRCompression(policy *v1alpha1.ClientTrafficPolicy) *ir.Compression {
+	if policy == nil || policy.Spec.Compression == nil {
+		return nil
+	}
+	compression := policy.Spec.Compression
+
+	irCompression := &ir.Compression{
+		ContentTypes:     compression.ContentTypes,
+		MinContentLength: compression.MinContentLength,
+	}
+	seen := map[v1alpha1.CompressionAlgorithm]bool{}
+	for _, algorithm := range compression.Algorithms {
+		if seen[algorithm] {
+			continue
+		}
+		seen[algorithm] = true
+		irCompression.Algorithms = append(irCompression.Algorithms, ir.CompressionAlgorithm(algorithm))
+	}
+	if compression.Level != nil {
+		irCompression.Level = ir.CompressionLevel(*compression.Level)
+	}
+
+	return irCompression
+}
+
+// buildIRLocalReply translates the local reply customization of the provided policy
+// for the HTTP and HTTPS listeners of the targeted Gateway into the IR. A body format
+// without a format matching its type is ignored.
+func buildIRLocalReply(policy *v1alpha1.ClientTrafficPolicy) *ir.LocalReply {
+	if policy == nil || policy.Spec.LocalReply == nil {
+		return nil
+	}
+	localReply := policy.Spec.LocalReply
+
+	irLocalReply := &ir.LocalReply{}
+	for _, mapper := range localReply.Mappers {
+		irMapper := &ir.LocalReplyMapper{
+			Body: mapper.Body,
+		}
+		for _, code := range mapper.StatusCodes {
+			irMapper.StatusCodes = append(irMapper.StatusCodes, uint32(code))
+		}
+		if mapper.StatusCode != nil {
+			code := uint32(*mapper.StatusCode)
+			irMapper.StatusCode = &code
+		}
+		irLocalReply.Mappers = append(irLocalReply.Mappers, irMapper)
+	}
+
+	if format := localReply.BodyFormat; format != nil {
+		irFormat := &ir.LocalReplyBodyFormat{}
+		if format.ContentType != nil {
+			irFormat.ContentType = *format.ContentType
+		}
+		switch {
+		case format.Type == v1alpha1.LocalReplyBodyFormatTypeText && format.Text != nil:
+			irFormat.Text = format.Text
+			irLocalReply.BodyFormat = irFormat
+		case format.Type == v1alpha1.LocalReplyBodyFormatTypeJSON && len(format.JSON) > 0:
+			irFormat.JSON = format.JSON
+			irLocalReply.BodyFormat = irFormat
+		}
+	}
+
+	if len(irLocalReply.Mappers) == 0 && irLocalReply.BodyFormat == nil {
+		return nil
+	}
+	return irLocalReply
+}
+
+// buildIRHSTSHeaders builds the Strict-Transport-Security response header of the HSTS
+// configuration of the provided policy, if any.
+func buildIRHSTSHeaders(policy *v1alpha1.ClientTrafficPolicy) []ir.AddHeader {
+	if policy == nil || policy.Spec.HSTS == nil {
+		return nil
+	}
+	hsts := policy.Spec.HSTS
+
+	value := fmt.Sprintf("max-age=%d", hsts.MaxAge)
+	if hsts.IncludeSubdomains != nil && *hsts.IncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if hsts.Preload != nil && *hsts.Preload {
+		value += "; preload"
+	}
+
+	return []ir.AddHeader{{
+		Name:  "strict-transport-security",
+		Value: value,
+	}}
+}
+
+// buildIRRequestHeaders builds the headers set and added to the requests by the
+// request header settings of the provided policy, if any.
+func buildIRRequestHeaders(policy *v1alpha1.ClientTrafficPolicy) []ir.AddHeader {
+	if policy == nil || policy.Spec.RequestHeaders == nil {
+		return nil
+	}
+	return buildIRAddHeaders(policy.Spec.RequestHeaders.Set, policy.Spec.RequestHeaders.Add)
+}
+
+// applyIRResponseHeaders applies the provided response header settings to the HTTP
+// listener. The Server header is only set if the listener doesn't have one, so the
+// settings applied first take precedence, while the removed headers accumulate.
+func applyIRResponseHeaders(irListener *ir.HTTPListener, settings *v1alpha1.ResponseHeaderSettings) {
+	if settings == nil {
+		return
+	}
+
+	if serverHeader := settings.ServerHeader; serverHeader != nil && irListener.ServerHeader == nil {
+		irServerHeader := &ir.ServerHeader{Transformation: ir.ServerHeaderOverwrite}
+		if serverHeader.Transformation != nil {
+			irServerHeader.Transformation = ir.ServerHeaderTransformation(*serverHeader.Transformation)
+		}
+		if serverHeader.Value != nil {
+			irServerHeader.Value = *serverHeader.Value
+		}
+		irListener.ServerHeader = irServerHeader
+	}
+
+	removed := map[string]bool{}
+	for _, name := range irListener.RemoveResponseHeaders {
+		removed[name] = true
+	}
+	for _, header := range settings.RemoveHeaders {
+		// Envoy doesn't allow removing pseudo-headers.
+		name := strings.ToLower(header)
+		if name == "" || strings.HasPrefix(name, ":") || removed[name] {
+			continue
+		}
+		removed[name] = true
+		irListener.RemoveResponseHeaders = append(irListener.RemoveResponseHeaders, name)
+	}
+}
+
+// clientResponseHeaders returns the response header settings of the provided policy,
+// if any.
+func clientResponseHeaders(policy *v1alpha1.ClientTrafficPolicy) *v1alpha1.ResponseHeaderSettings {
+	if policy == nil {
+		return nil
+	}
+	return policy.Spec.ResponseHeaders
+}
+
+// clientTCPKeepalive returns the TCP keepalive configuration of the provided policy,
+// if any.
+func clientTCPKeepalive(policy *v1alpha1.ClientTrafficPolicy) *v1alpha1.TCPKeepalive {
+	if policy == nil {
+		return nil
+	}
+	return policy.Spec.TCPKeepalive
+}
+
+// clientIPAccessControl returns the IP access control configuration of the provided
+// policy, if any.
+func clientIPAccessControl(policy *v1alpha1.ClientTrafficPolicy) *v1alpha1.IPAccessControl {
+	if policy == nil {
+		return nil
+	}
+	return policy.Spec.IPAccessControl
+}
@@ -143,7 +143,12 @@ func (l *ListenerContext) AllowsNamespace(namespace *v1.Namespace) bool {
 	if namespace == nil {
 		return false
 	}
-	switch *l.AllowedRoutes.Namespaces.From {
+	// NamespacesFromSame is the default
+	from := v1beta1.NamespacesFromSame
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != nil {
+		from = *l.AllowedRoutes.Namespaces.From
+	}
+	switch from {
 	case v1beta1.NamespacesFromAll:
 		return true
 	case v1beta1.NamespacesFromSelector:
@@ -152,7 +157,6 @@ func (l *ListenerContext) AllowsNamespace(namespace *v1.Namespace) bool {
 		}
 		return l.namespaceSelector.Matches(labels.Set(namespace.Labels))
 	default:
-		// NamespacesFromSame is the default
 		return l.gateway.Namespace == namespace.Name
 	}
 }
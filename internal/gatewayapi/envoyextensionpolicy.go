@@ -0,0 +1,107 @@
+package gatewayapi
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// protoDescriptorKey is the default key of the protobuf descriptor set in the binary
+// data of a ConfigMap.
+const protoDescriptorKey = "descriptor.pb"
+
+// envoyExtensionPolicyForGateway returns the oldest EnvoyExtensionPolicy targeting
+// gateway, or nil if no policy targets it.
+func envoyExtensionPolicyForGateway(policies []*v1alpha1.EnvoyExtensionPolicy, gateway *v1beta1.Gateway) *v1alpha1.EnvoyExtensionPolicy {
+	return policyForTarget(policies, func(policy *v1alpha1.EnvoyExtensionPolicy) v1alpha2.PolicyTargetReference {
+		return policy.Spec.TargetRef
+	}, KindGateway, gateway.Namespace, gateway.Name)
+}
+
+// envoyExtensionPolicyForRoute returns the EnvoyExtensionPolicy that applies to the
+// HTTPRoute attached to gateway, i.e. the oldest policy targeting the route, or the
+// oldest policy targeting the gateway if no policy targets the route.
+func envoyExtensionPolicyForRoute(policies []*v1alpha1.EnvoyExtensionPolicy, httpRoute *v1beta1.HTTPRoute, gateway *v1beta1.Gateway) *v1alpha1.EnvoyExtensionPolicy {
+	targetRef := func(policy *v1alpha1.EnvoyExtensionPolicy) v1alpha2.PolicyTargetReference {
+		return policy.Spec.TargetRef
+	}
+	if policy := policyForTarget(policies, targetRef, KindHTTPRoute, httpRoute.Namespace, httpRoute.Name); policy != nil {
+		return policy
+	}
+	return policyForTarget(policies, targetRef, KindGateway, gateway.Namespace, gateway.Name)
+}
+
+// buildIRLua translates the Lua script of the provided policy into the IR. The
+// script is named after the policy, so that the routes it applies to share it.
+func buildIRLua(policy *v1alpha1.EnvoyExtensionPolicy) *ir.Lua {
+	if policy == nil || policy.Spec.Lua == nil {
+		return nil
+	}
+
+	return &ir.Lua{
+		Name: fmt.Sprintf("%s/%s", policy.Namespace, policy.Name),
+		Code: policy.Spec.Lua.Inline,
+	}
+}
+
+// buildIRGRPCJSONTranscoder translates the gRPC-JSON transcoder of the provided
+// policy into the IR. The transcoder is ignored if its descriptor set can't be
+// resolved, so the requests are forwarded as is.
+func buildIRGRPCJSONTranscoder(policy *v1alpha1.EnvoyExtensionPolicy, resources *Resources) *ir.GRPCJSONTranscoder {
+	if policy == nil || policy.Spec.GRPCJSONTranscoder == nil {
+		return nil
+	}
+	transcoder := policy.Spec.GRPCJSONTranscoder
+
+	key := protoDescriptorKey
+	if transcoder.ProtoDescriptorRef.Key != nil {
+		key = *transcoder.ProtoDescriptorRef.Key
+	}
+	configMap := resources.GetConfigMap(policy.Namespace, transcoder.ProtoDescriptorRef.Name)
+	if configMap == nil || len(configMap.BinaryData[key]) == 0 {
+		return nil
+	}
+
+	return &ir.GRPCJSONTranscoder{
+		ProtoDescriptor: configMap.BinaryData[key],
+		Services:        transcoder.Services,
+	}
+}
+
+// buildIRWasms translates the Wasm extensions of the provided policy for the HTTP and
+// HTTPS listeners of the targeted Gateway into the IR. Extensions without a source
+// matching their source type, or reusing the name of a previous extension, are
+// ignored.
+func buildIRWasms(policy *v1alpha1.EnvoyExtensionPolicy) []*ir.Wasm {
+	if policy == nil || len(policy.Spec.Wasm) == 0 {
+		return nil
+	}
+
+	var wasms []*ir.Wasm
+	names := map[string]bool{}
+	for _, wasm := range policy.Spec.Wasm {
+		if wasm.Code.Type != v1alpha1.WasmCodeSourceTypeHTTP || wasm.Code.HTTP == nil || names[wasm.Name] {
+			continue
+		}
+		names[wasm.Name] = true
+
+		irWasm := &ir.Wasm{
+			Name:     wasm.Name,
+			URL:      wasm.Code.HTTP.URL,
+			SHA256:   wasm.Code.HTTP.SHA256,
+			FailOpen: wasm.FailOpen != nil && *wasm.FailOpen,
+		}
+		if wasm.RootID != nil {
+			irWasm.RootID = *wasm.RootID
+		}
+		if wasm.Config != nil {
+			irWasm.Config = string(wasm.Config.Raw)
+		}
+		wasms = append(wasms, irWasm)
+	}
+	return wasms
+}
@@ -0,0 +1,97 @@
+package gatewayapi
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// ProcessEnvoyPatchPolicies adds the EnvoyPatchPolicies targeting the relevant
+// gateways to their xdsIR, from the oldest to the newest policy. The policies that
+// can't be applied are returned with an Accepted condition set to false, while the
+// status of the applied policies is set by the xDS translation.
+func (t *Translator) ProcessEnvoyPatchPolicies(policies []*v1alpha1.EnvoyPatchPolicy, gateways []*GatewayContext, xdsIR XdsIRMap) []*v1alpha1.EnvoyPatchPolicy {
+	var rejected []*v1alpha1.EnvoyPatchPolicy
+	for _, policy := range sortByCreationTimestamp(policies) {
+		if !t.EnvoyPatchPolicyEnabled {
+			rejected = append(rejected, rejectEnvoyPatchPolicy(policy, v1alpha1.EnvoyPatchPolicyReasonDisabled,
+				"EnvoyPatchPolicies are not enabled in the extension APIs settings of Envoy Gateway"))
+			continue
+		}
+
+		gateway := envoyPatchPolicyTarget(policy, gateways)
+		if gateway == nil {
+			rejected = append(rejected, rejectEnvoyPatchPolicy(policy, v1alpha1.EnvoyPatchPolicyReasonTargetNotFound,
+				fmt.Sprintf("Gateway %s/%s not found", policy.Namespace, policy.Spec.TargetRef.Name)))
+			continue
+		}
+
+		irKey := irStringKey(gateway.Gateway)
+		xdsIR[irKey].EnvoyPatchPolicies = append(xdsIR[irKey].EnvoyPatchPolicies, buildIREnvoyPatchPolicy(policy))
+	}
+
+	return rejected
+}
+
+// envoyPatchPolicyTarget returns the relevant gateway targeted by the provided
+// policy, or nil if the policy doesn't target one.
+func envoyPatchPolicyTarget(policy *v1alpha1.EnvoyPatchPolicy, gateways []*GatewayContext) *GatewayContext {
+	ref := policy.Spec.TargetRef
+	if string(ref.Group) != v1beta1.GroupName || string(ref.Kind) != KindGateway {
+		return nil
+	}
+	if ref.Namespace != nil && string(*ref.Namespace) != policy.Namespace {
+		return nil
+	}
+	for _, gateway := range gateways {
+		if gateway.Namespace == policy.Namespace && gateway.Name == string(ref.Name) {
+			return gateway
+		}
+	}
+	return nil
+}
+
+// rejectEnvoyPatchPolicy returns a copy of the provided policy with an Accepted
+// condition set to false for the provided reason. The Programmed condition is
+// removed, since none of the patches of the policy are applied.
+func rejectEnvoyPatchPolicy(policy *v1alpha1.EnvoyPatchPolicy, reason v1alpha1.EnvoyPatchPolicyConditionReason, message string) *v1alpha1.EnvoyPatchPolicy {
+	policy = policy.DeepCopy()
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               string(v1alpha1.EnvoyPatchPolicyConditionAccepted),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: policy.Generation,
+	})
+	meta.RemoveStatusCondition(&policy.Status.Conditions, string(v1alpha1.EnvoyPatchPolicyConditionProgrammed))
+	return policy
+}
+
+// buildIREnvoyPatchPolicy translates the JSON patches of the provided policy into
+// the IR.
+func buildIREnvoyPatchPolicy(policy *v1alpha1.EnvoyPatchPolicy) *ir.EnvoyPatchPolicy {
+	irPolicy := &ir.EnvoyPatchPolicy{
+		Name:       policy.Name,
+		Namespace:  policy.Namespace,
+		Generation: policy.Generation,
+	}
+	for _, patch := range policy.Spec.JSONPatches {
+		irPatch := &ir.JSONPatchConfig{
+			Type: string(patch.Type),
+			Name: patch.Name,
+			Op:   string(patch.Operation.Op),
+			Path: patch.Operation.Path,
+			From: stringOrEmpty(patch.Operation.From),
+		}
+		if patch.Operation.Value != nil {
+			irPatch.Value = string(patch.Operation.Value.Raw)
+		}
+		irPolicy.JSONPatches = append(irPolicy.JSONPatches, irPatch)
+	}
+	return irPolicy
+}
@@ -0,0 +1,90 @@
+package gatewayapi
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	xdstranslator "github.com/envoyproxy/gateway/internal/xds/translator"
+)
+
+// FuzzTranslate translates arbitrary resources into the IR, and the IR into xDS
+// resources, checking that the translations don't panic and that the xDS
+// resources pass their validation. It is seeded with the translation test
+// inputs, and is run with:
+//
+//	go test ./internal/gatewayapi -run '^$' -fuzz FuzzTranslate
+func FuzzTranslate(f *testing.F) {
+	inputFiles, err := filepath.Glob(filepath.Join("testdata", "*.in.yaml"))
+	require.NoError(f, err)
+	for _, inputFile := range inputFiles {
+		input, err := os.ReadFile(inputFile)
+		require.NoError(f, err)
+		f.Add(input)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		resources := &Resources{}
+		if err := yaml.Unmarshal(input, resources); err != nil || hasNilResource(resources) {
+			t.Skip()
+		}
+		addTestFixtures(resources)
+
+		translator := &Translator{
+			GatewayClassName:        "envoy-gateway-class",
+			GlobalRateLimitEnabled:  true,
+			LuaEnabled:              true,
+			EnvoyPatchPolicyEnabled: true,
+		}
+		result := translator.Translate(resources)
+
+		xdsTranslator := &xdstranslator.Translator{
+			GlobalRateLimit: &xdstranslator.GlobalRateLimitSettings{
+				ServiceHost: "envoy-ratelimit.envoy-gateway-system.svc.cluster.local",
+				ServicePort: 8081,
+			},
+		}
+		for key, xdsIR := range result.XdsIR {
+			// The IR is allowed to fail translation, e.g. for an invalid wasm
+			// module URL.
+			tCtx, err := xdsTranslator.Translate(xdsIR)
+			if err != nil {
+				continue
+			}
+			for typ, xdsResources := range tCtx.XdsResources {
+				for _, xdsResource := range xdsResources {
+					v, ok := xdsResource.(interface{ Validate() error })
+					if !ok {
+						continue
+					}
+					if err := v.Validate(); err != nil {
+						t.Fatalf("invalid %s %s of %s: %v", typ, cachev3.GetResourceName(xdsResource), key, err)
+					}
+				}
+			}
+		}
+	})
+}
+
+// hasNilResource returns true if any of the resources is nil. The providers
+// never set nil resources, which the translator doesn't expect.
+func hasNilResource(resources *Resources) bool {
+	v := reflect.ValueOf(resources).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Slice {
+			continue
+		}
+		for j := 0; j < field.Len(); j++ {
+			if field.Index(j).IsNil() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,9 +1,12 @@
 package gatewayapi
 
 import (
+	"fmt"
+	"net/netip"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
@@ -62,6 +65,18 @@ func GatewayAddressTypePtr(addr v1beta1.AddressType) *v1beta1.AddressType {
 	return &addr
 }
 
+// ValidateGatewayAddress returns an error if the provided Gateway address is not
+// supported. Only addresses of the IPAddress type are supported.
+func ValidateGatewayAddress(addr v1beta1.GatewayAddress) error {
+	if addr.Type != nil && *addr.Type != v1beta1.IPAddressType {
+		return fmt.Errorf("address type %s is not supported, only %s addresses are supported", *addr.Type, v1beta1.IPAddressType)
+	}
+	if _, err := netip.ParseAddr(addr.Value); err != nil {
+		return fmt.Errorf("address %q is not a valid IP address", addr.Value)
+	}
+	return nil
+}
+
 func PathMatchTypeDerefOr(matchType *v1beta1.PathMatchType, defaultType v1beta1.PathMatchType) v1beta1.PathMatchType {
 	if matchType != nil {
 		return *matchType
@@ -148,7 +163,8 @@ func HasReadyListener(listeners []*ListenerContext) bool {
 }
 
 // computeHosts returns a list of the intersecting hostnames between the route
-// and the listener.
+// and the listener. For wildcard hostnames, the most specific hostname of the
+// intersection is used.
 func computeHosts(routeHostnames []string, listenerHostname *v1beta1.Hostname) []string {
 	var listenerHostnameVal string
 	if listenerHostname != nil {
@@ -166,33 +182,38 @@ func computeHosts(routeHostnames []string, listenerHostname *v1beta1.Hostname) [
 	}
 
 	var hostnames []string
+	found := sets.NewString()
 
 	for i := range routeHostnames {
 		routeHostname := routeHostnames[i]
 
 		// TODO ensure routeHostname is a valid hostname
 
+		var hostname string
 		switch {
 		// No listener hostname: use the route hostname.
 		case len(listenerHostnameVal) == 0:
-			hostnames = append(hostnames, routeHostname)
+			hostname = routeHostname
 
 		// Listener hostname matches the route hostname: use it.
 		case listenerHostnameVal == routeHostname:
-			hostnames = append(hostnames, routeHostname)
+			hostname = routeHostname
 
 		// Listener has a wildcard hostname: check if the route hostname matches.
-		case strings.HasPrefix(listenerHostnameVal, "*"):
-			if hostnameMatchesWildcardHostname(routeHostname, listenerHostnameVal) {
-				hostnames = append(hostnames, routeHostname)
-			}
+		case strings.HasPrefix(listenerHostnameVal, "*") && hostnameMatchesWildcardHostname(routeHostname, listenerHostnameVal):
+			hostname = routeHostname
 
 		// Route has a wildcard hostname: check if the listener hostname matches.
-		case strings.HasPrefix(routeHostname, "*"):
-			if hostnameMatchesWildcardHostname(listenerHostnameVal, routeHostname) {
-				hostnames = append(hostnames, listenerHostnameVal)
-			}
+		case strings.HasPrefix(routeHostname, "*") && hostnameMatchesWildcardHostname(listenerHostnameVal, routeHostname):
+			hostname = listenerHostnameVal
+		}
 
+		// Several route hostnames may intersect with the listener hostname
+		// to the same hostname, e.g. "*.example.com" and "*.com" with a
+		// "foo.example.com" listener.
+		if len(hostname) > 0 && !found.Has(hostname) {
+			found.Insert(hostname)
+			hostnames = append(hostnames, hostname)
 		}
 	}
 
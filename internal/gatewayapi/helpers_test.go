@@ -0,0 +1,84 @@
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestComputeHosts(t *testing.T) {
+	testCases := []struct {
+		name             string
+		routeHostnames   []string
+		listenerHostname string
+		expect           []string
+	}{
+		{
+			name:   "no route or listener hostnames",
+			expect: []string{"*"},
+		},
+		{
+			name:             "no route hostnames",
+			listenerHostname: "foo.example.com",
+			expect:           []string{"foo.example.com"},
+		},
+		{
+			name:           "no listener hostname",
+			routeHostnames: []string{"foo.example.com", "*.example.net"},
+			expect:         []string{"foo.example.com", "*.example.net"},
+		},
+		{
+			name:             "route hostname matching wildcard listener hostname",
+			routeHostnames:   []string{"foo.example.com", "foo.example.net"},
+			listenerHostname: "*.example.com",
+			expect:           []string{"foo.example.com"},
+		},
+		{
+			name:             "wildcard route hostname matching listener hostname",
+			routeHostnames:   []string{"*.example.com"},
+			listenerHostname: "foo.example.com",
+			expect:           []string{"foo.example.com"},
+		},
+		{
+			name:             "wildcard route hostname more specific than wildcard listener hostname",
+			routeHostnames:   []string{"*.foo.example.com"},
+			listenerHostname: "*.example.com",
+			expect:           []string{"*.foo.example.com"},
+		},
+		{
+			name:             "wildcard listener hostname more specific than wildcard route hostname",
+			routeHostnames:   []string{"*.example.com"},
+			listenerHostname: "*.foo.example.com",
+			expect:           []string{"*.foo.example.com"},
+		},
+		{
+			name:             "route hostnames intersecting to the same hostname",
+			routeHostnames:   []string{"*.example.com", "*.com"},
+			listenerHostname: "foo.example.com",
+			expect:           []string{"foo.example.com"},
+		},
+		{
+			name:             "disjoint hostnames",
+			routeHostnames:   []string{"foo.example.net", "*.example.net"},
+			listenerHostname: "*.example.com",
+		},
+		{
+			name:             "wildcard does not match its own suffix",
+			routeHostnames:   []string{"example.com"},
+			listenerHostname: "*.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var listenerHostname *v1beta1.Hostname
+			if tc.listenerHostname != "" {
+				hostname := v1beta1.Hostname(tc.listenerHostname)
+				listenerHostname = &hostname
+			}
+			assert.Equal(t, tc.expect, computeHosts(tc.routeHostnames, listenerHostname))
+		})
+	}
+}
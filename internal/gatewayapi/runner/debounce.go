@@ -0,0 +1,52 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/telepresenceio/watchable"
+)
+
+// notifyOnSnapshot sends a notification to notify for each snapshot received
+// from the provided subscription, until the subscription is closed. The
+// notification is dropped if one is already pending, since a pending
+// notification already triggers the translation of the latest resources.
+func notifyOnSnapshot[K comparable, V any](subscription <-chan watchable.Snapshot[K, V], notify chan<- struct{}) {
+	for range subscription {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// debounce waits for the notifications following a first notification to stop
+// for the duration of window, or for maxDelay to elapse since the first
+// notification, so that a burst of notifications triggers a single translation.
+// It returns false if ctx is done meanwhile.
+func debounce(ctx context.Context, notify <-chan struct{}, window, maxDelay time.Duration) bool {
+	if window <= 0 {
+		return ctx.Err() == nil
+	}
+
+	deadline := time.NewTimer(maxDelay)
+	defer deadline.Stop()
+	quiet := time.NewTimer(window)
+	defer quiet.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			return true
+		case <-quiet.C:
+			return true
+		case <-notify:
+			if !quiet.Stop() {
+				<-quiet.C
+			}
+			quiet.Reset(window)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebounce(t *testing.T) {
+	testCases := []struct {
+		name     string
+		window   time.Duration
+		maxDelay time.Duration
+		// notifications is the number of notifications sent 10ms apart.
+		notifications int
+		min, max      time.Duration
+	}{
+		{
+			name:     "disabled",
+			maxDelay: time.Second,
+			max:      50 * time.Millisecond,
+		},
+		{
+			name:     "quiet window",
+			window:   50 * time.Millisecond,
+			maxDelay: time.Second,
+			min:      50 * time.Millisecond,
+			max:      500 * time.Millisecond,
+		},
+		{
+			name:          "window restarted by the notifications",
+			window:        50 * time.Millisecond,
+			maxDelay:      time.Second,
+			notifications: 10,
+			min:           140 * time.Millisecond,
+			max:           900 * time.Millisecond,
+		},
+		{
+			name:          "max delay",
+			window:        50 * time.Millisecond,
+			maxDelay:      100 * time.Millisecond,
+			notifications: 50,
+			min:           100 * time.Millisecond,
+			max:           400 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			notify := make(chan struct{}, 1)
+			go func() {
+				for i := 0; i < tc.notifications; i++ {
+					time.Sleep(10 * time.Millisecond)
+					select {
+					case notify <- struct{}{}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
+			start := time.Now()
+			require.True(t, debounce(ctx, notify, tc.window, tc.maxDelay))
+			elapsed := time.Since(start)
+			require.GreaterOrEqual(t, elapsed, tc.min)
+			require.Less(t, elapsed, tc.max)
+		})
+	}
+}
+
+func TestDebounceCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.False(t, debounce(ctx, make(chan struct{}), time.Second, time.Second))
+	require.False(t, debounce(ctx, make(chan struct{}), 0, time.Second))
+}
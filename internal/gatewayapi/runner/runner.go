@@ -3,13 +3,19 @@ package runner
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/provider/utils"
+	"github.com/envoyproxy/gateway/internal/tracing"
 )
 
 type Config struct {
@@ -33,45 +39,61 @@ func (r *Runner) Name() string {
 
 // Start starts the gateway-api translator runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	logger, err := r.ComponentLogger(v1alpha1.LogComponentGatewayAPI)
+	if err != nil {
+		return err
+	}
+	r.Logger = logger.WithValues("runner", r.Name())
 	go r.subscribeAndTranslate(ctx)
 	r.Logger.Info("started")
 	return nil
 }
 
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
-	// Subscribe to resources
-	gatewayClassesCh := r.ProviderResources.GatewayClasses.Subscribe(ctx)
-	gatewaysCh := r.ProviderResources.Gateways.Subscribe(ctx)
-	secretsCh := r.ProviderResources.Secrets.Subscribe(ctx)
-	refGrantsCh := r.ProviderResources.ReferenceGrants.Subscribe(ctx)
-	httpRoutesCh := r.ProviderResources.HTTPRoutes.Subscribe(ctx)
-	tlsRoutesCh := r.ProviderResources.TLSRoutes.Subscribe(ctx)
-	servicesCh := r.ProviderResources.Services.Subscribe(ctx)
-	namespacesCh := r.ProviderResources.Namespaces.Subscribe(ctx)
+	// Subscribe to resources, and merge their notifications so that bursts of
+	// changes are debounced.
+	notify := make(chan struct{}, 1)
+	go notifyOnSnapshot(r.ProviderResources.GatewayClasses.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.Gateways.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.Secrets.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.ConfigMaps.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.ReferenceGrants.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.HTTPRoutes.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.TLSRoutes.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.Services.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.Namespaces.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.EnvoyProxies.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.BackendTrafficPolicies.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.ClientTrafficPolicies.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.EnvoyExtensionPolicies.Subscribe(ctx), notify)
+	go notifyOnSnapshot(r.ProviderResources.EnvoyPatchPolicies.Subscribe(ctx), notify)
+	translation := r.EnvoyGateway.GetTranslation()
 
 	for ctx.Err() == nil {
 		var in gatewayapi.Resources
 		// Receive subscribed resource notifications
 		select {
-		case <-gatewayClassesCh:
-		case <-gatewaysCh:
-		case <-secretsCh:
-		case <-refGrantsCh:
-		case <-httpRoutesCh:
-		case <-tlsRoutesCh:
-		case <-servicesCh:
-		case <-namespacesCh:
+		case <-ctx.Done():
+			continue
+		case <-notify:
+		}
+		if !debounce(ctx, notify, translation.DebounceWindow.Duration, translation.MaxDelay.Duration) {
+			continue
 		}
 		r.Logger.Info("received a notification")
 		// Load all resources required for translation
 		in.Gateways = r.ProviderResources.GetGateways()
 		in.Secrets = r.ProviderResources.GetSecrets()
+		in.ConfigMaps = r.ProviderResources.GetConfigMaps()
 		in.ReferenceGrants = r.ProviderResources.GetReferenceGrants()
 		in.HTTPRoutes = r.ProviderResources.GetHTTPRoutes()
 		in.TLSRoutes = r.ProviderResources.GetTLSRoutes()
 		in.Services = r.ProviderResources.GetServices()
 		in.Namespaces = r.ProviderResources.GetNamespaces()
+		in.BackendTrafficPolicies = r.ProviderResources.GetBackendTrafficPolicies()
+		in.ClientTrafficPolicies = r.ProviderResources.GetClientTrafficPolicies()
+		in.EnvoyExtensionPolicies = r.ProviderResources.GetEnvoyExtensionPolicies()
+		in.EnvoyPatchPolicies = r.ProviderResources.GetEnvoyPatchPolicies()
 		gatewayClasses := r.ProviderResources.GetGatewayClasses()
 		// Fetch the first gateway class since there should be only 1
 		// gateway class linked to this controller
@@ -82,10 +104,22 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 		default:
 			// Translate and publish IRs.
 			t := &gatewayapi.Translator{
-				GatewayClassName: v1beta1.ObjectName(gatewayClasses[0].GetName()),
+				GatewayClassName:        v1beta1.ObjectName(gatewayClasses[0].GetName()),
+				GlobalRateLimitEnabled:  r.EnvoyGateway.RateLimit != nil,
+				LuaEnabled:              r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableLua,
+				EnvoyPatchPolicyEnabled: r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableEnvoyPatchPolicy,
 			}
+			// Load the EnvoyProxy referenced by the gateway class, if any.
+			in.EnvoyProxy = r.ProviderResources.GetEnvoyProxy(gatewayClasses[0].GetName())
 			// Translate to IR
+			_, span := tracing.Tracer().Start(ctx, "gatewayapi.translate", trace.WithAttributes(
+				attribute.String("gatewayclass", gatewayClasses[0].GetName()),
+				attribute.Int("gateways", len(in.Gateways)),
+				attribute.Int("httproutes", len(in.HTTPRoutes)),
+				attribute.Int("tlsroutes", len(in.TLSRoutes)),
+			))
 			result := t.Translate(&in)
+			tracing.EndSpan(span, nil)
 
 			yamlInfraIR, _ := yaml.Marshal(&result.InfraIR)
 			r.Logger.WithValues("output", "infra-ir").Info(string(yamlInfraIR))
@@ -136,6 +170,19 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 				key := utils.NamespacedName(tlsRoute)
 				r.ProviderResources.TLSRouteStatuses.Store(key, tlsRoute)
 			}
+			for _, policy := range result.BackendTrafficPolicies {
+				key := utils.NamespacedName(policy)
+				if cond := meta.FindStatusCondition(policy.Status.Conditions, string(v1alpha1.BackendTrafficPolicyConditionAccepted)); cond != nil && cond.Status == metav1.ConditionFalse {
+					r.Logger.Info("backendtrafficpolicy is not accepted", "namespace", policy.Namespace, "name", policy.Name, "message", cond.Message)
+				}
+				r.ProviderResources.BackendTrafficPolicyStatuses.Store(key, &policy.Status)
+			}
+			// The status of the applied EnvoyPatchPolicies is published by
+			// the xds translator.
+			for _, policy := range result.EnvoyPatchPolicies {
+				key := utils.NamespacedName(policy)
+				r.ProviderResources.EnvoyPatchPolicyStatuses.Store(key, &policy.Status)
+			}
 		}
 	}
 	r.Logger.Info("shutting down")
@@ -3,6 +3,9 @@ package gatewayapi
 import (
 	"sort"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
@@ -11,7 +14,19 @@ type XdsIRRoutes []*ir.HTTPRoute
 func (x XdsIRRoutes) Len() int      { return len(x) }
 func (x XdsIRRoutes) Swap(i, j int) { x[i], x[j] = x[j], x[i] }
 func (x XdsIRRoutes) Less(i, j int) bool {
-	// 1. Sort based on characters in a matching path.
+	// 1. Sort based on the type of path match, exact matches taking
+	// precedence over prefix matches.
+	pTypeI := pathMatchTypeRank(x[i].PathMatch)
+	pTypeJ := pathMatchTypeRank(x[j].PathMatch)
+	if pTypeI < pTypeJ {
+		return true
+	}
+	if pTypeI > pTypeJ {
+		return false
+	}
+	// Equal case
+
+	// 2. Sort based on characters in a matching path.
 	pCountI := pathMatchCount(x[i].PathMatch)
 	pCountJ := pathMatchCount(x[j].PathMatch)
 	if pCountI < pCountJ {
@@ -22,7 +37,7 @@ func (x XdsIRRoutes) Less(i, j int) bool {
 	}
 	// Equal case
 
-	// 2. Sort based on the number of Header matches.
+	// 3. Sort based on the number of Header matches.
 	hCountI := len(x[i].HeaderMatches)
 	hCountJ := len(x[j].HeaderMatches)
 	if hCountI < hCountJ {
@@ -33,22 +48,68 @@ func (x XdsIRRoutes) Less(i, j int) bool {
 	}
 	// Equal case
 
-	// 3. Sort based on the number of Query param matches.
+	// 4. Sort based on the number of Query param matches.
 	qCountI := len(x[i].QueryParamMatches)
 	qCountJ := len(x[j].QueryParamMatches)
 	return qCountI < qCountJ
 }
 
 // sortXdsIR sorts the xdsIR based on the match precedence
-// defined in the Gateway API spec.
+// defined in the Gateway API spec. The sort is stable, so ties
+// keep the order in which the routes were translated, see sortHTTPRoutes.
 // https://gateway-api.sigs.k8s.io/references/spec/#gateway.networking.k8s.io/v1beta1.HTTPRouteRule
 func sortXdsIRMap(xdsIR XdsIRMap) {
 	for _, ir := range xdsIR {
 		for _, http := range ir.HTTP {
 			// descending order
-			sort.Sort(sort.Reverse(XdsIRRoutes(http.Routes)))
+			sort.Stable(sort.Reverse(XdsIRRoutes(http.Routes)))
+		}
+	}
+}
+
+// sortHTTPRoutes returns the HTTPRoutes sorted by the tie-breaking criteria of the
+// match precedence defined in the Gateway API spec: the oldest route based on
+// creation timestamp, then the route appearing first in alphabetical order by
+// "{namespace}/{name}".
+func sortHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute) []*v1beta1.HTTPRoute {
+	return sortByCreationTimestamp(httpRoutes)
+}
+
+// sortByCreationTimestamp returns the objects sorted from the oldest to the newest
+// based on creation timestamp, then in alphabetical order by "{namespace}/{name}",
+// so that the translation doesn't depend on the order the objects are listed in.
+func sortByCreationTimestamp[T metav1.Object](objs []T) []T {
+	sorted := make([]T, len(objs))
+	copy(sorted, objs)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iCreated, jCreated := sorted[i].GetCreationTimestamp(), sorted[j].GetCreationTimestamp()
+		if !iCreated.Equal(&jCreated) {
+			return iCreated.Before(&jCreated)
+		}
+		if sorted[i].GetNamespace() != sorted[j].GetNamespace() {
+			return sorted[i].GetNamespace() < sorted[j].GetNamespace()
+		}
+		return sorted[i].GetName() < sorted[j].GetName()
+	})
+
+	return sorted
+}
+
+// pathMatchTypeRank returns the precedence of the type of pathMatch.
+func pathMatchTypeRank(pathMatch *ir.StringMatch) int {
+	if pathMatch != nil {
+		if pathMatch.Exact != nil {
+			return 3
+		}
+		if pathMatch.Prefix != nil {
+			return 2
+		}
+		if pathMatch.SafeRegex != nil {
+			return 1
 		}
 	}
+	return 0
 }
 
 func pathMatchCount(pathMatch *ir.StringMatch) int {
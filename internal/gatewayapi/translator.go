@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
@@ -22,6 +23,14 @@ const (
 	KindTLSRoute  = "TLSRoute"
 	KindService   = "Service"
 	KindSecret    = "Secret"
+	KindConfigMap = "ConfigMap"
+
+	// caCertificateKey is the key of the CA bundle in a ConfigMap or Secret
+	// referenced by a policy.
+	caCertificateKey = "ca.crt"
+	// caCRLKey is the key of the certificate revocation list of the CA bundle
+	// in a ConfigMap or Secret referenced by a policy.
+	caCRLKey = "ca.crl"
 
 	// OwningGatewayNamespaceLabel is the owner reference label used for managed infra.
 	// The value should be the namespace of the accepted Envoy Gateway.
@@ -36,6 +45,16 @@ const (
 	// wellKnownPortShift is the constant added to the well known port (1-1023)
 	// to convert it into an ephemeral port.
 	wellKnownPortShift = 10000
+
+	// ipv4ListenerAddress is the IPv4 unspecified address used by listeners.
+	ipv4ListenerAddress = "0.0.0.0"
+	// ipv6ListenerAddress is the IPv6 unspecified address used by listeners
+	// of IPv6 and dual-stack proxies.
+	ipv6ListenerAddress = "::"
+
+	// httpsRedirectListenerName is the name of the listener redirecting requests
+	// to the HTTPS listeners of a Gateway.
+	httpsRedirectListenerName = "https-redirect"
 )
 
 type XdsIRMap map[string]*ir.Xds
@@ -51,6 +70,17 @@ type Resources struct {
 	Namespaces      []*v1.Namespace
 	Services        []*v1.Service
 	Secrets         []*v1.Secret
+	ConfigMaps      []*v1.ConfigMap
+	// EnvoyProxy is the EnvoyProxy referenced by the GatewayClass, if any.
+	EnvoyProxy *v1alpha1.EnvoyProxy
+	// BackendTrafficPolicies are the policies targeting Gateways and HTTPRoutes.
+	BackendTrafficPolicies []*v1alpha1.BackendTrafficPolicy
+	// ClientTrafficPolicies are the policies targeting Gateways.
+	ClientTrafficPolicies []*v1alpha1.ClientTrafficPolicy
+	// EnvoyExtensionPolicies are the policies targeting Gateways and HTTPRoutes.
+	EnvoyExtensionPolicies []*v1alpha1.EnvoyExtensionPolicy
+	// EnvoyPatchPolicies are the policies targeting Gateways.
+	EnvoyPatchPolicies []*v1alpha1.EnvoyPatchPolicy
 }
 
 func (r *Resources) GetNamespace(name string) *v1.Namespace {
@@ -83,10 +113,51 @@ func (r *Resources) GetSecret(namespace, name string) *v1.Secret {
 	return nil
 }
 
+func (r *Resources) GetConfigMap(namespace, name string) *v1.ConfigMap {
+	for _, configMap := range r.ConfigMaps {
+		if configMap.Namespace == namespace && configMap.Name == name {
+			return configMap
+		}
+	}
+
+	return nil
+}
+
+// getCACertificateRefData returns the value of key in the ConfigMap or Secret in
+// namespace referenced by ref, or nil if the referent or key doesn't exist.
+func (r *Resources) getCACertificateRefData(namespace string, ref *v1alpha1.CACertificateReference, key string) []byte {
+	switch {
+	case ref.Kind == nil || *ref.Kind == KindConfigMap:
+		if configMap := r.GetConfigMap(namespace, ref.Name); configMap != nil {
+			if data, ok := configMap.Data[key]; ok {
+				return []byte(data)
+			}
+		}
+	case *ref.Kind == KindSecret:
+		if secret := r.GetSecret(namespace, ref.Name); secret != nil {
+			return secret.Data[key]
+		}
+	}
+
+	return nil
+}
+
 // Translator translates Gateway API resources to IRs and computes status
 // for Gateway API resources.
 type Translator struct {
 	GatewayClassName v1beta1.ObjectName
+
+	// GlobalRateLimitEnabled is true if the global rate limit service is
+	// enabled. Global rate limits of BackendTrafficPolicies are ignored otherwise.
+	GlobalRateLimitEnabled bool
+
+	// LuaEnabled is true if the Lua scripts of EnvoyExtensionPolicies are
+	// enabled. They are ignored otherwise.
+	LuaEnabled bool
+
+	// EnvoyPatchPolicyEnabled is true if EnvoyPatchPolicies are enabled. They
+	// are rejected otherwise.
+	EnvoyPatchPolicyEnabled bool
 }
 
 type TranslateResult struct {
@@ -95,6 +166,11 @@ type TranslateResult struct {
 	TLSRoutes  []*v1alpha2.TLSRoute
 	XdsIR      XdsIRMap
 	InfraIR    InfraIRMap
+	// BackendTrafficPolicies are the policies with their Accepted condition.
+	BackendTrafficPolicies []*v1alpha1.BackendTrafficPolicy
+	// EnvoyPatchPolicies are the policies rejected by the translation. The
+	// status of the accepted policies is set by the xDS translation.
+	EnvoyPatchPolicies []*v1alpha1.EnvoyPatchPolicy
 }
 
 func newTranslateResult(gateways []*GatewayContext,
@@ -128,16 +204,29 @@ func (t *Translator) Translate(resources *Resources) *TranslateResult {
 	// Process all Listeners for all relevant Gateways.
 	t.ProcessListeners(gateways, xdsIR, infraIR, resources)
 
-	// Process all relevant HTTPRoutes.
-	httpRoutes := t.ProcessHTTPRoutes(resources.HTTPRoutes, gateways, resources, xdsIR)
+	// Process all relevant HTTPRoutes, oldest first, so that the xdsIR sort
+	// preserves the precedence of older routes for equal matches.
+	httpRoutes := t.ProcessHTTPRoutes(sortHTTPRoutes(resources.HTTPRoutes), gateways, resources, xdsIR)
+
+	// Process all relevant TLSRoutes, oldest first, so that the TCP listeners
+	// of the xdsIR don't depend on the order the routes are listed in.
+	tlsRoutes := t.ProcessTLSRoutes(sortByCreationTimestamp(resources.TLSRoutes), gateways, resources, xdsIR)
 
-	// Process all relevant TLSRoutes.
-	tlsRoutes := t.ProcessTLSRoutes(resources.TLSRoutes, gateways, resources, xdsIR)
+	// Validate all BackendTrafficPolicies, which are applied to the routes
+	// by the processing of the HTTPRoutes.
+	backendTrafficPolicies := t.ProcessBackendTrafficPolicies(resources.BackendTrafficPolicies)
+
+	// Process all EnvoyPatchPolicies, oldest first, so that newer policies
+	// patch the resources patched by older ones.
+	envoyPatchPolicies := t.ProcessEnvoyPatchPolicies(resources.EnvoyPatchPolicies, gateways, xdsIR)
 
 	// Sort xdsIR based on the Gateway API spec
 	sortXdsIRMap(xdsIR)
 
-	return newTranslateResult(gateways, httpRoutes, tlsRoutes, xdsIR, infraIR)
+	translateResult := newTranslateResult(gateways, httpRoutes, tlsRoutes, xdsIR, infraIR)
+	translateResult.BackendTrafficPolicies = backendTrafficPolicies
+	translateResult.EnvoyPatchPolicies = envoyPatchPolicies
+	return translateResult
 }
 
 func (t *Translator) GetRelevantGateways(gateways []*v1beta1.Gateway) []*GatewayContext {
@@ -169,26 +258,32 @@ func (t *Translator) GetRelevantGateways(gateways []*v1beta1.Gateway) []*Gateway
 }
 
 type portListeners struct {
-	listeners []*ListenerContext
-	protocols sets.String
-	hostnames map[string]int
+	listeners    []*ListenerContext
+	protocols    sets.String
+	hostnames    map[string]int
+	servicePorts sets.Int32
 }
 
 func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap, infraIR InfraIRMap, resources *Resources) {
 
 	// Iterate through all listeners and collect info about protocols
-	// and hostnames per port.
+	// and hostnames per port. Listeners are grouped by container port,
+	// since that's the port the Envoy listener binds to.
 	for _, gateway := range gateways {
-		portListenerInfo := map[v1beta1.PortNumber]*portListeners{}
+		portListenerInfo := map[int32]*portListeners{}
 		for _, listener := range gateway.listeners {
-			if portListenerInfo[listener.Port] == nil {
-				portListenerInfo[listener.Port] = &portListeners{
-					protocols: sets.NewString(),
-					hostnames: map[string]int{},
+			containerPort := servicePortToContainerPort(int32(listener.Port))
+			if portListenerInfo[containerPort] == nil {
+				portListenerInfo[containerPort] = &portListeners{
+					protocols:    sets.NewString(),
+					hostnames:    map[string]int{},
+					servicePorts: sets.NewInt32(),
 				}
 			}
 
-			portListenerInfo[listener.Port].listeners = append(portListenerInfo[listener.Port].listeners, listener)
+			info := portListenerInfo[containerPort]
+			info.listeners = append(info.listeners, listener)
+			info.servicePorts.Insert(int32(listener.Port))
 
 			var protocol string
 			switch listener.Protocol {
@@ -198,19 +293,32 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 			default:
 				protocol = string(listener.Protocol)
 			}
-			portListenerInfo[listener.Port].protocols.Insert(protocol)
+			info.protocols.Insert(protocol)
 
 			var hostname string
 			if listener.Hostname != nil {
 				hostname = string(*listener.Hostname)
 			}
 
-			portListenerInfo[listener.Port].hostnames[hostname]++
+			info.hostnames[hostname]++
 		}
 
 		// Set Conflicted conditions for any listeners with conflicting specs.
-		for _, info := range portListenerInfo {
+		for containerPort, info := range portListenerInfo {
 			for _, listener := range info.listeners {
+				// Distinct ports translated to the same container port, e.g. 80 and 10080,
+				// would result in multiple Envoy listeners bound to the same address.
+				if len(info.servicePorts) > 1 {
+					listener.SetCondition(
+						v1beta1.ListenerConditionConflicted,
+						metav1.ConditionTrue,
+						"PortConflict",
+						fmt.Sprintf("Port %d is translated to container port %d, which conflicts with port(s) %v",
+							listener.Port, containerPort, otherPorts(info.servicePorts, int32(listener.Port))),
+					)
+					continue
+				}
+
 				if len(info.protocols) > 1 {
 					listener.SetCondition(
 						v1beta1.ListenerConditionConflicted,
@@ -247,6 +355,15 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 		gwInfraIR := ir.NewInfra()
 		gwInfraIR.Proxy.Name = irKey
 		gwInfraIR.Proxy.GetProxyMetadata().Labels = GatewayOwnerLabels(gateway.Namespace, gateway.Name)
+		// Unsupported addresses are surfaced by the Gateway Ready condition.
+		for _, addr := range gateway.Spec.Addresses {
+			if ValidateGatewayAddress(addr) == nil {
+				gwInfraIR.Proxy.Addresses = append(gwInfraIR.Proxy.Addresses, addr.Value)
+			}
+		}
+		if resources.EnvoyProxy != nil {
+			gwInfraIR.Proxy.Config = resources.EnvoyProxy.DeepCopy()
+		}
 		// save the IR references in the map before the translation starts
 		xdsIR[irKey] = gwXdsIR
 		infraIR[irKey] = gwInfraIR
@@ -254,6 +371,20 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 		// Infra IR proxy ports must be unique.
 		var foundPorts []int32
 
+		// The client TLS and HTTP/3 settings apply to all HTTPS listeners of the gateway,
+		// the other client settings to all HTTP and HTTPS listeners.
+		clientTrafficPolicy := clientTrafficPolicyForGateway(resources.ClientTrafficPolicies, gateway.Gateway)
+		clientTLS, clientTLSErr := buildIRClientTLS(clientTrafficPolicy, resources)
+
+		// The Wasm extensions apply to all HTTP and HTTPS listeners of the gateway.
+		envoyExtensionPolicy := envoyExtensionPolicyForGateway(resources.EnvoyExtensionPolicies, gateway.Gateway)
+
+		// Bind on the IPv6 unspecified address if the proxy Service is IPv6 or dual-stack.
+		listenerAddress := ipv4ListenerAddress
+		if resources.EnvoyProxy.GetKubeProvider().Service.IPv6Enabled() {
+			listenerAddress = ipv6ListenerAddress
+		}
+
 		for _, listener := range gateway.listeners {
 			// Process protocol & supported kinds
 			switch listener.Protocol {
@@ -472,6 +603,16 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 					break
 				}
 
+				if clientTLSErr != nil {
+					listener.SetCondition(
+						v1beta1.ListenerConditionResolvedRefs,
+						metav1.ConditionFalse,
+						v1beta1.ListenerReasonInvalidCertificateRef,
+						clientTLSErr.Error(),
+					)
+					break
+				}
+
 				listener.SetTLSSecret(secret)
 			case v1beta1.TLSProtocolType:
 				if listener.TLS == nil {
@@ -550,10 +691,28 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 			case v1beta1.HTTPProtocolType, v1beta1.HTTPSProtocolType:
 				irListener := &ir.HTTPListener{
 					Name:    irListenerName(listener),
-					Address: "0.0.0.0",
+					Address: listenerAddress,
 					Port:    uint32(containerPort),
 					TLS:     irTLSConfig(listener.tlsSecret),
 				}
+				applyClientTLS(irListener.TLS, clientTLS)
+				if irListener.TLS != nil {
+					irListener.HTTP3 = buildIRHTTP3(clientTrafficPolicy, servicePort)
+					// Browsers ignore the HSTS header of plaintext responses.
+					irListener.AddResponseHeaders = buildIRHSTSHeaders(clientTrafficPolicy)
+				}
+				irListener.HTTP1 = buildIRHTTP1(clientTrafficPolicy)
+				irListener.ClientIPDetection = buildIRClientIPDetection(clientTrafficPolicy)
+				irListener.Timeouts = buildIRClientTimeouts(clientTrafficPolicy)
+				irListener.MaxRequestHeadersKB = buildIRMaxRequestHeadersKB(clientTrafficPolicy)
+				irListener.TCPKeepalive = buildIRTCPKeepalive(clientTCPKeepalive(clientTrafficPolicy))
+				irListener.SocketOptions = buildIRSocketOptions(clientTrafficPolicy)
+				irListener.Compression = buildIRCompression(clientTrafficPolicy)
+				irListener.IPAccessControl = buildIRIPAccessControl(clientIPAccessControl(clientTrafficPolicy))
+				irListener.LocalReply = buildIRLocalReply(clientTrafficPolicy)
+				irListener.AddRequestHeaders = buildIRRequestHeaders(clientTrafficPolicy)
+				applyIRResponseHeaders(irListener, clientResponseHeaders(clientTrafficPolicy))
+				irListener.Wasm = buildIRWasms(envoyExtensionPolicy)
 				if listener.Hostname != nil {
 					irListener.Hostnames = append(irListener.Hostnames, string(*listener.Hostname))
 				} else {
@@ -566,11 +725,14 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 			case v1beta1.TLSProtocolType:
 				irListener := &ir.TCPListener{
 					Name:    irListenerName(listener),
-					Address: "0.0.0.0",
+					Address: listenerAddress,
 					Port:    uint32(containerPort),
 					TLS: &ir.TLSInspectorConfig{
 						SNIs: []string{},
 					},
+					TCPKeepalive:    buildIRTCPKeepalive(clientTCPKeepalive(clientTrafficPolicy)),
+					SocketOptions:   buildIRSocketOptions(clientTrafficPolicy),
+					IPAccessControl: buildIRIPAccessControl(clientIPAccessControl(clientTrafficPolicy)),
 				}
 				if listener.Hostname == nil || *listener.Hostname == "" {
 					listener.SetCondition(
@@ -606,9 +768,255 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 				}
 				// Only 1 listener is supported.
 				gwInfraIR.Proxy.Listeners[0].Ports = append(gwInfraIR.Proxy.Listeners[0].Ports, infraPort)
+
+				// HTTP/3 is served on the UDP port with the same number.
+				if proto == ir.HTTPSProtocolType && buildIRHTTP3(clientTrafficPolicy, servicePort) != nil {
+					infraPort.Name = fmt.Sprintf("%s-http3", listener.Name)
+					infraPort.Protocol = ir.HTTP3ProtocolType
+					gwInfraIR.Proxy.Listeners[0].Ports = append(gwInfraIR.Proxy.Listeners[0].Ports, infraPort)
+				}
 			}
 		}
+
+		// Add a listener redirecting requests to the HTTPS listeners, if enabled.
+		if redirect := resources.EnvoyProxy.GetHTTPSRedirect(); redirect != nil {
+			processHTTPSRedirect(gateway, redirect, listenerAddress, gwXdsIR, gwInfraIR)
+		}
+
+		// Limit the active connections of every listener, if enabled.
+		if limit := buildIRConnectionLimit(resources.EnvoyProxy); limit != nil {
+			for _, irListener := range gwXdsIR.HTTP {
+				irListener.ConnectionLimit = limit.DeepCopy()
+			}
+			for _, irListener := range gwXdsIR.TCP {
+				irListener.ConnectionLimit = limit.DeepCopy()
+			}
+		}
+
+		// Write the access logs of every listener, if enabled.
+		if accessLog := buildIRAccessLog(resources.EnvoyProxy, gateway); accessLog != nil {
+			for _, irListener := range gwXdsIR.HTTP {
+				irListener.AccessLog = accessLog.DeepCopy()
+			}
+			for _, irListener := range gwXdsIR.TCP {
+				irListener.AccessLog = accessLog.DeepCopy()
+			}
+		}
+
+		// Apply the response header settings to every HTTP listener, after the
+		// settings of the ClientTrafficPolicy, which take precedence.
+		if resources.EnvoyProxy != nil {
+			for _, irListener := range gwXdsIR.HTTP {
+				applyIRResponseHeaders(irListener, resources.EnvoyProxy.Spec.ResponseHeaders)
+			}
+		}
+
+		// Trace the requests of every HTTP listener, if enabled.
+		if tracing := buildIRTracing(resources.EnvoyProxy, gateway); tracing != nil {
+			for _, irListener := range gwXdsIR.HTTP {
+				irListener.Tracing = tracing.DeepCopy()
+			}
+		}
+	}
+}
+
+// buildIRAccessLog returns the access logs of the listeners of the gateway configured
+// by the provided EnvoyProxy, or nil if no access logs are written.
+func buildIRAccessLog(envoyProxy *v1alpha1.EnvoyProxy, gateway *GatewayContext) *ir.AccessLog {
+	if envoyProxy == nil || envoyProxy.Spec.AccessLog == nil {
+		return nil
+	}
+	accessLog := envoyProxy.Spec.AccessLog
+
+	irAccessLog := &ir.AccessLog{}
+	if format := accessLog.Format; format != nil {
+		switch format.Type {
+		case v1alpha1.ProxyAccessLogFormatTypeText:
+			irAccessLog.Text = format.Text
+		case v1alpha1.ProxyAccessLogFormatTypeJSON:
+			irAccessLog.JSON = format.JSON
+		}
+	}
+	for _, sink := range accessLog.Sinks {
+		switch {
+		case sink.Type == v1alpha1.ProxyAccessLogSinkTypeFile && sink.File != nil:
+			irAccessLog.Files = append(irAccessLog.Files, sink.File.Path)
+		case sink.Type == v1alpha1.ProxyAccessLogSinkTypeALS && sink.ALS != nil:
+			irAccessLog.ALS = append(irAccessLog.ALS, buildIRALSAccessLog(sink.ALS, gateway))
+		}
+	}
+	if len(irAccessLog.Files) == 0 && len(irAccessLog.ALS) == 0 {
+		return nil
+	}
+	return irAccessLog
+}
+
+// buildIRTracing returns the tracing of the HTTP listeners of the gateway configured
+// by the provided EnvoyProxy, or nil if requests are not traced. The spans are named
+// after the gateway.
+func buildIRTracing(envoyProxy *v1alpha1.EnvoyProxy, gateway *GatewayContext) *ir.Tracing {
+	if envoyProxy == nil || envoyProxy.Spec.Tracing == nil {
+		return nil
+	}
+	tracing := envoyProxy.Spec.Tracing
+
+	irTracing := &ir.Tracing{
+		Provider:     ir.TracingProviderType(tracing.Provider.Type),
+		Host:         tracing.Provider.Host,
+		Port:         uint32(tracing.Provider.Port),
+		ServiceName:  fmt.Sprintf("%s.%s", gateway.Name, gateway.Namespace),
+		SamplingRate: 100,
+	}
+	if tracing.SamplingRate != nil {
+		irTracing.SamplingRate = uint32(*tracing.SamplingRate)
+	}
+	for _, tag := range tracing.CustomTags {
+		if irTag := buildIRCustomTag(tag); irTag != nil {
+			irTracing.CustomTags = append(irTracing.CustomTags, irTag)
+		}
+	}
+	return irTracing
+}
+
+// buildIRCustomTag translates a custom tag of the tracing of an EnvoyProxy into the
+// IR, or returns nil if the value source matching its type is missing.
+func buildIRCustomTag(tag v1alpha1.CustomTag) *ir.CustomTag {
+	irTag := &ir.CustomTag{Name: tag.Name}
+	switch {
+	case tag.Type == v1alpha1.CustomTagTypeLiteral && tag.Literal != nil:
+		irTag.Literal = &tag.Literal.Value
+	case tag.Type == v1alpha1.CustomTagTypeEnvironment && tag.Environment != nil:
+		irTag.Environment = &ir.CustomTagSource{
+			Name:         tag.Environment.Name,
+			DefaultValue: stringOrEmpty(tag.Environment.DefaultValue),
+		}
+	case tag.Type == v1alpha1.CustomTagTypeRequestHeader && tag.RequestHeader != nil:
+		irTag.RequestHeader = &ir.CustomTagSource{
+			Name:         tag.RequestHeader.Name,
+			DefaultValue: stringOrEmpty(tag.RequestHeader.DefaultValue),
+		}
+	case tag.Type == v1alpha1.CustomTagTypeMetadata && tag.Metadata != nil:
+		irTag.Metadata = &ir.CustomTagMetadata{
+			Kind:         ir.CustomTagMetadataKind(tag.Metadata.Kind),
+			Key:          tag.Metadata.Key,
+			Path:         tag.Metadata.Path,
+			DefaultValue: stringOrEmpty(tag.Metadata.DefaultValue),
+		}
+	default:
+		return nil
+	}
+	return irTag
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// buildIRALSAccessLog translates an access log service sink into the IR, naming the
+// access logs after the gateway unless a name is specified.
+func buildIRALSAccessLog(als *v1alpha1.ALSAccessLogSink, gateway *GatewayContext) *ir.ALSAccessLog {
+	irALS := &ir.ALSAccessLog{
+		LogName:             fmt.Sprintf("%s/%s", gateway.Namespace, gateway.Name),
+		Host:                als.Host,
+		Port:                uint32(als.Port),
+		BufferFlushInterval: als.BufferFlushInterval,
+	}
+	if als.LogName != nil {
+		irALS.LogName = *als.LogName
+	}
+	if als.BufferSize != nil {
+		bufferSize := uint32(*als.BufferSize)
+		irALS.BufferSize = &bufferSize
+	}
+	return irALS
+}
+
+// buildIRConnectionLimit returns the limit of active connections of the listeners
+// configured by the provided EnvoyProxy, or nil if the connections are not limited.
+func buildIRConnectionLimit(envoyProxy *v1alpha1.EnvoyProxy) *ir.ConnectionLimit {
+	if envoyProxy == nil || envoyProxy.Spec.ConnectionLimit == nil {
+		return nil
+	}
+	limit := envoyProxy.Spec.ConnectionLimit
+	return &ir.ConnectionLimit{
+		Value:      uint64(limit.Value),
+		CloseDelay: limit.CloseDelay,
+	}
+}
+
+// processHTTPSRedirect adds an HTTP listener to the IR of the gateway, redirecting
+// requests to its valid HTTPS listeners. The listener isn't added if the gateway has
+// no valid HTTPS listeners, or already has a listener on the redirect port.
+func processHTTPSRedirect(gateway *GatewayContext, redirect *v1alpha1.HTTPSRedirect, address string, xdsIR *ir.Xds, infraIR *ir.Infra) {
+	servicePort := *redirect.Port
+	containerPort := servicePortToContainerPort(servicePort)
+	for _, listener := range gateway.listeners {
+		if servicePortToContainerPort(int32(listener.Port)) == containerPort {
+			return
+		}
+	}
+
+	irListener := &ir.HTTPListener{
+		Name:    fmt.Sprintf("%s-%s-%s", gateway.Namespace, gateway.Name, httpsRedirectListenerName),
+		Address: address,
+		Port:    uint32(containerPort),
 	}
+
+	for _, listener := range gateway.listeners {
+		// Only the HTTPS listeners added to the IR are valid.
+		if listener.Protocol != v1beta1.HTTPSProtocolType || xdsIR.GetHTTPListener(irListenerName(listener)) == nil {
+			continue
+		}
+
+		hostname := "*"
+		if listener.Hostname != nil {
+			hostname = string(*listener.Hostname)
+		}
+		// Listeners on distinct ports may use the same hostname,
+		// redirect to the first one.
+		if slices.Contains(irListener.Hostnames, hostname) {
+			continue
+		}
+		irListener.Hostnames = append(irListener.Hostnames, hostname)
+
+		// Omit the default HTTPS port from the redirect location.
+		var port *uint32
+		if listener.Port != 443 {
+			p := uint32(listener.Port)
+			port = &p
+		}
+		irListener.Routes = append(irListener.Routes, &ir.HTTPRoute{
+			Name:      fmt.Sprintf("%s-%s", irListener.Name, hostname),
+			Hostname:  hostname,
+			PathMatch: &ir.StringMatch{Prefix: StringPtr("/")},
+			Redirect: &ir.Redirect{
+				Scheme:     StringPtr("https"),
+				Port:       port,
+				StatusCode: redirect.StatusCode,
+			},
+		})
+	}
+
+	if len(irListener.Routes) == 0 {
+		return
+	}
+
+	xdsIR.HTTP = append(xdsIR.HTTP, irListener)
+	// Only 1 listener is supported.
+	infraIR.Proxy.Listeners[0].Ports = append(infraIR.Proxy.Listeners[0].Ports, ir.ListenerPort{
+		Name:          httpsRedirectListenerName,
+		Protocol:      ir.HTTPProtocolType,
+		ServicePort:   servicePort,
+		ContainerPort: containerPort,
+	})
+}
+
+// otherPorts returns the sorted ports of the provided set, excluding port.
+func otherPorts(ports sets.Int32, port int32) []int32 {
+	return ports.Difference(sets.NewInt32(port)).List()
 }
 
 // servicePortToContainerPort translates a service port into an ephemeral
@@ -703,15 +1111,15 @@ func buildRuleRouteDest(backendRef v1beta1.HTTPBackendRef,
 		return nil, weight
 	}
 
-	var portFound bool
-	for _, port := range service.Spec.Ports {
+	var servicePort *v1.ServicePort
+	for i, port := range service.Spec.Ports {
 		if port.Port == int32(*backendRef.Port) {
-			portFound = true
+			servicePort = &service.Spec.Ports[i]
 			break
 		}
 	}
 
-	if !portFound {
+	if servicePort == nil {
 		parentRef.SetCondition(httpRoute,
 			v1beta1.RouteConditionResolvedRefs,
 			metav1.ConditionFalse,
@@ -722,13 +1130,29 @@ func buildRuleRouteDest(backendRef v1beta1.HTTPBackendRef,
 	}
 
 	return &ir.RouteDestination{
-		Host:   service.Spec.ClusterIP,
-		Port:   uint32(*backendRef.Port),
-		Weight: weight,
+		Host:     service.Spec.ClusterIP,
+		Port:     uint32(*backendRef.Port),
+		Weight:   weight,
+		Protocol: appProtocolForServicePort(servicePort),
 	}, weight
 
 }
 
+// appProtocolForServicePort returns the protocol used to connect to a Service port
+// based on its appProtocol. An empty protocol is returned for ports that don't declare
+// an HTTP/2 cleartext or gRPC appProtocol, so they are connected to using HTTP/1.1.
+func appProtocolForServicePort(port *v1.ServicePort) ir.AppProtocol {
+	if port.AppProtocol == nil {
+		return ""
+	}
+	switch strings.ToLower(*port.AppProtocol) {
+	case "h2c", "grpc", "kubernetes.io/h2c":
+		return ir.HTTP2
+	default:
+		return ""
+	}
+}
+
 func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways []*GatewayContext, resources *Resources, xdsIR XdsIRMap) []*HTTPRouteContext {
 	var relevantHTTPRoutes []*HTTPRouteContext
 
@@ -1128,35 +1552,39 @@ func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways
 				}
 				hasHostnameIntersection = true
 
+				policy := backendTrafficPolicyForRoute(resources.BackendTrafficPolicies, httpRoute.HTTPRoute, listener.gateway)
+				extensionPolicy := envoyExtensionPolicyForRoute(resources.EnvoyExtensionPolicies, httpRoute.HTTPRoute, listener.gateway)
+				var lua *ir.Lua
+				if t.LuaEnabled {
+					lua = buildIRLua(extensionPolicy)
+				}
+				grpcJSONTranscoder := buildIRGRPCJSONTranscoder(extensionPolicy, resources)
+
 				var perHostRoutes []*ir.HTTPRoute
 				for _, host := range hosts {
-					var headerMatches []*ir.StringMatch
-
-					// If the intersecting host is more specific than the Listener's hostname,
-					// add an additional header match to all of the routes for it
-					if host != "*" && (listener.Hostname == nil || string(*listener.Hostname) != host) {
-						headerMatches = append(headerMatches, &ir.StringMatch{
-							Name:  ":authority",
-							Exact: StringPtr(host),
-						})
-					}
-
 					for _, routeRoute := range routeRoutes {
 						hostRoute := &ir.HTTPRoute{
-							Name:                 fmt.Sprintf("%s-%s", routeRoute.Name, host),
-							PathMatch:            routeRoute.PathMatch,
-							HeaderMatches:        append(headerMatches, routeRoute.HeaderMatches...),
-							QueryParamMatches:    routeRoute.QueryParamMatches,
-							AddRequestHeaders:    routeRoute.AddRequestHeaders,
-							RemoveRequestHeaders: routeRoute.RemoveRequestHeaders,
-							Destinations:         routeRoute.Destinations,
-							Redirect:             routeRoute.Redirect,
-							DirectResponse:       routeRoute.DirectResponse,
+							Name:                  fmt.Sprintf("%s-%s", routeRoute.Name, host),
+							Hostname:              host,
+							PathMatch:             routeRoute.PathMatch,
+							HeaderMatches:         routeRoute.HeaderMatches,
+							QueryParamMatches:     routeRoute.QueryParamMatches,
+							AddRequestHeaders:     routeRoute.AddRequestHeaders,
+							RemoveRequestHeaders:  routeRoute.RemoveRequestHeaders,
+							AddResponseHeaders:    routeRoute.AddResponseHeaders,
+							RemoveResponseHeaders: routeRoute.RemoveResponseHeaders,
+							Destinations:          routeRoute.Destinations,
+							Redirect:              routeRoute.Redirect,
+							DirectResponse:        routeRoute.DirectResponse,
+							Timeout:               routeRoute.Timeout,
 						}
 						// Don't bother copying over the weights unless the route has invalid backends.
 						if routeRoute.BackendWeights.Invalid > 0 {
 							hostRoute.BackendWeights = routeRoute.BackendWeights
 						}
+						applyBackendTrafficPolicy(hostRoute, policy, resources, t.GlobalRateLimitEnabled)
+						hostRoute.Lua = lua
+						hostRoute.GRPCJSONTranscoder = grpcJSONTranscoder
 						perHostRoutes = append(perHostRoutes, hostRoute)
 					}
 				}
@@ -1536,6 +1964,7 @@ func irTLSConfig(tlsSecret *v1.Secret) *ir.TLSListenerConfig {
 	}
 
 	return &ir.TLSListenerConfig{
+		SecretName:        tlsSecret.Namespace + "/" + tlsSecret.Name,
 		ServerCertificate: tlsSecret.Data[v1.TLSCertKey],
 		PrivateKey:        tlsSecret.Data[v1.TLSPrivateKeyKey],
 	}
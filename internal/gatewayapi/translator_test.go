@@ -42,37 +42,13 @@ func TestTranslate(t *testing.T) {
 			mustUnmarshal(t, string(output), want)
 
 			translator := &Translator{
-				GatewayClassName: "envoy-gateway-class",
+				GatewayClassName:        "envoy-gateway-class",
+				GlobalRateLimitEnabled:  true,
+				LuaEnabled:              true,
+				EnvoyPatchPolicyEnabled: true,
 			}
 
-			// Add common test fixtures
-			for i := 1; i <= 3; i++ {
-				resources.Services = append(resources.Services,
-					&v1.Service{
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: "default",
-							Name:      "service-" + strconv.Itoa(i),
-						},
-						Spec: v1.ServiceSpec{
-							ClusterIP: "7.7.7.7",
-							Ports: []v1.ServicePort{
-								{Port: 8080},
-								{Port: 8443},
-							},
-						},
-					},
-				)
-			}
-
-			resources.Namespaces = append(resources.Namespaces, &v1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "envoy-gateway",
-				},
-			}, &v1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "default",
-				},
-			})
+			addTestFixtures(resources)
 
 			got := translator.Translate(resources)
 
@@ -82,6 +58,38 @@ func TestTranslate(t *testing.T) {
 	}
 }
 
+// addTestFixtures adds the Services and Namespaces shared by the translation
+// test inputs.
+func addTestFixtures(resources *Resources) {
+	for i := 1; i <= 3; i++ {
+		resources.Services = append(resources.Services,
+			&v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "service-" + strconv.Itoa(i),
+				},
+				Spec: v1.ServiceSpec{
+					ClusterIP: "7.7.7.7",
+					Ports: []v1.ServicePort{
+						{Port: 8080},
+						{Port: 8443},
+					},
+				},
+			},
+		)
+	}
+
+	resources.Namespaces = append(resources.Namespaces, &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "envoy-gateway",
+		},
+	}, &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+	})
+}
+
 func testName(inputFile string) string {
 	_, fileName := filepath.Split(inputFile)
 	return strings.TrimSuffix(fileName, ".in.yaml")
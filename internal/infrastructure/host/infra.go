@@ -0,0 +1,301 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// xdsServerHost is the host of the xDS server the Envoy processes connect to.
+	xdsServerHost = "127.0.0.1"
+	// bootstrapFileName is the name of the bootstrap configuration file of an
+	// Envoy process.
+	bootstrapFileName = "bootstrap.yaml"
+	// sdsDirName is the name of the directory of the SDS files of an Envoy process.
+	sdsDirName = "sds"
+	// restartDelay is the delay before an Envoy process that exited is restarted.
+	restartDelay = time.Second
+)
+
+var _ infrastructure.Manager = (*Infra)(nil)
+
+func init() {
+	// The Envoy proxies run on the host of Envoy Gateway with the File provider.
+	infrastructure.Register(v1alpha1.ProviderTypeFile, func(cfg *config.Server) (infrastructure.Manager, error) {
+		return NewInfra(cfg.EnvoyGateway.Provider.File.GetHostInfrastructure(), cfg.Logger), nil
+	})
+}
+
+// Infra manages the Envoy proxies as processes running on the host of Envoy
+// Gateway. The Envoy processes are stopped once the context they were started
+// with is done, i.e. when Envoy Gateway shuts down.
+type Infra struct {
+	// EnvoyPath is the path of the Envoy binary.
+	EnvoyPath string
+	// HomeDir is the directory holding the bootstrap configurations of the
+	// Envoy processes.
+	HomeDir string
+	// CertsDir is the directory holding the xDS client certificate of the Envoy
+	// processes.
+	CertsDir string
+
+	log     logr.Logger
+	mu      sync.Mutex
+	proxies map[string]*proxy
+}
+
+// proxy is an Envoy process supervised by the host infra.
+type proxy struct {
+	// bootstrap and args are the bootstrap configuration and the arguments of
+	// the Envoy process.
+	bootstrap string
+	args      []string
+	// adminPort and readinessPort are the ports of the Envoy process, kept
+	// across restarts.
+	adminPort, readinessPort int32
+	// running is 1 while the Envoy process runs, accessed atomically.
+	running int32
+	// cancel stops the Envoy process, and done is closed once it's stopped.
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewInfra returns a new Infra with the provided settings.
+func NewInfra(host *v1alpha1.HostInfrastructure, log logr.Logger) *Infra {
+	return &Infra{
+		EnvoyPath: host.EnvoyPath,
+		HomeDir:   host.HomeDir,
+		CertsDir:  host.CertsDir,
+		log:       log,
+		proxies:   map[string]*proxy{},
+	}
+}
+
+// CreateOrUpdateInfra starts the Envoy process of the provided infra, restarting
+// it if its bootstrap configuration or its arguments changed.
+func (i *Infra) CreateOrUpdateInfra(ctx context.Context, infra *ir.Infra) error {
+	if infra == nil {
+		return errors.New("infra ir is nil")
+	}
+	if infra.Proxy == nil {
+		return errors.New("infra proxy ir is nil")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	name := infra.Proxy.Name
+	current := i.proxies[name]
+	adminPort, readinessPort, err := i.proxyPorts(current)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(i.HomeDir, name)
+	bootstrap, err := kubernetes.RenderBootstrap(infra, &kubernetes.BootstrapOptions{
+		XdsServerHost: xdsServerHost,
+		SdsDir:        filepath.Join(dir, sdsDirName),
+		AdminPort:     adminPort,
+		ReadinessPort: readinessPort,
+	})
+	if err != nil {
+		return err
+	}
+	logArgs, err := kubernetes.LogArgs(infra.GetProxyInfra().Config)
+	if err != nil {
+		return err
+	}
+	args := []string{
+		"--service-cluster", name,
+		"--service-node", name,
+		"--config-path", filepath.Join(dir, bootstrapFileName),
+		// Several Envoy processes may run on the host.
+		"--use-dynamic-base-id",
+	}
+	for _, arg := range logArgs {
+		args = append(args, strings.Fields(arg)...)
+	}
+	// Envoy doesn't reload its bootstrap configuration, so the process is
+	// restarted when it or the arguments change.
+	if current != nil && current.bootstrap == bootstrap && reflect.DeepEqual(current.args, args) {
+		return nil
+	}
+
+	if current != nil {
+		i.stop(name, current)
+	}
+	if err := i.writeConfig(dir, bootstrap); err != nil {
+		delete(i.proxies, name)
+		return err
+	}
+	i.proxies[name] = i.start(ctx, name, args, bootstrap, adminPort, readinessPort)
+	return nil
+}
+
+// DeleteInfra stops the Envoy process of the provided infra.
+func (i *Infra) DeleteInfra(_ context.Context, infra *ir.Infra) error {
+	if infra == nil {
+		return errors.New("infra ir is nil")
+	}
+	if infra.Proxy == nil {
+		return errors.New("infra proxy ir is nil")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	name := infra.Proxy.Name
+	if p, ok := i.proxies[name]; ok {
+		i.stop(name, p)
+		delete(i.proxies, name)
+	}
+	return os.RemoveAll(filepath.Join(i.HomeDir, name))
+}
+
+// GetStatus returns the status of the Envoy process of the provided infra, which
+// is ready while the process runs.
+func (i *Infra) GetStatus(_ context.Context, infra *ir.Infra) (*infrastructure.Status, error) {
+	if infra == nil {
+		return nil, errors.New("infra ir is nil")
+	}
+	if infra.Proxy == nil {
+		return nil, errors.New("infra proxy ir is nil")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	p, ok := i.proxies[infra.Proxy.Name]
+	switch {
+	case !ok:
+		return &infrastructure.Status{Message: "envoy process not started"}, nil
+	case atomic.LoadInt32(&p.running) == 0:
+		return &infrastructure.Status{Message: "envoy process not running"}, nil
+	default:
+		return &infrastructure.Status{Ready: true, Message: "envoy process running"}, nil
+	}
+}
+
+// CreateOrUpdateRateLimitInfra returns an error, since the global rate limit service
+// isn't supported on the host.
+func (i *Infra) CreateOrUpdateRateLimitInfra(_ context.Context, _ *ir.RateLimitInfra) error {
+	return errors.New("the global rate limit service is not supported by the host infrastructure")
+}
+
+// DeleteRateLimitInfra does nothing, since the global rate limit service isn't
+// supported on the host.
+func (i *Infra) DeleteRateLimitInfra(_ context.Context) error {
+	return nil
+}
+
+// writeConfig writes the bootstrap configuration and the SDS files of an Envoy
+// process to the provided directory.
+func (i *Infra) writeConfig(dir, bootstrap string) error {
+	sdsDir := filepath.Join(dir, sdsDirName)
+	if err := os.MkdirAll(sdsDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", sdsDir, err)
+	}
+	for name, data := range kubernetes.SdsConfigs(i.CertsDir) {
+		if err := os.WriteFile(filepath.Join(sdsDir, name), []byte(data), 0o600); err != nil {
+			return fmt.Errorf("failed to write sds file %s: %w", name, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, bootstrapFileName), []byte(bootstrap), 0o600); err != nil {
+		return fmt.Errorf("failed to write bootstrap file: %w", err)
+	}
+	return nil
+}
+
+// start starts an Envoy process with the provided arguments, restarting it
+// until it's stopped or ctx is done.
+func (i *Infra) start(ctx context.Context, name string, args []string, bootstrap string, adminPort, readinessPort int32) *proxy {
+	ctx, cancel := context.WithCancel(ctx)
+	p := &proxy{
+		bootstrap:     bootstrap,
+		args:          args,
+		adminPort:     adminPort,
+		readinessPort: readinessPort,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+	log := i.log.WithValues("proxy", name)
+
+	go func() {
+		defer close(p.done)
+		for {
+			cmd := exec.CommandContext(ctx, i.EnvoyPath, args...)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			log.Info("starting envoy", "args", args)
+			err := cmd.Start()
+			if err == nil {
+				atomic.StoreInt32(&p.running, 1)
+				err = cmd.Wait()
+				atomic.StoreInt32(&p.running, 0)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(err, "envoy exited, restarting", "delay", restartDelay)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(restartDelay):
+			}
+		}
+	}()
+	return p
+}
+
+// stop stops the Envoy process of the provided proxy and waits until it exits.
+func (i *Infra) stop(name string, p *proxy) {
+	i.log.Info("stopping envoy", "proxy", name)
+	p.cancel()
+	<-p.done
+}
+
+// proxyPorts returns the admin and readiness ports of the provided proxy, or
+// free ports of the host if the proxy doesn't exist yet.
+func (i *Infra) proxyPorts(p *proxy) (int32, int32, error) {
+	if p != nil {
+		return p.adminPort, p.readinessPort, nil
+	}
+	adminPort, err := freePort()
+	if err != nil {
+		return 0, 0, err
+	}
+	readinessPort, err := freePort()
+	if err != nil {
+		return 0, 0, err
+	}
+	return adminPort, readinessPort, nil
+}
+
+// freePort returns a free TCP port of the host.
+func freePort() (int32, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close()
+	return int32(l.Addr().(*net.TCPAddr).Port), nil
+}
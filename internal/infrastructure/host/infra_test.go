@@ -0,0 +1,112 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// newTestInfra returns an Infra running a fake Envoy binary, which appends its
+// arguments to the returned file and then runs the provided command.
+func newTestInfra(t *testing.T, command string) (*Infra, string) {
+	dir := t.TempDir()
+	launches := filepath.Join(dir, "launches")
+	envoy := filepath.Join(dir, "envoy")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %s\n%s\n", launches, command)
+	require.NoError(t, os.WriteFile(envoy, []byte(script), 0o700))
+
+	infra := NewInfra(&v1alpha1.HostInfrastructure{
+		EnvoyPath: envoy,
+		HomeDir:   filepath.Join(dir, "home"),
+		CertsDir:  "/certs",
+	}, logr.Discard())
+	return infra, launches
+}
+
+// readLaunches waits for the fake Envoy binary to be launched n times, and
+// returns the arguments of the launches.
+func readLaunches(t *testing.T, file string, n int) []string {
+	var launches []string
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return false
+		}
+		launches = strings.Split(strings.TrimSpace(string(data)), "\n")
+		return len(launches) >= n
+	}, 5*time.Second, 10*time.Millisecond)
+	return launches
+}
+
+func TestCreateOrUpdateInfra(t *testing.T) {
+	infra, launches := newTestInfra(t, "exec sleep 60")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := ir.NewInfra()
+	in.Proxy.Name = "test"
+	status, err := infra.GetStatus(ctx, in)
+	require.NoError(t, err)
+	require.False(t, status.Ready)
+	require.NoError(t, infra.CreateOrUpdateInfra(ctx, in))
+
+	dir := filepath.Join(infra.HomeDir, "test")
+	args := readLaunches(t, launches, 1)
+	require.Eventually(t, func() bool {
+		status, err := infra.GetStatus(ctx, in)
+		return err == nil && status.Ready
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, fmt.Sprintf("--service-cluster test --service-node test --config-path %s "+
+		"--use-dynamic-base-id --log-level info", filepath.Join(dir, bootstrapFileName)), args[0])
+
+	bootstrap, err := os.ReadFile(filepath.Join(dir, bootstrapFileName))
+	require.NoError(t, err)
+	require.Contains(t, string(bootstrap), filepath.Join(dir, sdsDirName, "xds-certificate.json"))
+	ca, err := os.ReadFile(filepath.Join(dir, sdsDirName, "xds-trusted-ca.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(ca), "/certs/ca.crt")
+
+	// The process isn't restarted if nothing changed.
+	require.NoError(t, infra.CreateOrUpdateInfra(ctx, in))
+	// The process is restarted if its arguments changed.
+	level := v1alpha1.LogLevelDebug
+	in.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{Logging: &v1alpha1.ProxyLogging{Level: &level}},
+	}
+	require.NoError(t, infra.CreateOrUpdateInfra(ctx, in))
+	args = readLaunches(t, launches, 2)
+	require.Len(t, args, 2)
+	require.True(t, strings.HasSuffix(args[1], "--log-level debug"))
+
+	require.NoError(t, infra.DeleteInfra(ctx, in))
+	require.Empty(t, infra.proxies)
+	require.NoDirExists(t, dir)
+}
+
+func TestCreateOrUpdateInfraRestart(t *testing.T) {
+	// The fake Envoy binary exits right away.
+	infra, launches := newTestInfra(t, "exit 1")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, infra.CreateOrUpdateInfra(ctx, ir.NewInfra()))
+	readLaunches(t, launches, 2)
+	require.NoError(t, infra.DeleteInfra(ctx, ir.NewInfra()))
+}
+
+func TestCreateOrUpdateInfraNil(t *testing.T) {
+	infra, _ := newTestInfra(t, "exec sleep 60")
+	require.Error(t, infra.CreateOrUpdateInfra(context.Background(), nil))
+	require.Error(t, infra.CreateOrUpdateInfra(context.Background(), &ir.Infra{}))
+	require.Error(t, infra.DeleteInfra(context.Background(), nil))
+}
@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// hashAnnotation is the annotation used to record the hash of the desired
+	// state of a managed infra resource.
+	hashAnnotation = "gateway.envoyproxy.io/hash"
+)
+
+// createOrUpdate creates the desired object in the kube api server if it doesn't
+// exist and updates it if it does, but only when it is out of date. The current
+// object is read into current, which must be an empty object of the same type.
+//
+// An object is out of date when the hash of its desired state differs from the
+// hash recorded in the hashAnnotation of the current object, or when a field set
+// in the desired object was changed by an external actor. Fields that are unset
+// in the desired object, e.g. fields defaulted by the api server, are ignored, so
+// they do not cause spurious updates. If mergeCurrent is not nil, it is called
+// after reading the current object to carry over values that are not managed by
+// Envoy Gateway, e.g. values allocated by the api server or other controllers.
+func (i *Infra) createOrUpdate(ctx context.Context, desired, current client.Object, mergeCurrent func()) error {
+	gvk, err := apiutil.GVKForObject(desired, i.Client.Scheme())
+	if err != nil {
+		return err
+	}
+	kind := strings.ToLower(gvk.Kind)
+
+	hash, err := objectHash(desired)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s %s/%s: %w", kind, desired.GetNamespace(), desired.GetName(), err)
+	}
+	annotations := map[string]string{}
+	for k, v := range desired.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[hashAnnotation] = hash
+	desired.SetAnnotations(annotations)
+
+	if err := i.Client.Get(ctx, client.ObjectKeyFromObject(desired), current); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get %s %s/%s: %w", kind, desired.GetNamespace(), desired.GetName(), err)
+		}
+		// Create if not found.
+		if err := i.Client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create %s %s/%s: %w", kind, desired.GetNamespace(), desired.GetName(), err)
+		}
+		return nil
+	}
+
+	if mergeCurrent != nil {
+		mergeCurrent()
+	}
+
+	if !outOfDate(desired, current) {
+		return nil
+	}
+	desired.SetResourceVersion(current.GetResourceVersion())
+	if err := i.Client.Update(ctx, desired); err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", kind, desired.GetNamespace(), desired.GetName(), err)
+	}
+
+	return nil
+}
+
+// outOfDate returns true if the current object must be updated to the desired object.
+func outOfDate(desired, current client.Object) bool {
+	if desired.GetAnnotations()[hashAnnotation] != current.GetAnnotations()[hashAnnotation] {
+		return true
+	}
+
+	// The type meta of objects read by the client may be unset.
+	expected := desired.DeepCopyObject().(client.Object)
+	expected.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+
+	return !equality.Semantic.DeepDerivative(expected, current)
+}
+
+// objectHash returns the hash of the desired state of the provided object.
+func objectHash(obj client.Object) (string, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+)
+
+func TestCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+
+	infra := newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Deployment: &v1alpha1.KubeDeployment{
+			Pod: &v1alpha1.KubePod{
+				NodeSelector: map[string]string{"node-pool": "edge"},
+			},
+		},
+	})
+
+	key := client.ObjectKey{Namespace: kube.Namespace, Name: expectedDeploymentName(infra.Proxy.Name)}
+	get := func() *appsv1.Deployment {
+		deploy := &appsv1.Deployment{}
+		require.NoError(t, kube.Client.Get(ctx, key, deploy))
+		return deploy
+	}
+
+	// Create the deployment.
+	require.NoError(t, kube.createOrUpdateDeployment(ctx, infra))
+	created := get()
+	require.NotEmpty(t, created.Annotations[hashAnnotation])
+
+	// Applying the same infra is a no-op.
+	require.NoError(t, kube.createOrUpdateDeployment(ctx, infra))
+	require.Equal(t, created.ResourceVersion, get().ResourceVersion)
+
+	// Fields defaulted by the api server do not cause an update.
+	defaulted := get()
+	defaulted.Spec.RevisionHistoryLimit = pointer.Int32(10)
+	defaulted.Spec.ProgressDeadlineSeconds = pointer.Int32(600)
+	require.NoError(t, kube.Client.Update(ctx, defaulted))
+	defaulted = get()
+	require.NoError(t, kube.createOrUpdateDeployment(ctx, infra))
+	require.Equal(t, defaulted.ResourceVersion, get().ResourceVersion)
+
+	// External mutations of managed fields are reverted.
+	mutated := get()
+	mutated.Spec.Template.Spec.Containers[0].Image = "envoyproxy/envoy:mutated"
+	require.NoError(t, kube.Client.Update(ctx, mutated))
+	require.NoError(t, kube.createOrUpdateDeployment(ctx, infra))
+	require.Equal(t, infra.Proxy.Image, get().Spec.Template.Spec.Containers[0].Image)
+
+	// Fields removed from the desired state are removed, and the replica
+	// count set by the HorizontalPodAutoscaler is preserved.
+	scaled := get()
+	scaled.Spec.Replicas = pointer.Int32(3)
+	require.NoError(t, kube.Client.Update(ctx, scaled))
+	infra.Proxy.Config.Spec.Provider.Kubernetes.Deployment.Pod.NodeSelector = nil
+	infra.Proxy.Config.Spec.Provider.Kubernetes.EnvoyHPA = &v1alpha1.KubeHorizontalPodAutoscaler{MaxReplicas: 5}
+	require.NoError(t, kube.createOrUpdateDeployment(ctx, infra))
+	updated := get()
+	require.Nil(t, updated.Spec.Template.Spec.NodeSelector)
+	require.Equal(t, pointer.Int32(3), updated.Spec.Replicas)
+}
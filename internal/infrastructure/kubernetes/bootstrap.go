@@ -0,0 +1,213 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"regexp"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+)
+
+const (
+	// envoyCfgMountPath is the mount path of the Envoy bootstrap configuration.
+	envoyCfgMountPath = "/config"
+	// envoySdsMountPath is the mount path of the SDS files of the xDS client
+	// certificate of Envoy.
+	envoySdsMountPath = "/sds"
+	// bootstrapHashAnnotation is the annotation of the Envoy pods containing the hash
+	// of the bootstrap configuration. Envoy doesn't reload its bootstrap configuration,
+	// so the pods are replaced when the hash changes.
+	bootstrapHashAnnotation = "gateway.envoyproxy.io/bootstrap-hash"
+)
+
+// expectedBootstrap returns the Envoy bootstrap configuration in yaml format based
+// on the provided infra, including the bootstrap override of the EnvoyProxy config.
+func (i *Infra) expectedBootstrap(infra *ir.Infra) (string, error) {
+	return RenderBootstrap(infra, &BootstrapOptions{
+		XdsServerHost: i.expectedXdsServerHost(infra),
+		SdsDir:        envoySdsMountPath,
+		AdminPort:     EnvoyAdminPort,
+		ReadinessPort: envoyReadinessPort,
+	})
+}
+
+// BootstrapOptions are the settings of the Envoy proxies a bootstrap configuration
+// is rendered for.
+type BootstrapOptions struct {
+	// XdsServerHost is the host of the xDS server.
+	XdsServerHost string
+	// SdsDir is the directory of the SDS files of the xDS client certificate.
+	SdsDir string
+	// AdminPort is the port of the Envoy admin interface.
+	AdminPort int32
+	// ReadinessPort is the port of the Envoy readiness listener.
+	ReadinessPort int32
+}
+
+// RenderBootstrap returns the Envoy bootstrap configuration in yaml format of the
+// provided infra for the Envoy proxies with the provided options, including the
+// bootstrap override of the EnvoyProxy config.
+func RenderBootstrap(infra *ir.Infra, opts *BootstrapOptions) (string, error) {
+	metrics := infra.GetProxyInfra().Config.GetMetrics()
+	stats, err := expectedStatsParameters(metrics)
+	if err != nil {
+		return "", err
+	}
+
+	cfg := bootstrapConfig{
+		parameters: bootstrapParameters{
+			XdsServer: xdsServerParameters{
+				Address: opts.XdsServerHost,
+				Port:    xdsrunner.XdsServerPort,
+				SdsDir:  opts.SdsDir,
+			},
+			AdminServer: adminServerParameters{
+				Address:       envoyAdminAddress,
+				Port:          opts.AdminPort,
+				AccessLogPath: envoyAdminAccessLogPath,
+			},
+			ReadinessServer: readinessServerParameters{
+				Address:       envoyReadinessAddress,
+				Port:          opts.ReadinessPort,
+				ReadinessPath: envoyReadinessPath,
+				MetricsPath:   envoyMetricsPath,
+			},
+			Overload: expectedOverloadParameters(infra.GetProxyInfra().Config.GetOverload()),
+			Stats:    stats,
+		},
+	}
+	if metrics != nil && metrics.Prometheus != nil {
+		cfg.parameters.ReadinessServer.MetricsPath = ""
+		cfg.parameters.MetricsServer = &metricsServerParameters{
+			Address:     envoyMetricsAddress,
+			Port:        metrics.Prometheus.Port,
+			MetricsPath: envoyMetricsPath,
+		}
+	}
+	if err := cfg.render(); err != nil {
+		return "", err
+	}
+
+	proxyCfg := infra.GetProxyInfra().Config
+	if proxyCfg == nil || proxyCfg.Spec.Bootstrap == nil {
+		return cfg.rendered, nil
+	}
+
+	return overrideBootstrap(cfg.rendered, proxyCfg.Spec.Bootstrap)
+}
+
+// expectedOverloadParameters returns the overload manager parameters of the bootstrap
+// configuration based on the provided overload protection config, or nil if overload
+// protection is not enabled.
+func expectedOverloadParameters(overload *v1alpha1.ProxyOverload) *overloadParameters {
+	if overload == nil {
+		return nil
+	}
+
+	params := new(overloadParameters)
+	if overload.MaxActiveDownstreamConnections != nil {
+		params.MaxActiveDownstreamConnections = *overload.MaxActiveDownstreamConnections
+	}
+	if heap := overload.Heap; heap != nil {
+		params.MaxHeapSizeBytes = heap.MaxHeapSizeBytes
+		params.ShrinkHeapThreshold = float64(*heap.ShrinkHeapThreshold) / 100
+		params.StopAcceptingRequestsThreshold = float64(*heap.StopAcceptingRequestsThreshold) / 100
+	}
+
+	return params
+}
+
+// expectedStatsParameters returns the stats parameters of the bootstrap configuration
+// based on the provided metrics config, or nil if the Envoy defaults are used.
+func expectedStatsParameters(metrics *v1alpha1.ProxyMetrics) (*statsParameters, error) {
+	if metrics == nil || (metrics.Matcher == nil && len(metrics.StatsTags) == 0 && len(metrics.Sinks) == 0) {
+		return nil, nil
+	}
+
+	params := new(statsParameters)
+	if matcher := metrics.Matcher; matcher != nil {
+		if len(matcher.InclusionPrefixes) > 0 && len(matcher.ExclusionPrefixes) > 0 {
+			return nil, fmt.Errorf("only one of inclusionPrefixes or exclusionPrefixes may be specified")
+		}
+		params.InclusionPrefixes = matcher.InclusionPrefixes
+		params.ExclusionPrefixes = matcher.ExclusionPrefixes
+	}
+	for _, tag := range metrics.StatsTags {
+		if _, err := regexp.Compile(tag.Regex); err != nil {
+			return nil, fmt.Errorf("invalid regex of stats tag %s: %w", tag.Name, err)
+		}
+		params.Tags = append(params.Tags, statsTagParameters{Name: tag.Name, Regex: tag.Regex})
+	}
+	for _, sink := range metrics.Sinks {
+		switch sink.Type {
+		case v1alpha1.ProxyMetricSinkTypeStatsd:
+			if sink.Statsd == nil {
+				return nil, fmt.Errorf("missing statsd metric sink")
+			}
+			if net.ParseIP(sink.Statsd.Address) == nil {
+				return nil, fmt.Errorf("invalid statsd address %q: must be an IP address", sink.Statsd.Address)
+			}
+			statsd := statsdSinkParameters{Address: sink.Statsd.Address, Port: sink.Statsd.Port}
+			if sink.Statsd.Prefix != nil {
+				statsd.Prefix = *sink.Statsd.Prefix
+			}
+			params.StatsdSinks = append(params.StatsdSinks, statsd)
+		default:
+			return nil, fmt.Errorf("unsupported metric sink type %q", sink.Type)
+		}
+	}
+
+	return params, nil
+}
+
+// overrideBootstrap applies the provided bootstrap override to the rendered bootstrap
+// configuration.
+func overrideBootstrap(rendered string, override *v1alpha1.ProxyBootstrap) (string, error) {
+	overrideType := v1alpha1.BootstrapTypeReplace
+	if override.Type != nil {
+		overrideType = *override.Type
+	}
+
+	switch overrideType {
+	case v1alpha1.BootstrapTypeReplace:
+		if _, err := yaml.YAMLToJSON([]byte(override.Value)); err != nil {
+			return "", fmt.Errorf("invalid bootstrap: %w", err)
+		}
+		return override.Value, nil
+	case v1alpha1.BootstrapTypeJSONPatch:
+		doc, err := yaml.YAMLToJSON([]byte(rendered))
+		if err != nil {
+			return "", fmt.Errorf("failed to convert bootstrap to json: %w", err)
+		}
+		patchJSON, err := yaml.YAMLToJSON([]byte(override.Value))
+		if err != nil {
+			return "", fmt.Errorf("invalid bootstrap json patch: %w", err)
+		}
+		patch, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return "", fmt.Errorf("invalid bootstrap json patch: %w", err)
+		}
+		patched, err := patch.Apply(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to apply bootstrap json patch: %w", err)
+		}
+		out, err := yaml.JSONToYAML(patched)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert bootstrap to yaml: %w", err)
+		}
+		return string(out), nil
+	default:
+		return "", fmt.Errorf("unsupported bootstrap type %q", overrideType)
+	}
+}
+
+// bootstrapHash returns the hash of the provided bootstrap configuration.
+func bootstrapHash(bootstrap string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(bootstrap)))
+}
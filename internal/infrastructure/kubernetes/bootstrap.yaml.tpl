@@ -5,29 +5,99 @@ admin:
       address: {{ .AdminServer.Address }}
       port_value: {{ .AdminServer.Port }}
 dynamic_resources:
+  ads_config:
+    api_type: DELTA_GRPC
+    transport_api_version: V3
+    grpc_services:
+    - envoy_grpc:
+        cluster_name: xds_cluster
+    set_node_on_first_message_only: true
   cds_config:
     resource_api_version: V3
-    api_config_source:
-      api_type: DELTA_GRPC
-      transport_api_version: V3
-      grpc_services:
-      - envoy_grpc:
-          cluster_name: xds_cluster
-      set_node_on_first_message_only: true
+    ads: {}
   lds_config:
     resource_api_version: V3
-    api_config_source:
-      api_type: DELTA_GRPC
-      transport_api_version: V3
-      grpc_services:
-      - envoy_grpc:
-          cluster_name: xds_cluster
-      set_node_on_first_message_only: true
+    ads: {}
 node:
   cluster: envoy-gateway-system
   id: envoy-default
 static_resources:
+  listeners:
+  - name: envoy-gateway-proxy-ready-{{ .ReadinessServer.Address }}-{{ .ReadinessServer.Port }}
+    address:
+      socket_address:
+        address: {{ .ReadinessServer.Address }}
+        port_value: {{ .ReadinessServer.Port }}
+        protocol: TCP
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          stat_prefix: eg-ready-http
+          route_config:
+            name: local_route
+            virtual_hosts:
+            - name: ready_route
+              domains:
+              - "*"
+              routes:
+              - match:
+                  path: {{ .ReadinessServer.ReadinessPath }}
+                route:
+                  cluster: envoy_admin
+{{- if .ReadinessServer.MetricsPath }}
+              - match:
+                  path: {{ .ReadinessServer.MetricsPath }}
+                route:
+                  cluster: envoy_admin
+{{- end }}
+          http_filters:
+          - name: envoy.filters.http.router
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.router.v3.Router
+{{- with .MetricsServer }}
+  - name: envoy-gateway-proxy-metrics-{{ .Address }}-{{ .Port }}
+    address:
+      socket_address:
+        address: {{ .Address }}
+        port_value: {{ .Port }}
+        protocol: TCP
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          stat_prefix: eg-metrics-http
+          route_config:
+            name: local_route
+            virtual_hosts:
+            - name: metrics_route
+              domains:
+              - "*"
+              routes:
+              - match:
+                  path: {{ .MetricsPath }}
+                route:
+                  cluster: envoy_admin
+          http_filters:
+          - name: envoy.filters.http.router
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.router.v3.Router
+{{- end }}
   clusters:
+  - connect_timeout: 0.25s
+    load_assignment:
+      cluster_name: envoy_admin
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: {{ .AdminServer.Address }}
+                port_value: {{ .AdminServer.Port }}
+    name: envoy_admin
+    type: STATIC
   - connect_timeout: 1s
     load_assignment:
       cluster_name: xds_cluster
@@ -52,24 +122,91 @@ static_resources:
           - name: xds_certificate
             sds_config:
               path_config_source:
-                path: "/sds/xds-certificate.json"
+                path: "{{ .XdsServer.SdsDir }}/xds-certificate.json"
               resource_api_version: V3
           validation_context_sds_secret_config:
             name: xds_trusted_ca
             sds_config:
               path_config_source:
-                path: "/sds/xds-trusted-ca.json"
+                path: "{{ .XdsServer.SdsDir }}/xds-trusted-ca.json"
               resource_api_version: V3
+{{- with .Overload }}
+{{- if .MaxHeapSizeBytes }}
+overload_manager:
+  refresh_interval: 0.25s
+  resource_monitors:
+  - name: envoy.resource_monitors.fixed_heap
+    typed_config:
+      "@type": type.googleapis.com/envoy.extensions.resource_monitors.fixed_heap.v3.FixedHeapConfig
+      max_heap_size_bytes: {{ .MaxHeapSizeBytes }}
+  actions:
+  - name: envoy.overload_actions.shrink_heap
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: {{ .ShrinkHeapThreshold }}
+  - name: envoy.overload_actions.stop_accepting_requests
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: {{ .StopAcceptingRequestsThreshold }}
+{{- end }}
+{{- end }}
+{{- with .Stats }}
+{{- if or .InclusionPrefixes .ExclusionPrefixes .Tags }}
+stats_config:
+{{- if .InclusionPrefixes }}
+  stats_matcher:
+    inclusion_list:
+      patterns:
+{{- range .InclusionPrefixes }}
+      - prefix: {{ printf "%q" . }}
+{{- end }}
+{{- else if .ExclusionPrefixes }}
+  stats_matcher:
+    exclusion_list:
+      patterns:
+{{- range .ExclusionPrefixes }}
+      - prefix: {{ printf "%q" . }}
+{{- end }}
+{{- end }}
+{{- if .Tags }}
+  stats_tags:
+{{- range .Tags }}
+  - tag_name: {{ printf "%q" .Name }}
+    regex: {{ printf "%q" .Regex }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .StatsdSinks }}
+stats_sinks:
+{{- range .StatsdSinks }}
+- name: envoy.stat_sinks.statsd
+  typed_config:
+    "@type": type.googleapis.com/envoy.config.metrics.v3.StatsdSink
+    address:
+      socket_address:
+        address: {{ .Address }}
+        port_value: {{ .Port }}
+        protocol: UDP
+{{- if .Prefix }}
+    prefix: {{ printf "%q" .Prefix }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
 layered_runtime:
   layers:
+{{- with .Overload }}
+{{- if .MaxActiveDownstreamConnections }}
+    - name: overload
+      static_layer:
+        overload.global_downstream_max_connections: {{ .MaxActiveDownstreamConnections }}
+{{- end }}
+{{- end }}
     - name: runtime-0
       rtds_layer:
         rtds_config:
           resource_api_version: V3
-          api_config_source:
-            transport_api_version: V3
-            api_type: DELTA_GRPC
-            grpc_services:
-              envoy_grpc:
-                cluster_name: xds_cluster
+          ads: {}
         name: runtime-0
@@ -0,0 +1,229 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestExpectedBootstrap(t *testing.T) {
+	replace := v1alpha1.BootstrapTypeReplace
+	jsonPatch := v1alpha1.BootstrapTypeJSONPatch
+
+	testCases := []struct {
+		name      string
+		bootstrap *v1alpha1.ProxyBootstrap
+		overload  *v1alpha1.ProxyOverload
+		metrics   *v1alpha1.ProxyMetrics
+		expect    func(t *testing.T, rendered map[string]interface{})
+		expectErr bool
+	}{
+		{
+			name: "default",
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				assert.Contains(t, rendered, "dynamic_resources")
+				assert.NotContains(t, rendered, "overload_manager")
+				assert.NotContains(t, rendered, "stats_config")
+				assert.NotContains(t, rendered, "stats_sinks")
+			},
+		},
+		{
+			name: "metrics",
+			metrics: &v1alpha1.ProxyMetrics{
+				Prometheus: &v1alpha1.ProxyPrometheus{Port: 19002},
+				Matcher: &v1alpha1.ProxyStatsMatcher{
+					ExclusionPrefixes: []string{"cluster.xds_cluster"},
+				},
+				StatsTags: []v1alpha1.ProxyStatsTag{{
+					Name:  "route_name",
+					Regex: `^vhost\.[^.]+\.route\.(([^.]+)\.)`,
+				}},
+				Sinks: []v1alpha1.ProxyMetricSink{{
+					Type: v1alpha1.ProxyMetricSinkTypeStatsd,
+					Statsd: &v1alpha1.StatsdMetricSink{
+						Address: "10.0.0.1",
+						Port:    8125,
+						Prefix:  pointer.String("eg"),
+					},
+				}},
+			},
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				listeners := rendered["static_resources"].(map[string]interface{})["listeners"].([]interface{})
+				require.Len(t, listeners, 2)
+				assert.Equal(t, "envoy-gateway-proxy-metrics-0.0.0.0-19002", listeners[1].(map[string]interface{})["name"])
+
+				statsConfig := rendered["stats_config"].(map[string]interface{})
+				assert.Equal(t, map[string]interface{}{
+					"exclusion_list": map[string]interface{}{
+						"patterns": []interface{}{map[string]interface{}{"prefix": "cluster.xds_cluster"}},
+					},
+				}, statsConfig["stats_matcher"])
+				assert.Equal(t, []interface{}{map[string]interface{}{
+					"tag_name": "route_name",
+					"regex":    `^vhost\.[^.]+\.route\.(([^.]+)\.)`,
+				}}, statsConfig["stats_tags"])
+
+				sinks := rendered["stats_sinks"].([]interface{})
+				require.Len(t, sinks, 1)
+				typedConfig := sinks[0].(map[string]interface{})["typed_config"].(map[string]interface{})
+				assert.Equal(t, "eg", typedConfig["prefix"])
+			},
+		},
+		{
+			name: "metrics sinks only",
+			metrics: &v1alpha1.ProxyMetrics{
+				Sinks: []v1alpha1.ProxyMetricSink{{
+					Type:   v1alpha1.ProxyMetricSinkTypeStatsd,
+					Statsd: &v1alpha1.StatsdMetricSink{Address: "10.0.0.1", Port: 8125},
+				}},
+			},
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				assert.NotContains(t, rendered, "stats_config")
+				assert.Len(t, rendered["stats_sinks"], 1)
+				listeners := rendered["static_resources"].(map[string]interface{})["listeners"].([]interface{})
+				assert.Len(t, listeners, 1)
+			},
+		},
+		{
+			name: "invalid stats matcher",
+			metrics: &v1alpha1.ProxyMetrics{
+				Matcher: &v1alpha1.ProxyStatsMatcher{
+					InclusionPrefixes: []string{"http"},
+					ExclusionPrefixes: []string{"cluster"},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid statsd address",
+			metrics: &v1alpha1.ProxyMetrics{
+				Sinks: []v1alpha1.ProxyMetricSink{{
+					Type:   v1alpha1.ProxyMetricSinkTypeStatsd,
+					Statsd: &v1alpha1.StatsdMetricSink{Address: "statsd.example.com", Port: 8125},
+				}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "overload",
+			overload: &v1alpha1.ProxyOverload{
+				MaxActiveDownstreamConnections: pointer.Int64(50000),
+				Heap: &v1alpha1.HeapOverload{
+					MaxHeapSizeBytes:    1073741824,
+					ShrinkHeapThreshold: pointer.Int32(90),
+				},
+			},
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				overloadManager := rendered["overload_manager"].(map[string]interface{})
+				actions := overloadManager["actions"].([]interface{})
+				require.Len(t, actions, 2)
+				thresholds := []interface{}{}
+				for _, action := range actions {
+					trigger := action.(map[string]interface{})["triggers"].([]interface{})[0].(map[string]interface{})
+					thresholds = append(thresholds, trigger["threshold"].(map[string]interface{})["value"])
+				}
+				assert.Equal(t, []interface{}{0.9, 0.98}, thresholds)
+				monitor := overloadManager["resource_monitors"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, float64(1073741824), monitor["typed_config"].(map[string]interface{})["max_heap_size_bytes"])
+
+				layers := rendered["layered_runtime"].(map[string]interface{})["layers"].([]interface{})
+				require.Len(t, layers, 2)
+				assert.Equal(t, map[string]interface{}{"overload.global_downstream_max_connections": float64(50000)},
+					layers[0].(map[string]interface{})["static_layer"])
+			},
+		},
+		{
+			name: "connection overload only",
+			overload: &v1alpha1.ProxyOverload{
+				MaxActiveDownstreamConnections: pointer.Int64(50000),
+			},
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				assert.NotContains(t, rendered, "overload_manager")
+				layers := rendered["layered_runtime"].(map[string]interface{})["layers"].([]interface{})
+				assert.Len(t, layers, 2)
+			},
+		},
+		{
+			name: "replace",
+			bootstrap: &v1alpha1.ProxyBootstrap{
+				Type:  &replace,
+				Value: "node:\n  id: custom\n",
+			},
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				assert.Equal(t, map[string]interface{}{"node": map[string]interface{}{"id": "custom"}}, rendered)
+			},
+		},
+		{
+			name: "replace by default",
+			bootstrap: &v1alpha1.ProxyBootstrap{
+				Value: "node:\n  id: custom\n",
+			},
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				assert.NotContains(t, rendered, "dynamic_resources")
+			},
+		},
+		{
+			name: "json patch",
+			bootstrap: &v1alpha1.ProxyBootstrap{
+				Type: &jsonPatch,
+				Value: `- op: add
+  path: /overload_manager
+  value:
+    refresh_interval: 0.25s
+`,
+			},
+			expect: func(t *testing.T, rendered map[string]interface{}) {
+				assert.Contains(t, rendered, "dynamic_resources")
+				assert.Equal(t, map[string]interface{}{"refresh_interval": "0.25s"}, rendered["overload_manager"])
+			},
+		},
+		{
+			name: "invalid replace",
+			bootstrap: &v1alpha1.ProxyBootstrap{
+				Type:  &replace,
+				Value: "node: [",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid json patch",
+			bootstrap: &v1alpha1.ProxyBootstrap{
+				Type:  &jsonPatch,
+				Value: `[{"op": "remove", "path": "/nonexistent"}]`,
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := &Infra{Namespace: "envoy-gateway-system"}
+			infra := ir.NewInfra()
+			infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+				Spec: v1alpha1.EnvoyProxySpec{
+					Bootstrap: tc.bootstrap,
+					Overload:  tc.overload,
+					Telemetry: &v1alpha1.ProxyTelemetry{Metrics: tc.metrics},
+				},
+			}
+
+			bootstrap, err := kube.expectedBootstrap(infra)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			rendered := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal([]byte(bootstrap), &rendered))
+			tc.expect(t, rendered)
+		})
+	}
+}
@@ -3,12 +3,11 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"path/filepath"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
@@ -32,13 +31,33 @@ const (
 
 var (
 	// xDS certificate rotation is supported by using SDS path-based resource files.
-	sdsCAConfigMapData = fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
+	sdsCAConfigMapData   = sdsCAConfig(xdsTLSCaFilename)
+	sdsCertConfigMapData = sdsCertConfig(xdsTLSCertFilename, xdsTLSKeyFilename)
+)
+
+// SdsConfigs returns the SDS files of the xDS client certificate of Envoy, keyed
+// by file name, for the ca.crt, tls.crt and tls.key files of certsDir.
+func SdsConfigs(certsDir string) map[string]string {
+	return map[string]string{
+		sdsCAFilename:   sdsCAConfig(filepath.Join(certsDir, "ca.crt")),
+		sdsCertFilename: sdsCertConfig(filepath.Join(certsDir, "tls.crt"), filepath.Join(certsDir, "tls.key")),
+	}
+}
+
+// sdsCAConfig returns the SDS file of the CA certificate trusted by Envoy to
+// verify the xDS server.
+func sdsCAConfig(caFile string) string {
+	return fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
 		`"name":"xds_trusted_ca","validation_context":{"trusted_ca":{"filename":"%s"},`+
-		`"match_typed_subject_alt_names":[{"san_type":"DNS","matcher":{"exact":"envoy-gateway"}}]}}]}`, xdsTLSCaFilename)
-	sdsCertConfigMapData = fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
+		`"match_typed_subject_alt_names":[{"san_type":"DNS","matcher":{"exact":"envoy-gateway"}}]}}]}`, caFile)
+}
+
+// sdsCertConfig returns the SDS file of the xDS client certificate of Envoy.
+func sdsCertConfig(certFile, keyFile string) string {
+	return fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
 		`"name":"xds_certificate","tls_certificate":{"certificate_chain":{"filename":"%s"},`+
-		`"private_key":{"filename":"%s"}}}]}`, xdsTLSCertFilename, xdsTLSKeyFilename)
-)
+		`"private_key":{"filename":"%s"}}}]}`, certFile, keyFile)
+}
 
 // expectedConfigMap returns the expected ConfigMap based on the provided infra.
 func (i *Infra) expectedConfigMap(infra *ir.Infra) (*corev1.ConfigMap, error) {
@@ -48,15 +67,21 @@ func (i *Infra) expectedConfigMap(infra *ir.Infra) (*corev1.ConfigMap, error) {
 		return nil, fmt.Errorf("missing owning gateway labels")
 	}
 
+	bootstrap, err := i.expectedBootstrap(infra)
+	if err != nil {
+		return nil, err
+	}
+
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
+			Namespace: i.proxyNamespace(infra),
 			Name:      expectedConfigMapName(infra.Proxy.Name),
 			Labels:    labels,
 		},
 		Data: map[string]string{
-			sdsCAFilename:   sdsCAConfigMapData,
-			sdsCertFilename: sdsCertConfigMapData,
+			sdsCAFilename:    sdsCAConfigMapData,
+			sdsCertFilename:  sdsCertConfigMapData,
+			envoyCfgFileName: bootstrap,
 		},
 	}, nil
 }
@@ -69,26 +94,14 @@ func (i *Infra) createOrUpdateConfigMap(ctx context.Context, infra *ir.Infra) (*
 		return nil, err
 	}
 
-	current := &corev1.ConfigMap{}
-	key := types.NamespacedName{
-		Namespace: i.Namespace,
-		Name:      expectedConfigMapName(infra.Proxy.Name),
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return nil, err
 	}
+	cm.OwnerReferences = owners
 
-	if err := i.Client.Get(ctx, key, current); err != nil {
-		// Create if not found.
-		if kerrors.IsNotFound(err) {
-			if err := i.Client.Create(ctx, cm); err != nil {
-				return nil, fmt.Errorf("failed to create configmap %s/%s: %w", cm.Namespace, cm.Name, err)
-			}
-		}
-	} else {
-		// Update if current value is different.
-		if !reflect.DeepEqual(cm.Data, current.Data) {
-			if err := i.Client.Update(ctx, cm); err != nil {
-				return nil, fmt.Errorf("failed to update configmap %s/%s: %w", cm.Namespace, cm.Name, err)
-			}
-		}
+	if err := i.createOrUpdate(ctx, cm, &corev1.ConfigMap{}, nil); err != nil {
+		return nil, err
 	}
 
 	return cm, nil
@@ -98,7 +111,7 @@ func (i *Infra) createOrUpdateConfigMap(ctx context.Context, infra *ir.Infra) (*
 func (i *Infra) deleteConfigMap(ctx context.Context, infra *ir.Infra) error {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
+			Namespace: i.proxyNamespace(infra),
 			Name:      expectedConfigMapName(infra.Proxy.Name),
 		},
 	}
@@ -42,8 +42,9 @@ func TestExpectedConfigMap(t *testing.T) {
 	assert.Equal(t, sdsCAConfigMapData, cm.Data[sdsCAFilename])
 	require.Contains(t, cm.Data, sdsCertFilename)
 	assert.Equal(t, sdsCertConfigMapData, cm.Data[sdsCertFilename])
+	require.Contains(t, cm.Data, envoyCfgFileName)
 
-	wantLabels := envoyAppLabel()
+	wantLabels := EnvoyAppLabel()
 	wantLabels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
 	wantLabels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
 	assert.True(t, apiequality.Semantic.DeepEqual(wantLabels, cm.Labels))
@@ -56,6 +57,9 @@ func TestCreateOrUpdateConfigMap(t *testing.T) {
 	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
 	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
 
+	bootstrap, err := kube.expectedBootstrap(infra)
+	require.NoError(t, err)
+
 	testCases := []struct {
 		name    string
 		current *corev1.ConfigMap
@@ -73,7 +77,11 @@ func TestCreateOrUpdateConfigMap(t *testing.T) {
 						gatewayapi.OwningGatewayNameLabel:      "test",
 					},
 				},
-				Data: map[string]string{sdsCAFilename: sdsCAConfigMapData, sdsCertFilename: sdsCertConfigMapData},
+				Data: map[string]string{
+					sdsCAFilename:    sdsCAConfigMapData,
+					sdsCertFilename:  sdsCertConfigMapData,
+					envoyCfgFileName: bootstrap,
+				},
 			},
 		},
 		{
@@ -100,7 +108,11 @@ func TestCreateOrUpdateConfigMap(t *testing.T) {
 						gatewayapi.OwningGatewayNameLabel:      "test",
 					},
 				},
-				Data: map[string]string{sdsCAFilename: sdsCAConfigMapData, sdsCertFilename: sdsCertConfigMapData},
+				Data: map[string]string{
+					sdsCAFilename:    sdsCAConfigMapData,
+					sdsCertFilename:  sdsCertConfigMapData,
+					envoyCfgFileName: bootstrap,
+				},
 			},
 		},
 	}
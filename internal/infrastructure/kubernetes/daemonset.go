@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+func expectedDaemonSetName(proxyName string) string {
+	daemonSetName := utils.GetHashedName(proxyName)
+	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, daemonSetName)
+}
+
+// expectedDaemonSet returns the expected DaemonSet based on the provided infra.
+func (i *Infra) expectedDaemonSet(infra *ir.Infra) (*appsv1.DaemonSet, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	podTemplate, err := i.expectedPodTemplate(infra)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the workload configuration from the EnvoyProxy config, using defaults if unspecified.
+	deployCfg := infra.GetProxyInfra().Config.GetKubeProvider().Deployment
+
+	daemonSet := &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   i.proxyNamespace(infra),
+			Name:        expectedDaemonSetName(infra.Proxy.Name),
+			Labels:      mergeLabels(labels, deployCfg.Labels),
+			Annotations: deployCfg.Annotations,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels),
+			Template: *podTemplate,
+		},
+	}
+
+	return daemonSet, nil
+}
+
+// createOrUpdateDaemonSet creates a DaemonSet in the kube api server based on the provided
+// infra, if it doesn't exist and updates it if it does.
+func (i *Infra) createOrUpdateDaemonSet(ctx context.Context, infra *ir.Infra) error {
+	daemonSet, err := i.expectedDaemonSet(infra)
+	if err != nil {
+		return err
+	}
+
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
+	}
+	daemonSet.OwnerReferences = owners
+
+	return i.createOrUpdate(ctx, daemonSet, &appsv1.DaemonSet{}, nil)
+}
+
+// deleteDaemonSet deletes the Envoy DaemonSet in the kube api server, if it exists.
+func (i *Infra) deleteDaemonSet(ctx context.Context, infra *ir.Infra) error {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedDaemonSetName(infra.Proxy.Name),
+		},
+	}
+
+	if err := i.Client.Delete(ctx, daemonSet); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete daemonset %s/%s: %w", daemonSet.Namespace, daemonSet.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func daemonSetInfra() *ir.Infra {
+	workloadType := v1alpha1.KubeWorkloadTypeDaemonSet
+	return newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		WorkloadType: &workloadType,
+	})
+}
+
+func TestExpectedDaemonSet(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+
+	// An infra without Gateway owner labels should trigger
+	// an error.
+	_, err := kube.expectedDaemonSet(ir.NewInfra())
+	require.NotNil(t, err)
+
+	infra := daemonSetInfra()
+	ds, err := kube.expectedDaemonSet(infra)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedDaemonSetName(infra.Proxy.Name), ds.Name)
+
+	// The DaemonSet uses the same pod template as the Deployment.
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	assert.Equal(t, deploy.Spec.Template, ds.Spec.Template)
+	assert.Equal(t, deploy.Spec.Selector, ds.Spec.Selector)
+}
+
+func TestCreateOrUpdateDaemonSet(t *testing.T) {
+	kube := NewInfra(nil)
+	infra := daemonSetInfra()
+
+	ds, err := kube.expectedDaemonSet(infra)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		in      *ir.Infra
+		current *appsv1.DaemonSet
+		want    *appsv1.DaemonSet
+	}{
+		{
+			name: "create daemonset",
+			in:   infra,
+			want: ds,
+		},
+		{
+			name:    "daemonset exists",
+			in:      infra,
+			current: ds,
+			want:    ds,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.current != nil {
+				kube.Client = fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(tc.current).Build()
+			} else {
+				kube.Client = fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build()
+			}
+			err := kube.createOrUpdateDaemonSet(context.Background(), tc.in)
+			require.NoError(t, err)
+
+			actual := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: kube.Namespace,
+					Name:      expectedDaemonSetName(tc.in.Proxy.Name),
+				},
+			}
+			require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(actual), actual))
+			require.Equal(t, tc.want.Spec, actual.Spec)
+		})
+	}
+}
+
+func TestCreateOrUpdateWorkload(t *testing.T) {
+	kube := NewInfra(nil)
+	infra := daemonSetInfra()
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	kube.Client = fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(deploy).Build()
+
+	// Switching to a DaemonSet removes the existing Deployment.
+	require.NoError(t, kube.createOrUpdateWorkload(context.Background(), infra))
+
+	ds := &appsv1.DaemonSet{}
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKey{
+		Namespace: kube.Namespace,
+		Name:      expectedDaemonSetName(infra.Proxy.Name),
+	}, ds))
+
+	err = kube.Client.Get(context.Background(), client.ObjectKeyFromObject(deploy), &appsv1.Deployment{})
+	require.True(t, kerrors.IsNotFound(err))
+
+	// Switching back to a Deployment removes the DaemonSet.
+	infra.Proxy.Config = nil
+	require.NoError(t, kube.createOrUpdateWorkload(context.Background(), infra))
+
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(deploy), &appsv1.Deployment{}))
+	err = kube.Client.Get(context.Background(), client.ObjectKeyFromObject(ds), &appsv1.DaemonSet{})
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func TestDeleteDaemonSet(t *testing.T) {
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+	infra := ir.NewInfra()
+	err := kube.deleteDaemonSet(context.Background(), infra)
+	require.NoError(t, err)
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -12,14 +13,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/provider/utils"
-	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
 )
 
 const (
@@ -40,10 +42,28 @@ const (
 	envoyGatewayXdsServerHost = "envoy-gateway"
 	// envoyAdminAddress is the listening address of the envoy admin interface.
 	envoyAdminAddress = "127.0.0.1"
-	// envoyAdminPort is the port used to expose admin interface.
-	envoyAdminPort = 19000
+	// EnvoyAdminPort is the port of the Envoy admin interface, listening on
+	// envoyAdminAddress.
+	EnvoyAdminPort = 19000
 	// envoyAdminAccessLogPath is the path used to expose admin access log.
 	envoyAdminAccessLogPath = "/dev/null"
+	// envoyReadinessAddress is the listening address of the Envoy readiness listener.
+	envoyReadinessAddress = "0.0.0.0"
+	// envoyReadinessPort is the port of the Envoy readiness listener. The listener
+	// exposes the readiness endpoint of the Envoy admin interface, which only listens
+	// on the loopback address.
+	envoyReadinessPort = int32(19001)
+	// envoyReadinessPath is the path of the Envoy readiness endpoint.
+	envoyReadinessPath = "/ready"
+	// envoyMetricsPath is the path of the Envoy Prometheus metrics endpoint, exposed
+	// by the Envoy readiness listener unless a dedicated listener is configured.
+	envoyMetricsPath = "/stats/prometheus"
+	// envoyMetricsPortName is the name of the container port of the listener serving
+	// the Envoy Prometheus metrics endpoint.
+	envoyMetricsPortName = "metrics"
+	// envoyMetricsAddress is the listening address of the dedicated Envoy Prometheus
+	// metrics listener.
+	envoyMetricsAddress = "0.0.0.0"
 )
 
 //go:embed bootstrap.yaml.tpl
@@ -51,6 +71,9 @@ var bootstrapTmplStr string
 
 var bootstrapTmpl = template.Must(template.New(envoyCfgFileName).Parse(bootstrapTmplStr))
 
+// logComponentRegex matches the names of the Envoy log components.
+var logComponentRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 // envoyBootstrap defines the envoy Bootstrap configuration.
 type bootstrapConfig struct {
 	// parameters defines configurable bootstrap configuration parameters.
@@ -65,6 +88,68 @@ type bootstrapParameters struct {
 	XdsServer xdsServerParameters
 	// AdminServer defines the configuration of the Envoy admin interface.
 	AdminServer adminServerParameters
+	// ReadinessServer defines the configuration of the Envoy readiness listener.
+	ReadinessServer readinessServerParameters
+	// Overload defines the configuration of the Envoy overload manager. If nil,
+	// the overload manager is not configured.
+	Overload *overloadParameters
+	// MetricsServer defines the configuration of the dedicated Envoy Prometheus
+	// metrics listener. If nil, the metrics are served by the readiness listener.
+	MetricsServer *metricsServerParameters
+	// Stats defines the configuration of the Envoy stats. If nil, the Envoy
+	// defaults are used.
+	Stats *statsParameters
+}
+
+type metricsServerParameters struct {
+	// Address is the address of the Envoy Prometheus metrics listener.
+	Address string
+	// Port is the port of the Envoy Prometheus metrics listener.
+	Port int32
+	// MetricsPath is the path of the Envoy Prometheus metrics endpoint.
+	MetricsPath string
+}
+
+type statsParameters struct {
+	// InclusionPrefixes are the prefixes of the names of the stats created.
+	InclusionPrefixes []string
+	// ExclusionPrefixes are the prefixes of the names of the stats not created.
+	ExclusionPrefixes []string
+	// Tags are the tags extracted from the names of the stats.
+	Tags []statsTagParameters
+	// StatsdSinks are the statsd servers the stats are flushed to.
+	StatsdSinks []statsdSinkParameters
+}
+
+type statsTagParameters struct {
+	// Name is the name of the tag.
+	Name string
+	// Regex is the regular expression extracting the value of the tag.
+	Regex string
+}
+
+type statsdSinkParameters struct {
+	// Address is the IP address of the statsd server.
+	Address string
+	// Port is the UDP port of the statsd server.
+	Port int32
+	// Prefix is the prefix added to the names of the stats, or empty to use
+	// the Envoy default.
+	Prefix string
+}
+
+type overloadParameters struct {
+	// MaxActiveDownstreamConnections is the maximum number of active downstream
+	// connections of Envoy, or 0 if the connections are not limited.
+	MaxActiveDownstreamConnections int64
+	// MaxHeapSizeBytes is the maximum heap size of Envoy, or 0 if the heap usage
+	// doesn't trigger overload actions.
+	MaxHeapSizeBytes int64
+	// ShrinkHeapThreshold is the heap usage ratio at which Envoy releases free memory.
+	ShrinkHeapThreshold float64
+	// StopAcceptingRequestsThreshold is the heap usage ratio at which Envoy stops
+	// accepting requests.
+	StopAcceptingRequestsThreshold float64
 }
 
 type xdsServerParameters struct {
@@ -72,6 +157,9 @@ type xdsServerParameters struct {
 	Address string
 	// Port is the port of the XDS Server that Envoy is managed by.
 	Port int32
+	// SdsDir is the directory of the SDS files of the xDS client certificate
+	// of Envoy.
+	SdsDir string
 }
 
 type adminServerParameters struct {
@@ -83,6 +171,18 @@ type adminServerParameters struct {
 	AccessLogPath string
 }
 
+type readinessServerParameters struct {
+	// Address is the address of the Envoy readiness listener.
+	Address string
+	// Port is the port of the Envoy readiness listener.
+	Port int32
+	// ReadinessPath is the path of the Envoy readiness endpoint.
+	ReadinessPath string
+	// MetricsPath is the path of the Envoy Prometheus metrics endpoint, or empty
+	// if the metrics are served by a dedicated listener.
+	MetricsPath string
+}
+
 // render the stringified bootstrap config in yaml format.
 func (b *bootstrapConfig) render() error {
 	buf := new(strings.Builder)
@@ -101,72 +201,124 @@ func expectedDeploymentName(proxyName string) string {
 
 // expectedDeployment returns the expected Deployment based on the provided infra.
 func (i *Infra) expectedDeployment(infra *ir.Infra) (*appsv1.Deployment, error) {
-	containers, err := expectedContainers(infra)
-	if err != nil {
-		return nil, err
-	}
-
 	// Set the labels based on the owning gateway name.
 	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
 	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
 		return nil, fmt.Errorf("missing owning gateway labels")
 	}
 
+	podTemplate, err := i.expectedPodTemplate(infra)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the Deployment configuration from the EnvoyProxy config, using defaults if unspecified.
+	deployCfg := infra.GetProxyInfra().Config.GetKubeProvider().Deployment
+
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Deployment",
 			APIVersion: "apps/v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
-			Name:      expectedDeploymentName(infra.Proxy.Name),
-			Labels:    labels,
+			Namespace:   i.proxyNamespace(infra),
+			Name:        expectedDeploymentName(infra.Proxy.Name),
+			Labels:      mergeLabels(labels, deployCfg.Labels),
+			Annotations: deployCfg.Annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: pointer.Int32(1),
 			Selector: envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels),
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels).MatchLabels,
+			Template: *podTemplate,
+		},
+	}
+
+	if deployCfg.Strategy != nil {
+		deployment.Spec.Strategy = *deployCfg.Strategy
+	}
+
+	// The replica count is managed by the HorizontalPodAutoscaler, if enabled.
+	if hpaEnabled(infra) {
+		deployment.Spec.Replicas = nil
+	}
+
+	return deployment, nil
+}
+
+// expectedPodTemplate returns the expected template of the Envoy pods based on
+// the provided infra. The template is shared by the Envoy Deployment and DaemonSet.
+func (i *Infra) expectedPodTemplate(infra *ir.Infra) (*corev1.PodTemplateSpec, error) {
+	containers, err := i.expectedContainers(infra)
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrap, err := i.expectedBootstrap(infra)
+	if err != nil {
+		return nil, err
+	}
+
+	podTemplate := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels).MatchLabels,
+			Annotations: map[string]string{
+				bootstrapHashAnnotation: bootstrapHash(bootstrap),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:                    containers,
+			ServiceAccountName:            expectedServiceAccountName(infra.Proxy.Name),
+			AutomountServiceAccountToken:  pointer.BoolPtr(roleEnabled(infra)),
+			TerminationGracePeriodSeconds: pointer.Int64Ptr(int64(300)),
+			DNSPolicy:                     corev1.DNSClusterFirst,
+			RestartPolicy:                 corev1.RestartPolicyAlways,
+			SchedulerName:                 "default-scheduler",
+			Volumes: []corev1.Volume{
+				{
+					Name: "certs",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: envoyCertsSecretName,
+						},
+					},
 				},
-				Spec: corev1.PodSpec{
-					Containers:                    containers,
-					ServiceAccountName:            expectedServiceAccountName(infra.Proxy.Name),
-					AutomountServiceAccountToken:  pointer.BoolPtr(false),
-					TerminationGracePeriodSeconds: pointer.Int64Ptr(int64(300)),
-					DNSPolicy:                     corev1.DNSClusterFirst,
-					RestartPolicy:                 corev1.RestartPolicyAlways,
-					SchedulerName:                 "default-scheduler",
-					Volumes: []corev1.Volume{
-						{
-							Name: "certs",
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName: "envoy",
+				{
+					Name: "sds",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: expectedConfigMapName(infra.Proxy.Name),
+							},
+							Items: []corev1.KeyToPath{
+								{
+									Key:  sdsCAFilename,
+									Path: sdsCAFilename,
+								},
+								{
+									Key:  sdsCertFilename,
+									Path: sdsCertFilename,
 								},
 							},
+							DefaultMode: pointer.Int32Ptr(int32(420)),
+							Optional:    pointer.BoolPtr(false),
 						},
-						{
-							Name: "sds",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: expectedConfigMapName(infra.Proxy.Name),
-									},
-									Items: []corev1.KeyToPath{
-										{
-											Key:  sdsCAFilename,
-											Path: sdsCAFilename,
-										},
-										{
-											Key:  sdsCertFilename,
-											Path: sdsCertFilename,
-										},
-									},
-									DefaultMode: pointer.Int32Ptr(int32(420)),
-									Optional:    pointer.BoolPtr(false),
+					},
+				},
+				{
+					Name: "bootstrap",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: expectedConfigMapName(infra.Proxy.Name),
+							},
+							Items: []corev1.KeyToPath{
+								{
+									Key:  envoyCfgFileName,
+									Path: envoyCfgFileName,
 								},
 							},
+							DefaultMode: pointer.Int32Ptr(int32(420)),
+							Optional:    pointer.BoolPtr(false),
 						},
 					},
 				},
@@ -174,10 +326,135 @@ func (i *Infra) expectedDeployment(infra *ir.Infra) (*appsv1.Deployment, error)
 		},
 	}
 
-	return deployment, nil
+	// Get the pod configuration from the EnvoyProxy config, using defaults if unspecified.
+	if pod := infra.GetProxyInfra().Config.GetKubeProvider().Deployment.Pod; pod != nil {
+		podMeta := &podTemplate.ObjectMeta
+		podMeta.Labels = mergeLabels(podMeta.Labels, pod.Labels)
+		for k, v := range pod.Annotations {
+			if _, ok := podMeta.Annotations[k]; !ok {
+				podMeta.Annotations[k] = v
+			}
+		}
+
+		podSpec := &podTemplate.Spec
+		podSpec.SecurityContext = pod.SecurityContext
+		podSpec.NodeSelector = pod.NodeSelector
+		podSpec.Affinity = pod.Affinity
+		podSpec.Tolerations = pod.Tolerations
+		podSpec.TopologySpreadConstraints = pod.TopologySpreadConstraints
+		if pod.PriorityClassName != nil {
+			podSpec.PriorityClassName = *pod.PriorityClassName
+		}
+		podSpec.RuntimeClassName = pod.RuntimeClassName
+		if pod.HostNetwork != nil && *pod.HostNetwork {
+			podSpec.HostNetwork = true
+			podSpec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+		}
+		if err := validatePodExtensions(podSpec, pod); err != nil {
+			return nil, err
+		}
+		podSpec.Volumes = append(podSpec.Volumes, pod.Volumes...)
+		podSpec.InitContainers = pod.InitContainers
+		podSpec.Containers = append(podSpec.Containers, pod.SidecarContainers...)
+	}
+
+	// Fall back to the Prometheus scrape annotations if metrics scraping is enabled
+	// and PodMonitors are not supported by the cluster.
+	if prometheusEnabled(infra) && !i.podMonitorSupported() {
+		for k, v := range prometheusScrapeAnnotations(infra) {
+			podTemplate.Annotations[k] = v
+		}
+	}
+
+	return podTemplate, nil
+}
+
+// validatePodExtensions returns an error if the volumes, init containers or
+// sidecar containers of the provided pod config collide with the volumes or
+// containers generated in the provided pod spec.
+func validatePodExtensions(podSpec *corev1.PodSpec, pod *v1alpha1.KubePod) error {
+	volumes := sets.NewString()
+	for _, volume := range podSpec.Volumes {
+		volumes.Insert(volume.Name)
+	}
+	for _, volume := range pod.Volumes {
+		if volumes.Has(volume.Name) {
+			return fmt.Errorf("pod volume %s collides with a generated volume", volume.Name)
+		}
+	}
+
+	containers := sets.NewString()
+	for _, container := range podSpec.Containers {
+		containers.Insert(container.Name)
+	}
+	for _, container := range pod.InitContainers {
+		if containers.Has(container.Name) {
+			return fmt.Errorf("pod init container %s collides with a generated container", container.Name)
+		}
+	}
+	for _, container := range pod.SidecarContainers {
+		if containers.Has(container.Name) {
+			return fmt.Errorf("pod sidecar container %s collides with a generated container", container.Name)
+		}
+	}
+
+	return nil
 }
 
-func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
+// expectedHostPorts returns the provided container ports with the listener ports of
+// the provided infra exposed on the node, if required by the provided pod config.
+// Declaring the listener ports lets the scheduler avoid placing pods with
+// conflicting ports on the same node.
+func expectedHostPorts(infra *ir.Infra, ports []corev1.ContainerPort, pod *v1alpha1.KubePod) ([]corev1.ContainerPort, error) {
+	if pod == nil {
+		return ports, nil
+	}
+	hostNetwork := pod.HostNetwork != nil && *pod.HostNetwork
+	hostPorts := pod.HostPorts != nil && *pod.HostPorts
+	if hostNetwork && hostPorts {
+		return nil, fmt.Errorf("pod hostNetwork and hostPorts are mutually exclusive")
+	}
+	if !hostNetwork && !hostPorts {
+		return ports, nil
+	}
+
+	for _, listener := range infra.Proxy.Listeners {
+		for _, listenerPort := range listener.Ports {
+			// Ports of the host network are the container ports.
+			hostPort := listenerPort.ContainerPort
+			if hostPorts {
+				hostPort = listenerPort.ServicePort
+			}
+
+			protocol := expectedPortProtocol(listenerPort)
+			found := false
+			for i := range ports {
+				if ports[i].ContainerPort == listenerPort.ContainerPort && ports[i].Protocol == protocol {
+					ports[i].HostPort = hostPort
+					found = true
+				}
+			}
+			if !found {
+				ports = append(ports, corev1.ContainerPort{
+					ContainerPort: listenerPort.ContainerPort,
+					HostPort:      hostPort,
+					Protocol:      protocol,
+				})
+			}
+		}
+	}
+
+	// All ports of a pod on the host network are exposed on the node.
+	if hostNetwork {
+		for i := range ports {
+			ports[i].HostPort = ports[i].ContainerPort
+		}
+	}
+
+	return ports, nil
+}
+
+func (i *Infra) expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 	ports := []corev1.ContainerPort{
 		{
 			Name:          "http",
@@ -189,22 +466,23 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 			ContainerPort: envoyHTTPSPort,
 			Protocol:      corev1.ProtocolTCP,
 		},
+		{
+			Name:          envoyMetricsPortName,
+			ContainerPort: metricsPort(infra),
+			Protocol:      corev1.ProtocolTCP,
+		},
 	}
 
-	cfg := bootstrapConfig{
-		parameters: bootstrapParameters{
-			XdsServer: xdsServerParameters{
-				Address: envoyGatewayXdsServerHost,
-				Port:    xdsrunner.XdsServerPort,
-			},
-			AdminServer: adminServerParameters{
-				Address:       envoyAdminAddress,
-				Port:          envoyAdminPort,
-				AccessLogPath: envoyAdminAccessLogPath,
-			},
-		},
+	// Get the Deployment configuration from the EnvoyProxy config, using defaults if unspecified.
+	deployCfg := infra.GetProxyInfra().Config.GetKubeProvider().Deployment
+
+	ports, err := expectedHostPorts(infra, ports, deployCfg.Pod)
+	if err != nil {
+		return nil, err
 	}
-	if err := cfg.render(); err != nil {
+
+	logArgs, err := LogArgs(infra.GetProxyInfra().Config)
+	if err != nil {
 		return nil, err
 	}
 
@@ -216,12 +494,11 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 			Command: []string{
 				"envoy",
 			},
-			Args: []string{
+			Args: append([]string{
 				fmt.Sprintf("--service-cluster %s", infra.Proxy.Name),
 				fmt.Sprintf("--service-node $(%s)", envoyPodEnvVar),
-				fmt.Sprintf("--config-yaml %s", cfg.rendered),
-				"--log-level info",
-			},
+				fmt.Sprintf("--config-path %s/%s", envoyCfgMountPath, envoyCfgFileName),
+			}, logArgs...),
 			Env: []corev1.EnvVar{
 				{
 					Name: envoyNsEnvVar,
@@ -251,7 +528,12 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 				},
 				{
 					Name:      "sds",
-					MountPath: "/sds",
+					MountPath: envoySdsMountPath,
+				},
+				{
+					Name:      "bootstrap",
+					MountPath: envoyCfgMountPath,
+					ReadOnly:  true,
 				},
 			},
 			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
@@ -259,9 +541,143 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 		},
 	}
 
+	if container := deployCfg.Container; container != nil {
+		if container.Image != nil {
+			containers[0].Image = *container.Image
+		}
+		if container.Resources != nil {
+			containers[0].Resources = *container.Resources
+		}
+		containers[0].SecurityContext = container.SecurityContext
+		containers[0].ReadinessProbe = expectedProbe(container.ReadinessProbe, defaultReadinessProbe())
+		containers[0].LivenessProbe = expectedProbe(container.LivenessProbe, defaultLivenessProbe())
+		containers[0].Args = append(containers[0].Args, container.Args...)
+		containers[0].Env = mergeEnvVars(containers[0].Env, container.Env)
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, container.VolumeMounts...)
+	}
+
 	return containers, nil
 }
 
+// LogArgs returns the Envoy arguments setting the log levels of the provided
+// EnvoyProxy config, logging at the "info" level if unspecified. Component log levels
+// are sorted by component so that the arguments are stable.
+func LogArgs(proxyCfg *v1alpha1.EnvoyProxy) ([]string, error) {
+	level := v1alpha1.LogLevelInfo
+	var logging *v1alpha1.ProxyLogging
+	if proxyCfg != nil && proxyCfg.Spec.Logging != nil {
+		logging = proxyCfg.Spec.Logging
+		if logging.Level != nil {
+			level = *logging.Level
+		}
+	}
+	args := []string{fmt.Sprintf("--log-level %s", level)}
+	if logging == nil || len(logging.ComponentLevels) == 0 {
+		return args, nil
+	}
+
+	components := make([]string, 0, len(logging.ComponentLevels))
+	for component := range logging.ComponentLevels {
+		if !logComponentRegex.MatchString(component) {
+			return nil, fmt.Errorf("invalid log component %q", component)
+		}
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	levels := make([]string, 0, len(components))
+	for _, component := range components {
+		levels = append(levels, fmt.Sprintf("%s:%s", component, logging.ComponentLevels[component]))
+	}
+
+	return append(args, fmt.Sprintf("--component-log-level %s", strings.Join(levels, ","))), nil
+}
+
+// defaultReadinessProbe returns the default readiness probe of the Envoy container.
+// Envoy reports ready once its initial xDS configuration has been received, so the
+// pod does not receive traffic before its configuration has converged.
+func defaultReadinessProbe() *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler:     readinessProbeHandler(),
+		TimeoutSeconds:   1,
+		PeriodSeconds:    5,
+		SuccessThreshold: 1,
+		FailureThreshold: 1,
+	}
+}
+
+// defaultLivenessProbe returns the default liveness probe of the Envoy container.
+func defaultLivenessProbe() *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler:        readinessProbeHandler(),
+		InitialDelaySeconds: 15,
+		TimeoutSeconds:      1,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+}
+
+func readinessProbeHandler() corev1.ProbeHandler {
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   envoyReadinessPath,
+			Port:   intstr.FromInt(int(envoyReadinessPort)),
+			Scheme: corev1.URISchemeHTTP,
+		},
+	}
+}
+
+// expectedProbe returns the probe merged with the default probe. The handler of the
+// default probe is used if the probe does not define one, and unset thresholds are
+// taken from the default probe.
+func expectedProbe(probe, defaultProbe *corev1.Probe) *corev1.Probe {
+	if probe == nil {
+		return defaultProbe
+	}
+
+	merged := probe.DeepCopy()
+	if merged.ProbeHandler == (corev1.ProbeHandler{}) {
+		merged.ProbeHandler = defaultProbe.ProbeHandler
+	}
+	if merged.InitialDelaySeconds == 0 {
+		merged.InitialDelaySeconds = defaultProbe.InitialDelaySeconds
+	}
+	if merged.TimeoutSeconds == 0 {
+		merged.TimeoutSeconds = defaultProbe.TimeoutSeconds
+	}
+	if merged.PeriodSeconds == 0 {
+		merged.PeriodSeconds = defaultProbe.PeriodSeconds
+	}
+	if merged.SuccessThreshold == 0 {
+		merged.SuccessThreshold = defaultProbe.SuccessThreshold
+	}
+	if merged.FailureThreshold == 0 {
+		merged.FailureThreshold = defaultProbe.FailureThreshold
+	}
+
+	return merged
+}
+
+// mergeEnvVars returns the environment variables of envVars merged with userEnvVars.
+// Environment variables of envVars are managed by Envoy Gateway and take precedence
+// over user-provided variables with the same name.
+func mergeEnvVars(envVars, userEnvVars []corev1.EnvVar) []corev1.EnvVar {
+	managed := make(map[string]bool, len(envVars))
+	for _, env := range envVars {
+		managed[env.Name] = true
+	}
+
+	merged := envVars
+	for _, env := range userEnvVars {
+		if !managed[env.Name] {
+			merged = append(merged, env)
+		}
+	}
+
+	return merged
+}
+
 // createDeployment creates a Deployment in the kube api server based on the provided
 // infra, if it doesn't exist and updates it if it does.
 func (i *Infra) createOrUpdateDeployment(ctx context.Context, infra *ir.Infra) error {
@@ -270,38 +686,26 @@ func (i *Infra) createOrUpdateDeployment(ctx context.Context, infra *ir.Infra) e
 		return err
 	}
 
-	current := &appsv1.Deployment{}
-	key := types.NamespacedName{
-		Namespace: i.Namespace,
-		Name:      expectedDeploymentName(infra.Proxy.Name),
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
 	}
+	deploy.OwnerReferences = owners
 
-	if err := i.Client.Get(ctx, key, current); err != nil {
-		// Create if not found.
-		if kerrors.IsNotFound(err) {
-			if err := i.Client.Create(ctx, deploy); err != nil {
-				return fmt.Errorf("failed to create deployment %s/%s: %w",
-					deploy.Namespace, deploy.Name, err)
-			}
-		}
-	} else {
-		// Update if current value is different.
-		if !reflect.DeepEqual(deploy.Spec, current.Spec) {
-			if err := i.Client.Update(ctx, deploy); err != nil {
-				return fmt.Errorf("failed to update deployment %s/%s: %w",
-					deploy.Namespace, deploy.Name, err)
-			}
+	current := &appsv1.Deployment{}
+	return i.createOrUpdate(ctx, deploy, current, func() {
+		// Preserve the replica count set by the HorizontalPodAutoscaler, if any.
+		if deploy.Spec.Replicas == nil {
+			deploy.Spec.Replicas = current.Spec.Replicas
 		}
-	}
-
-	return nil
+	})
 }
 
 // deleteDeployment deletes the Envoy Deployment in the kube api server, if it exists.
 func (i *Infra) deleteDeployment(ctx context.Context, infra *ir.Infra) error {
 	deploy := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
+			Namespace: i.proxyNamespace(infra),
 			Name:      expectedDeploymentName(infra.Proxy.Name),
 		},
 	}
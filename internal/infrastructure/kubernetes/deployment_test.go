@@ -10,10 +10,14 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -122,23 +126,31 @@ func TestExpectedDeployment(t *testing.T) {
 	checkEnvVar(t, deploy, envoyContainerName, envoyPodEnvVar)
 	checkLabels(t, deploy, deploy.Labels)
 
-	// Create a bootstrap config, render it into an arg, and ensure it's as expected.
+	// Create a bootstrap config, render it, and ensure the pods are annotated with its hash.
 	cfg := &bootstrapConfig{
 		parameters: bootstrapParameters{
 			XdsServer: xdsServerParameters{
 				Address: envoyGatewayXdsServerHost,
 				Port:    xdsrunner.XdsServerPort,
+				SdsDir:  envoySdsMountPath,
 			},
 			AdminServer: adminServerParameters{
 				Address:       envoyAdminAddress,
-				Port:          envoyAdminPort,
+				Port:          EnvoyAdminPort,
 				AccessLogPath: envoyAdminAccessLogPath,
 			},
+			ReadinessServer: readinessServerParameters{
+				Address:       envoyReadinessAddress,
+				Port:          envoyReadinessPort,
+				ReadinessPath: envoyReadinessPath,
+				MetricsPath:   envoyMetricsPath,
+			},
 		},
 	}
 	err = cfg.render()
 	require.NoError(t, err)
-	checkContainerHasArg(t, container, fmt.Sprintf("--config-yaml %s", cfg.rendered))
+	checkContainerHasArg(t, container, fmt.Sprintf("--config-path %s/%s", envoyCfgMountPath, envoyCfgFileName))
+	assert.Equal(t, bootstrapHash(cfg.rendered), deploy.Spec.Template.Annotations[bootstrapHashAnnotation])
 
 	// Check container ports for the deployment are as expected.
 	ports := []int32{envoyHTTPPort, envoyHTTPSPort}
@@ -147,6 +159,407 @@ func TestExpectedDeployment(t *testing.T) {
 	}
 }
 
+func TestExpectedDeploymentPodScheduling(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	pod := &v1alpha1.KubePod{
+		NodeSelector: map[string]string{"node-pool": "edge"},
+		Affinity: &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: envoySelector(infra.Proxy.GetProxyMetadata().Labels),
+							TopologyKey:   "kubernetes.io/hostname",
+						},
+					},
+				},
+			},
+		},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "edge",
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     envoySelector(infra.Proxy.GetProxyMetadata().Labels),
+			},
+		},
+		PriorityClassName: pointer.String("system-cluster-critical"),
+		RuntimeClassName:  pointer.String("gvisor"),
+	}
+	infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Deployment: &v1alpha1.KubeDeployment{
+			Pod: pod,
+		},
+	})
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	podSpec := deploy.Spec.Template.Spec
+	assert.Equal(t, pod.NodeSelector, podSpec.NodeSelector)
+	assert.Equal(t, pod.Affinity, podSpec.Affinity)
+	assert.Equal(t, pod.Tolerations, podSpec.Tolerations)
+	assert.Equal(t, pod.TopologySpreadConstraints, podSpec.TopologySpreadConstraints)
+	assert.Equal(t, "system-cluster-critical", podSpec.PriorityClassName)
+	assert.Equal(t, pod.RuntimeClassName, podSpec.RuntimeClassName)
+}
+
+func TestExpectedDeploymentLabelsAndAnnotations(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Deployment: &v1alpha1.KubeDeployment{
+			Labels: map[string]string{
+				"team":                           "edge",
+				"app.gateway.envoyproxy.io/name": "other",
+			},
+			Annotations: map[string]string{"owner": "edge-team"},
+			Pod: &v1alpha1.KubePod{
+				Labels:      map[string]string{"cost-center": "1234"},
+				Annotations: map[string]string{"prometheus.io/scrape": "true"},
+			},
+		},
+	})
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	// Labels managed by Envoy Gateway must not be overridden.
+	assert.Equal(t, "envoy", deploy.Labels["app.gateway.envoyproxy.io/name"])
+	assert.Equal(t, "edge", deploy.Labels["team"])
+	assert.Equal(t, map[string]string{"owner": "edge-team"}, deploy.Annotations)
+
+	podMeta := deploy.Spec.Template.ObjectMeta
+	for k, v := range deploy.Spec.Selector.MatchLabels {
+		assert.Equal(t, v, podMeta.Labels[k])
+	}
+	assert.Equal(t, "1234", podMeta.Labels["cost-center"])
+	assert.Equal(t, "true", podMeta.Annotations["prometheus.io/scrape"])
+	assert.Contains(t, podMeta.Annotations, bootstrapHashAnnotation)
+}
+
+func TestExpectedDeploymentSecurityContext(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	// The secure defaults apply when unspecified.
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	podSC := deploy.Spec.Template.Spec.SecurityContext
+	require.NotNil(t, podSC)
+	assert.True(t, *podSC.RunAsNonRoot)
+
+	container := checkContainer(t, deploy, envoyContainerName, true)
+	sc := container.SecurityContext
+	require.NotNil(t, sc)
+	assert.True(t, *sc.RunAsNonRoot)
+	assert.True(t, *sc.ReadOnlyRootFilesystem)
+	assert.False(t, *sc.AllowPrivilegeEscalation)
+	assert.Equal(t, []corev1.Capability{"ALL"}, sc.Capabilities.Drop)
+	assert.Equal(t, []corev1.Capability{"NET_BIND_SERVICE"}, sc.Capabilities.Add)
+
+	// User-provided security contexts replace the defaults.
+	podCustom := &corev1.PodSecurityContext{RunAsUser: pointer.Int64(1000)}
+	containerCustom := &corev1.SecurityContext{ReadOnlyRootFilesystem: pointer.Bool(false)}
+	infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Deployment: &v1alpha1.KubeDeployment{
+			Pod:       &v1alpha1.KubePod{SecurityContext: podCustom},
+			Container: &v1alpha1.KubeContainer{SecurityContext: containerCustom},
+		},
+	})
+
+	deploy, err = kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	assert.Equal(t, podCustom, deploy.Spec.Template.Spec.SecurityContext)
+	container = checkContainer(t, deploy, envoyContainerName, true)
+	assert.Equal(t, containerCustom, container.SecurityContext)
+}
+
+func TestExpectedDeploymentContainerCustomization(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	caVolume := corev1.Volume{
+		Name: "custom-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "custom-ca"},
+			},
+		},
+	}
+	caMount := corev1.VolumeMount{Name: "custom-ca", MountPath: "/etc/ssl/custom", ReadOnly: true}
+	initContainer := corev1.Container{Name: "init", Image: "busybox"}
+	sidecar := corev1.Container{Name: "metrics", Image: "example.com/metrics:v1"}
+
+	infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Deployment: &v1alpha1.KubeDeployment{
+			Pod: &v1alpha1.KubePod{
+				Volumes:           []corev1.Volume{caVolume},
+				InitContainers:    []corev1.Container{initContainer},
+				SidecarContainers: []corev1.Container{sidecar},
+			},
+			Container: &v1alpha1.KubeContainer{
+				Image: pointer.String("envoyproxy/envoy:v1.23.1"),
+				Args:  []string{"--component-log-level upstream:debug"},
+				Env: []corev1.EnvVar{
+					{Name: "FOO", Value: "bar"},
+					{Name: envoyPodEnvVar, Value: "overridden"},
+				},
+				VolumeMounts: []corev1.VolumeMount{caMount},
+			},
+		},
+	})
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	container := checkContainer(t, deploy, envoyContainerName, true)
+	checkContainerImage(t, container, "envoyproxy/envoy:v1.23.1")
+	checkContainerHasArg(t, container, "--log-level info")
+	checkContainerHasArg(t, container, "--component-log-level upstream:debug")
+	checkEnvVar(t, deploy, envoyContainerName, "FOO")
+
+	// Environment variables managed by Envoy Gateway are not overridden.
+	for _, env := range container.Env {
+		if env.Name == envoyPodEnvVar {
+			assert.NotNil(t, env.ValueFrom)
+			assert.Empty(t, env.Value)
+		}
+	}
+	assert.Contains(t, container.VolumeMounts, caMount)
+
+	podSpec := deploy.Spec.Template.Spec
+	assert.Contains(t, podSpec.Volumes, caVolume)
+	assert.Equal(t, []corev1.Container{initContainer}, podSpec.InitContainers)
+	checkContainer(t, deploy, "metrics", true)
+}
+
+func TestExpectedDeploymentPodCollisions(t *testing.T) {
+	testCases := []struct {
+		name string
+		pod  *v1alpha1.KubePod
+	}{
+		{
+			name: "certs volume",
+			pod:  &v1alpha1.KubePod{Volumes: []corev1.Volume{{Name: "certs"}}},
+		},
+		{
+			name: "sds volume",
+			pod:  &v1alpha1.KubePod{Volumes: []corev1.Volume{{Name: "sds"}}},
+		},
+		{
+			name: "bootstrap volume",
+			pod:  &v1alpha1.KubePod{Volumes: []corev1.Volume{{Name: "bootstrap"}}},
+		},
+		{
+			name: "envoy init container",
+			pod:  &v1alpha1.KubePod{InitContainers: []corev1.Container{{Name: envoyContainerName, Image: "busybox"}}},
+		},
+		{
+			name: "envoy sidecar container",
+			pod:  &v1alpha1.KubePod{SidecarContainers: []corev1.Container{{Name: envoyContainerName, Image: "busybox"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+			infra := newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+				Deployment: &v1alpha1.KubeDeployment{Pod: tc.pod},
+			})
+
+			_, err := kube.expectedDeployment(infra)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestExpectedDeploymentLogLevels(t *testing.T) {
+	debug := v1alpha1.LogLevelDebug
+
+	testCases := []struct {
+		name      string
+		logging   *v1alpha1.ProxyLogging
+		expect    []string
+		expectErr bool
+	}{
+		{
+			name:   "default",
+			expect: []string{"--log-level info"},
+		},
+		{
+			name:    "level",
+			logging: &v1alpha1.ProxyLogging{Level: &debug},
+			expect:  []string{"--log-level debug"},
+		},
+		{
+			name: "component levels",
+			logging: &v1alpha1.ProxyLogging{
+				ComponentLevels: map[string]v1alpha1.LogLevel{
+					"upstream": v1alpha1.LogLevelTrace,
+					"http":     v1alpha1.LogLevelDebug,
+				},
+			},
+			expect: []string{"--log-level info", "--component-log-level http:debug,upstream:trace"},
+		},
+		{
+			name: "invalid component",
+			logging: &v1alpha1.ProxyLogging{
+				ComponentLevels: map[string]v1alpha1.LogLevel{"http,upstream": v1alpha1.LogLevelDebug},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+			infra := ir.NewInfra()
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+			infra.Proxy.Config = &v1alpha1.EnvoyProxy{Spec: v1alpha1.EnvoyProxySpec{Logging: tc.logging}}
+
+			deploy, err := kube.expectedDeployment(infra)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			container := checkContainer(t, deploy, envoyContainerName, true)
+			assert.Equal(t, tc.expect, container.Args[3:])
+		})
+	}
+}
+
+func TestExpectedDeploymentProbes(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	// The default probes check the Envoy readiness endpoint.
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	container := checkContainer(t, deploy, envoyContainerName, true)
+	assert.Equal(t, defaultReadinessProbe(), container.ReadinessProbe)
+	assert.Equal(t, defaultLivenessProbe(), container.LivenessProbe)
+	assert.Equal(t, envoyReadinessPath, container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, int(envoyReadinessPort), container.ReadinessProbe.HTTPGet.Port.IntValue())
+
+	// User-provided thresholds are merged with the default probes.
+	infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Deployment: &v1alpha1.KubeDeployment{
+			Container: &v1alpha1.KubeContainer{
+				ReadinessProbe: &corev1.Probe{FailureThreshold: 3},
+				LivenessProbe:  &corev1.Probe{InitialDelaySeconds: 60, PeriodSeconds: 30},
+			},
+		},
+	})
+
+	deploy, err = kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	container = checkContainer(t, deploy, envoyContainerName, true)
+	readiness := defaultReadinessProbe()
+	readiness.FailureThreshold = 3
+	assert.Equal(t, readiness, container.ReadinessProbe)
+
+	liveness := defaultLivenessProbe()
+	liveness.InitialDelaySeconds = 60
+	liveness.PeriodSeconds = 30
+	assert.Equal(t, liveness, container.LivenessProbe)
+}
+
+func TestExpectedDeploymentStrategy(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	maxSurge := intstr.FromString("50%")
+	maxUnavailable := intstr.FromInt(0)
+
+	testCases := []struct {
+		name     string
+		strategy *appsv1.DeploymentStrategy
+		expect   appsv1.DeploymentStrategy
+	}{
+		{
+			name:   "default",
+			expect: appsv1.DeploymentStrategy{},
+		},
+		{
+			name: "rolling update",
+			strategy: &appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			expect: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+		},
+		{
+			name:     "recreate",
+			strategy: &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			expect:   appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+				Deployment: &v1alpha1.KubeDeployment{
+					Strategy: tc.strategy,
+				},
+			})
+
+			deploy, err := kube.expectedDeployment(infra)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, deploy.Spec.Strategy)
+		})
+	}
+}
+
 func deploymentWithImage(deploy *appsv1.Deployment, image string) *appsv1.Deployment {
 	dCopy := deploy.DeepCopy()
 	for i, c := range dCopy.Spec.Template.Spec.Containers {
@@ -157,6 +570,101 @@ func deploymentWithImage(deploy *appsv1.Deployment, image string) *appsv1.Deploy
 	return dCopy
 }
 
+func deploymentWithResources(deploy *appsv1.Deployment, resources corev1.ResourceRequirements) *appsv1.Deployment {
+	dCopy := deploy.DeepCopy()
+	for i, c := range dCopy.Spec.Template.Spec.Containers {
+		if c.Name == envoyContainerName {
+			dCopy.Spec.Template.Spec.Containers[i].Resources = resources
+		}
+	}
+	return dCopy
+}
+
+func TestExpectedDeploymentHostPorts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pod           *v1alpha1.KubePod
+		hostNetwork   bool
+		dnsPolicy     corev1.DNSPolicy
+		expectedPorts map[int32]int32
+		expectErr     bool
+	}{
+		{
+			name:          "default",
+			pod:           &v1alpha1.KubePod{},
+			dnsPolicy:     corev1.DNSClusterFirst,
+			expectedPorts: map[int32]int32{envoyHTTPPort: 0, envoyHTTPSPort: 0, envoyReadinessPort: 0},
+		},
+		{
+			name:        "host network",
+			pod:         &v1alpha1.KubePod{HostNetwork: pointer.Bool(true)},
+			hostNetwork: true,
+			dnsPolicy:   corev1.DNSClusterFirstWithHostNet,
+			expectedPorts: map[int32]int32{
+				envoyHTTPPort:      envoyHTTPPort,
+				envoyHTTPSPort:     envoyHTTPSPort,
+				envoyReadinessPort: envoyReadinessPort,
+				10080:              10080,
+			},
+		},
+		{
+			name:      "host ports",
+			pod:       &v1alpha1.KubePod{HostPorts: pointer.Bool(true)},
+			dnsPolicy: corev1.DNSClusterFirst,
+			expectedPorts: map[int32]int32{
+				envoyHTTPPort:      0,
+				envoyHTTPSPort:     0,
+				envoyReadinessPort: 0,
+				10080:              80,
+			},
+		},
+		{
+			name:      "host network and host ports",
+			pod:       &v1alpha1.KubePod{HostNetwork: pointer.Bool(true), HostPorts: pointer.Bool(true)},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+			infra := ir.NewInfra()
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+			infra.Proxy.Listeners = []ir.ProxyListener{
+				{
+					Ports: []ir.ListenerPort{
+						{Name: "EnvoyHTTPPort", Protocol: ir.HTTPProtocolType, ServicePort: 80, ContainerPort: 10080},
+					},
+				},
+			}
+			infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+				Deployment: &v1alpha1.KubeDeployment{
+					Pod: tc.pod,
+				},
+			})
+
+			deploy, err := kube.expectedDeployment(infra)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			podSpec := deploy.Spec.Template.Spec
+			assert.Equal(t, tc.hostNetwork, podSpec.HostNetwork)
+			assert.Equal(t, tc.dnsPolicy, podSpec.DNSPolicy)
+
+			ports := map[int32]int32{}
+			for _, port := range podSpec.Containers[0].Ports {
+				ports[port.ContainerPort] = port.HostPort
+			}
+			assert.Equal(t, tc.expectedPorts, ports)
+		})
+	}
+}
+
 func TestCreateOrUpdateDeployment(t *testing.T) {
 	kube := NewInfra(nil)
 	infra := ir.NewInfra()
@@ -167,6 +675,16 @@ func TestCreateOrUpdateDeployment(t *testing.T) {
 	deploy, err := kube.expectedDeployment(infra)
 	require.NoError(t, err)
 
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+
 	testCases := []struct {
 		name    string
 		in      *ir.Infra
@@ -202,6 +720,31 @@ func TestCreateOrUpdateDeployment(t *testing.T) {
 			current: deploy,
 			want:    deploymentWithImage(deploy, "envoyproxy/gateway-dev:v1.2.3"),
 		},
+		{
+			name: "update deployment resources",
+			in: &ir.Infra{
+				Proxy: &ir.ProxyInfra{
+					Metadata: &ir.InfraMetadata{
+						Labels: map[string]string{
+							gatewayapi.OwningGatewayNamespaceLabel: "default",
+							gatewayapi.OwningGatewayNameLabel:      infra.Proxy.Name,
+						},
+					},
+					Name: ir.DefaultProxyName,
+					Config: newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+						Deployment: &v1alpha1.KubeDeployment{
+							Container: &v1alpha1.KubeContainer{
+								Resources: &resources,
+							},
+						},
+					}),
+					Image:     ir.DefaultProxyImage,
+					Listeners: ir.NewProxyListeners(),
+				},
+			},
+			current: deploy,
+			want:    deploymentWithResources(deploy, resources),
+		},
 	}
 
 	for _, tc := range testCases {
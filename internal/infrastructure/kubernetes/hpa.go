@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+func expectedHPAName(proxyName string) string {
+	hpaName := utils.GetHashedName(proxyName)
+	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, hpaName)
+}
+
+// hpaEnabled returns true if the provided infra requires a HorizontalPodAutoscaler
+// for the Envoy Deployment.
+func hpaEnabled(infra *ir.Infra) bool {
+	kp := infra.GetProxyInfra().Config.GetKubeProvider()
+	return kp.EnvoyHPA != nil && *kp.WorkloadType == v1alpha1.KubeWorkloadTypeDeployment
+}
+
+// expectedHPA returns the expected HorizontalPodAutoscaler based on the provided infra.
+func (i *Infra) expectedHPA(infra *ir.Infra) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	hpaCfg := infra.GetProxyInfra().Config.GetKubeProvider().EnvoyHPA
+	if hpaCfg == nil {
+		return nil, fmt.Errorf("missing horizontal pod autoscaler config")
+	}
+	if *hpaCfg.MinReplicas > hpaCfg.MaxReplicas {
+		return nil, fmt.Errorf("horizontal pod autoscaler minReplicas %d is greater than maxReplicas %d",
+			*hpaCfg.MinReplicas, hpaCfg.MaxReplicas)
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedHPAName(infra.Proxy.Name),
+			Labels:    labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       expectedDeploymentName(infra.Proxy.Name),
+			},
+			MinReplicas: hpaCfg.MinReplicas,
+			MaxReplicas: hpaCfg.MaxReplicas,
+			Metrics:     hpaCfg.Metrics,
+			Behavior:    hpaCfg.Behavior,
+		},
+	}
+
+	return hpa, nil
+}
+
+// createOrUpdateHPA creates a HorizontalPodAutoscaler in the kube api server based on the
+// provided infra, if it doesn't exist and updates it if it does.
+func (i *Infra) createOrUpdateHPA(ctx context.Context, infra *ir.Infra) error {
+	hpa, err := i.expectedHPA(infra)
+	if err != nil {
+		return err
+	}
+
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
+	}
+	hpa.OwnerReferences = owners
+
+	return i.createOrUpdate(ctx, hpa, &autoscalingv2.HorizontalPodAutoscaler{}, nil)
+}
+
+// deleteHPA deletes the Envoy HorizontalPodAutoscaler in the kube api server, if it exists.
+func (i *Infra) deleteHPA(ctx context.Context, infra *ir.Infra) error {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedHPAName(infra.Proxy.Name),
+		},
+	}
+
+	if err := i.Client.Delete(ctx, hpa); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete hpa %s/%s: %w", hpa.Namespace, hpa.Name, err)
+	}
+
+	return nil
+}
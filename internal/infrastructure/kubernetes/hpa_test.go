@@ -0,0 +1,125 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func hpaInfra(hpa *v1alpha1.KubeHorizontalPodAutoscaler) *ir.Infra {
+	return newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		EnvoyHPA: hpa,
+	})
+}
+
+func TestExpectedHPA(t *testing.T) {
+	memoryMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceMemory,
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: resource.NewQuantity(512*1024*1024, resource.BinarySI),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		hpa         *v1alpha1.KubeHorizontalPodAutoscaler
+		minReplicas int32
+		metrics     []autoscalingv2.MetricSpec
+		expectErr   bool
+	}{
+		{
+			name:        "defaults",
+			hpa:         &v1alpha1.KubeHorizontalPodAutoscaler{MaxReplicas: 5},
+			minReplicas: 1,
+			metrics:     v1alpha1.DefaultKubeHPAMetrics(),
+		},
+		{
+			name: "custom metrics",
+			hpa: &v1alpha1.KubeHorizontalPodAutoscaler{
+				MinReplicas: pointer.Int32(2),
+				MaxReplicas: 10,
+				Metrics:     []autoscalingv2.MetricSpec{memoryMetric},
+			},
+			minReplicas: 2,
+			metrics:     []autoscalingv2.MetricSpec{memoryMetric},
+		},
+		{
+			name: "min replicas greater than max replicas",
+			hpa: &v1alpha1.KubeHorizontalPodAutoscaler{
+				MinReplicas: pointer.Int32(3),
+				MaxReplicas: 2,
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+			infra := hpaInfra(tc.hpa)
+
+			hpa, err := kube.expectedHPA(infra)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, expectedDeploymentName(infra.Proxy.Name), hpa.Spec.ScaleTargetRef.Name)
+			assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+			assert.Equal(t, tc.minReplicas, *hpa.Spec.MinReplicas)
+			assert.Equal(t, tc.hpa.MaxReplicas, hpa.Spec.MaxReplicas)
+			assert.Equal(t, tc.metrics, hpa.Spec.Metrics)
+		})
+	}
+}
+
+func TestCreateOrUpdateInfraHPA(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := hpaInfra(&v1alpha1.KubeHorizontalPodAutoscaler{MaxReplicas: 5})
+
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	hpaKey := client.ObjectKey{Namespace: kube.Namespace, Name: expectedHPAName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), hpaKey, &autoscalingv2.HorizontalPodAutoscaler{}))
+
+	// The Deployment replica count is left to the autoscaler.
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	assert.Nil(t, deploy.Spec.Replicas)
+
+	// Removing the autoscaler config deletes the HorizontalPodAutoscaler.
+	infra.Proxy.Config = nil
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	err = kube.Client.Get(context.Background(), hpaKey, &autoscalingv2.HorizontalPodAutoscaler{})
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func TestDeleteHPA(t *testing.T) {
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+	infra := ir.NewInfra()
+	err := kube.deleteHPA(context.Background(), infra)
+	require.NoError(t, err)
+}
@@ -5,12 +5,22 @@ import (
 	"errors"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils/env"
 )
 
+var _ infrastructure.Manager = (*Infra)(nil)
+
+func init() {
+	infrastructure.Register(v1alpha1.ProviderTypeKubernetes, newManager)
+}
+
 // Infra manages the creation and deletion of Kubernetes infrastructure
 // based on Infra IR resources.
 type Infra struct {
@@ -18,6 +28,10 @@ type Infra struct {
 
 	// Namespace is the Namespace used for managed infra.
 	Namespace string
+
+	// RateLimitEnabled is true if the global rate limit service is enabled, so
+	// that the managed Envoy pods must be allowed to reach it.
+	RateLimitEnabled bool
 }
 
 // NewInfra returns a new Infra.
@@ -28,6 +42,17 @@ func NewInfra(cli client.Client) *Infra {
 	}
 }
 
+// newManager returns a new Infra managing the infra with the kube api server.
+func newManager(cfg *config.Server) (infrastructure.Manager, error) {
+	cli, err := client.New(clicfg.GetConfigOrDie(), client.Options{Scheme: envoygateway.GetScheme()})
+	if err != nil {
+		return nil, err
+	}
+	infra := NewInfra(cli)
+	infra.RateLimitEnabled = cfg.EnvoyGateway.RateLimit != nil
+	return infra, nil
+}
+
 // CreateOrUpdateInfra creates the managed kube infra, if it doesn't exist.
 func (i *Infra) CreateOrUpdateInfra(ctx context.Context, infra *ir.Infra) error {
 	if infra == nil {
@@ -38,15 +63,31 @@ func (i *Infra) CreateOrUpdateInfra(ctx context.Context, infra *ir.Infra) error
 		return errors.New("infra proxy ir is nil")
 	}
 
+	if err := i.createNamespaceIfNotExists(ctx, infra); err != nil {
+		return err
+	}
+
+	if err := i.createOrUpdateCertsSecret(ctx, infra); err != nil {
+		return err
+	}
+
 	if err := i.createOrUpdateServiceAccount(ctx, infra); err != nil {
 		return err
 	}
 
+	if roleEnabled(infra) {
+		if err := i.createOrUpdateRole(ctx, infra); err != nil {
+			return err
+		}
+	} else if err := i.deleteRole(ctx, infra); err != nil {
+		return err
+	}
+
 	if _, err := i.createOrUpdateConfigMap(ctx, infra); err != nil {
 		return err
 	}
 
-	if err := i.createOrUpdateDeployment(ctx, infra); err != nil {
+	if err := i.createOrUpdateWorkload(ctx, infra); err != nil {
 		return err
 	}
 
@@ -54,6 +95,38 @@ func (i *Infra) CreateOrUpdateInfra(ctx context.Context, infra *ir.Infra) error
 		return err
 	}
 
+	if hpaEnabled(infra) {
+		if err := i.createOrUpdateHPA(ctx, infra); err != nil {
+			return err
+		}
+	} else if err := i.deleteHPA(ctx, infra); err != nil {
+		return err
+	}
+
+	if pdbEnabled(infra) {
+		if err := i.createOrUpdatePDB(ctx, infra); err != nil {
+			return err
+		}
+	} else if err := i.deletePDB(ctx, infra); err != nil {
+		return err
+	}
+
+	if networkPolicyEnabled(infra) {
+		if err := i.createOrUpdateNetworkPolicy(ctx, infra); err != nil {
+			return err
+		}
+	} else if err := i.deleteNetworkPolicy(ctx, infra); err != nil {
+		return err
+	}
+
+	if prometheusEnabled(infra) {
+		if err := i.createOrUpdatePodMonitor(ctx, infra); err != nil {
+			return err
+		}
+	} else if err := i.deletePodMonitor(ctx, infra); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -63,6 +136,22 @@ func (i *Infra) DeleteInfra(ctx context.Context, infra *ir.Infra) error {
 		return errors.New("infra ir is nil")
 	}
 
+	if err := i.deleteHPA(ctx, infra); err != nil {
+		return err
+	}
+
+	if err := i.deletePDB(ctx, infra); err != nil {
+		return err
+	}
+
+	if err := i.deleteNetworkPolicy(ctx, infra); err != nil {
+		return err
+	}
+
+	if err := i.deletePodMonitor(ctx, infra); err != nil {
+		return err
+	}
+
 	if err := i.deleteService(ctx, infra); err != nil {
 		return err
 	}
@@ -71,13 +160,39 @@ func (i *Infra) DeleteInfra(ctx context.Context, infra *ir.Infra) error {
 		return err
 	}
 
+	if err := i.deleteDaemonSet(ctx, infra); err != nil {
+		return err
+	}
+
 	if err := i.deleteConfigMap(ctx, infra); err != nil {
 		return err
 	}
 
+	if err := i.deleteRole(ctx, infra); err != nil {
+		return err
+	}
+
 	if err := i.deleteServiceAccount(ctx, infra); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// createOrUpdateWorkload creates or updates the workload resource running Envoy
+// based on the workload type of the provided infra, and removes the workload
+// resource of the other type, if it exists.
+func (i *Infra) createOrUpdateWorkload(ctx context.Context, infra *ir.Infra) error {
+	switch *infra.GetProxyInfra().Config.GetKubeProvider().WorkloadType {
+	case v1alpha1.KubeWorkloadTypeDaemonSet:
+		if err := i.deleteDeployment(ctx, infra); err != nil {
+			return err
+		}
+		return i.createOrUpdateDaemonSet(ctx, infra)
+	default:
+		if err := i.deleteDaemonSet(ctx, infra); err != nil {
+			return err
+		}
+		return i.createOrUpdateDeployment(ctx, infra)
+	}
+}
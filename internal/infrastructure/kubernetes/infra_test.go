@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -19,7 +20,7 @@ import (
 func TestCreateInfra(t *testing.T) {
 	// Infra with Gateway owner labels.
 	infraWithLabels := ir.NewInfra()
-	infraWithLabels.GetProxyInfra().GetProxyMetadata().Labels = envoyAppLabel()
+	infraWithLabels.GetProxyInfra().GetProxyMetadata().Labels = EnvoyAppLabel()
 	infraWithLabels.GetProxyInfra().GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
 	infraWithLabels.GetProxyInfra().GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = "test-gw"
 
@@ -138,3 +139,26 @@ func TestDeleteInfra(t *testing.T) {
 		})
 	}
 }
+
+// newTestInfraWithKubeProvider returns an infra owned by a Gateway, configured
+// with the provided Kubernetes provider.
+func newTestInfraWithKubeProvider(provider *v1alpha1.ProxyKubeProvider) *ir.Infra {
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(provider)
+	return infra
+}
+
+// newTestEnvoyProxyWithKubeProvider returns an EnvoyProxy configured with the
+// provided Kubernetes provider.
+func newTestEnvoyProxyWithKubeProvider(provider *v1alpha1.ProxyKubeProvider) *v1alpha1.EnvoyProxy {
+	return &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			Provider: &v1alpha1.ProxyProvider{
+				Type:       v1alpha1.ProviderTypeKubernetes,
+				Kubernetes: provider,
+			},
+		},
+	}
+}
@@ -4,8 +4,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// envoyAppLabel returns the labels used for all Envoy resources.
-func envoyAppLabel() map[string]string {
+// EnvoyAppLabel returns the labels of all the Envoy resources, e.g. to select
+// the pods of the managed proxies.
+func EnvoyAppLabel() map[string]string {
 	return map[string]string{
 		"app.gateway.envoyproxy.io/name": "envoy",
 	}
@@ -21,10 +22,25 @@ func envoySelector(extraLbls map[string]string) *metav1.LabelSelector {
 
 // envoyLabels returns the labels, including extraLbls, used for Envoy resources.
 func envoyLabels(extraLbls map[string]string) map[string]string {
-	lbls := envoyAppLabel()
+	lbls := EnvoyAppLabel()
 	for k, v := range extraLbls {
 		lbls[k] = v
 	}
 
 	return lbls
 }
+
+// mergeLabels returns the labels of lbls merged with userLbls. Labels of lbls
+// are managed by Envoy Gateway and take precedence over user-provided labels
+// with the same key.
+func mergeLabels(lbls, userLbls map[string]string) map[string]string {
+	merged := make(map[string]string, len(lbls)+len(userLbls))
+	for k, v := range userLbls {
+		merged[k] = v
+	}
+	for k, v := range lbls {
+		merged[k] = v
+	}
+
+	return merged
+}
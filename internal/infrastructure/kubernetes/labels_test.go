@@ -30,3 +30,41 @@ func TestEnvoyPodSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeLabels(t *testing.T) {
+	cases := []struct {
+		name     string
+		lbls     map[string]string
+		userLbls map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "no user labels",
+			lbls:     map[string]string{"app.gateway.envoyproxy.io/name": "envoy"},
+			expected: map[string]string{"app.gateway.envoyproxy.io/name": "envoy"},
+		},
+		{
+			name:     "user labels added",
+			lbls:     map[string]string{"app.gateway.envoyproxy.io/name": "envoy"},
+			userLbls: map[string]string{"team": "edge"},
+			expected: map[string]string{
+				"app.gateway.envoyproxy.io/name": "envoy",
+				"team":                           "edge",
+			},
+		},
+		{
+			name:     "managed labels take precedence",
+			lbls:     map[string]string{"app.gateway.envoyproxy.io/name": "envoy"},
+			userLbls: map[string]string{"app.gateway.envoyproxy.io/name": "other"},
+			expected: map[string]string{"app.gateway.envoyproxy.io/name": "envoy"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := mergeLabels(tc.lbls, tc.userLbls)
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// RBAC for creating the namespace of the managed Envoy resources.
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=create
+
+const (
+	// envoyCertsSecretName is the name of the Secret containing the certificates
+	// used by Envoy to connect to the xDS server.
+	envoyCertsSecretName = "envoy"
+)
+
+// proxyNamespace returns the namespace of the managed Envoy resources of the
+// provided infra, defaulting to the namespace of Envoy Gateway.
+func (i *Infra) proxyNamespace(infra *ir.Infra) string {
+	if ns := infra.GetProxyInfra().Config.GetKubeProvider().Namespace; ns != nil {
+		return *ns
+	}
+	return i.Namespace
+}
+
+// expectedXdsServerHost returns the DNS name of the xDS server used by the Envoy
+// pods of the provided infra. The namespace qualified name of the Envoy Gateway
+// Service is used when the pods run in a different namespace.
+func (i *Infra) expectedXdsServerHost(infra *ir.Infra) string {
+	if i.proxyNamespace(infra) == i.Namespace {
+		return envoyGatewayXdsServerHost
+	}
+	return fmt.Sprintf("%s.%s.svc", envoyGatewayXdsServerHost, i.Namespace)
+}
+
+// createNamespaceIfNotExists creates the namespace of the managed Envoy resources
+// of the provided infra, if it doesn't exist.
+func (i *Infra) createNamespaceIfNotExists(ctx context.Context, infra *ir.Infra) error {
+	name := i.proxyNamespace(infra)
+	if name == i.Namespace {
+		return nil
+	}
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+
+	if err := i.Client.Get(ctx, client.ObjectKeyFromObject(ns), &corev1.Namespace{}); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get namespace %s: %w", name, err)
+		}
+		if err := i.Client.Create(ctx, ns); err != nil && !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// createOrUpdateCertsSecret copies the Envoy certificates Secret from the namespace
+// of Envoy Gateway to the namespace of the managed Envoy resources of the provided
+// infra, since pods can't mount Secrets of other namespaces. The Secret is shared
+// by all Envoy pods of the namespace, so it's not removed with the infra. The copy
+// is labeled so that certgen updates it when the certificates are rotated.
+func (i *Infra) createOrUpdateCertsSecret(ctx context.Context, infra *ir.Infra) error {
+	ns := i.proxyNamespace(infra)
+	if ns == i.Namespace {
+		return nil
+	}
+
+	key := client.ObjectKey{Namespace: i.Namespace, Name: envoyCertsSecretName}
+	src := &corev1.Secret{}
+	if err := i.Client.Get(ctx, key, src); err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", key, err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      envoyCertsSecretName,
+			Labels: map[string]string{
+				config.EnvoyCertsCopyLabel: "true",
+			},
+		},
+		Type: src.Type,
+		Data: src.Data,
+	}
+
+	return i.createOrUpdate(ctx, secret, &corev1.Secret{}, nil)
+}
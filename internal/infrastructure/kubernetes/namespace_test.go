@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func namespaceInfra(ns *string) *ir.Infra {
+	return newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Namespace: ns,
+	})
+}
+
+func TestProxyNamespace(t *testing.T) {
+	testCases := []struct {
+		name         string
+		namespace    *string
+		expectedNs   string
+		expectedHost string
+	}{
+		{
+			name:         "default",
+			expectedNs:   "envoy-gateway-system",
+			expectedHost: "envoy-gateway",
+		},
+		{
+			name:         "same namespace",
+			namespace:    pointer.String("envoy-gateway-system"),
+			expectedNs:   "envoy-gateway-system",
+			expectedHost: "envoy-gateway",
+		},
+		{
+			name:         "other namespace",
+			namespace:    pointer.String("edge"),
+			expectedNs:   "edge",
+			expectedHost: "envoy-gateway.envoy-gateway-system.svc",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := &Infra{Namespace: "envoy-gateway-system"}
+			infra := namespaceInfra(tc.namespace)
+
+			assert.Equal(t, tc.expectedNs, kube.proxyNamespace(infra))
+			assert.Equal(t, tc.expectedHost, kube.expectedXdsServerHost(infra))
+		})
+	}
+}
+
+func TestCreateOrUpdateInfraNamespace(t *testing.T) {
+	certs := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "envoy-gateway-system",
+			Name:      envoyCertsSecretName,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{"tls.crt": []byte("cert")},
+	}
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(certs).Build(),
+		Namespace: "envoy-gateway-system",
+	}
+	infra := namespaceInfra(pointer.String("edge"))
+
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	// The namespace is created.
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKey{Name: "edge"}, &corev1.Namespace{}))
+
+	// The certificates are copied to the namespace.
+	secret := &corev1.Secret{}
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKey{Namespace: "edge", Name: envoyCertsSecretName}, secret))
+	assert.Equal(t, certs.Type, secret.Type)
+	assert.Equal(t, certs.Data, secret.Data)
+	assert.Equal(t, "true", secret.Labels[config.EnvoyCertsCopyLabel])
+
+	// The Envoy resources are created in the namespace.
+	deployKey := client.ObjectKey{Namespace: "edge", Name: expectedDeploymentName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), deployKey, &appsv1.Deployment{}))
+	svcKey := client.ObjectKey{Namespace: "edge", Name: expectedServiceName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), svcKey, &corev1.Service{}))
+
+	require.NoError(t, kube.DeleteInfra(context.Background(), infra))
+	require.Error(t, kube.Client.Get(context.Background(), deployKey, &appsv1.Deployment{}))
+}
+
+func TestCreateOrUpdateInfraNamespaceMissingCerts(t *testing.T) {
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "envoy-gateway-system",
+	}
+	infra := namespaceInfra(pointer.String("edge"))
+
+	require.Error(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+}
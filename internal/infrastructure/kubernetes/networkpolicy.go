@@ -0,0 +1,174 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+)
+
+const (
+	// dnsPort is the port of the cluster DNS service.
+	dnsPort = 53
+)
+
+// envoyGatewayPodLabels returns the labels of the Envoy Gateway pods.
+func envoyGatewayPodLabels() map[string]string {
+	return map[string]string{"control-plane": "envoy-gateway"}
+}
+
+func expectedNetworkPolicyName(proxyName string) string {
+	npName := utils.GetHashedName(proxyName)
+	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, npName)
+}
+
+// networkPolicyEnabled returns true if the provided infra requires a NetworkPolicy
+// for the Envoy pods.
+func networkPolicyEnabled(infra *ir.Infra) bool {
+	return infra.GetProxyInfra().Config.GetKubeProvider().NetworkPolicy != nil
+}
+
+// expectedNetworkPolicy returns the expected NetworkPolicy based on the provided infra.
+func (i *Infra) expectedNetworkPolicy(infra *ir.Infra) (*networkingv1.NetworkPolicy, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	npCfg := infra.GetProxyInfra().Config.GetKubeProvider().NetworkPolicy
+	if npCfg == nil {
+		return nil, fmt.Errorf("missing network policy config")
+	}
+
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+
+	// Allow ingress to the listener, readiness and metrics ports.
+	var ingressPorts []networkingv1.NetworkPolicyPort
+	for _, listener := range infra.Proxy.Listeners {
+		for _, port := range listener.Ports {
+			target := intstr.FromInt(int(port.ContainerPort))
+			protocol := expectedPortProtocol(port)
+			ingressPorts = append(ingressPorts, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &target})
+		}
+	}
+	readinessPort := intstr.FromInt(int(envoyReadinessPort))
+	ingressPorts = append(ingressPorts, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &readinessPort})
+	if port := metricsPort(infra); port != envoyReadinessPort {
+		metricsPort := intstr.FromInt(int(port))
+		ingressPorts = append(ingressPorts, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &metricsPort})
+	}
+
+	// Allow egress to the Envoy Gateway xDS server and DNS.
+	xdsPort := intstr.FromInt(xdsrunner.XdsServerPort)
+	dns := intstr.FromInt(dnsPort)
+	egress := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{MatchLabels: envoyGatewayPodLabels()},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{corev1.LabelMetadataName: i.Namespace},
+					},
+				},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &xdsPort}},
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &dns},
+				{Protocol: &tcp, Port: &dns},
+			},
+		},
+	}
+	// Allow egress to the global rate limit service, if enabled.
+	if i.RateLimitEnabled {
+		rateLimitPort := intstr.FromInt(int(RateLimitGRPCPort))
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{MatchLabels: rateLimitLabels()},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{corev1.LabelMetadataName: i.Namespace},
+					},
+				},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &rateLimitPort}},
+		})
+	}
+	egress = append(egress, npCfg.Egress...)
+
+	np := &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedNetworkPolicyName(infra.Proxy.Name),
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: *envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels),
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: ingressPorts,
+				},
+			},
+			Egress: egress,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+		},
+	}
+
+	return np, nil
+}
+
+// createOrUpdateNetworkPolicy creates a NetworkPolicy in the kube api server based on the
+// provided infra, if it doesn't exist and updates it if it does.
+func (i *Infra) createOrUpdateNetworkPolicy(ctx context.Context, infra *ir.Infra) error {
+	np, err := i.expectedNetworkPolicy(infra)
+	if err != nil {
+		return err
+	}
+
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
+	}
+	np.OwnerReferences = owners
+
+	return i.createOrUpdate(ctx, np, &networkingv1.NetworkPolicy{}, nil)
+}
+
+// deleteNetworkPolicy deletes the Envoy NetworkPolicy in the kube api server, if it exists.
+func (i *Infra) deleteNetworkPolicy(ctx context.Context, infra *ir.Infra) error {
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedNetworkPolicyName(infra.Proxy.Name),
+		},
+	}
+
+	if err := i.Client.Delete(ctx, np); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete networkpolicy %s/%s: %w", np.Namespace, np.Name, err)
+	}
+
+	return nil
+}
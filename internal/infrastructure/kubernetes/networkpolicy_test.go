@@ -0,0 +1,123 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+)
+
+func networkPolicyInfra(np *v1alpha1.KubeNetworkPolicy) *ir.Infra {
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	infra.Proxy.Listeners = []ir.ProxyListener{
+		{
+			Ports: []ir.ListenerPort{
+				{Name: "EnvoyHTTPPort", Protocol: ir.HTTPProtocolType, ServicePort: 80, ContainerPort: 10080},
+			},
+		},
+	}
+	infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		NetworkPolicy: np,
+	})
+
+	return infra
+}
+
+func TestExpectedNetworkPolicy(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	backendPort := intstr.FromInt(8080)
+	backendRule := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &backendPort}},
+	}
+
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := networkPolicyInfra(&v1alpha1.KubeNetworkPolicy{
+		Egress: []networkingv1.NetworkPolicyEgressRule{backendRule},
+	})
+
+	np, err := kube.expectedNetworkPolicy(infra)
+	require.NoError(t, err)
+
+	assert.Equal(t, *envoySelector(infra.Proxy.GetProxyMetadata().Labels), np.Spec.PodSelector)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, np.Spec.PolicyTypes)
+
+	// Ingress is only allowed to the listener and readiness ports.
+	require.Len(t, np.Spec.Ingress, 1)
+	var ingressPorts []int
+	for _, port := range np.Spec.Ingress[0].Ports {
+		ingressPorts = append(ingressPorts, port.Port.IntValue())
+	}
+	assert.Equal(t, []int{10080, int(envoyReadinessPort)}, ingressPorts)
+
+	// Egress is allowed to the xDS server, DNS and the user-provided rules.
+	require.Len(t, np.Spec.Egress, 3)
+	assert.Equal(t, envoyGatewayPodLabels(), np.Spec.Egress[0].To[0].PodSelector.MatchLabels)
+	assert.Equal(t, xdsrunner.XdsServerPort, np.Spec.Egress[0].Ports[0].Port.IntValue())
+	assert.Equal(t, dnsPort, np.Spec.Egress[1].Ports[0].Port.IntValue())
+	assert.Equal(t, backendRule, np.Spec.Egress[2])
+}
+
+func TestExpectedNetworkPolicyRateLimit(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	kube.RateLimitEnabled = true
+	infra := networkPolicyInfra(&v1alpha1.KubeNetworkPolicy{})
+
+	np, err := kube.expectedNetworkPolicy(infra)
+	require.NoError(t, err)
+
+	// Egress is allowed to the rate limit service after the xDS server and DNS.
+	require.Len(t, np.Spec.Egress, 3)
+	assert.Equal(t, rateLimitLabels(), np.Spec.Egress[2].To[0].PodSelector.MatchLabels)
+	assert.Equal(t, int(RateLimitGRPCPort), np.Spec.Egress[2].Ports[0].Port.IntValue())
+}
+
+func TestExpectedNetworkPolicyMissingLabels(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := networkPolicyInfra(&v1alpha1.KubeNetworkPolicy{})
+	delete(infra.Proxy.GetProxyMetadata().Labels, gatewayapi.OwningGatewayNameLabel)
+
+	_, err := kube.expectedNetworkPolicy(infra)
+	require.Error(t, err)
+}
+
+func TestCreateOrUpdateInfraNetworkPolicy(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := networkPolicyInfra(&v1alpha1.KubeNetworkPolicy{})
+
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	npKey := client.ObjectKey{Namespace: kube.Namespace, Name: expectedNetworkPolicyName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), npKey, &networkingv1.NetworkPolicy{}))
+
+	// Removing the network policy config deletes the NetworkPolicy.
+	infra.Proxy.Config = nil
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	err := kube.Client.Get(context.Background(), npKey, &networkingv1.NetworkPolicy{})
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func TestDeleteNetworkPolicy(t *testing.T) {
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+	infra := ir.NewInfra()
+	err := kube.deleteNetworkPolicy(context.Background(), infra)
+	require.NoError(t, err)
+}
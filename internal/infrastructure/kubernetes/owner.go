@@ -0,0 +1,53 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// expectedOwnerReferences returns the owner references of the managed infra resources
+// based on the provided infra. Managed infra resources are owned by the GatewayClass of
+// the owning Gateway, so Kubernetes garbage collects them when the GatewayClass is
+// deleted. The owning Gateway itself cannot be used as owner since it typically resides
+// in a different namespace than the managed infra. No owner references are returned if
+// the owning Gateway or its GatewayClass does not exist.
+func (i *Infra) expectedOwnerReferences(ctx context.Context, infra *ir.Infra) ([]metav1.OwnerReference, error) {
+	labels := infra.GetProxyInfra().GetProxyMetadata().Labels
+	gwKey := types.NamespacedName{
+		Namespace: labels[gatewayapi.OwningGatewayNamespaceLabel],
+		Name:      labels[gatewayapi.OwningGatewayNameLabel],
+	}
+
+	gw := &gwapiv1b1.Gateway{}
+	if err := i.Client.Get(ctx, gwKey, gw); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get gateway %s: %w", gwKey, err)
+	}
+
+	gc := &gwapiv1b1.GatewayClass{}
+	if err := i.Client.Get(ctx, types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get gatewayclass %s: %w", gw.Spec.GatewayClassName, err)
+	}
+
+	return []metav1.OwnerReference{
+		{
+			APIVersion: gwapiv1b1.GroupVersion.String(),
+			Kind:       "GatewayClass",
+			Name:       gc.Name,
+			UID:        gc.UID,
+		},
+	}, nil
+}
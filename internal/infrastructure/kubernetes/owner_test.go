@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestExpectedOwnerReferences(t *testing.T) {
+	gc := &gwapiv1b1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-gc",
+			UID:  "test-uid",
+		},
+	}
+	gw := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-gw",
+		},
+		Spec: gwapiv1b1.GatewaySpec{
+			GatewayClassName: "test-gc",
+		},
+	}
+
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = gw.Namespace
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = gw.Name
+
+	testCases := []struct {
+		name   string
+		objs   []client.Object
+		expect []metav1.OwnerReference
+	}{
+		{
+			name: "gateway and gatewayclass exist",
+			objs: []client.Object{gc, gw},
+			expect: []metav1.OwnerReference{
+				{
+					APIVersion: gwapiv1b1.GroupVersion.String(),
+					Kind:       "GatewayClass",
+					Name:       "test-gc",
+					UID:        "test-uid",
+				},
+			},
+		},
+		{
+			name: "gatewayclass does not exist",
+			objs: []client.Object{gw},
+		},
+		{
+			name: "gateway does not exist",
+			objs: []client.Object{gc},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := &Infra{
+				Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(tc.objs...).Build(),
+				Namespace: "envoy-gateway-system",
+			}
+
+			owners, err := kube.expectedOwnerReferences(context.Background(), infra)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, owners)
+
+			// All managed infra resources are owned by the GatewayClass.
+			require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+			objs := []client.Object{
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: expectedServiceAccountName(infra.Proxy.Name)}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: expectedConfigMapName(infra.Proxy.Name)}},
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: expectedDeploymentName(infra.Proxy.Name)}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: expectedServiceName(infra.Proxy.Name)}},
+			}
+			for _, obj := range objs {
+				obj.SetNamespace(kube.Namespace)
+				require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(obj), obj))
+				require.Equal(t, tc.expect, obj.GetOwnerReferences())
+			}
+		})
+	}
+}
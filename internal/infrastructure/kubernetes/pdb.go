@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+func expectedPDBName(proxyName string) string {
+	pdbName := utils.GetHashedName(proxyName)
+	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, pdbName)
+}
+
+// pdbEnabled returns true if the provided infra requires a PodDisruptionBudget
+// for the Envoy pods.
+func pdbEnabled(infra *ir.Infra) bool {
+	return infra.GetProxyInfra().Config.GetKubeProvider().EnvoyPDB != nil
+}
+
+// expectedPDB returns the expected PodDisruptionBudget based on the provided infra.
+func (i *Infra) expectedPDB(infra *ir.Infra) (*policyv1.PodDisruptionBudget, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	pdbCfg := infra.GetProxyInfra().Config.GetKubeProvider().EnvoyPDB
+	if pdbCfg == nil {
+		return nil, fmt.Errorf("missing pod disruption budget config")
+	}
+	if pdbCfg.MinAvailable != nil && pdbCfg.MaxUnavailable != nil {
+		return nil, fmt.Errorf("pod disruption budget minAvailable and maxUnavailable are mutually exclusive")
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedPDBName(infra.Proxy.Name),
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels),
+			MinAvailable:   pdbCfg.MinAvailable,
+			MaxUnavailable: pdbCfg.MaxUnavailable,
+		},
+	}
+
+	return pdb, nil
+}
+
+// createOrUpdatePDB creates a PodDisruptionBudget in the kube api server based on the
+// provided infra, if it doesn't exist and updates it if it does.
+func (i *Infra) createOrUpdatePDB(ctx context.Context, infra *ir.Infra) error {
+	pdb, err := i.expectedPDB(infra)
+	if err != nil {
+		return err
+	}
+
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
+	}
+	pdb.OwnerReferences = owners
+
+	return i.createOrUpdate(ctx, pdb, &policyv1.PodDisruptionBudget{}, nil)
+}
+
+// deletePDB deletes the Envoy PodDisruptionBudget in the kube api server, if it exists.
+func (i *Infra) deletePDB(ctx context.Context, infra *ir.Infra) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedPDBName(infra.Proxy.Name),
+		},
+	}
+
+	if err := i.Client.Delete(ctx, pdb); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete pdb %s/%s: %w", pdb.Namespace, pdb.Name, err)
+	}
+
+	return nil
+}
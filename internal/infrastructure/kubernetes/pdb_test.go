@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func pdbInfra(pdb *v1alpha1.KubePodDisruptionBudget) *ir.Infra {
+	return newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		EnvoyPDB: pdb,
+	})
+}
+
+func TestExpectedPDB(t *testing.T) {
+	one := intstr.FromInt(1)
+	half := intstr.FromString("50%")
+
+	testCases := []struct {
+		name           string
+		pdb            *v1alpha1.KubePodDisruptionBudget
+		minAvailable   *intstr.IntOrString
+		maxUnavailable *intstr.IntOrString
+		expectErr      bool
+	}{
+		{
+			name:         "defaults",
+			pdb:          &v1alpha1.KubePodDisruptionBudget{},
+			minAvailable: &one,
+		},
+		{
+			name:           "max unavailable",
+			pdb:            &v1alpha1.KubePodDisruptionBudget{MaxUnavailable: &half},
+			maxUnavailable: &half,
+		},
+		{
+			name:      "min available and max unavailable",
+			pdb:       &v1alpha1.KubePodDisruptionBudget{MinAvailable: &one, MaxUnavailable: &half},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+			infra := pdbInfra(tc.pdb)
+
+			pdb, err := kube.expectedPDB(infra)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, envoySelector(infra.Proxy.GetProxyMetadata().Labels), pdb.Spec.Selector)
+			assert.Equal(t, tc.minAvailable, pdb.Spec.MinAvailable)
+			assert.Equal(t, tc.maxUnavailable, pdb.Spec.MaxUnavailable)
+		})
+	}
+}
+
+func TestCreateOrUpdateInfraPDB(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := pdbInfra(&v1alpha1.KubePodDisruptionBudget{})
+
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	pdbKey := client.ObjectKey{Namespace: kube.Namespace, Name: expectedPDBName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), pdbKey, &policyv1.PodDisruptionBudget{}))
+
+	// Removing the disruption budget config deletes the PodDisruptionBudget.
+	infra.Proxy.Config = nil
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	err := kube.Client.Get(context.Background(), pdbKey, &policyv1.PodDisruptionBudget{})
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func TestDeletePDB(t *testing.T) {
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+	infra := ir.NewInfra()
+	err := kube.deletePDB(context.Background(), infra)
+	require.NoError(t, err)
+}
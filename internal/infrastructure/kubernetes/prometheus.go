@@ -0,0 +1,146 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+// podMonitorGVK is the GroupVersionKind of the Prometheus Operator PodMonitor.
+// A PodMonitor is used instead of a ServiceMonitor since the metrics port is
+// not exposed by the Envoy Service.
+var podMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PodMonitor",
+}
+
+func expectedPodMonitorName(proxyName string) string {
+	pmName := utils.GetHashedName(proxyName)
+	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, pmName)
+}
+
+// prometheusEnabled returns true if the provided infra requires Prometheus metrics
+// scraping of the Envoy pods.
+func prometheusEnabled(infra *ir.Infra) bool {
+	return infra.GetProxyInfra().Config.GetKubeProvider().Prometheus != nil
+}
+
+// podMonitorSupported returns true if the Prometheus Operator PodMonitor CRD is
+// installed in the cluster.
+func (i *Infra) podMonitorSupported() bool {
+	_, err := i.Client.RESTMapper().RESTMapping(podMonitorGVK.GroupKind(), podMonitorGVK.Version)
+	return err == nil
+}
+
+// metricsPort returns the container port serving the Envoy Prometheus metrics
+// endpoint of the provided infra.
+func metricsPort(infra *ir.Infra) int32 {
+	if metrics := infra.GetProxyInfra().Config.GetMetrics(); metrics != nil && metrics.Prometheus != nil {
+		return metrics.Prometheus.Port
+	}
+	return envoyReadinessPort
+}
+
+// prometheusScrapeAnnotations returns the "prometheus.io" scrape annotations of
+// the Envoy pods of the provided infra.
+func prometheusScrapeAnnotations(infra *ir.Infra) map[string]string {
+	return map[string]string{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/port":   strconv.Itoa(int(metricsPort(infra))),
+		"prometheus.io/path":   envoyMetricsPath,
+	}
+}
+
+// expectedPodMonitor returns the expected PodMonitor based on the provided infra.
+func (i *Infra) expectedPodMonitor(infra *ir.Infra) (*unstructured.Unstructured, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	promCfg := infra.GetProxyInfra().Config.GetKubeProvider().Prometheus
+	if promCfg == nil {
+		return nil, fmt.Errorf("missing prometheus config")
+	}
+
+	endpoint := map[string]interface{}{
+		"port": envoyMetricsPortName,
+		"path": envoyMetricsPath,
+	}
+	if promCfg.Interval != nil {
+		endpoint["interval"] = *promCfg.Interval
+	}
+
+	matchLabels := map[string]interface{}{}
+	for k, v := range envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels).MatchLabels {
+		matchLabels[k] = v
+	}
+
+	pm := &unstructured.Unstructured{}
+	pm.SetGroupVersionKind(podMonitorGVK)
+	pm.SetNamespace(i.proxyNamespace(infra))
+	pm.SetName(expectedPodMonitorName(infra.Proxy.Name))
+	pm.SetLabels(mergeLabels(labels, promCfg.Labels))
+	pm.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"podMetricsEndpoints": []interface{}{endpoint},
+	}
+
+	return pm, nil
+}
+
+// createOrUpdatePodMonitor creates a PodMonitor in the kube api server based on the
+// provided infra, if it doesn't exist and updates it if it does. Nothing is created
+// if PodMonitors are not supported by the cluster.
+func (i *Infra) createOrUpdatePodMonitor(ctx context.Context, infra *ir.Infra) error {
+	if !i.podMonitorSupported() {
+		return nil
+	}
+
+	pm, err := i.expectedPodMonitor(infra)
+	if err != nil {
+		return err
+	}
+
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
+	}
+	pm.SetOwnerReferences(owners)
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(podMonitorGVK)
+
+	return i.createOrUpdate(ctx, pm, current, nil)
+}
+
+// deletePodMonitor deletes the Envoy PodMonitor in the kube api server, if it exists.
+func (i *Infra) deletePodMonitor(ctx context.Context, infra *ir.Infra) error {
+	pm := &unstructured.Unstructured{}
+	pm.SetGroupVersionKind(podMonitorGVK)
+	pm.SetNamespace(i.proxyNamespace(infra))
+	pm.SetName(expectedPodMonitorName(infra.Proxy.Name))
+
+	if err := i.Client.Delete(ctx, pm); err != nil {
+		if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete podmonitor %s/%s: %w", pm.GetNamespace(), pm.GetName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func prometheusInfra(prom *v1alpha1.KubePrometheus) *ir.Infra {
+	return newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		Prometheus: prom,
+	})
+}
+
+// podMonitorRESTMapper returns a RESTMapper of a cluster with the Prometheus
+// Operator CRDs installed.
+func podMonitorRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(podMonitorGVK, meta.RESTScopeNamespace)
+	return mapper
+}
+
+func TestExpectedPodMonitor(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := prometheusInfra(&v1alpha1.KubePrometheus{
+		Labels:   map[string]string{"release": "prometheus"},
+		Interval: pointer.String("30s"),
+	})
+
+	pm, err := kube.expectedPodMonitor(infra)
+	require.NoError(t, err)
+
+	assert.Equal(t, "prometheus", pm.GetLabels()["release"])
+	matchLabels, _, err := unstructured.NestedStringMap(pm.Object, "spec", "selector", "matchLabels")
+	require.NoError(t, err)
+	assert.Equal(t, envoySelector(infra.Proxy.GetProxyMetadata().Labels).MatchLabels, matchLabels)
+
+	endpoints, _, err := unstructured.NestedSlice(pm.Object, "spec", "podMetricsEndpoints")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"port":     envoyMetricsPortName,
+			"path":     envoyMetricsPath,
+			"interval": "30s",
+		},
+	}, endpoints)
+}
+
+func TestCreateOrUpdateInfraPodMonitor(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithRESTMapper(podMonitorRESTMapper()).Build()
+	kube := NewInfra(cli)
+	infra := prometheusInfra(&v1alpha1.KubePrometheus{})
+
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	pmKey := client.ObjectKey{Namespace: kube.Namespace, Name: expectedPodMonitorName(infra.Proxy.Name)}
+	pm := &unstructured.Unstructured{}
+	pm.SetGroupVersionKind(podMonitorGVK)
+	require.NoError(t, kube.Client.Get(context.Background(), pmKey, pm))
+
+	// The scrape annotations are not needed when a PodMonitor is used.
+	deploy := &appsv1.Deployment{}
+	deployKey := client.ObjectKey{Namespace: kube.Namespace, Name: expectedDeploymentName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), deployKey, deploy))
+	assert.NotContains(t, deploy.Spec.Template.Annotations, "prometheus.io/scrape")
+
+	// Removing the prometheus config deletes the PodMonitor.
+	infra.Proxy.Config = nil
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	err := kube.Client.Get(context.Background(), pmKey, pm)
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func TestExpectedDeploymentPrometheusAnnotations(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := prometheusInfra(&v1alpha1.KubePrometheus{})
+
+	// PodMonitors are not supported, so the pods are annotated instead.
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	for k, v := range prometheusScrapeAnnotations(infra) {
+		assert.Equal(t, v, deploy.Spec.Template.Annotations[k])
+	}
+
+	infra.Proxy.Config = nil
+	deploy, err = kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	assert.NotContains(t, deploy.Spec.Template.Annotations, "prometheus.io/scrape")
+}
+
+func TestExpectedDeploymentPrometheusPort(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := prometheusInfra(&v1alpha1.KubePrometheus{})
+	infra.Proxy.Config.Spec.Provider.Kubernetes.NetworkPolicy = &v1alpha1.KubeNetworkPolicy{}
+	infra.Proxy.Config.Spec.Telemetry = &v1alpha1.ProxyTelemetry{
+		Metrics: &v1alpha1.ProxyMetrics{Prometheus: &v1alpha1.ProxyPrometheus{Port: 19002}},
+	}
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	assert.Equal(t, "19002", deploy.Spec.Template.Annotations["prometheus.io/port"])
+	var found bool
+	for _, port := range deploy.Spec.Template.Spec.Containers[0].Ports {
+		if port.Name == envoyMetricsPortName {
+			found = true
+			assert.Equal(t, int32(19002), port.ContainerPort)
+		}
+	}
+	assert.True(t, found)
+
+	np, err := kube.expectedNetworkPolicy(infra)
+	require.NoError(t, err)
+	ports := np.Spec.Ingress[0].Ports
+	assert.Equal(t, 19002, ports[len(ports)-1].Port.IntValue())
+}
+
+func TestDeletePodMonitor(t *testing.T) {
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+	infra := ir.NewInfra()
+	err := kube.deletePodMonitor(context.Background(), infra)
+	require.NoError(t, err)
+}
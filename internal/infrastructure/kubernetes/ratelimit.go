@@ -0,0 +1,201 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// rateLimitName is the name of the managed global rate limit service resources.
+	rateLimitName = "envoy-ratelimit"
+	// rateLimitContainerName is the name of the rate limit service container.
+	rateLimitContainerName = "envoy-ratelimit"
+	// rateLimitImage is the container image of the rate limit service.
+	rateLimitImage = "docker.io/envoyproxy/ratelimit:master"
+	// RateLimitGRPCPort is the gRPC port of the rate limit service.
+	RateLimitGRPCPort = int32(8081)
+	// rateLimitHTTPPort is the HTTP port of the rate limit service, serving the
+	// health check endpoint.
+	rateLimitHTTPPort = int32(8080)
+	// rateLimitRuntimeRoot is the directory holding the runtime of the rate limit
+	// service. The configurations are read from the config directory of the
+	// rateLimitRuntimeSubdirectory of the runtime.
+	rateLimitRuntimeRoot         = "/data"
+	rateLimitRuntimeSubdirectory = "ratelimit"
+)
+
+// rateLimitLabels returns the labels of the rate limit service resources.
+func rateLimitLabels() map[string]string {
+	return map[string]string{
+		"app.gateway.envoyproxy.io/name": rateLimitName,
+	}
+}
+
+// RateLimitServiceHost returns the DNS name of the rate limit Service managed in the
+// provided namespace.
+func RateLimitServiceHost(namespace string) string {
+	return fmt.Sprintf("%s.%s.svc", rateLimitName, namespace)
+}
+
+// CreateOrUpdateRateLimitInfra creates the managed global rate limit service infra,
+// if it doesn't exist, and updates it if it does.
+func (i *Infra) CreateOrUpdateRateLimitInfra(ctx context.Context, infra *ir.RateLimitInfra) error {
+	if infra == nil {
+		return errors.New("rate limit infra ir is nil")
+	}
+
+	backend := infra.Backend
+	if backend == nil || backend.Type != v1alpha1.RateLimitDatabaseBackendTypeRedis ||
+		backend.Redis == nil || backend.Redis.URL == "" {
+		return errors.New("rate limit infra requires a redis database backend url")
+	}
+
+	if err := i.createOrUpdate(ctx, i.expectedRateLimitConfigMap(infra), &corev1.ConfigMap{}, nil); err != nil {
+		return err
+	}
+
+	if err := i.createOrUpdate(ctx, i.expectedRateLimitDeployment(infra), &appsv1.Deployment{}, nil); err != nil {
+		return err
+	}
+
+	svc := i.expectedRateLimitService()
+	current := &corev1.Service{}
+	return i.createOrUpdate(ctx, svc, current, func() {
+		mergeAllocatedServiceValues(svc, current)
+	})
+}
+
+// DeleteRateLimitInfra removes the managed global rate limit service infra, if it exists.
+func (i *Infra) DeleteRateLimitInfra(ctx context.Context) error {
+	meta := metav1.ObjectMeta{Namespace: i.Namespace, Name: rateLimitName}
+	for _, obj := range []client.Object{
+		&corev1.Service{ObjectMeta: meta},
+		&appsv1.Deployment{ObjectMeta: meta},
+		&corev1.ConfigMap{ObjectMeta: meta},
+	} {
+		if err := i.Client.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete rate limit infra %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// expectedRateLimitConfigMap returns the expected ConfigMap holding the configurations
+// of the rate limit service, a file per rate limit domain.
+func (i *Infra) expectedRateLimitConfigMap(infra *ir.RateLimitInfra) *corev1.ConfigMap {
+	data := make(map[string]string, len(infra.Configs))
+	for domain, cfg := range infra.Configs {
+		data[domain+".yaml"] = cfg
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      rateLimitName,
+			Labels:    rateLimitLabels(),
+		},
+		Data: data,
+	}
+}
+
+// expectedRateLimitDeployment returns the expected Deployment of the rate limit service.
+func (i *Infra) expectedRateLimitDeployment(infra *ir.RateLimitInfra) *appsv1.Deployment {
+	env := []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "REDIS_SOCKET_TYPE", Value: "tcp"},
+		{Name: "REDIS_URL", Value: infra.Backend.Redis.URL},
+		{Name: "RUNTIME_ROOT", Value: rateLimitRuntimeRoot},
+		{Name: "RUNTIME_SUBDIRECTORY", Value: rateLimitRuntimeSubdirectory},
+		// ConfigMap volumes are updated by swapping a symlink, so the runtime
+		// must ignore the hidden files of the volume and watch the subdirectory.
+		{Name: "RUNTIME_IGNOREDOTFILES", Value: "true"},
+		{Name: "RUNTIME_WATCH_ROOT", Value: "false"},
+		{Name: "USE_STATSD", Value: "false"},
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      rateLimitName,
+			Labels:    rateLimitLabels(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(1),
+			Selector: &metav1.LabelSelector{MatchLabels: rateLimitLabels()},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: rateLimitLabels(),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:            rateLimitContainerName,
+						Image:           rateLimitImage,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/bin/ratelimit"},
+						Env:             env,
+						Ports: []corev1.ContainerPort{
+							{Name: "grpc", ContainerPort: RateLimitGRPCPort, Protocol: corev1.ProtocolTCP},
+							{Name: "http", ContainerPort: rateLimitHTTPPort, Protocol: corev1.ProtocolTCP},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path:   "/healthcheck",
+									Port:   intstr.FromInt(int(rateLimitHTTPPort)),
+									Scheme: corev1.URISchemeHTTP,
+								},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "config",
+							MountPath: fmt.Sprintf("%s/%s/config", rateLimitRuntimeRoot, rateLimitRuntimeSubdirectory),
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: rateLimitName},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// expectedRateLimitService returns the expected Service of the rate limit service.
+func (i *Infra) expectedRateLimitService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      rateLimitName,
+			Labels:    rateLimitLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: rateLimitLabels(),
+			Ports: []corev1.ServicePort{{
+				Name:       "grpc",
+				Protocol:   corev1.ProtocolTCP,
+				Port:       RateLimitGRPCPort,
+				TargetPort: intstr.FromInt(int(RateLimitGRPCPort)),
+			}},
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func rateLimitInfra(configs map[string]string) *ir.RateLimitInfra {
+	return &ir.RateLimitInfra{
+		Backend: &v1alpha1.RateLimitDatabaseBackend{
+			Type:  v1alpha1.RateLimitDatabaseBackendTypeRedis,
+			Redis: &v1alpha1.RateLimitRedisSettings{URL: "redis.redis.svc:6379"},
+		},
+		Configs: configs,
+	}
+}
+
+func TestCreateOrUpdateRateLimitInfra(t *testing.T) {
+	ctx := context.Background()
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	key := client.ObjectKey{Namespace: kube.Namespace, Name: rateLimitName}
+
+	require.NoError(t, kube.CreateOrUpdateRateLimitInfra(ctx, rateLimitInfra(nil)))
+
+	deploy := &appsv1.Deployment{}
+	require.NoError(t, kube.Client.Get(ctx, key, deploy))
+	require.Len(t, deploy.Spec.Template.Spec.Containers, 1)
+	assert.Contains(t, deploy.Spec.Template.Spec.Containers[0].Env,
+		corev1.EnvVar{Name: "REDIS_URL", Value: "redis.redis.svc:6379"})
+
+	svc := &corev1.Service{}
+	require.NoError(t, kube.Client.Get(ctx, key, svc))
+	assert.Equal(t, RateLimitGRPCPort, svc.Spec.Ports[0].Port)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, kube.Client.Get(ctx, key, cm))
+	assert.Empty(t, cm.Data)
+
+	// Updating the configs updates the ConfigMap, a file per domain.
+	require.NoError(t, kube.CreateOrUpdateRateLimitInfra(ctx, rateLimitInfra(map[string]string{
+		"envoy-gateway-gateway-1-http": "domain: envoy-gateway-gateway-1-http\n",
+	})))
+	require.NoError(t, kube.Client.Get(ctx, key, cm))
+	assert.Equal(t, map[string]string{"envoy-gateway-gateway-1-http.yaml": "domain: envoy-gateway-gateway-1-http\n"}, cm.Data)
+}
+
+func TestCreateOrUpdateRateLimitInfraInvalid(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+
+	require.Error(t, kube.CreateOrUpdateRateLimitInfra(context.Background(), nil))
+	require.Error(t, kube.CreateOrUpdateRateLimitInfra(context.Background(), &ir.RateLimitInfra{
+		Backend: &v1alpha1.RateLimitDatabaseBackend{Type: v1alpha1.RateLimitDatabaseBackendTypeRedis},
+	}))
+}
+
+func TestDeleteRateLimitInfra(t *testing.T) {
+	ctx := context.Background()
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+
+	// Deleting infra that doesn't exist is not an error.
+	require.NoError(t, kube.DeleteRateLimitInfra(ctx))
+
+	require.NoError(t, kube.CreateOrUpdateRateLimitInfra(ctx, rateLimitInfra(nil)))
+	require.NoError(t, kube.DeleteRateLimitInfra(ctx))
+
+	key := client.ObjectKey{Namespace: kube.Namespace, Name: rateLimitName}
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.ConfigMap{}} {
+		err := kube.Client.Get(ctx, key, obj)
+		require.True(t, kerrors.IsNotFound(err))
+	}
+}
@@ -0,0 +1,174 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+var (
+	// allowedRoleVerbs are the verbs the Role of the Envoy ServiceAccount may grant.
+	allowedRoleVerbs = sets.NewString("get", "list", "watch")
+	// allowedRoleResources are the core resources the Role of the Envoy ServiceAccount
+	// may grant access to. Envoy Gateway holds the read access to these resources in
+	// the proxy namespaces, and can't grant any other permission.
+	allowedRoleResources = sets.NewString("configmaps", "endpoints", "pods", "services")
+)
+
+func expectedRoleName(proxyName string) string {
+	roleName := utils.GetHashedName(proxyName)
+	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, roleName)
+}
+
+// roleEnabled returns true if the provided infra requires a Role and RoleBinding
+// for the Envoy ServiceAccount.
+func roleEnabled(infra *ir.Infra) bool {
+	return len(infra.GetProxyInfra().Config.GetKubeProvider().ServiceAccount.Rules) > 0
+}
+
+// expectedRole returns the expected Role of the Envoy ServiceAccount based on the
+// provided infra.
+func (i *Infra) expectedRole(infra *ir.Infra) (*rbacv1.Role, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	rules := infra.GetProxyInfra().Config.GetKubeProvider().ServiceAccount.Rules
+	if err := validateRoleRules(rules); err != nil {
+		return nil, err
+	}
+
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.proxyNamespace(infra),
+			Name:      expectedRoleName(infra.Proxy.Name),
+			Labels:    labels,
+		},
+		Rules: rules,
+	}, nil
+}
+
+// validateRoleRules returns an error if the provided rules grant anything but the
+// allowed verbs on the allowed core resources.
+func validateRoleRules(rules []rbacv1.PolicyRule) error {
+	for i, rule := range rules {
+		if len(rule.NonResourceURLs) > 0 {
+			return fmt.Errorf("serviceAccount rule %d: nonResourceURLs are not allowed", i)
+		}
+		for _, group := range rule.APIGroups {
+			if group != corev1.GroupName {
+				return fmt.Errorf("serviceAccount rule %d: apiGroup %q is not allowed, only the core group is", i, group)
+			}
+		}
+		for _, resource := range rule.Resources {
+			if !allowedRoleResources.Has(resource) {
+				return fmt.Errorf("serviceAccount rule %d: resource %q is not allowed, only %v are", i, resource, allowedRoleResources.List())
+			}
+		}
+		for _, verb := range rule.Verbs {
+			if !allowedRoleVerbs.Has(verb) {
+				return fmt.Errorf("serviceAccount rule %d: verb %q is not allowed, only %v are", i, verb, allowedRoleVerbs.List())
+			}
+		}
+	}
+	return nil
+}
+
+// expectedRoleBinding returns the expected RoleBinding binding the Envoy Role to the
+// Envoy ServiceAccount based on the provided infra.
+func (i *Infra) expectedRoleBinding(infra *ir.Infra) (*rbacv1.RoleBinding, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	ns := i.proxyNamespace(infra)
+
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      expectedRoleName(infra.Proxy.Name),
+			Labels:    labels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     expectedRoleName(infra.Proxy.Name),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: ns,
+				Name:      expectedServiceAccountName(infra.Proxy.Name),
+			},
+		},
+	}, nil
+}
+
+// createOrUpdateRole creates the Envoy Role and RoleBinding in the kube api server,
+// if they don't exist and updates them if they do.
+func (i *Infra) createOrUpdateRole(ctx context.Context, infra *ir.Infra) error {
+	role, err := i.expectedRole(infra)
+	if err != nil {
+		return err
+	}
+
+	binding, err := i.expectedRoleBinding(infra)
+	if err != nil {
+		return err
+	}
+
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
+	}
+	role.OwnerReferences = owners
+	binding.OwnerReferences = owners
+
+	if err := i.createOrUpdate(ctx, role, &rbacv1.Role{}, nil); err != nil {
+		return err
+	}
+
+	return i.createOrUpdate(ctx, binding, &rbacv1.RoleBinding{}, nil)
+}
+
+// deleteRole deletes the Envoy RoleBinding and Role in the kube api server, if they exist.
+func (i *Infra) deleteRole(ctx context.Context, infra *ir.Infra) error {
+	meta := metav1.ObjectMeta{
+		Namespace: i.proxyNamespace(infra),
+		Name:      expectedRoleName(infra.Proxy.Name),
+	}
+
+	binding := &rbacv1.RoleBinding{ObjectMeta: meta}
+	if err := i.Client.Delete(ctx, binding); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete rolebinding %s/%s: %w", binding.Namespace, binding.Name, err)
+	}
+
+	role := &rbacv1.Role{ObjectMeta: meta}
+	if err := i.Client.Delete(ctx, role); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete role %s/%s: %w", role.Namespace, role.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func roleInfra(rules []rbacv1.PolicyRule) *ir.Infra {
+	return newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		ServiceAccount: &v1alpha1.KubeServiceAccount{
+			Rules: rules,
+		},
+	})
+}
+
+func TestExpectedRole(t *testing.T) {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := roleInfra(rules)
+
+	role, err := kube.expectedRole(infra)
+	require.NoError(t, err)
+	assert.Equal(t, rules, role.Rules)
+
+	binding, err := kube.expectedRoleBinding(infra)
+	require.NoError(t, err)
+	assert.Equal(t, role.Name, binding.RoleRef.Name)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, expectedServiceAccountName(infra.Proxy.Name), binding.Subjects[0].Name)
+	assert.Equal(t, kube.Namespace, binding.Subjects[0].Namespace)
+}
+
+func TestExpectedRoleDisallowedRules(t *testing.T) {
+	testCases := []struct {
+		name string
+		rule rbacv1.PolicyRule
+	}{
+		{
+			name: "secrets",
+			rule: rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+		},
+		{
+			name: "wildcard resource",
+			rule: rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"*"}, Verbs: []string{"get"}},
+		},
+		{
+			name: "write verb",
+			rule: rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"update"}},
+		},
+		{
+			name: "non-core group",
+			rule: rbacv1.PolicyRule{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"roles"}, Verbs: []string{"get"}},
+		},
+		{
+			name: "non-resource URL",
+			rule: rbacv1.PolicyRule{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+		},
+	}
+
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := kube.expectedRole(roleInfra([]rbacv1.PolicyRule{tc.rule}))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestCreateOrUpdateInfraRole(t *testing.T) {
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	infra := roleInfra([]rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get"},
+		},
+	})
+
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	key := client.ObjectKey{Namespace: kube.Namespace, Name: expectedRoleName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), key, &rbacv1.Role{}))
+	require.NoError(t, kube.Client.Get(context.Background(), key, &rbacv1.RoleBinding{}))
+
+	// The ServiceAccount token is mounted to make use of the granted permissions.
+	deploy := &appsv1.Deployment{}
+	deployKey := client.ObjectKey{Namespace: kube.Namespace, Name: expectedDeploymentName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), deployKey, deploy))
+	assert.True(t, *deploy.Spec.Template.Spec.AutomountServiceAccountToken)
+
+	// Removing the rules deletes the Role and RoleBinding.
+	infra.Proxy.Config = nil
+	require.NoError(t, kube.CreateOrUpdateInfra(context.Background(), infra))
+
+	err := kube.Client.Get(context.Background(), key, &rbacv1.Role{})
+	require.True(t, kerrors.IsNotFound(err))
+	err = kube.Client.Get(context.Background(), key, &rbacv1.RoleBinding{})
+	require.True(t, kerrors.IsNotFound(err))
+
+	require.NoError(t, kube.Client.Get(context.Background(), deployKey, deploy))
+	assert.False(t, *deploy.Spec.Template.Spec.AutomountServiceAccountToken)
+}
+
+func TestDeleteRole(t *testing.T) {
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+	infra := ir.NewInfra()
+	err := kube.deleteRole(context.Background(), infra)
+	require.NoError(t, err)
+}
@@ -3,14 +3,13 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -22,6 +21,14 @@ func expectedServiceName(proxyName string) string {
 	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, svcName)
 }
 
+// expectedPortProtocol returns the transport protocol of the provided listener port.
+func expectedPortProtocol(port ir.ListenerPort) corev1.Protocol {
+	if port.Protocol == ir.HTTP3ProtocolType {
+		return corev1.ProtocolUDP
+	}
+	return corev1.ProtocolTCP
+}
+
 // expectedService returns the expected Service based on the provided infra.
 func (i *Infra) expectedService(infra *ir.Infra) (*corev1.Service, error) {
 	var ports []corev1.ServicePort
@@ -30,7 +37,7 @@ func (i *Infra) expectedService(infra *ir.Infra) (*corev1.Service, error) {
 			target := intstr.IntOrString{IntVal: port.ContainerPort}
 			p := corev1.ServicePort{
 				Name:       port.Name,
-				Protocol:   corev1.ProtocolTCP,
+				Protocol:   expectedPortProtocol(port),
 				Port:       port.ServicePort,
 				TargetPort: target,
 			}
@@ -44,25 +51,79 @@ func (i *Infra) expectedService(infra *ir.Infra) (*corev1.Service, error) {
 		return nil, fmt.Errorf("missing owning gateway labels")
 	}
 
+	// Get the Service configuration from the EnvoyProxy config, using defaults if unspecified.
+	svcCfg := infra.GetProxyInfra().Config.GetKubeProvider().Service
+
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
-			Name:      expectedServiceName(infra.Proxy.Name),
-			Labels:    labels,
+			Namespace:   i.proxyNamespace(infra),
+			Name:        expectedServiceName(infra.Proxy.Name),
+			Labels:      mergeLabels(labels, svcCfg.Labels),
+			Annotations: svcCfg.Annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Type:            corev1.ServiceTypeLoadBalancer,
+			Type:            expectedServiceType(svcCfg.Type),
 			Ports:           ports,
 			Selector:        envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels).MatchLabels,
 			SessionAffinity: corev1.ServiceAffinityNone,
-			// Preserve the client source IP and avoid a second hop for LoadBalancer.
-			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
 		},
 	}
 
+	for _, family := range svcCfg.IPFamilies {
+		svc.Spec.IPFamilies = append(svc.Spec.IPFamilies, corev1.IPFamily(family))
+	}
+	if svcCfg.IPFamilyPolicy != nil {
+		policy := corev1.IPFamilyPolicyType(*svcCfg.IPFamilyPolicy)
+		svc.Spec.IPFamilyPolicy = &policy
+	}
+
+	// An external traffic policy can only be set for externally-facing Service types.
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer || svc.Spec.Type == corev1.ServiceTypeNodePort {
+		svc.Spec.ExternalTrafficPolicy = expectedExternalTrafficPolicy(svcCfg.ExternalTrafficPolicy)
+	}
+
+	// Load balancer settings only apply to LoadBalancer Services.
+	addresses := infra.Proxy.Addresses
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		switch {
+		case svcCfg.LoadBalancerIP != nil:
+			svc.Spec.LoadBalancerIP = *svcCfg.LoadBalancerIP
+		case len(addresses) > 0:
+			// The first Gateway address is requested from the load balancer.
+			svc.Spec.LoadBalancerIP = addresses[0]
+			addresses = addresses[1:]
+		}
+		svc.Spec.LoadBalancerClass = svcCfg.LoadBalancerClass
+		svc.Spec.AllocateLoadBalancerNodePorts = svcCfg.AllocateLoadBalancerNodePorts
+	}
+
+	// Any other Gateway address is exposed as an external IP of the Service.
+	if len(addresses) > 0 {
+		svc.Spec.ExternalIPs = addresses
+	}
+
 	return svc, nil
 }
 
+// expectedServiceType returns the Service type based on the provided svcType,
+// defaulting to LoadBalancer if unspecified.
+func expectedServiceType(svcType *v1alpha1.KubeServiceType) corev1.ServiceType {
+	if svcType == nil {
+		return corev1.ServiceTypeLoadBalancer
+	}
+	return corev1.ServiceType(*svcType)
+}
+
+// expectedExternalTrafficPolicy returns the external traffic policy based on the
+// provided policy, defaulting to Local to preserve the client source IP and avoid
+// a second hop.
+func expectedExternalTrafficPolicy(policy *v1alpha1.KubeServiceExternalTrafficPolicy) corev1.ServiceExternalTrafficPolicyType {
+	if policy == nil {
+		return corev1.ServiceExternalTrafficPolicyTypeLocal
+	}
+	return corev1.ServiceExternalTrafficPolicyType(*policy)
+}
+
 // createOrUpdateService creates a Service in the kube api server based on the provided infra,
 // if it doesn't exist or updates it if it does.
 func (i *Infra) createOrUpdateService(ctx context.Context, infra *ir.Infra) error {
@@ -71,38 +132,23 @@ func (i *Infra) createOrUpdateService(ctx context.Context, infra *ir.Infra) erro
 		return fmt.Errorf("failed to generate expected service: %w", err)
 	}
 
-	current := &corev1.Service{}
-	key := types.NamespacedName{
-		Namespace: i.Namespace,
-		Name:      expectedServiceName(infra.Proxy.Name),
-	}
-
-	if err := i.Client.Get(ctx, key, current); err != nil {
-		// Create if not found.
-		if kerrors.IsNotFound(err) {
-			if err := i.Client.Create(ctx, svc); err != nil {
-				return fmt.Errorf("failed to create service %s/%s: %w",
-					svc.Namespace, svc.Name, err)
-			}
-		}
-	} else {
-		// Update if current value is different.
-		if !reflect.DeepEqual(svc.Spec, current.Spec) {
-			if err := i.Client.Update(ctx, svc); err != nil {
-				return fmt.Errorf("failed to update service %s/%s: %w",
-					svc.Namespace, svc.Name, err)
-			}
-		}
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
 	}
+	svc.OwnerReferences = owners
 
-	return nil
+	current := &corev1.Service{}
+	return i.createOrUpdate(ctx, svc, current, func() {
+		mergeAllocatedServiceValues(svc, current)
+	})
 }
 
 // deleteService deletes the Envoy Service in the kube api server, if it exists.
 func (i *Infra) deleteService(ctx context.Context, infra *ir.Infra) error {
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
+			Namespace: i.proxyNamespace(infra),
 			Name:      expectedServiceName(infra.Proxy.Name),
 		},
 	}
@@ -116,3 +162,28 @@ func (i *Infra) deleteService(ctx context.Context, infra *ir.Infra) error {
 
 	return nil
 }
+
+// mergeAllocatedServiceValues copies the node ports allocated by the api server
+// from the current Service to the desired Service, if still applicable.
+func mergeAllocatedServiceValues(desired, current *corev1.Service) {
+	if desired.Spec.Type != corev1.ServiceTypeLoadBalancer && desired.Spec.Type != corev1.ServiceTypeNodePort {
+		return
+	}
+
+	for i := range desired.Spec.Ports {
+		if desired.Spec.Ports[i].NodePort != 0 {
+			continue
+		}
+		for _, port := range current.Spec.Ports {
+			if port.Name == desired.Spec.Ports[i].Name && port.Protocol == desired.Spec.Ports[i].Protocol {
+				desired.Spec.Ports[i].NodePort = port.NodePort
+			}
+		}
+	}
+
+	if desired.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+		desired.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal &&
+		desired.Spec.HealthCheckNodePort == 0 {
+		desired.Spec.HealthCheckNodePort = current.Spec.HealthCheckNodePort
+	}
+}
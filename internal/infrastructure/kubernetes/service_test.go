@@ -9,8 +9,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -92,7 +95,7 @@ func TestDesiredService(t *testing.T) {
 	checkServiceHasTargetPort(t, svc, 2443)
 
 	// Ensure the Envoy service has the expected labels.
-	lbls := envoyAppLabel()
+	lbls := EnvoyAppLabel()
 	lbls[gatewayapi.OwningGatewayNamespaceLabel] = "default"
 	lbls[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
 	checkServiceHasLabels(t, svc, lbls)
@@ -102,6 +105,265 @@ func TestDesiredService(t *testing.T) {
 	}
 }
 
+func TestDesiredServiceHTTP3(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	infra.Proxy.Listeners[0].Ports = []ir.ListenerPort{
+		{
+			Name:          "https",
+			Protocol:      ir.HTTPSProtocolType,
+			ServicePort:   443,
+			ContainerPort: 10443,
+		},
+		{
+			Name:          "https-http3",
+			Protocol:      ir.HTTP3ProtocolType,
+			ServicePort:   443,
+			ContainerPort: 10443,
+		},
+	}
+	svc, err := kube.expectedService(infra)
+	require.NoError(t, err)
+
+	// The HTTPS and HTTP/3 ports share the port number over TCP and UDP.
+	require.Len(t, svc.Spec.Ports, 2)
+	assert.Equal(t, corev1.ProtocolTCP, svc.Spec.Ports[0].Protocol)
+	assert.Equal(t, corev1.ProtocolUDP, svc.Spec.Ports[1].Protocol)
+	for _, port := range svc.Spec.Ports {
+		assert.Equal(t, int32(443), port.Port)
+		assert.Equal(t, int32(10443), port.TargetPort.IntVal)
+	}
+}
+
+func TestDesiredServiceType(t *testing.T) {
+	clusterIP := v1alpha1.KubeServiceTypeClusterIP
+	nodePort := v1alpha1.KubeServiceTypeNodePort
+
+	testCases := []struct {
+		name          string
+		svc           *v1alpha1.KubeService
+		expectType    corev1.ServiceType
+		expectTraffic corev1.ServiceExternalTrafficPolicyType
+	}{
+		{
+			name:          "default",
+			svc:           nil,
+			expectType:    corev1.ServiceTypeLoadBalancer,
+			expectTraffic: corev1.ServiceExternalTrafficPolicyTypeLocal,
+		},
+		{
+			name:          "unspecified type",
+			svc:           &v1alpha1.KubeService{},
+			expectType:    corev1.ServiceTypeLoadBalancer,
+			expectTraffic: corev1.ServiceExternalTrafficPolicyTypeLocal,
+		},
+		{
+			name:       "cluster ip",
+			svc:        &v1alpha1.KubeService{Type: &clusterIP},
+			expectType: corev1.ServiceTypeClusterIP,
+		},
+		{
+			name:          "node port",
+			svc:           &v1alpha1.KubeService{Type: &nodePort},
+			expectType:    corev1.ServiceTypeNodePort,
+			expectTraffic: corev1.ServiceExternalTrafficPolicyTypeLocal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+			kube := NewInfra(cli)
+			infra := newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+				Service: tc.svc,
+			})
+
+			svc, err := kube.expectedService(infra)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectType, svc.Spec.Type)
+			assert.Equal(t, tc.expectTraffic, svc.Spec.ExternalTrafficPolicy)
+		})
+	}
+}
+
+func TestDesiredServiceLoadBalancer(t *testing.T) {
+	clusterIP := v1alpha1.KubeServiceTypeClusterIP
+	clusterPolicy := v1alpha1.KubeServiceExternalTrafficPolicyCluster
+	dualStack := v1alpha1.KubeIPFamilyPolicyRequireDualStack
+
+	testCases := []struct {
+		name   string
+		svc    *v1alpha1.KubeService
+		expect func(t *testing.T, svc *corev1.Service)
+	}{
+		{
+			name: "load balancer settings",
+			svc: &v1alpha1.KubeService{
+				Annotations: map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+				},
+				LoadBalancerIP:                pointer.String("1.2.3.4"),
+				LoadBalancerClass:             pointer.String("example.com/lb"),
+				AllocateLoadBalancerNodePorts: pointer.Bool(false),
+				ExternalTrafficPolicy:         &clusterPolicy,
+			},
+			expect: func(t *testing.T, svc *corev1.Service) {
+				assert.Equal(t, "true", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+				assert.Equal(t, "1.2.3.4", svc.Spec.LoadBalancerIP)
+				assert.Equal(t, pointer.String("example.com/lb"), svc.Spec.LoadBalancerClass)
+				assert.Equal(t, pointer.Bool(false), svc.Spec.AllocateLoadBalancerNodePorts)
+				assert.Equal(t, corev1.ServiceExternalTrafficPolicyTypeCluster, svc.Spec.ExternalTrafficPolicy)
+			},
+		},
+		{
+			name: "dual-stack",
+			svc: &v1alpha1.KubeService{
+				IPFamilies:     []v1alpha1.KubeIPFamily{v1alpha1.KubeIPFamilyIPv6, v1alpha1.KubeIPFamilyIPv4},
+				IPFamilyPolicy: &dualStack,
+			},
+			expect: func(t *testing.T, svc *corev1.Service) {
+				assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, svc.Spec.IPFamilies)
+				require.NotNil(t, svc.Spec.IPFamilyPolicy)
+				assert.Equal(t, corev1.IPFamilyPolicyRequireDualStack, *svc.Spec.IPFamilyPolicy)
+			},
+		},
+		{
+			name: "load balancer settings ignored for cluster ip",
+			svc: &v1alpha1.KubeService{
+				Type:                          &clusterIP,
+				LoadBalancerIP:                pointer.String("1.2.3.4"),
+				LoadBalancerClass:             pointer.String("example.com/lb"),
+				AllocateLoadBalancerNodePorts: pointer.Bool(false),
+				ExternalTrafficPolicy:         &clusterPolicy,
+			},
+			expect: func(t *testing.T, svc *corev1.Service) {
+				assert.Empty(t, svc.Spec.LoadBalancerIP)
+				assert.Nil(t, svc.Spec.LoadBalancerClass)
+				assert.Nil(t, svc.Spec.AllocateLoadBalancerNodePorts)
+				assert.Empty(t, svc.Spec.ExternalTrafficPolicy)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+			kube := NewInfra(cli)
+			infra := newTestInfraWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+				Service: tc.svc,
+			})
+
+			svc, err := kube.expectedService(infra)
+			require.NoError(t, err)
+			tc.expect(t, svc)
+		})
+	}
+}
+
+func TestDesiredServiceAddresses(t *testing.T) {
+	clusterIP := v1alpha1.KubeServiceTypeClusterIP
+
+	testCases := []struct {
+		name        string
+		svc         *v1alpha1.KubeService
+		addresses   []string
+		lbIP        string
+		externalIPs []string
+	}{
+		{
+			name:      "load balancer with single address",
+			addresses: []string{"1.2.3.4"},
+			lbIP:      "1.2.3.4",
+		},
+		{
+			name:        "load balancer with multiple addresses",
+			addresses:   []string{"1.2.3.4", "5.6.7.8"},
+			lbIP:        "1.2.3.4",
+			externalIPs: []string{"5.6.7.8"},
+		},
+		{
+			name:        "load balancer ip set by envoyproxy",
+			svc:         &v1alpha1.KubeService{LoadBalancerIP: pointer.String("9.9.9.9")},
+			addresses:   []string{"1.2.3.4"},
+			lbIP:        "9.9.9.9",
+			externalIPs: []string{"1.2.3.4"},
+		},
+		{
+			name:        "cluster ip",
+			svc:         &v1alpha1.KubeService{Type: &clusterIP},
+			addresses:   []string{"1.2.3.4", "5.6.7.8"},
+			externalIPs: []string{"1.2.3.4", "5.6.7.8"},
+		},
+		{
+			name: "no addresses",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+			kube := NewInfra(cli)
+			infra := ir.NewInfra()
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+			infra.Proxy.Addresses = tc.addresses
+			if tc.svc != nil {
+				infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+					Service: tc.svc,
+				})
+			}
+
+			svc, err := kube.expectedService(infra)
+			require.NoError(t, err)
+			assert.Equal(t, tc.lbIP, svc.Spec.LoadBalancerIP)
+			assert.Equal(t, tc.externalIPs, svc.Spec.ExternalIPs)
+		})
+	}
+}
+
+func TestCreateOrUpdateServiceAllocatedValues(t *testing.T) {
+	ctx := context.Background()
+	kube := &Infra{
+		Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+		Namespace: "test",
+	}
+
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	infra.Proxy.Listeners[0].Ports = []ir.ListenerPort{
+		{
+			Name:          "http",
+			Protocol:      ir.HTTPProtocolType,
+			ServicePort:   80,
+			ContainerPort: 8080,
+		},
+	}
+
+	require.NoError(t, kube.createOrUpdateService(ctx, infra))
+
+	// Simulate the node ports allocated by the api server.
+	key := client.ObjectKey{Namespace: kube.Namespace, Name: expectedServiceName(infra.Proxy.Name)}
+	allocated := &corev1.Service{}
+	require.NoError(t, kube.Client.Get(ctx, key, allocated))
+	allocated.Spec.Ports[0].NodePort = 30080
+	allocated.Spec.HealthCheckNodePort = 30999
+	require.NoError(t, kube.Client.Update(ctx, allocated))
+	require.NoError(t, kube.Client.Get(ctx, key, allocated))
+
+	// Allocated values do not cause an update.
+	require.NoError(t, kube.createOrUpdateService(ctx, infra))
+	current := &corev1.Service{}
+	require.NoError(t, kube.Client.Get(ctx, key, current))
+	require.Equal(t, allocated.ResourceVersion, current.ResourceVersion)
+}
+
 func TestDeleteService(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -7,7 +7,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
@@ -28,15 +27,19 @@ func (i *Infra) expectedServiceAccount(infra *ir.Infra) (*corev1.ServiceAccount,
 		return nil, fmt.Errorf("missing owning gateway labels")
 	}
 
+	// Get the ServiceAccount configuration from the EnvoyProxy config, using defaults if unspecified.
+	saCfg := infra.GetProxyInfra().Config.GetKubeProvider().ServiceAccount
+
 	return &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ServiceAccount",
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
-			Name:      expectedServiceAccountName(infra.Proxy.Name),
-			Labels:    labels,
+			Namespace:   i.proxyNamespace(infra),
+			Name:        expectedServiceAccountName(infra.Proxy.Name),
+			Labels:      mergeLabels(labels, saCfg.Labels),
+			Annotations: saCfg.Annotations,
 		},
 	}, nil
 }
@@ -49,30 +52,13 @@ func (i *Infra) createOrUpdateServiceAccount(ctx context.Context, infra *ir.Infr
 		return err
 	}
 
-	current := &corev1.ServiceAccount{}
-	key := types.NamespacedName{
-		Namespace: i.Namespace,
-		Name:      expectedServiceAccountName(infra.Proxy.Name),
-	}
-
-	if err := i.Client.Get(ctx, key, current); err != nil {
-		if kerrors.IsNotFound(err) {
-			// Create if it does not exist.
-			if err := i.Client.Create(ctx, sa); err != nil {
-				return fmt.Errorf("failed to create serviceaccount %s/%s: %w",
-					sa.Namespace, sa.Name, err)
-			}
-		}
-	} else {
-		// Since the ServiceAccount does not have a specific Spec field to compare
-		// just perform an update for now.
-		if err := i.Client.Update(ctx, sa); err != nil {
-			return fmt.Errorf("failed to update serviceaccount %s/%s: %w",
-				sa.Namespace, sa.Name, err)
-		}
+	owners, err := i.expectedOwnerReferences(ctx, infra)
+	if err != nil {
+		return err
 	}
+	sa.OwnerReferences = owners
 
-	return nil
+	return i.createOrUpdate(ctx, sa, &corev1.ServiceAccount{}, nil)
 }
 
 // deleteServiceAccount deletes the Envoy ServiceAccount in the kube api server,
@@ -80,7 +66,7 @@ func (i *Infra) createOrUpdateServiceAccount(ctx context.Context, infra *ir.Infr
 func (i *Infra) deleteServiceAccount(ctx context.Context, infra *ir.Infra) error {
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: i.Namespace,
+			Namespace: i.proxyNamespace(infra),
 			Name:      expectedServiceAccountName(infra.Proxy.Name),
 		},
 	}
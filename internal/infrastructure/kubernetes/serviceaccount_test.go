@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -38,12 +39,39 @@ func TestExpectedServiceAccount(t *testing.T) {
 	// Check the serviceaccount name is as expected.
 	assert.Equal(t, sa.Name, expectedServiceAccountName(infra.Proxy.Name))
 
-	wantLabels := envoyAppLabel()
+	wantLabels := EnvoyAppLabel()
 	wantLabels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
 	wantLabels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
 	assert.True(t, apiequality.Semantic.DeepEqual(wantLabels, sa.Labels))
 }
 
+func TestExpectedServiceAccountAnnotations(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	annotations := map[string]string{
+		"eks.amazonaws.com/role-arn":     "arn:aws:iam::111122223333:role/envoy",
+		"iam.gke.io/gcp-service-account": "envoy@project.iam.gserviceaccount.com",
+	}
+	infra.Proxy.Config = newTestEnvoyProxyWithKubeProvider(&v1alpha1.ProxyKubeProvider{
+		ServiceAccount: &v1alpha1.KubeServiceAccount{
+			Labels:      map[string]string{"team": "edge"},
+			Annotations: annotations,
+		},
+	})
+
+	sa, err := kube.expectedServiceAccount(infra)
+	require.NoError(t, err)
+
+	assert.Equal(t, annotations, sa.Annotations)
+	assert.Equal(t, "edge", sa.Labels["team"])
+	assert.Equal(t, "envoy", sa.Labels["app.gateway.envoyproxy.io/name"])
+}
+
 func TestCreateOrUpdateServiceAccount(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -197,7 +225,13 @@ func TestCreateOrUpdateServiceAccount(t *testing.T) {
 			}
 			require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(actual), actual))
 
-			opts := cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion")
+			// The hash of the desired state is verified by TestCreateOrUpdate.
+			delete(actual.Annotations, hashAnnotation)
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+				cmpopts.EquateEmpty(),
+			}
 			assert.Equal(t, true, cmp.Equal(tc.want, actual, opts))
 		})
 	}
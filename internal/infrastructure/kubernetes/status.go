@@ -0,0 +1,79 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// GetStatus returns the status of the workload resource running Envoy, which is
+// ready once all its pods are ready.
+func (i *Infra) GetStatus(ctx context.Context, infra *ir.Infra) (*infrastructure.Status, error) {
+	if infra == nil {
+		return nil, errors.New("infra ir is nil")
+	}
+
+	if infra.Proxy == nil {
+		return nil, errors.New("infra proxy ir is nil")
+	}
+
+	switch *infra.GetProxyInfra().Config.GetKubeProvider().WorkloadType {
+	case v1alpha1.KubeWorkloadTypeDaemonSet:
+		return i.daemonSetStatus(ctx, infra)
+	default:
+		return i.deploymentStatus(ctx, infra)
+	}
+}
+
+// deploymentStatus returns the status of the Envoy Deployment.
+func (i *Infra) deploymentStatus(ctx context.Context, infra *ir.Infra) (*infrastructure.Status, error) {
+	key := types.NamespacedName{
+		Namespace: i.proxyNamespace(infra),
+		Name:      expectedDeploymentName(infra.Proxy.Name),
+	}
+	deploy := new(appsv1.Deployment)
+	if err := i.Client.Get(ctx, key, deploy); err != nil {
+		if kerrors.IsNotFound(err) {
+			return &infrastructure.Status{Message: fmt.Sprintf("deployment %s not found", key)}, nil
+		}
+		return nil, fmt.Errorf("failed to get deployment %s: %w", key, err)
+	}
+
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	return &infrastructure.Status{
+		Ready:   deploy.Status.ReadyReplicas >= replicas,
+		Message: fmt.Sprintf("%d/%d replicas ready", deploy.Status.ReadyReplicas, replicas),
+	}, nil
+}
+
+// daemonSetStatus returns the status of the Envoy DaemonSet.
+func (i *Infra) daemonSetStatus(ctx context.Context, infra *ir.Infra) (*infrastructure.Status, error) {
+	key := types.NamespacedName{
+		Namespace: i.proxyNamespace(infra),
+		Name:      expectedDaemonSetName(infra.Proxy.Name),
+	}
+	daemonSet := new(appsv1.DaemonSet)
+	if err := i.Client.Get(ctx, key, daemonSet); err != nil {
+		if kerrors.IsNotFound(err) {
+			return &infrastructure.Status{Message: fmt.Sprintf("daemonset %s not found", key)}, nil
+		}
+		return nil, fmt.Errorf("failed to get daemonset %s: %w", key, err)
+	}
+
+	return &infrastructure.Status{
+		Ready: daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled,
+		Message: fmt.Sprintf("%d/%d pods ready",
+			daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled),
+	}, nil
+}
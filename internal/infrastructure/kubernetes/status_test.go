@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestGetStatus(t *testing.T) {
+	replicas := int32(2)
+	deploy := func(ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      expectedDeploymentName(ir.DefaultProxyName),
+			},
+			Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      expectedDaemonSetName(ir.DefaultProxyName),
+		},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 3},
+	}
+
+	testCases := []struct {
+		name    string
+		in      *ir.Infra
+		current client.Object
+		expect  *infrastructure.Status
+	}{
+		{
+			name:   "deployment not found",
+			in:     ir.NewInfra(),
+			expect: &infrastructure.Status{Message: "deployment test/envoy-default-64656661 not found"},
+		},
+		{
+			name:    "deployment not ready",
+			in:      ir.NewInfra(),
+			current: deploy(1),
+			expect:  &infrastructure.Status{Message: "1/2 replicas ready"},
+		},
+		{
+			name:    "deployment ready",
+			in:      ir.NewInfra(),
+			current: deploy(2),
+			expect:  &infrastructure.Status{Ready: true, Message: "2/2 replicas ready"},
+		},
+		{
+			name:    "daemonset ready",
+			in:      daemonSetInfra(),
+			current: daemonSet,
+			expect:  &infrastructure.Status{Ready: true, Message: "3/3 pods ready"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme())
+			if tc.current != nil {
+				builder = builder.WithObjects(tc.current)
+			}
+			kube := &Infra{Client: builder.Build(), Namespace: "test"}
+
+			status, err := kube.GetStatus(context.Background(), tc.in)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, status)
+		})
+	}
+}
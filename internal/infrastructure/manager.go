@@ -3,40 +3,65 @@ package infrastructure
 import (
 	"context"
 	"fmt"
-
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sync"
 
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
-	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
-	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes"
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
-var _ Manager = (*kubernetes.Infra)(nil)
-
 // Manager provides the scaffolding for managing infrastructure.
 type Manager interface {
 	// CreateOrUpdateInfra creates or updates infra.
 	CreateOrUpdateInfra(ctx context.Context, infra *ir.Infra) error
 	// DeleteInfra deletes infra
 	DeleteInfra(ctx context.Context, infra *ir.Infra) error
+	// GetStatus returns the status of infra.
+	GetStatus(ctx context.Context, infra *ir.Infra) (*Status, error)
+	// CreateOrUpdateRateLimitInfra creates or updates the global rate limit service infra.
+	CreateOrUpdateRateLimitInfra(ctx context.Context, infra *ir.RateLimitInfra) error
+	// DeleteRateLimitInfra deletes the global rate limit service infra.
+	DeleteRateLimitInfra(ctx context.Context) error
+}
+
+// Status is the status of the managed proxy infra.
+type Status struct {
+	// Ready is true if the proxies are ready to serve traffic.
+	Ready bool
+	// Message is a human-readable description of the status.
+	Message string
 }
 
-// NewManager returns a new infrastructure Manager.
+// Factory returns a new Manager for the provided configuration.
+type Factory func(cfg *config.Server) (Manager, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[v1alpha1.ProviderType]Factory{}
+)
+
+// Register registers the Manager factory of the provided provider type. It's
+// meant to be called from the init function of the package implementing the
+// Manager, and panics if a factory is already registered for the provider type.
+func Register(providerType v1alpha1.ProviderType, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if _, ok := factories[providerType]; ok {
+		panic(fmt.Sprintf("infrastructure manager already registered for provider type %v", providerType))
+	}
+	factories[providerType] = factory
+}
+
+// NewManager returns a new infrastructure Manager, created by the factory
+// registered for the provider type of cfg.
 func NewManager(cfg *config.Server) (Manager, error) {
-	var mgr Manager
-	if cfg.EnvoyGateway.Provider.Type == v1alpha1.ProviderTypeKubernetes {
-		cli, err := client.New(clicfg.GetConfigOrDie(), client.Options{Scheme: envoygateway.GetScheme()})
-		if err != nil {
-			return nil, err
-		}
-		mgr = kubernetes.NewInfra(cli)
-	} else {
-		// Kube is the only supported provider type for now.
+	factoriesMu.RLock()
+	factory, ok := factories[cfg.EnvoyGateway.Provider.Type]
+	factoriesMu.RUnlock()
+
+	if !ok {
 		return nil, fmt.Errorf("unsupported provider type %v", cfg.EnvoyGateway.Provider.Type)
 	}
-
-	return mgr, nil
+	return factory(cfg)
 }
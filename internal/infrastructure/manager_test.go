@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// fakeManager is a Manager doing nothing.
+type fakeManager struct{}
+
+func (fakeManager) CreateOrUpdateInfra(context.Context, *ir.Infra) error { return nil }
+func (fakeManager) DeleteInfra(context.Context, *ir.Infra) error         { return nil }
+func (fakeManager) GetStatus(context.Context, *ir.Infra) (*Status, error) {
+	return &Status{Ready: true}, nil
+}
+func (fakeManager) CreateOrUpdateRateLimitInfra(context.Context, *ir.RateLimitInfra) error {
+	return nil
+}
+func (fakeManager) DeleteRateLimitInfra(context.Context) error { return nil }
+
+func TestNewManager(t *testing.T) {
+	const providerType v1alpha1.ProviderType = "Fake"
+	Register(providerType, func(*config.Server) (Manager, error) {
+		return fakeManager{}, nil
+	})
+	// A provider type can't be registered twice.
+	require.Panics(t, func() {
+		Register(providerType, func(*config.Server) (Manager, error) {
+			return nil, nil
+		})
+	})
+
+	cfg, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.Provider = &v1alpha1.Provider{Type: providerType}
+	mgr, err := NewManager(cfg)
+	require.NoError(t, err)
+	require.Equal(t, fakeManager{}, mgr)
+
+	cfg.EnvoyGateway.Provider = &v1alpha1.Provider{Type: "Unknown"}
+	_, err = NewManager(cfg)
+	require.EqualError(t, err, "unsupported provider type Unknown")
+}
@@ -2,16 +2,35 @@ package runner
 
 import (
 	"context"
+	"time"
 
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/infrastructure"
+	// Register the infrastructure managers.
+	_ "github.com/envoyproxy/gateway/internal/infrastructure/host"
+	_ "github.com/envoyproxy/gateway/internal/infrastructure/kubernetes"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/translator"
 )
 
+// statusInterval is the interval between the updates of the status of the
+// managed proxy infra.
+var statusInterval = 5 * time.Second
+
 type Config struct {
 	config.Server
-	InfraIR *message.InfraIR
+	// ProviderResources is written with the status of the proxy infra of
+	// each Gateway.
+	ProviderResources *message.ProviderResources
+	InfraIR           *message.InfraIR
+	// XdsIR is subscribed to for the rate limit configurations of the global
+	// rate limit service, if enabled.
+	XdsIR *message.XdsIR
 }
 
 type Runner struct {
@@ -29,13 +48,28 @@ func New(cfg *Config) *Runner {
 
 // Start starts the infrastructure runner
 func (r *Runner) Start(ctx context.Context) error {
-	var err error
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	logger, err := r.ComponentLogger(v1alpha1.LogComponentInfra)
+	if err != nil {
+		return err
+	}
+	r.Logger = logger.WithValues("runner", r.Name())
 	r.mgr, err = infrastructure.NewManager(&r.Config.Server)
 	if err != nil {
-		r.Logger.Error(err, "failed to create new manager")
+		// The infrastructure isn't managed by the provider type.
+		r.Logger.Error(err, "failed to create new manager, the infrastructure isn't managed")
+		return nil
 	}
 	go r.subscribeAndTranslate(ctx)
+	go r.updateStatus(ctx, statusInterval)
+	if r.EnvoyGateway.RateLimit != nil {
+		go r.subscribeAndManageRateLimit(ctx)
+	} else {
+		// Remove the rate limit service infra left over from a previous
+		// configuration, if any.
+		if err := r.mgr.DeleteRateLimitInfra(ctx); err != nil {
+			r.Logger.Error(err, "failed to delete rate limit infra")
+		}
+	}
 	r.Logger.Info("started")
 	return nil
 }
@@ -44,7 +78,9 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 	// Subscribe to resources
 	message.HandleSubscription(r.InfraIR.Subscribe(ctx),
 		func(update message.Update[string, *ir.Infra]) {
-			val := update.Value
+			// Manage a copy of the infra ir, since the managers set its defaults
+			// while the subscription may still compare it with the next update.
+			val := update.Value.DeepCopy()
 
 			if update.Delete {
 				if err := r.mgr.DeleteInfra(ctx, val); err != nil {
@@ -60,3 +96,83 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 	)
 	r.Logger.Info("subscriber shutting down")
 }
+
+// updateStatus stores the status of the managed proxy infra of each Gateway
+// at the provided interval, until ctx is done.
+func (r *Runner) updateStatus(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.Logger.Info("status updater shutting down")
+			return
+		case <-ticker.C:
+		}
+
+		current := map[types.NamespacedName]bool{}
+		for _, infra := range r.InfraIR.LoadAll() {
+			labels := infra.GetProxyInfra().GetProxyMetadata().Labels
+			key := types.NamespacedName{
+				Namespace: labels[gatewayapi.OwningGatewayNamespaceLabel],
+				Name:      labels[gatewayapi.OwningGatewayNameLabel],
+			}
+			current[key] = true
+			status, err := r.mgr.GetStatus(ctx, infra)
+			if err != nil {
+				r.Logger.Error(err, "failed to get infra status", "namespace", key.Namespace, "name", key.Name)
+				continue
+			}
+			r.ProviderResources.InfraStatuses.Store(key, *status)
+		}
+
+		// Delete the status of the deleted infras.
+		for key := range r.ProviderResources.InfraStatuses.LoadAll() {
+			if !current[key] {
+				r.ProviderResources.InfraStatuses.Delete(key)
+			}
+		}
+	}
+}
+
+// subscribeAndManageRateLimit manages the global rate limit service infra, updating
+// its rate limit configurations from the xDS IR of every gateway.
+func (r *Runner) subscribeAndManageRateLimit(ctx context.Context) {
+	// Rate limit configurations keyed by xDS IR key.
+	configs := map[string]map[string]string{}
+	apply := func() {
+		infra := &ir.RateLimitInfra{
+			Backend: &r.EnvoyGateway.RateLimit.Backend,
+			Configs: map[string]string{},
+		}
+		for _, irConfigs := range configs {
+			for domain, cfg := range irConfigs {
+				infra.Configs[domain] = cfg
+			}
+		}
+		if err := r.mgr.CreateOrUpdateRateLimitInfra(ctx, infra); err != nil {
+			r.Logger.Error(err, "failed to create or update rate limit infra")
+		}
+	}
+
+	// Deploy the service before any rate limit is configured.
+	apply()
+
+	message.HandleSubscription(r.XdsIR.Subscribe(ctx),
+		func(update message.Update[string, *ir.Xds]) {
+			if update.Delete {
+				delete(configs, update.Key)
+			} else {
+				irConfigs, err := translator.BuildRateLimitServiceConfigs(update.Value)
+				if err != nil {
+					r.Logger.Error(err, "failed to build rate limit configs")
+					return
+				}
+				configs[update.Key] = irConfigs
+			}
+			apply()
+		},
+	)
+	r.Logger.Info("rate limit subscriber shutting down")
+}
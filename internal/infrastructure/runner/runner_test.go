@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+// fakeProviderType is the provider type of fakeManager.
+const fakeProviderType v1alpha1.ProviderType = "Fake"
+
+// fakeManager records the images of the managed proxies, setting the defaults of
+// the infra ir like the managers do.
+type fakeManager struct {
+	mu     sync.Mutex
+	images map[string]string
+}
+
+func (m *fakeManager) CreateOrUpdateInfra(_ context.Context, infra *ir.Infra) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	proxy := infra.GetProxyInfra()
+	m.images[proxy.Name] = proxy.Image
+	return nil
+}
+
+func (m *fakeManager) DeleteInfra(_ context.Context, infra *ir.Infra) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.images, infra.GetProxyInfra().Name)
+	return nil
+}
+
+func (m *fakeManager) GetStatus(context.Context, *ir.Infra) (*infrastructure.Status, error) {
+	return &infrastructure.Status{Ready: true}, nil
+}
+
+func (m *fakeManager) CreateOrUpdateRateLimitInfra(context.Context, *ir.RateLimitInfra) error {
+	return nil
+}
+
+func (m *fakeManager) DeleteRateLimitInfra(context.Context) error {
+	return nil
+}
+
+func (m *fakeManager) image(name string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.images[name]
+}
+
+// fakeMgr is the manager created by the factory of fakeProviderType.
+var fakeMgr = &fakeManager{images: map[string]string{}}
+
+func init() {
+	infrastructure.Register(fakeProviderType, func(*config.Server) (infrastructure.Manager, error) {
+		return fakeMgr, nil
+	})
+}
+
+func TestRunner(t *testing.T) {
+	mgr := fakeMgr
+
+	cfg, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.Provider = &v1alpha1.Provider{Type: fakeProviderType}
+	infraIR := new(message.InfraIR)
+	r := New(&Config{Server: *cfg, ProviderResources: new(message.ProviderResources), InfraIR: infraIR})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, r.Start(ctx))
+
+	// The managers set the defaults of the infra ir they receive, while the
+	// subscription compares the last infra ir with the next update.
+	for i := 0; i < 50; i++ {
+		image := ""
+		if i%2 == 0 {
+			image = "custom"
+		}
+		infraIR.Store("test", &ir.Infra{Proxy: &ir.ProxyInfra{Name: "test", Image: image}})
+		time.Sleep(time.Millisecond)
+	}
+	require.Eventually(t, func() bool {
+		return mgr.image("test") == ir.DefaultProxyImage
+	}, time.Second, 10*time.Millisecond)
+
+	infraIR.Delete("test")
+	require.Eventually(t, func() bool {
+		return mgr.image("test") == ""
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRunnerStatus(t *testing.T) {
+	interval := statusInterval
+	statusInterval = 10 * time.Millisecond
+	t.Cleanup(func() { statusInterval = interval })
+
+	cfg, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.Provider = &v1alpha1.Provider{Type: fakeProviderType}
+	resources := new(message.ProviderResources)
+	infraIR := new(message.InfraIR)
+	r := New(&Config{Server: *cfg, ProviderResources: resources, InfraIR: infraIR})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, r.Start(ctx))
+
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = "gateway"
+	infraIR.Store("default-gateway", infra)
+
+	key := types.NamespacedName{Namespace: "default", Name: "gateway"}
+	require.Eventually(t, func() bool {
+		status, ok := resources.InfraStatuses.Load(key)
+		return ok && status.Ready
+	}, time.Second, 10*time.Millisecond)
+
+	infraIR.Delete("default-gateway")
+	require.Eventually(t, func() bool {
+		_, ok := resources.InfraStatuses.Load(key)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
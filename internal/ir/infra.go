@@ -3,8 +3,10 @@ package ir
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
@@ -18,23 +20,36 @@ const (
 // +k8s:deepcopy-gen=true
 type Infra struct {
 	// Proxy defines managed proxy infrastructure.
-	Proxy *ProxyInfra
+	Proxy *ProxyInfra `json:"proxy,omitempty"`
 }
 
 // ProxyInfra defines managed proxy infrastructure.
 // +k8s:deepcopy-gen=true
 type ProxyInfra struct {
 	// Metadata defines metadata for the managed proxy infrastructure.
-	Metadata *InfraMetadata
+	Metadata *InfraMetadata `json:"metadata,omitempty"`
 	// Name is the name used for managed proxy infrastructure.
-	Name string
+	Name string `json:"name"`
 	// Config defines user-facing configuration of the managed proxy infrastructure.
-	Config *v1alpha1.EnvoyProxy
+	Config *v1alpha1.EnvoyProxy `json:"config,omitempty"`
 	// Image is the container image used for the managed proxy infrastructure.
 	// If unset, defaults to "envoyproxy/envoy:v1.23-latest".
-	Image string
+	Image string `json:"image,omitempty"`
 	// Listeners define the listeners exposed by the proxy infrastructure.
-	Listeners []ProxyListener
+	Listeners []ProxyListener `json:"listeners,omitempty"`
+	// Addresses are the static IP addresses requested for the proxy infrastructure,
+	// i.e. the addresses of the Gateway.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// RateLimitInfra defines managed global rate limit service infrastructure.
+// +k8s:deepcopy-gen=true
+type RateLimitInfra struct {
+	// Backend is the database backend of the rate limit service.
+	Backend *v1alpha1.RateLimitDatabaseBackend `json:"backend,omitempty"`
+	// Configs are the rate limit configurations of the service, keyed by the
+	// rate limit domain.
+	Configs map[string]string `json:"configs,omitempty"`
 }
 
 // InfraMetadata defines metadata for the managed proxy infrastructure.
@@ -42,29 +57,29 @@ type ProxyInfra struct {
 type InfraMetadata struct {
 	// Labels define a map of string keys and values that can be used to organize
 	// and categorize proxy infrastructure objects.
-	Labels map[string]string
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // ProxyListener defines the listener configuration of the proxy infrastructure.
 // +k8s:deepcopy-gen=true
 type ProxyListener struct {
 	// Address is the address that the listener should listen on.
-	Address string
+	Address string `json:"address,omitempty"`
 	// Ports define network ports of the listener.
-	Ports []ListenerPort
+	Ports []ListenerPort `json:"ports,omitempty"`
 }
 
 // ListenerPort defines a network port of a listener.
 // +k8s:deepcopy-gen=true
 type ListenerPort struct {
 	// Name is the name of the listener port.
-	Name string
+	Name string `json:"name"`
 	// Protocol is the protocol that the listener port will listener for.
-	Protocol ProtocolType
+	Protocol ProtocolType `json:"protocol,omitempty"`
 	// ServicePort is the port number the proxy service is listening on.
-	ServicePort int32
+	ServicePort int32 `json:"servicePort,omitempty"`
 	// ContainerPort is the port number the proxy container is listening on.
-	ContainerPort int32
+	ContainerPort int32 `json:"containerPort,omitempty"`
 }
 
 // ProtocolType defines the application protocol accepted by a ListenerPort.
@@ -82,6 +97,9 @@ const (
 
 	// Accepts TLS sessions over TCP.
 	TLSProtocolType ProtocolType = "TLS"
+
+	// HTTP3ProtocolType accepts HTTP/3 sessions over QUIC.
+	HTTP3ProtocolType ProtocolType = "HTTP3"
 )
 
 // NewInfra returns a new Infra with default parameters.
@@ -177,33 +195,95 @@ func (p *ProxyInfra) Validate() error {
 		errs = append(errs, errors.New("image field required"))
 	}
 
+	if p.Metadata != nil {
+		if err := p.Metadata.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if p.Config != nil && p.Config.GetProxyProviderType() != v1alpha1.ProviderTypeKubernetes {
+		errs = append(errs, fmt.Errorf("unsupported provider type %v", p.Config.GetProxyProviderType()))
+	}
+
 	if len(p.Listeners) > 1 {
 		errs = append(errs, errors.New("no more than 1 listener is supported"))
 	}
 
-	if len(p.Listeners) > 0 {
-		for i := range p.Listeners {
-			listener := p.Listeners[i]
-			if len(listener.Ports) == 0 {
-				errs = append(errs, errors.New("listener ports field required"))
-			}
-			for j := range listener.Ports {
-				if len(listener.Ports[j].Name) == 0 {
-					errs = append(errs, errors.New("listener name field required"))
-				}
-				if listener.Ports[j].ServicePort < 1 || listener.Ports[j].ServicePort > 65353 {
-					errs = append(errs, errors.New("listener service port must be a valid port number"))
-				}
-				if listener.Ports[j].ContainerPort < 1024 || listener.Ports[j].ContainerPort > 65353 {
-					errs = append(errs, errors.New("listener container port must be a valid ephemeral port number"))
-				}
-			}
+	for i := range p.Listeners {
+		if err := p.Listeners[i].Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// Validate validates the provided InfraMetadata. The labels must be valid
+// Kubernetes labels, since they are set on the managed proxy infrastructure.
+func (m *InfraMetadata) Validate() error {
+	var errs []error
+
+	keys := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, msg := range validation.IsQualifiedName(k) {
+			errs = append(errs, fmt.Errorf("invalid label key %q: %s", k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(m.Labels[k]) {
+			errs = append(errs, fmt.Errorf("invalid value of label %q: %s", k, msg))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// Validate validates the provided ProxyListener.
+func (p *ProxyListener) Validate() error {
+	var errs []error
+
+	if len(p.Ports) == 0 {
+		errs = append(errs, errors.New("listener ports field required"))
+	}
+	for i := range p.Ports {
+		if err := p.Ports[i].Validate(); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
 	return utilerrors.NewAggregate(errs)
 }
 
+// Validate validates the provided ListenerPort.
+func (l *ListenerPort) Validate() error {
+	var errs []error
+
+	if len(l.Name) == 0 {
+		errs = append(errs, errors.New("listener name field required"))
+	}
+	if l.ServicePort < 1 || l.ServicePort > 65353 {
+		errs = append(errs, errors.New("listener service port must be a valid port number"))
+	}
+	if l.ContainerPort < 1024 || l.ContainerPort > 65353 {
+		errs = append(errs, errors.New("listener container port must be a valid ephemeral port number"))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// Validate validates the provided RateLimitInfra.
+func (r *RateLimitInfra) Validate() error {
+	if r == nil {
+		return errors.New("rate limit infra ir is nil")
+	}
+	if r.Backend == nil {
+		return errors.New("rate limit backend field required")
+	}
+	return nil
+}
+
 // ObjectName returns the name of the proxy infrastructure object.
 func (p *ProxyInfra) ObjectName() string {
 	if len(p.Name) == 0 {
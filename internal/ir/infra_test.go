@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
 
 func TestValidateInfra(t *testing.T) {
@@ -113,7 +115,36 @@ func TestValidateInfra(t *testing.T) {
 			},
 			expect: false,
 		},
-
+		{
+			name: "unsupported-provider-type",
+			infra: &Infra{
+				Proxy: &ProxyInfra{
+					Name:      "test",
+					Image:     "image",
+					Listeners: NewProxyListeners(),
+					Config: &v1alpha1.EnvoyProxy{
+						Spec: v1alpha1.EnvoyProxySpec{
+							Provider: &v1alpha1.ProxyProvider{
+								Type: v1alpha1.ProviderTypeFile,
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "invalid-metadata-labels",
+			infra: &Infra{
+				Proxy: &ProxyInfra{
+					Metadata:  &InfraMetadata{Labels: map[string]string{"invalid key": "value", "key": "invalid value"}},
+					Name:      "test",
+					Image:     "image",
+					Listeners: NewProxyListeners(),
+				},
+			},
+			expect: false,
+		},
 		{
 			name: "no-image",
 			infra: &Infra{
@@ -0,0 +1,46 @@
+package ir
+
+import (
+	"sigs.k8s.io/yaml"
+)
+
+// ToYAML returns the YAML representation of the Xds IR, e.g. to dump it
+// for debugging or to write a golden test input.
+func (x *Xds) ToYAML() ([]byte, error) {
+	return yaml.Marshal(x)
+}
+
+// FromYAML decodes the provided YAML or JSON representation of an Xds IR
+// into x, and validates it. Unknown fields are rejected, and x is left unchanged
+// if the decoded IR is invalid.
+func (x *Xds) FromYAML(data []byte) error {
+	out := new(Xds)
+	if err := yaml.UnmarshalStrict(data, out); err != nil {
+		return err
+	}
+	if err := out.Validate(); err != nil {
+		return err
+	}
+	*x = *out
+	return nil
+}
+
+// ToYAML returns the YAML representation of the Infra IR.
+func (i *Infra) ToYAML() ([]byte, error) {
+	return yaml.Marshal(i)
+}
+
+// FromYAML decodes the provided YAML or JSON representation of an Infra IR
+// into i, and validates it. Unknown fields are rejected, and i is left unchanged
+// if the decoded IR is invalid.
+func (i *Infra) FromYAML(data []byte) error {
+	out := new(Infra)
+	if err := yaml.UnmarshalStrict(data, out); err != nil {
+		return err
+	}
+	if err := out.Validate(); err != nil {
+		return err
+	}
+	*i = *out
+	return nil
+}
@@ -0,0 +1,79 @@
+package ir
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXdsYAML(t *testing.T) {
+	in := &Xds{
+		HTTP: []*HTTPListener{&happyHTTPListener},
+		TCP:  []*TCPListener{&happyTCPListenerTLSPassthrough},
+	}
+	data, err := in.ToYAML()
+	require.NoError(t, err)
+	require.Contains(t, string(data), "http:\n- address: 0.0.0.0\n")
+
+	out := new(Xds)
+	require.NoError(t, out.FromYAML(data))
+	require.Equal(t, in, out)
+
+	// JSON is accepted too.
+	out = new(Xds)
+	require.NoError(t, out.FromYAML([]byte(`{"http": [{"name": "json", "address": "0.0.0.0", "port": 80, "hostnames": ["*"]}]}`)))
+	require.Equal(t, "json", out.HTTP[0].Name)
+}
+
+func TestXdsFromYAMLInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "unknown field",
+			data: "http:\n- name: listener\n  unknown: value\n",
+		},
+		{
+			name: "invalid ir",
+			data: "http:\n- name: listener\n  address: 0.0.0.0\n",
+		},
+		{
+			name: "invalid yaml",
+			data: "http: [",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			out := &Xds{HTTP: []*HTTPListener{&happyHTTPListener}}
+			require.Error(t, out.FromYAML([]byte(tc.data)))
+			// The IR is left unchanged.
+			require.Equal(t, &Xds{HTTP: []*HTTPListener{&happyHTTPListener}}, out)
+		})
+	}
+}
+
+func TestInfraYAML(t *testing.T) {
+	in := NewInfra()
+	in.Proxy.Metadata.Labels["app"] = "envoy"
+	in.Proxy.Listeners[0].Ports = []ListenerPort{{
+		Name:          "http",
+		Protocol:      HTTPProtocolType,
+		ServicePort:   80,
+		ContainerPort: 8080,
+	}}
+	data, err := in.ToYAML()
+	require.NoError(t, err)
+
+	out := new(Infra)
+	require.NoError(t, out.FromYAML(data))
+	require.Equal(t, in, out)
+
+	// The ports of a listener are required.
+	in.Proxy.Listeners[0].Ports = nil
+	data, err = in.ToYAML()
+	require.NoError(t, err)
+	require.Error(t, out.FromYAML(data))
+}
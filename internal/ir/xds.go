@@ -1,10 +1,19 @@
 package ir
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"math"
 	"net"
+	"net/url"
+	"time"
 
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/tetratelabs/multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
 
 var (
@@ -15,10 +24,63 @@ var (
 	ErrTCPListenesSNIsEmpty          = errors.New("field SNIs must be specified with at least a single server name entry")
 	ErrTLSServerCertEmpty            = errors.New("field ServerCertificate must be specified")
 	ErrTLSPrivateKey                 = errors.New("field PrivateKey must be specified")
+	ErrTLSClientCAEmpty              = errors.New("field ClientCACertificate must be specified")
+	ErrForwardClientCertMode         = errors.New("field Mode specified is invalid")
+	ErrTLSVersionInvalid             = errors.New("only TLS versions 1.0 - 1.3 are supported")
+	ErrTLSVersionRange               = errors.New("field MinVersion must not be greater than MaxVersion")
+	ErrHTTP3TLSEmpty                 = errors.New("field TLS must be specified when HTTP3 is specified")
+	ErrHTTP3AdvertisedPortInvalid    = errors.New("field AdvertisedPort specified is invalid")
+	ErrHTTP1HeaderCaseInvalid        = errors.New("field HeaderCase must be PreserveCase or ProperCase")
+	ErrServerHeaderInvalid           = errors.New("field Transformation must be Overwrite, AppendIfAbsent or PassThrough")
+	ErrClientIPDetectionInvalid      = errors.New("only one of the XForwardedFor or CustomHeader fields must be specified")
+	ErrCustomHeaderNameEmpty         = errors.New("field Name must be specified")
+	ErrConnectionLimitInvalid        = errors.New("field Value must be greater than zero and CloseDelay must not be negative")
+	ErrClientTimeoutNegative         = errors.New("fields Idle, StreamIdle and Request must not be negative")
+	ErrTCPKeepaliveInvalid           = errors.New("fields Probes, IdleTime and Interval must be greater than zero")
+	ErrSocketOptionStateInvalid      = errors.New("only Prebind, Bound and Listening are supported for the socket option state")
+	ErrCompressionAlgorithmsEmpty    = errors.New("field Algorithms must be specified with at least a single algorithm entry")
+	ErrCompressionAlgorithmInvalid   = errors.New("only unique Gzip and Brotli entries are supported for the compression algorithms")
+	ErrCompressionLevelInvalid       = errors.New("only BestSpeed, Default and BestCompression are supported for the compression level")
+	ErrIPAccessControlEmpty          = errors.New("either Allow or Deny fields must be specified")
+	ErrCIDRInvalid                   = errors.New("field Prefix must be a valid IP address and PrefixLen must not exceed its length")
+	ErrLocalReplyEmpty               = errors.New("either Mappers or BodyFormat fields must be specified")
+	ErrLocalReplyStatusCodesEmpty    = errors.New("field StatusCodes must be specified")
+	ErrLocalReplyStatusInvalid       = errors.New("only HTTP status codes 100 - 599 are supported for local replies")
+	ErrLocalReplyBodyFormatInvalid   = errors.New("exactly one of the Text or JSON fields must be specified")
+	ErrAccessLogFormatInvalid        = errors.New("only one of the Text or JSON fields must be specified")
+	ErrAccessLogSinksEmpty           = errors.New("either Files or ALS fields must be specified")
+	ErrALSLogNameEmpty               = errors.New("field LogName must be specified")
+	ErrALSHostEmpty                  = errors.New("field Host must be specified")
+	ErrALSPortInvalid                = errors.New("field Port specified is invalid")
+	ErrTracingProviderInvalid        = errors.New("only OpenTelemetry, Zipkin and Datadog are supported for the tracing provider")
+	ErrTracingHostEmpty              = errors.New("field Host must be specified")
+	ErrTracingPortInvalid            = errors.New("field Port specified is invalid")
+	ErrTracingServiceNameEmpty       = errors.New("field ServiceName must be specified")
+	ErrTracingSamplingRateInvalid    = errors.New("field SamplingRate must be between 0 and 100")
+	ErrCustomTagNameEmpty            = errors.New("field Name must be specified")
+	ErrCustomTagValueInvalid         = errors.New("exactly one of the Literal, Environment, RequestHeader or Metadata fields must be specified")
+	ErrCustomTagMetadataInvalid      = errors.New("fields Kind, Key and Path must be specified")
+	ErrCustomTagSourceNameEmpty      = errors.New("field Name must be specified")
+	ErrWasmNameEmpty                 = errors.New("field Name must be specified")
+	ErrWasmNameDuplicate             = errors.New("field Name must be unique within the Wasm extensions of a listener")
+	ErrWasmURLInvalid                = errors.New("field URL must be a valid http or https URL")
+	ErrWasmSHA256Invalid             = errors.New("field SHA256 must be a hex-encoded SHA-256 checksum")
+	ErrFaultInjectionEmpty           = errors.New("either Delay or Abort fields must be specified")
+	ErrFaultInjectionDelayInvalid    = errors.New("field FixedDelay must be greater than zero")
+	ErrFaultInjectionStatusInvalid   = errors.New("only HTTP status codes 200 - 599 are supported for fault injection aborts")
+	ErrFaultInjectionPercentInvalid  = errors.New("field Percentage must be between 0 and 100")
+	ErrBufferMaxRequestBytesInvalid  = errors.New("field MaxRequestBytes must be greater than zero")
+	ErrProtoDescriptorEmpty          = errors.New("field ProtoDescriptor must be specified")
+	ErrGRPCServicesEmpty             = errors.New("field Services must be specified")
+	ErrLuaNameEmpty                  = errors.New("field Name must be specified")
+	ErrLuaCodeEmpty                  = errors.New("field Code must be specified")
 	ErrHTTPRouteNameEmpty            = errors.New("field Name must be specified")
 	ErrHTTPRouteMatchEmpty           = errors.New("either PathMatch, HeaderMatches or QueryParamMatches fields must be specified")
+	ErrBackendWeightsOverflow        = errors.New("the sum of fields Valid and Invalid must not exceed the maximum uint32 value")
+	ErrUpgradeEmpty                  = errors.New("either WebSocket or Connect fields must be set")
 	ErrRouteDestinationHostInvalid   = errors.New("field Address must be a valid IP address")
 	ErrRouteDestinationPortInvalid   = errors.New("field Port specified is invalid")
+	ErrDestinationProtocolInvalid    = errors.New("only HTTP and HTTP2 are supported for the destination protocol")
 	ErrStringMatchConditionInvalid   = errors.New("only one of the Exact, Prefix or SafeRegex fields must be specified")
 	ErrDirectResponseStatusInvalid   = errors.New("only HTTP status codes 100 - 599 are supported for DirectResponse")
 	ErrRedirectUnsupportedStatus     = errors.New("only HTTP status codes 301 and 302 are supported for redirect filters")
@@ -28,6 +90,30 @@ var (
 	ErrAddHeaderEmptyName            = errors.New("header modifier filter cannot configure a header without a name to be added")
 	ErrAddHeaderDuplicate            = errors.New("header modifier filter attempts to add the same header more than once (case insensitive)")
 	ErrRemoveHeaderDuplicate         = errors.New("header modifier filter attempts to remove the same header more than once (case insensitive)")
+	ErrHTTPTimeoutNegative           = errors.New("field Request and BackendRequest must not be negative")
+	ErrHTTPTimeoutBackendRequest     = errors.New("field BackendRequest must not be greater than Request")
+	ErrRetryStatusCodeInvalid        = errors.New("only HTTP status codes 100 - 599 are supported for retries")
+	ErrRetryBackOffIntervalInvalid   = errors.New("field BaseInterval must be greater than zero and not greater than MaxInterval")
+	ErrMaxEjectionPercentInvalid     = errors.New("field MaxEjectionPercent must be between 0 and 100")
+	ErrHealthCheckerInvalid          = errors.New("exactly one of the HTTP or TCP fields must be specified")
+	ErrHealthCheckTimeoutInvalid     = errors.New("fields Timeout and Interval must be greater than zero")
+	ErrHealthCheckThresholdInvalid   = errors.New("fields HealthyThreshold and UnhealthyThreshold must be greater than zero")
+	ErrHTTPHealthCheckPathEmpty      = errors.New("field Path must be specified")
+	ErrHTTPHealthCheckStatusInvalid  = errors.New("only HTTP status codes 100 - 599 are supported for health checks")
+	ErrLoadBalancerInvalid           = errors.New("only one of the RoundRobin, LeastRequest, Random or ConsistentHash fields must be specified")
+	ErrLeastRequestChoiceCount       = errors.New("field ChoiceCount must be at least 2")
+	ErrConsistentHashKeyInvalid      = errors.New("exactly one of the SourceIP, Header or Cookie fields must be specified")
+	ErrConsistentHashAlgorithm       = errors.New("only RingHash and Maglev are supported for the consistent hash algorithm")
+	ErrHashNameEmpty                 = errors.New("field Name must be specified")
+	ErrSubjectAltNameEmpty           = errors.New("field SubjectAltNames must not contain empty names")
+	ErrRateLimitValueInvalid         = errors.New("field Requests must be greater than zero and Unit must be Second, Minute or Hour")
+	ErrRateLimitRuleHeadersEmpty     = errors.New("field HeaderMatches must be specified with at least a single header entry")
+	ErrRateLimitHeaderNameEmpty      = errors.New("field Name must be specified")
+	ErrLocalRateLimitRuleUnit        = errors.New("field Unit of a rule must not be shorter than the Unit of the default limit")
+	ErrEnvoyPatchPolicyNameEmpty     = errors.New("fields Name and Namespace must be specified")
+	ErrJSONPatchTypeInvalid          = errors.New("only Listener, RouteConfiguration and Cluster types are supported for JSON patches")
+	ErrJSONPatchNameEmpty            = errors.New("field Name must be specified")
+	ErrJSONPatchOpInvalid            = errors.New("only add, remove, replace, move, copy and test are supported for JSON patch operations")
 )
 
 // Xds holds the intermediate representation of a Gateway and is
@@ -35,9 +121,11 @@ var (
 // +k8s:deepcopy-gen=true
 type Xds struct {
 	// HTTP listeners exposed by the gateway.
-	HTTP []*HTTPListener
+	HTTP []*HTTPListener `json:"http,omitempty"`
 	// TCP Listeners exposed by the gateway.
-	TCP []*TCPListener
+	TCP []*TCPListener `json:"tcp,omitempty"`
+	// EnvoyPatchPolicies patching the xDS resources of the gateway, in order.
+	EnvoyPatchPolicies []*EnvoyPatchPolicy `json:"envoyPatchPolicies,omitempty"`
 }
 
 // Validate the fields within the Xds structure.
@@ -48,6 +136,11 @@ func (x Xds) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+	for _, policy := range x.EnvoyPatchPolicies {
+		if err := policy.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
 	return errs
 }
 
@@ -73,20 +166,71 @@ func (x Xds) GetTCPListener(name string) *TCPListener {
 // +k8s:deepcopy-gen=true
 type HTTPListener struct {
 	// Name of the HttpListener
-	Name string
+	Name string `json:"name"`
 	// Address that the listener should listen on.
-	Address string
+	Address string `json:"address,omitempty"`
 	// Port on which the service can be expected to be accessed by clients.
-	Port uint32
+	Port uint32 `json:"port,omitempty"`
 	// Hostnames (Host/Authority header value) with which the service can be expected to be accessed by clients.
 	// This field is required. Wildcard hosts are supported in the suffix or prefix form.
 	// Refer to https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/route/v3/route_components.proto#config-route-v3-virtualhost
 	// for more info.
-	Hostnames []string
+	Hostnames []string `json:"hostnames,omitempty"`
 	// Tls certificate info. If omitted, the gateway will expose a plain text HTTP server.
-	TLS *TLSListenerConfig
+	TLS *TLSListenerConfig `json:"tls,omitempty"`
+	// HTTP3 serves the listener over HTTP/3 as well, on a UDP socket bound to
+	// the same address and port. It requires TLS to be set.
+	HTTP3 *HTTP3Settings `json:"http3,omitempty"`
+	// HTTP1 defines the HTTP/1.1 settings of the connections from the clients and
+	// to the destinations of the routes of the listener.
+	HTTP1 *HTTP1Settings `json:"http1,omitempty"`
+	// ClientIPDetection defines how the address of the client is detected. If
+	// unset, the Envoy defaults are used.
+	ClientIPDetection *ClientIPDetection `json:"clientIPDetection,omitempty"`
+	// ConnectionLimit limits the active connections of the listener. If unset,
+	// the connections are not limited.
+	ConnectionLimit *ConnectionLimit `json:"connectionLimit,omitempty"`
+	// Timeouts of the connections and requests of the clients. If unset, the
+	// Envoy defaults are used.
+	Timeouts *ClientTimeouts `json:"timeouts,omitempty"`
+	// MaxRequestHeadersKB is the maximum size of the request headers in KiB. If
+	// zero, the Envoy default is used.
+	MaxRequestHeadersKB uint32 `json:"maxRequestHeadersKB,omitempty"`
+	// TCPKeepalive enables TCP keepalive on the connections of the clients.
+	TCPKeepalive *TCPKeepalive `json:"tcpKeepalive,omitempty"`
+	// SocketOptions are set on the listening socket and the connections of the clients.
+	SocketOptions []*SocketOption `json:"socketOptions,omitempty"`
+	// Compression compresses the responses sent to the clients. If unset,
+	// responses are not compressed.
+	Compression *Compression `json:"compression,omitempty"`
+	// IPAccessControl restricts the clients of the listener by address. If
+	// unset, all clients are allowed.
+	IPAccessControl *IPAccessControl `json:"ipAccessControl,omitempty"`
+	// LocalReply customizes the responses generated by Envoy. If unset, Envoy
+	// generates plain text responses.
+	LocalReply *LocalReply `json:"localReply,omitempty"`
+	// Wasm extensions processing the requests of the listener, in order.
+	Wasm []*Wasm `json:"wasm,omitempty"`
+	// AccessLog defines the access logs of the listener. If unset, no access
+	// logs are written.
+	AccessLog *AccessLog `json:"accessLog,omitempty"`
+	// Tracing defines the tracing of the requests of the listener. If unset,
+	// requests are not traced.
+	Tracing *Tracing `json:"tracing,omitempty"`
+	// AddRequestHeaders defines header/value sets to be added to the headers of
+	// the requests of all the routes of the listener.
+	AddRequestHeaders []AddHeader `json:"addRequestHeaders,omitempty"`
+	// AddResponseHeaders defines header/value sets to be added to the headers of
+	// the responses of all the routes of the listener.
+	AddResponseHeaders []AddHeader `json:"addResponseHeaders,omitempty"`
+	// RemoveResponseHeaders defines a list of headers to be removed from the
+	// responses of all the routes of the listener.
+	RemoveResponseHeaders []string `json:"removeResponseHeaders,omitempty"`
+	// ServerHeader defines the Server header of the responses. If unset, Envoy
+	// overwrites the Server header with "envoy".
+	ServerHeader *ServerHeader `json:"serverHeader,omitempty"`
 	// Routes associated with HTTP traffic to the service.
-	Routes []*HTTPRoute
+	Routes []*HTTPRoute `json:"routes,omitempty"`
 }
 
 // Validate the fields within the HTTPListener structure
@@ -109,6 +253,93 @@ func (h HTTPListener) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+	if h.HTTP3 != nil {
+		if h.TLS == nil {
+			errs = multierror.Append(errs, ErrHTTP3TLSEmpty)
+		}
+		if err := h.HTTP3.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.HTTP1 != nil {
+		if err := h.HTTP1.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.ClientIPDetection != nil {
+		if err := h.ClientIPDetection.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.ConnectionLimit != nil {
+		if err := h.ConnectionLimit.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.Timeouts != nil {
+		if err := h.Timeouts.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.TCPKeepalive != nil {
+		if err := h.TCPKeepalive.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	for _, option := range h.SocketOptions {
+		if err := option.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.Compression != nil {
+		if err := h.Compression.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.IPAccessControl != nil {
+		if err := h.IPAccessControl.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.LocalReply != nil {
+		if err := h.LocalReply.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	wasmNames := map[string]bool{}
+	for _, wasm := range h.Wasm {
+		if err := wasm.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		if wasmNames[wasm.Name] {
+			errs = multierror.Append(errs, ErrWasmNameDuplicate)
+		}
+		wasmNames[wasm.Name] = true
+	}
+	if h.AccessLog != nil {
+		if err := h.AccessLog.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.Tracing != nil {
+		if err := h.Tracing.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if err := validateAddHeaders(h.AddRequestHeaders); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := validateAddHeaders(h.AddResponseHeaders); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := validateRemoveHeaders(h.RemoveResponseHeaders); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if h.ServerHeader != nil {
+		if err := h.ServerHeader.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
 	for _, route := range h.Routes {
 		if err := route.Validate(); err != nil {
 			errs = multierror.Append(errs, err)
@@ -117,13 +348,198 @@ func (h HTTPListener) Validate() error {
 	return errs
 }
 
+// HTTP3Settings holds the configuration of the HTTP/3 listener.
+// +k8s:deepcopy-gen=true
+type HTTP3Settings struct {
+	// AdvertisedPort is the port advertised to clients in the alt-svc header,
+	// i.e. the port clients connect to rather than the port Envoy binds.
+	AdvertisedPort uint32 `json:"advertisedPort,omitempty"`
+}
+
+// Validate the fields within the HTTP3Settings structure
+func (h HTTP3Settings) Validate() error {
+	if h.AdvertisedPort == 0 || h.AdvertisedPort > 65535 {
+		return ErrHTTP3AdvertisedPortInvalid
+	}
+	return nil
+}
+
+// ServerHeaderTransformation is the handling of the Server header of the responses
+// of the destinations.
+type ServerHeaderTransformation string
+
+const (
+	// ServerHeaderOverwrite overwrites the Server header of the responses.
+	ServerHeaderOverwrite ServerHeaderTransformation = "Overwrite"
+	// ServerHeaderAppendIfAbsent sets the Server header of the responses without one.
+	ServerHeaderAppendIfAbsent ServerHeaderTransformation = "AppendIfAbsent"
+	// ServerHeaderPassThrough passes the Server header of the responses through.
+	ServerHeaderPassThrough ServerHeaderTransformation = "PassThrough"
+)
+
+// ServerHeader holds the Server header settings of a listener.
+// +k8s:deepcopy-gen=true
+type ServerHeader struct {
+	// Transformation is the handling of the Server header of the responses of
+	// the destinations.
+	Transformation ServerHeaderTransformation `json:"transformation,omitempty"`
+	// Value is the value of the Server header set by Envoy, or empty to use the
+	// Envoy default.
+	Value string `json:"value,omitempty"`
+}
+
+// Validate the fields within the ServerHeader structure
+func (s ServerHeader) Validate() error {
+	switch s.Transformation {
+	case ServerHeaderOverwrite, ServerHeaderAppendIfAbsent, ServerHeaderPassThrough:
+		return nil
+	default:
+		return ErrServerHeaderInvalid
+	}
+}
+
+// HeaderCase is the casing of HTTP/1.1 header names.
+type HeaderCase string
+
+const (
+	// HeaderCasePreserve preserves the casing of the header names as received.
+	HeaderCasePreserve HeaderCase = "PreserveCase"
+	// HeaderCaseProper capitalizes the first letter of the words of the header
+	// names, e.g. "Content-Type".
+	HeaderCaseProper HeaderCase = "ProperCase"
+)
+
+// HTTP1Settings holds the HTTP/1.1 settings of a listener.
+// +k8s:deepcopy-gen=true
+type HTTP1Settings struct {
+	// HeaderCase is the casing of the header names sent to the clients and the
+	// destinations. Envoy lowercases the header names by default.
+	HeaderCase HeaderCase `json:"headerCase,omitempty"`
+}
+
+// Validate the fields within the HTTP1Settings structure
+func (h HTTP1Settings) Validate() error {
+	if h.HeaderCase != HeaderCasePreserve && h.HeaderCase != HeaderCaseProper {
+		return ErrHTTP1HeaderCaseInvalid
+	}
+	return nil
+}
+
+// ClientTimeouts holds the timeouts of the connections and requests of the clients.
+// A zero timeout disables the timeout.
+// +k8s:deepcopy-gen=true
+type ClientTimeouts struct {
+	// Idle is the idle timeout of connections without active requests.
+	Idle *metav1.Duration `json:"idle,omitempty"`
+	// StreamIdle is the idle timeout of requests.
+	StreamIdle *metav1.Duration `json:"streamIdle,omitempty"`
+	// Request is the time allowed to receive a complete request.
+	Request *metav1.Duration `json:"request,omitempty"`
+}
+
+// Validate the fields within the ClientTimeouts structure
+func (c ClientTimeouts) Validate() error {
+	for _, timeout := range []*metav1.Duration{c.Idle, c.StreamIdle, c.Request} {
+		if timeout != nil && timeout.Duration < 0 {
+			return ErrClientTimeoutNegative
+		}
+	}
+	return nil
+}
+
+// ClientIPDetection holds the configuration of the detection of the client address.
+// +k8s:deepcopy-gen=true
+type ClientIPDetection struct {
+	// XForwardedFor detects the client address from the X-Forwarded-For header.
+	XForwardedFor *XForwardedForIPDetection `json:"xForwardedFor,omitempty"`
+	// CustomHeader detects the client address from a custom header.
+	CustomHeader *CustomHeaderIPDetection `json:"customHeader,omitempty"`
+}
+
+// Validate the fields within the ClientIPDetection structure
+func (c ClientIPDetection) Validate() error {
+	if (c.XForwardedFor == nil) == (c.CustomHeader == nil) {
+		return ErrClientIPDetectionInvalid
+	}
+	if c.XForwardedFor != nil {
+		return c.XForwardedFor.Validate()
+	}
+	if c.CustomHeader != nil {
+		return c.CustomHeader.Validate()
+	}
+	return nil
+}
+
+// XForwardedForIPDetection holds the configuration of the detection of the client
+// address from the X-Forwarded-For header.
+// +k8s:deepcopy-gen=true
+type XForwardedForIPDetection struct {
+	// NumTrustedHops is the number of trusted proxies in front of Envoy.
+	NumTrustedHops uint32 `json:"numTrustedHops,omitempty"`
+	// SkipAppend doesn't append the peer address to the X-Forwarded-For header.
+	SkipAppend bool `json:"skipAppend,omitempty"`
+}
+
+// Validate the fields within the XForwardedForIPDetection structure. Any number
+// of trusted hops is valid, the peer address being used if there are fewer
+// addresses in the header.
+func (x XForwardedForIPDetection) Validate() error {
+	return nil
+}
+
+// CustomHeaderIPDetection holds the configuration of the detection of the client
+// address from a custom header.
+// +k8s:deepcopy-gen=true
+type CustomHeaderIPDetection struct {
+	// Name of the header.
+	Name string `json:"name"`
+	// FailClosed rejects requests without a valid address in the header.
+	FailClosed bool `json:"failClosed,omitempty"`
+}
+
+// Validate the fields within the CustomHeaderIPDetection structure
+func (c CustomHeaderIPDetection) Validate() error {
+	if c.Name == "" {
+		return ErrCustomHeaderNameEmpty
+	}
+	return nil
+}
+
 // TLSListenerConfig holds the configuration for downstream TLS context.
 // +k8s:deepcopy-gen=true
 type TLSListenerConfig struct {
+	// SecretName is the name of the Secret holding the ServerCertificate and the
+	// PrivateKey, in the "namespace/name" form. The listeners sharing a Secret
+	// share the secret served over SDS, so rotating the Secret only updates the
+	// served secret. If unset, the served secret is specific to the listener.
+	SecretName string `json:"secretName,omitempty"`
 	// ServerCertificate of the server.
-	ServerCertificate []byte
+	ServerCertificate []byte `json:"serverCertificate,omitempty"`
 	// PrivateKey for the server.
-	PrivateKey []byte
+	PrivateKey []byte `json:"privateKey,omitempty"`
+	// ClientCACertificate is the CA bundle used to verify client certificates.
+	// If unset, clients are not asked for certificates.
+	ClientCACertificate []byte `json:"clientCACertificate,omitempty"`
+	// ClientCRL is the certificate revocation list of the ClientCACertificate.
+	ClientCRL []byte `json:"clientCRL,omitempty"`
+	// RequireClientCertificate rejects clients that don't present a certificate.
+	RequireClientCertificate bool `json:"requireClientCertificate,omitempty"`
+	// ForwardClientCertDetails defines the x-forwarded-client-cert header of
+	// requests received over mutual TLS.
+	ForwardClientCertDetails *ForwardClientCertDetails `json:"forwardClientCertDetails,omitempty"`
+	// MinVersion is the minimum TLS version. If unset, the Envoy default is used.
+	MinVersion TLSVersion `json:"minVersion,omitempty"`
+	// MaxVersion is the maximum TLS version. If unset, the Envoy default is used.
+	MaxVersion TLSVersion `json:"maxVersion,omitempty"`
+	// Ciphers is the list of cipher suites supported for TLS 1.0 - 1.2. If unset,
+	// the Envoy defaults are used.
+	Ciphers []string `json:"ciphers,omitempty"`
+	// ECDHCurves is the list of supported ECDH curves. If unset, the Envoy
+	// defaults are used.
+	ECDHCurves []string `json:"ecdhCurves,omitempty"`
+	// ALPNProtocols is the list of protocols advertised over ALPN, in order of
+	// preference. If unset, no protocol is negotiated.
+	ALPNProtocols []string `json:"alpnProtocols,omitempty"`
 }
 
 // Validate the fields within the TLSListenerConfig structure
@@ -135,38 +551,161 @@ func (t TLSListenerConfig) Validate() error {
 	if len(t.PrivateKey) == 0 {
 		errs = multierror.Append(errs, ErrTLSPrivateKey)
 	}
+	if len(t.ClientCACertificate) == 0 && (len(t.ClientCRL) > 0 || t.RequireClientCertificate) {
+		errs = multierror.Append(errs, ErrTLSClientCAEmpty)
+	}
+	if t.ForwardClientCertDetails != nil {
+		if err := t.ForwardClientCertDetails.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if !t.MinVersion.valid() || !t.MaxVersion.valid() {
+		errs = multierror.Append(errs, ErrTLSVersionInvalid)
+	} else if t.MinVersion != "" && t.MaxVersion != "" && t.MinVersion > t.MaxVersion {
+		errs = multierror.Append(errs, ErrTLSVersionRange)
+	}
 	return errs
 }
 
+// TLSVersion is a TLS protocol version.
+type TLSVersion string
+
+const (
+	TLSv10 TLSVersion = "1.0"
+	TLSv11 TLSVersion = "1.1"
+	TLSv12 TLSVersion = "1.2"
+	TLSv13 TLSVersion = "1.3"
+)
+
+// valid returns true if the version is unset or a supported TLS version.
+func (v TLSVersion) valid() bool {
+	switch v {
+	case "", TLSv10, TLSv11, TLSv12, TLSv13:
+		return true
+	default:
+		return false
+	}
+}
+
+// ForwardClientCertMode is the handling of the x-forwarded-client-cert header.
+type ForwardClientCertMode string
+
+const (
+	ForwardClientCertSanitize          ForwardClientCertMode = "Sanitize"
+	ForwardClientCertForwardOnly       ForwardClientCertMode = "ForwardOnly"
+	ForwardClientCertAppendForward     ForwardClientCertMode = "AppendForward"
+	ForwardClientCertSanitizeSet       ForwardClientCertMode = "SanitizeSet"
+	ForwardClientCertAlwaysForwardOnly ForwardClientCertMode = "AlwaysForwardOnly"
+)
+
+// ForwardClientCertDetails holds the handling of the x-forwarded-client-cert header
+// and the details of the client certificate set in it.
+// +k8s:deepcopy-gen=true
+type ForwardClientCertDetails struct {
+	// Mode is the handling of the header.
+	Mode ForwardClientCertMode `json:"mode,omitempty"`
+	// Subject sets the subject of the client certificate.
+	Subject bool `json:"subject,omitempty"`
+	// Cert sets the PEM encoded client certificate.
+	Cert bool `json:"cert,omitempty"`
+	// Chain sets the PEM encoded client certificate chain.
+	Chain bool `json:"chain,omitempty"`
+	// DNS sets the DNS subject alternative names of the client certificate.
+	DNS bool `json:"dns,omitempty"`
+	// URI sets the URI subject alternative name of the client certificate.
+	URI bool `json:"uri,omitempty"`
+}
+
+// Validate the fields within the ForwardClientCertDetails structure
+func (f ForwardClientCertDetails) Validate() error {
+	switch f.Mode {
+	case ForwardClientCertSanitize, ForwardClientCertForwardOnly, ForwardClientCertAppendForward,
+		ForwardClientCertSanitizeSet, ForwardClientCertAlwaysForwardOnly:
+		return nil
+	default:
+		return ErrForwardClientCertMode
+	}
+}
+
 // DestinationWeights stores the weights of valid and invalid backends for the route so that 500 error responses can be returned in the same proportions
+// +k8s:deepcopy-gen=true
 type BackendWeights struct {
-	Valid   uint32
-	Invalid uint32
+	Valid   uint32 `json:"valid,omitempty"`
+	Invalid uint32 `json:"invalid,omitempty"`
+}
+
+// Validate the fields within the BackendWeights structure. The weights are summed
+// into the total weight of the route's clusters, which must fit in a uint32.
+func (b BackendWeights) Validate() error {
+	if uint64(b.Valid)+uint64(b.Invalid) > math.MaxUint32 {
+		return ErrBackendWeightsOverflow
+	}
+	return nil
 }
 
 // HTTPRoute holds the route information associated with the HTTP Route
 // +k8s:deepcopy-gen=true
 type HTTPRoute struct {
 	// Name of the HTTPRoute
-	Name string
+	Name string `json:"name"`
+	// Hostname that the route matches, used as the domain of the route's virtual host.
+	// If unset, the route matches the Hostnames of its HTTPListener.
+	Hostname string `json:"hostname,omitempty"`
 	// PathMatch defines the match conditions on the path.
-	PathMatch *StringMatch
+	PathMatch *StringMatch `json:"pathMatch,omitempty"`
 	// HeaderMatches define the match conditions on the request headers for this route.
-	HeaderMatches []*StringMatch
+	HeaderMatches []*StringMatch `json:"headerMatches,omitempty"`
 	// QueryParamMatches define the match conditions on the query parameters.
-	QueryParamMatches []*StringMatch
+	QueryParamMatches []*StringMatch `json:"queryParamMatches,omitempty"`
 	// DestinationWeights stores the weights of valid and invalid backends for the route so that 500 error responses can be returned in the same proportions
-	BackendWeights BackendWeights
+	BackendWeights BackendWeights `json:"backendWeights,omitempty"`
 	// AddRequestHeaders defines header/value sets to be added to the headers of requests.
-	AddRequestHeaders []AddHeader
+	AddRequestHeaders []AddHeader `json:"addRequestHeaders,omitempty"`
 	// RemoveRequestHeaders defines a list of headers to be removed from requests.
-	RemoveRequestHeaders []string
+	RemoveRequestHeaders []string `json:"removeRequestHeaders,omitempty"`
+	// AddResponseHeaders defines header/value sets to be added to the headers of responses.
+	AddResponseHeaders []AddHeader `json:"addResponseHeaders,omitempty"`
+	// RemoveResponseHeaders defines a list of headers to be removed from responses.
+	RemoveResponseHeaders []string `json:"removeResponseHeaders,omitempty"`
 	// Direct responses to be returned for this route. Takes precedence over Destinations and Redirect.
-	DirectResponse *DirectResponse
+	DirectResponse *DirectResponse `json:"directResponse,omitempty"`
 	// Redirections to be returned for this route. Takes precedence over Destinations.
-	Redirect *Redirect
+	Redirect *Redirect `json:"redirect,omitempty"`
 	// Destinations associated with this matched route.
-	Destinations []*RouteDestination
+	Destinations []*RouteDestination `json:"destinations,omitempty"`
+	// Timeout defines the request and backend request timeouts of the route.
+	Timeout *HTTPTimeout `json:"timeout,omitempty"`
+	// Retry defines the retry policy of requests matching the route.
+	Retry *Retry `json:"retry,omitempty"`
+	// LoadBalancer defines the load balancing policy of the route's destinations.
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+	// CircuitBreaker defines the connection and request limits of the route's destinations.
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	// HealthCheck defines how the health of the route's destinations is determined.
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+	// BackendTLS defines the TLS connections originated to the route's destinations.
+	BackendTLS *BackendTLSConfig `json:"backendTLS,omitempty"`
+	// TCPKeepalive enables TCP keepalive on the connections to the route's destinations.
+	TCPKeepalive *TCPKeepalive `json:"tcpKeepalive,omitempty"`
+	// RateLimit defines the rate limits of the requests matching the route.
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// IPAccessControl restricts the clients of the route by address, in
+	// addition to the IPAccessControl of the listener.
+	IPAccessControl *IPAccessControl `json:"ipAccessControl,omitempty"`
+	// Lua is the Lua script processing the requests matching the route.
+	Lua *Lua `json:"lua,omitempty"`
+	// FaultInjection defines the faults injected into the requests matching
+	// the route.
+	FaultInjection *FaultInjection `json:"faultInjection,omitempty"`
+	// GRPCJSONTranscoder transcodes the JSON requests matching the route into
+	// gRPC requests.
+	GRPCJSONTranscoder *GRPCJSONTranscoder `json:"grpcJSONTranscoder,omitempty"`
+	// Buffer limits the size of the request bodies of the route, buffering
+	// the requests until they are complete.
+	Buffer *Buffer `json:"buffer,omitempty"`
+	// Upgrade defines the protocol upgrades allowed for the requests of the route.
+	// If unset, upgrades are not allowed.
+	Upgrade *Upgrade `json:"upgrade,omitempty"`
 }
 
 // Validate the fields within the HTTPRoute structure
@@ -193,6 +732,9 @@ func (h HTTPRoute) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+	if err := h.BackendWeights.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
 	for _, dest := range h.Destinations {
 		if err := dest.Validate(); err != nil {
 			errs = multierror.Append(errs, err)
@@ -208,44 +750,148 @@ func (h HTTPRoute) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
-	if len(h.AddRequestHeaders) > 0 {
-		occurred := map[string]bool{}
-		for _, header := range h.AddRequestHeaders {
-			if err := header.Validate(); err != nil {
-				errs = multierror.Append(errs, err)
-			}
-			if !occurred[header.Name] {
-				occurred[header.Name] = true
-			} else {
-				errs = multierror.Append(errs, ErrAddHeaderDuplicate)
-				break
-			}
+	if h.Timeout != nil {
+		if err := h.Timeout.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.Retry != nil {
+		if err := h.Retry.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.LoadBalancer != nil {
+		if err := h.LoadBalancer.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.CircuitBreaker != nil {
+		if err := h.CircuitBreaker.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.HealthCheck != nil {
+		if err := h.HealthCheck.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.BackendTLS != nil {
+		if err := h.BackendTLS.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.TCPKeepalive != nil {
+		if err := h.TCPKeepalive.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.RateLimit != nil {
+		if err := h.RateLimit.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.IPAccessControl != nil {
+		if err := h.IPAccessControl.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.Lua != nil {
+		if err := h.Lua.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.FaultInjection != nil {
+		if err := h.FaultInjection.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.GRPCJSONTranscoder != nil {
+		if err := h.GRPCJSONTranscoder.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.Buffer != nil {
+		if err := h.Buffer.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.Upgrade != nil {
+		if err := h.Upgrade.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if err := validateAddHeaders(h.AddRequestHeaders); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := validateRemoveHeaders(h.RemoveRequestHeaders); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := validateAddHeaders(h.AddResponseHeaders); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := validateRemoveHeaders(h.RemoveResponseHeaders); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	return errs
+}
+
+// validateAddHeaders validates the provided headers to be added, which must be named
+// and added at most once.
+func validateAddHeaders(headers []AddHeader) error {
+	var errs error
+	occurred := map[string]bool{}
+	for _, header := range headers {
+		if err := header.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		if !occurred[header.Name] {
+			occurred[header.Name] = true
+		} else {
+			errs = multierror.Append(errs, ErrAddHeaderDuplicate)
+			break
 		}
 	}
-	if len(h.RemoveRequestHeaders) > 0 {
-		occurred := map[string]bool{}
-		for _, header := range h.RemoveRequestHeaders {
-			if !occurred[header] {
-				occurred[header] = true
-			} else {
-				errs = multierror.Append(errs, ErrRemoveHeaderDuplicate)
-				break
-			}
+	return errs
+}
+
+// validateRemoveHeaders validates the provided headers to be removed, which must be
+// removed at most once.
+func validateRemoveHeaders(headers []string) error {
+	var errs error
+	occurred := map[string]bool{}
+	for _, header := range headers {
+		if !occurred[header] {
+			occurred[header] = true
+		} else {
+			errs = multierror.Append(errs, ErrRemoveHeaderDuplicate)
+			break
 		}
 	}
 	return errs
 }
 
 // RouteDestination holds the destination details associated with the route
+// +k8s:deepcopy-gen=true
 type RouteDestination struct {
 	// Host refers to the FQDN or IP address of the backend service.
-	Host string
+	Host string `json:"host,omitempty"`
 	// Port on the service to forward the request to.
-	Port uint32
+	Port uint32 `json:"port,omitempty"`
 	// Weight associated with this destination.
-	Weight uint32
+	Weight uint32 `json:"weight,omitempty"`
+	// Protocol used to connect to the destination. Defaults to HTTP/1.1
+	// when unset.
+	Protocol AppProtocol `json:"protocol,omitempty"`
 }
 
+// AppProtocol is the application protocol used to connect to a destination.
+type AppProtocol string
+
+const (
+	HTTP  AppProtocol = "HTTP"
+	HTTP2 AppProtocol = "HTTP2"
+)
+
 // Validate the fields within the RouteDestination structure
 func (r RouteDestination) Validate() error {
 	var errs error
@@ -256,16 +902,19 @@ func (r RouteDestination) Validate() error {
 	if r.Port == 0 {
 		errs = multierror.Append(errs, ErrRouteDestinationPortInvalid)
 	}
+	if r.Protocol != "" && r.Protocol != HTTP && r.Protocol != HTTP2 {
+		errs = multierror.Append(errs, ErrDestinationProtocolInvalid)
+	}
 
 	return errs
 }
 
-// Add header configures a headder to be added to a request.
+// Add header configures a header to be added to a request or response.
 // +k8s:deepcopy-gen=true
 type AddHeader struct {
-	Name   string
-	Value  string
-	Append bool
+	Name   string `json:"name"`
+	Value  string `json:"value,omitempty"`
+	Append bool   `json:"append,omitempty"`
 }
 
 // Validate the fields within the AddHeader structure
@@ -283,9 +932,9 @@ func (h AddHeader) Validate() error {
 type DirectResponse struct {
 	// Body configures the body of the direct response. Currently only a string response
 	// is supported, but in the future a config.core.v3.DataSource may replace it.
-	Body *string
+	Body *string `json:"body,omitempty"`
 	// StatusCode will be used for the direct response's status code.
-	StatusCode uint32
+	StatusCode uint32 `json:"statusCode,omitempty"`
 }
 
 // Validate the fields within the DirectResponse structure
@@ -302,15 +951,15 @@ func (r DirectResponse) Validate() error {
 // +k8s:deepcopy-gen=true
 type Redirect struct {
 	// Scheme configures the replacement of the request's scheme.
-	Scheme *string
+	Scheme *string `json:"scheme,omitempty"`
 	// Hostname configures the replacement of the request's hostname.
-	Hostname *string
+	Hostname *string `json:"hostname,omitempty"`
 	// Path contains config for rewriting the path of the request.
-	Path *HTTPPathModifier
+	Path *HTTPPathModifier `json:"path,omitempty"`
 	// Port configures the replacement of the request's port.
-	Port *uint32
+	Port *uint32 `json:"port,omitempty"`
 	// Status code configures the redirection response's status code.
-	StatusCode *int32
+	StatusCode *int32 `json:"statusCode,omitempty"`
 }
 
 // Validate the fields within the Redirect structure
@@ -338,106 +987,1450 @@ func (r Redirect) Validate() error {
 	return errs
 }
 
-// HTTPPathModifier holds instructions for how to modify the path of a request on a redirect response
+// HTTPTimeout holds the timeouts applied to requests matching an HTTPRoute.
+// A zero duration disables the corresponding timeout.
 // +k8s:deepcopy-gen=true
-type HTTPPathModifier struct {
-	// FullReplace provides a string to replace the full path of the request.
-	FullReplace *string
-	// PrefixMatchReplace provides a string to replace the matched prefix of the request.
-	PrefixMatchReplace *string
+type HTTPTimeout struct {
+	// Request is the timeout for the complete request, from the downstream
+	// request being received until the upstream response is fully sent,
+	// including any retries.
+	Request *metav1.Duration `json:"request,omitempty"`
+	// BackendRequest is the timeout for a single request from the proxy to a
+	// backend, i.e. the per-try timeout.
+	BackendRequest *metav1.Duration `json:"backendRequest,omitempty"`
 }
 
-// Validate the fields within the HTTPPathModifier structure
-func (r HTTPPathModifier) Validate() error {
+// Validate the fields within the HTTPTimeout structure
+func (t HTTPTimeout) Validate() error {
 	var errs error
 
-	if r.FullReplace != nil && r.PrefixMatchReplace != nil {
-		errs = multierror.Append(errs, ErrHTTPPathModifierDoubleReplace)
+	if (t.Request != nil && t.Request.Duration < 0) || (t.BackendRequest != nil && t.BackendRequest.Duration < 0) {
+		errs = multierror.Append(errs, ErrHTTPTimeoutNegative)
 	}
 
-	if r.FullReplace == nil && r.PrefixMatchReplace == nil {
-		errs = multierror.Append(errs, ErrHTTPPathModifierNoReplace)
+	// A zero request timeout disables it, so any backend request timeout fits within it.
+	if t.Request != nil && t.BackendRequest != nil && t.Request.Duration > 0 &&
+		t.BackendRequest.Duration > t.Request.Duration {
+		errs = multierror.Append(errs, ErrHTTPTimeoutBackendRequest)
 	}
 
 	return errs
 }
 
-// StringMatch holds the various match conditions.
-// Only one of Exact, Prefix or SafeRegex can be set.
+// Retry holds the retry policy of an HTTPRoute.
 // +k8s:deepcopy-gen=true
-type StringMatch struct {
-	// Name of the field to match on.
-	Name string
-	// Exact match condition.
-	Exact *string
-	// Prefix match condition.
-	Prefix *string
-	// SafeRegex match condition.
-	SafeRegex *string
+type Retry struct {
+	// NumRetries is the number of retries of a request.
+	NumRetries *uint32 `json:"numRetries,omitempty"`
+	// RetryOn is the list of Envoy retry conditions, e.g. "5xx" or "connect-failure".
+	RetryOn []string `json:"retryOn,omitempty"`
+	// RetriableStatusCodes is the list of HTTP status codes for which a request is retried.
+	RetriableStatusCodes []uint32 `json:"retriableStatusCodes,omitempty"`
+	// PerTryTimeout is the timeout of each attempt of a request.
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+	// BackOff defines the exponential back-off between retries.
+	BackOff *BackOff `json:"backOff,omitempty"`
 }
 
-// Validate the fields within the StringMatch structure
-func (s StringMatch) Validate() error {
+// Validate the fields within the Retry structure
+func (r Retry) Validate() error {
 	var errs error
-	matchCount := 0
-	if s.Exact != nil {
-		matchCount++
-	}
-	if s.Prefix != nil {
-		matchCount++
+
+	for _, code := range r.RetriableStatusCodes {
+		if code < 100 || code > 599 {
+			errs = multierror.Append(errs, ErrRetryStatusCodeInvalid)
+			break
+		}
 	}
-	if s.SafeRegex != nil {
-		matchCount++
+
+	if r.BackOff != nil {
+		if err := r.BackOff.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
 
-	if matchCount != 1 {
-		errs = multierror.Append(errs, ErrStringMatchConditionInvalid)
+	return errs
+}
+
+// BackOff holds the exponential back-off between retries.
+// +k8s:deepcopy-gen=true
+type BackOff struct {
+	// BaseInterval is the base interval between retries.
+	BaseInterval metav1.Duration `json:"baseInterval,omitempty"`
+	// MaxInterval is the maximum interval between retries.
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+}
+
+// Validate the fields within the BackOff structure
+func (b BackOff) Validate() error {
+	var errs error
+
+	if b.BaseInterval.Duration <= 0 || (b.MaxInterval != nil && b.MaxInterval.Duration < b.BaseInterval.Duration) {
+		errs = multierror.Append(errs, ErrRetryBackOffIntervalInvalid)
 	}
 
 	return errs
 }
 
-// TCPListener holds the TCP listener configuration.
+// LoadBalancer holds the load balancing policy of a route's destinations.
+// A nil LoadBalancer load balances requests round robin.
 // +k8s:deepcopy-gen=true
-type TCPListener struct {
-	// Name of the TCPListener
-	Name string
-	// Address that the listener should listen on.
-	Address string
-	// Port on which the service can be expected to be accessed by clients.
-	Port uint32
-	// TLS information required for TLS Passthrough, If provided, incoming
-	// connections' server names are inspected and routed to backends accordingly.
-	TLS *TLSInspectorConfig
-	// Destinations associated with TCP traffic to the service.
-	Destinations []*RouteDestination
+type LoadBalancer struct {
+	// RoundRobin selects destinations in turn.
+	RoundRobin *RoundRobin `json:"roundRobin,omitempty"`
+	// LeastRequest selects the destination with the fewest active requests.
+	LeastRequest *LeastRequest `json:"leastRequest,omitempty"`
+	// Random selects a random destination.
+	Random *Random `json:"random,omitempty"`
+	// ConsistentHash load balances requests by hashing a key of the request.
+	ConsistentHash *ConsistentHash `json:"consistentHash,omitempty"`
 }
 
-// Validate the fields within the TCPListener structure
-func (h TCPListener) Validate() error {
+// Validate the fields within the LoadBalancer structure
+func (l LoadBalancer) Validate() error {
 	var errs error
-	if h.Name == "" {
-		errs = multierror.Append(errs, ErrListenerNameEmpty)
+
+	set := 0
+	if l.RoundRobin != nil {
+		set++
+		if err := l.RoundRobin.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
-	if ip := net.ParseIP(h.Address); ip == nil {
-		errs = multierror.Append(errs, ErrListenerAddressInvalid)
+	if l.LeastRequest != nil {
+		set++
+		if err := l.LeastRequest.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
-	if h.Port == 0 {
+	if l.Random != nil {
+		set++
+		if err := l.Random.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if l.ConsistentHash != nil {
+		set++
+	}
+	if set > 1 {
+		errs = multierror.Append(errs, ErrLoadBalancerInvalid)
+	}
+
+	if l.ConsistentHash != nil {
+		if err := l.ConsistentHash.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// RoundRobin holds the configuration of a round robin load balancer.
+// +k8s:deepcopy-gen=true
+type RoundRobin struct{}
+
+// Validate the fields within the RoundRobin structure, which has none.
+func (r RoundRobin) Validate() error {
+	return nil
+}
+
+// Random holds the configuration of a random load balancer.
+// +k8s:deepcopy-gen=true
+type Random struct{}
+
+// Validate the fields within the Random structure, which has none.
+func (r Random) Validate() error {
+	return nil
+}
+
+// LeastRequest holds the configuration of a least request load balancer.
+// +k8s:deepcopy-gen=true
+type LeastRequest struct {
+	// ChoiceCount is the number of random destinations compared to select the
+	// one with the fewest active requests.
+	ChoiceCount *uint32 `json:"choiceCount,omitempty"`
+}
+
+// Validate the fields within the LeastRequest structure
+func (l LeastRequest) Validate() error {
+	var errs error
+
+	if l.ChoiceCount != nil && *l.ChoiceCount < 2 {
+		errs = multierror.Append(errs, ErrLeastRequestChoiceCount)
+	}
+
+	return errs
+}
+
+// ConsistentHashAlgorithm is the hashing algorithm of a consistent hash load balancer.
+type ConsistentHashAlgorithm string
+
+const (
+	RingHashAlgorithm ConsistentHashAlgorithm = "RingHash"
+	MaglevAlgorithm   ConsistentHashAlgorithm = "Maglev"
+)
+
+// ConsistentHash holds the hash key and algorithm of a consistent hash load balancer.
+// Exactly one of SourceIP, Header or Cookie must be set.
+// +k8s:deepcopy-gen=true
+type ConsistentHash struct {
+	// Algorithm is the hashing algorithm.
+	Algorithm ConsistentHashAlgorithm `json:"algorithm,omitempty"`
+	// SourceIP hashes the IP address of the client.
+	SourceIP bool `json:"sourceIP,omitempty"`
+	// Header hashes the value of a request header.
+	Header *HeaderHash `json:"header,omitempty"`
+	// Cookie hashes the value of a cookie.
+	Cookie *CookieHash `json:"cookie,omitempty"`
+}
+
+// Validate the fields within the ConsistentHash structure
+func (c ConsistentHash) Validate() error {
+	var errs error
+
+	if c.Algorithm != RingHashAlgorithm && c.Algorithm != MaglevAlgorithm {
+		errs = multierror.Append(errs, ErrConsistentHashAlgorithm)
+	}
+
+	keys := 0
+	if c.SourceIP {
+		keys++
+	}
+	if c.Header != nil {
+		keys++
+		if err := c.Header.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if c.Cookie != nil {
+		keys++
+		if err := c.Cookie.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if keys != 1 {
+		errs = multierror.Append(errs, ErrConsistentHashKeyInvalid)
+	}
+
+	return errs
+}
+
+// HeaderHash holds the request header used as a hash key.
+// +k8s:deepcopy-gen=true
+type HeaderHash struct {
+	// Name of the request header.
+	Name string `json:"name"`
+}
+
+// Validate the fields within the HeaderHash structure
+func (h HeaderHash) Validate() error {
+	if h.Name == "" {
+		return ErrHashNameEmpty
+	}
+	return nil
+}
+
+// CookieHash holds the cookie used as a hash key.
+// +k8s:deepcopy-gen=true
+type CookieHash struct {
+	// Name of the cookie.
+	Name string `json:"name"`
+	// TTL of the cookie generated when a request doesn't carry it. No cookie is
+	// generated if unset.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Path of the generated cookie.
+	Path *string `json:"path,omitempty"`
+}
+
+// Validate the fields within the CookieHash structure
+func (h CookieHash) Validate() error {
+	if h.Name == "" {
+		return ErrHashNameEmpty
+	}
+	return nil
+}
+
+// CircuitBreaker holds the connection and request limits of a route's destinations.
+// Unset limits use the Envoy defaults.
+// +k8s:deepcopy-gen=true
+type CircuitBreaker struct {
+	// MaxConnections is the maximum number of connections to the destinations.
+	MaxConnections *uint32 `json:"maxConnections,omitempty"`
+	// MaxPendingRequests is the maximum number of requests waiting for a connection.
+	MaxPendingRequests *uint32 `json:"maxPendingRequests,omitempty"`
+	// MaxParallelRequests is the maximum number of parallel requests.
+	MaxParallelRequests *uint32 `json:"maxParallelRequests,omitempty"`
+	// MaxParallelRetries is the maximum number of parallel retries.
+	MaxParallelRetries *uint32 `json:"maxParallelRetries,omitempty"`
+}
+
+// Validate the fields within the CircuitBreaker structure. Any limit is valid,
+// a zero limit rejecting all connections, requests or retries.
+func (c CircuitBreaker) Validate() error {
+	return nil
+}
+
+// HealthCheck holds the health checks of a route's destinations.
+// +k8s:deepcopy-gen=true
+type HealthCheck struct {
+	// Active is the active health check of the destinations.
+	Active *ActiveHealthCheck `json:"active,omitempty"`
+	// Passive is the outlier detection of the destinations.
+	Passive *OutlierDetection `json:"passive,omitempty"`
+}
+
+// Validate the fields within the HealthCheck structure
+func (h HealthCheck) Validate() error {
+	var errs error
+
+	if h.Active != nil {
+		if err := h.Active.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if h.Passive != nil {
+		if err := h.Passive.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// ActiveHealthCheck holds the active health check of a route's destinations.
+// Exactly one of HTTP or TCP must be set.
+// +k8s:deepcopy-gen=true
+type ActiveHealthCheck struct {
+	// Timeout is the time to wait for a health check response.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Interval is the time between health checks.
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// HealthyThreshold is the number of successful health checks after which a
+	// destination is marked healthy.
+	HealthyThreshold uint32 `json:"healthyThreshold,omitempty"`
+	// UnhealthyThreshold is the number of failed health checks after which a
+	// destination is marked unhealthy.
+	UnhealthyThreshold uint32 `json:"unhealthyThreshold,omitempty"`
+	// HTTP probes the destinations with HTTP requests.
+	HTTP *HTTPHealthChecker `json:"http,omitempty"`
+	// TCP probes the destinations by opening TCP connections.
+	TCP *TCPHealthChecker `json:"tcp,omitempty"`
+}
+
+// Validate the fields within the ActiveHealthCheck structure
+func (a ActiveHealthCheck) Validate() error {
+	var errs error
+
+	if a.Timeout.Duration <= 0 || a.Interval.Duration <= 0 {
+		errs = multierror.Append(errs, ErrHealthCheckTimeoutInvalid)
+	}
+	if a.HealthyThreshold == 0 || a.UnhealthyThreshold == 0 {
+		errs = multierror.Append(errs, ErrHealthCheckThresholdInvalid)
+	}
+	if (a.HTTP == nil) == (a.TCP == nil) {
+		errs = multierror.Append(errs, ErrHealthCheckerInvalid)
+	}
+	if a.HTTP != nil {
+		if err := a.HTTP.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if a.TCP != nil {
+		if err := a.TCP.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// HTTPHealthChecker holds the configuration of an HTTP health checker.
+// +k8s:deepcopy-gen=true
+type HTTPHealthChecker struct {
+	// Path of the health check requests.
+	Path string `json:"path,omitempty"`
+	// ExpectedStatuses is the list of response status codes of healthy destinations.
+	ExpectedStatuses []uint32 `json:"expectedStatuses,omitempty"`
+}
+
+// Validate the fields within the HTTPHealthChecker structure
+func (h HTTPHealthChecker) Validate() error {
+	var errs error
+
+	if h.Path == "" {
+		errs = multierror.Append(errs, ErrHTTPHealthCheckPathEmpty)
+	}
+	for _, status := range h.ExpectedStatuses {
+		if status < 100 || status > 599 {
+			errs = multierror.Append(errs, ErrHTTPHealthCheckStatusInvalid)
+			break
+		}
+	}
+
+	return errs
+}
+
+// TCPHealthChecker holds the configuration of a TCP health checker, which
+// only checks that a connection can be established.
+// +k8s:deepcopy-gen=true
+type TCPHealthChecker struct{}
+
+// Validate the fields within the TCPHealthChecker structure, which has none.
+func (t TCPHealthChecker) Validate() error {
+	return nil
+}
+
+// OutlierDetection holds the passive health check of a route's destinations.
+// Unset fields use the Envoy defaults.
+// +k8s:deepcopy-gen=true
+type OutlierDetection struct {
+	// Consecutive5xxErrors is the number of consecutive 5xx responses after which
+	// a destination is ejected.
+	Consecutive5xxErrors *uint32 `json:"consecutive5xxErrors,omitempty"`
+	// Interval is the time between ejection analysis sweeps.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// BaseEjectionTime is the base duration of an ejection.
+	BaseEjectionTime *metav1.Duration `json:"baseEjectionTime,omitempty"`
+	// MaxEjectionPercent is the maximum percentage of destinations ejected at the same time.
+	MaxEjectionPercent *uint32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// Validate the fields within the OutlierDetection structure
+func (o OutlierDetection) Validate() error {
+	var errs error
+
+	if o.MaxEjectionPercent != nil && *o.MaxEjectionPercent > 100 {
+		errs = multierror.Append(errs, ErrMaxEjectionPercentInvalid)
+	}
+
+	return errs
+}
+
+// BackendTLSConfig holds the configuration of TLS connections originated to destinations.
+// +k8s:deepcopy-gen=true
+type BackendTLSConfig struct {
+	// SNI is the server name sent in the TLS handshake.
+	SNI string `json:"sni,omitempty"`
+	// SubjectAltNames is the list of subject alternative names, one of which the
+	// certificate presented by a destination must match.
+	SubjectAltNames []string `json:"subjectAltNames,omitempty"`
+	// CACertificate is the CA bundle used to verify the certificate presented by a
+	// destination. If unset, the system CA bundle of the proxy is used.
+	CACertificate []byte `json:"caCertificate,omitempty"`
+}
+
+// Validate the fields within the BackendTLSConfig structure
+func (b BackendTLSConfig) Validate() error {
+	var errs error
+	for _, name := range b.SubjectAltNames {
+		if name == "" {
+			errs = multierror.Append(errs, ErrSubjectAltNameEmpty)
+			break
+		}
+	}
+	return errs
+}
+
+// RateLimit holds the rate limits of the requests matching a route.
+// +k8s:deepcopy-gen=true
+type RateLimit struct {
+	// Local limits the rate of requests independently in each Envoy proxy.
+	Local *LocalRateLimit `json:"local,omitempty"`
+	// Global limits the rate of requests across all Envoy proxies, using the
+	// global rate limit service.
+	Global *GlobalRateLimit `json:"global,omitempty"`
+}
+
+// Validate the fields within the RateLimit structure
+func (r RateLimit) Validate() error {
+	var errs error
+	if r.Local != nil {
+		if err := r.Local.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if r.Global != nil {
+		if err := r.Global.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// RateLimitUnit is the unit of time of a rate limit.
+type RateLimitUnit string
+
+const (
+	RateLimitUnitSecond RateLimitUnit = "Second"
+	RateLimitUnitMinute RateLimitUnit = "Minute"
+	RateLimitUnitHour   RateLimitUnit = "Hour"
+)
+
+// Duration returns the duration of the unit, or zero if the unit is invalid.
+func (u RateLimitUnit) Duration() time.Duration {
+	switch u {
+	case RateLimitUnitSecond:
+		return time.Second
+	case RateLimitUnitMinute:
+		return time.Minute
+	case RateLimitUnitHour:
+		return time.Hour
+	default:
+		return 0
+	}
+}
+
+// RateLimitValue holds the number of requests allowed per unit of time.
+// +k8s:deepcopy-gen=true
+type RateLimitValue struct {
+	// Requests is the number of requests allowed per unit.
+	Requests uint32 `json:"requests,omitempty"`
+	// Unit is the unit of time of the limit.
+	Unit RateLimitUnit `json:"unit,omitempty"`
+}
+
+// Validate the fields within the RateLimitValue structure
+func (r RateLimitValue) Validate() error {
+	if r.Requests == 0 || r.Unit.Duration() == 0 {
+		return ErrRateLimitValueInvalid
+	}
+	return nil
+}
+
+// LocalRateLimit holds the configuration of a token bucket limiting the rate of
+// requests in each Envoy proxy.
+// +k8s:deepcopy-gen=true
+type LocalRateLimit struct {
+	// Default is the limit of the requests not matching any rule.
+	Default RateLimitValue `json:"default,omitempty"`
+	// Burst is the maximum number of requests allowed at once. If zero, the
+	// requests of the default limit are used.
+	Burst uint32 `json:"burst,omitempty"`
+	// Rules are limits of the requests matching specific headers. A request
+	// matching several rules counts against each of them.
+	Rules []*LocalRateLimitRule `json:"rules,omitempty"`
+}
+
+// Validate the fields within the LocalRateLimit structure
+func (l LocalRateLimit) Validate() error {
+	var errs error
+	if err := l.Default.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	for _, rule := range l.Rules {
+		if err := rule.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		// Envoy requires the fill interval of the bucket of a rule to be a
+		// multiple of the fill interval of the default bucket.
+		if rule.Limit.Unit.Duration() < l.Default.Unit.Duration() {
+			errs = multierror.Append(errs, ErrLocalRateLimitRuleUnit)
+		}
+	}
+	return errs
+}
+
+// LocalRateLimitRule holds the limit of the requests matching all of the headers.
+// +k8s:deepcopy-gen=true
+type LocalRateLimitRule struct {
+	// HeaderMatches are the headers the requests must match.
+	HeaderMatches []*RateLimitHeaderMatch `json:"headerMatches,omitempty"`
+	// Limit of the matching requests.
+	Limit RateLimitValue `json:"limit,omitempty"`
+}
+
+// Validate the fields within the LocalRateLimitRule structure
+func (l LocalRateLimitRule) Validate() error {
+	var errs error
+	if len(l.HeaderMatches) == 0 {
+		errs = multierror.Append(errs, ErrRateLimitRuleHeadersEmpty)
+	}
+	for _, match := range l.HeaderMatches {
+		if err := match.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if err := l.Limit.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	return errs
+}
+
+// GlobalRateLimit holds the rules limiting the rate of requests across all Envoy
+// proxies.
+// +k8s:deepcopy-gen=true
+type GlobalRateLimit struct {
+	// Rules are the limits of the requests matching specific headers. A request
+	// matching several rules counts against each of them.
+	Rules []*GlobalRateLimitRule `json:"rules,omitempty"`
+}
+
+// Validate the fields within the GlobalRateLimit structure
+func (g GlobalRateLimit) Validate() error {
+	var errs error
+	for _, rule := range g.Rules {
+		if err := rule.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// GlobalRateLimitRule holds the limit of the requests matching all of the headers.
+// +k8s:deepcopy-gen=true
+type GlobalRateLimitRule struct {
+	// HeaderMatches are the headers the requests must match. If empty, all
+	// requests match.
+	HeaderMatches []*RateLimitHeaderMatch `json:"headerMatches,omitempty"`
+	// Limit of the matching requests.
+	Limit RateLimitValue `json:"limit,omitempty"`
+}
+
+// Validate the fields within the GlobalRateLimitRule structure
+func (g GlobalRateLimitRule) Validate() error {
+	var errs error
+	for _, match := range g.HeaderMatches {
+		if err := match.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if err := g.Limit.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	return errs
+}
+
+// RateLimitHeaderMatch holds a header whose value is exactly matched.
+// +k8s:deepcopy-gen=true
+type RateLimitHeaderMatch struct {
+	// Name of the header.
+	Name string `json:"name"`
+	// Value of the header. For global rate limits, an empty value matches any
+	// value and each distinct value is limited separately.
+	Value string `json:"value,omitempty"`
+}
+
+// Validate the fields within the RateLimitHeaderMatch structure
+func (r RateLimitHeaderMatch) Validate() error {
+	if r.Name == "" {
+		return ErrRateLimitHeaderNameEmpty
+	}
+	return nil
+}
+
+// HTTPPathModifier holds instructions for how to modify the path of a request on a redirect response
+// +k8s:deepcopy-gen=true
+type HTTPPathModifier struct {
+	// FullReplace provides a string to replace the full path of the request.
+	FullReplace *string `json:"fullReplace,omitempty"`
+	// PrefixMatchReplace provides a string to replace the matched prefix of the request.
+	PrefixMatchReplace *string `json:"prefixMatchReplace,omitempty"`
+}
+
+// Validate the fields within the HTTPPathModifier structure
+func (r HTTPPathModifier) Validate() error {
+	var errs error
+
+	if r.FullReplace != nil && r.PrefixMatchReplace != nil {
+		errs = multierror.Append(errs, ErrHTTPPathModifierDoubleReplace)
+	}
+
+	if r.FullReplace == nil && r.PrefixMatchReplace == nil {
+		errs = multierror.Append(errs, ErrHTTPPathModifierNoReplace)
+	}
+
+	return errs
+}
+
+// StringMatch holds the various match conditions.
+// Only one of Exact, Prefix or SafeRegex can be set.
+// +k8s:deepcopy-gen=true
+type StringMatch struct {
+	// Name of the field to match on.
+	Name string `json:"name"`
+	// Exact match condition.
+	Exact *string `json:"exact,omitempty"`
+	// Prefix match condition.
+	Prefix *string `json:"prefix,omitempty"`
+	// SafeRegex match condition.
+	SafeRegex *string `json:"safeRegex,omitempty"`
+}
+
+// Validate the fields within the StringMatch structure
+func (s StringMatch) Validate() error {
+	var errs error
+	matchCount := 0
+	if s.Exact != nil {
+		matchCount++
+	}
+	if s.Prefix != nil {
+		matchCount++
+	}
+	if s.SafeRegex != nil {
+		matchCount++
+	}
+
+	if matchCount != 1 {
+		errs = multierror.Append(errs, ErrStringMatchConditionInvalid)
+	}
+
+	return errs
+}
+
+// TCPListener holds the TCP listener configuration.
+// +k8s:deepcopy-gen=true
+type TCPListener struct {
+	// Name of the TCPListener
+	Name string `json:"name"`
+	// Address that the listener should listen on.
+	Address string `json:"address,omitempty"`
+	// Port on which the service can be expected to be accessed by clients.
+	Port uint32 `json:"port,omitempty"`
+	// TLS information required for TLS Passthrough, If provided, incoming
+	// connections' server names are inspected and routed to backends accordingly.
+	TLS *TLSInspectorConfig `json:"tls,omitempty"`
+	// Destinations associated with TCP traffic to the service.
+	Destinations []*RouteDestination `json:"destinations,omitempty"`
+	// ConnectionLimit limits the active connections of the listener. If unset,
+	// the connections are not limited.
+	ConnectionLimit *ConnectionLimit `json:"connectionLimit,omitempty"`
+	// TCPKeepalive enables TCP keepalive on the connections of the clients.
+	TCPKeepalive *TCPKeepalive `json:"tcpKeepalive,omitempty"`
+	// SocketOptions are set on the listening socket and the connections of the clients.
+	SocketOptions []*SocketOption `json:"socketOptions,omitempty"`
+	// IPAccessControl restricts the clients of the listener by address. If
+	// unset, all clients are allowed.
+	IPAccessControl *IPAccessControl `json:"ipAccessControl,omitempty"`
+	// AccessLog defines the access logs of the listener. If unset, no access
+	// logs are written.
+	AccessLog *AccessLog `json:"accessLog,omitempty"`
+}
+
+// Validate the fields within the TCPListener structure
+func (h TCPListener) Validate() error {
+	var errs error
+	if h.Name == "" {
+		errs = multierror.Append(errs, ErrListenerNameEmpty)
+	}
+	if ip := net.ParseIP(h.Address); ip == nil {
+		errs = multierror.Append(errs, ErrListenerAddressInvalid)
+	}
+	if h.Port == 0 {
 		errs = multierror.Append(errs, ErrListenerPortInvalid)
 	}
-	if h.TLS != nil {
-		if err := h.TLS.Validate(); err != nil {
+	if h.TLS != nil {
+		if err := h.TLS.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	for _, route := range h.Destinations {
+		if err := route.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.ConnectionLimit != nil {
+		if err := h.ConnectionLimit.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.TCPKeepalive != nil {
+		if err := h.TCPKeepalive.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	for _, option := range h.SocketOptions {
+		if err := option.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.IPAccessControl != nil {
+		if err := h.IPAccessControl.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.AccessLog != nil {
+		if err := h.AccessLog.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ConnectionLimit holds the limit of active connections of a listener.
+// +k8s:deepcopy-gen=true
+type ConnectionLimit struct {
+	// Value is the maximum number of active connections.
+	Value uint64 `json:"value,omitempty"`
+	// CloseDelay is the delay before closing connections above the limit.
+	CloseDelay *metav1.Duration `json:"closeDelay,omitempty"`
+}
+
+// Validate the fields within the ConnectionLimit structure
+func (c ConnectionLimit) Validate() error {
+	if c.Value == 0 || (c.CloseDelay != nil && c.CloseDelay.Duration < 0) {
+		return ErrConnectionLimitInvalid
+	}
+	return nil
+}
+
+// TCPKeepalive holds the configuration of TCP keepalive. Unset fields use the
+// operating system defaults.
+// +k8s:deepcopy-gen=true
+type TCPKeepalive struct {
+	// Probes is the number of unacknowledged probes before the connection is
+	// considered dead.
+	Probes *uint32 `json:"probes,omitempty"`
+	// IdleTime is the idle time in seconds before the first probe is sent.
+	IdleTime *uint32 `json:"idleTime,omitempty"`
+	// Interval is the time in seconds between probes.
+	Interval *uint32 `json:"interval,omitempty"`
+}
+
+// Validate the fields within the TCPKeepalive structure
+func (t TCPKeepalive) Validate() error {
+	for _, value := range []*uint32{t.Probes, t.IdleTime, t.Interval} {
+		if value != nil && *value == 0 {
+			return ErrTCPKeepaliveInvalid
+		}
+	}
+	return nil
+}
+
+// SocketOptionState is the state of the socket in which a socket option is set.
+type SocketOptionState string
+
+const (
+	// SocketOptionStatePrebind sets the option before the socket is bound.
+	SocketOptionStatePrebind SocketOptionState = "Prebind"
+	// SocketOptionStateBound sets the option after the socket is bound.
+	SocketOptionStateBound SocketOptionState = "Bound"
+	// SocketOptionStateListening sets the option after the socket is listening.
+	SocketOptionStateListening SocketOptionState = "Listening"
+)
+
+// SocketOption holds an integer socket option, as passed to setsockopt(2).
+// +k8s:deepcopy-gen=true
+type SocketOption struct {
+	// Level is the protocol level of the option, e.g. SOL_SOCKET.
+	Level int64 `json:"level,omitempty"`
+	// Name is the name of the option, e.g. SO_KEEPALIVE.
+	Name int64 `json:"name"`
+	// Value is the integer value of the option.
+	Value int64 `json:"value,omitempty"`
+	// State is the state of the socket in which the option is set.
+	State SocketOptionState `json:"state,omitempty"`
+}
+
+// Validate the fields within the SocketOption structure
+func (s SocketOption) Validate() error {
+	switch s.State {
+	case SocketOptionStatePrebind, SocketOptionStateBound, SocketOptionStateListening:
+		return nil
+	default:
+		return ErrSocketOptionStateInvalid
+	}
+}
+
+// CompressionAlgorithm is an algorithm compressing the responses.
+type CompressionAlgorithm string
+
+const (
+	// CompressionAlgorithmGzip is the gzip algorithm.
+	CompressionAlgorithmGzip CompressionAlgorithm = "Gzip"
+	// CompressionAlgorithmBrotli is the brotli algorithm.
+	CompressionAlgorithmBrotli CompressionAlgorithm = "Brotli"
+)
+
+// CompressionLevel is the trade-off between the speed and the ratio of the
+// compression, portable across algorithms.
+type CompressionLevel string
+
+const (
+	// CompressionLevelBestSpeed favors the speed of the compression.
+	CompressionLevelBestSpeed CompressionLevel = "BestSpeed"
+	// CompressionLevelDefault is the default level of the algorithm.
+	CompressionLevelDefault CompressionLevel = "Default"
+	// CompressionLevelBestCompression favors the ratio of the compression.
+	CompressionLevelBestCompression CompressionLevel = "BestCompression"
+)
+
+// Compression holds the configuration of the compression of the responses.
+// +k8s:deepcopy-gen=true
+type Compression struct {
+	// Algorithms are the compression algorithms supported, in order of
+	// preference when clients accept several of them equally.
+	Algorithms []CompressionAlgorithm `json:"algorithms,omitempty"`
+	// ContentTypes are the content types of the responses compressed. If
+	// empty, the Envoy defaults are used.
+	ContentTypes []string `json:"contentTypes,omitempty"`
+	// MinContentLength is the minimum length in bytes of the responses
+	// compressed. If unset, the Envoy default is used.
+	MinContentLength *uint32 `json:"minContentLength,omitempty"`
+	// Level is the level of the compression. If empty, the default level of
+	// each algorithm is used.
+	Level CompressionLevel `json:"level,omitempty"`
+}
+
+// Validate the fields within the Compression structure
+func (c Compression) Validate() error {
+	var errs error
+	if len(c.Algorithms) == 0 {
+		errs = multierror.Append(errs, ErrCompressionAlgorithmsEmpty)
+	}
+	seen := map[CompressionAlgorithm]bool{}
+	for _, algorithm := range c.Algorithms {
+		if (algorithm != CompressionAlgorithmGzip && algorithm != CompressionAlgorithmBrotli) || seen[algorithm] {
+			errs = multierror.Append(errs, ErrCompressionAlgorithmInvalid)
+			break
+		}
+		seen[algorithm] = true
+	}
+	switch c.Level {
+	case "", CompressionLevelBestSpeed, CompressionLevelDefault, CompressionLevelBestCompression:
+	default:
+		errs = multierror.Append(errs, ErrCompressionLevelInvalid)
+	}
+	return errs
+}
+
+// IPAccessControl holds access control based on the address of the client. A
+// client is allowed if its address doesn't match Deny and, if Allow is set,
+// matches Allow.
+// +k8s:deepcopy-gen=true
+type IPAccessControl struct {
+	// Allow are the address ranges of the clients allowed.
+	Allow []*CIDR `json:"allow,omitempty"`
+	// Deny are the address ranges of the clients denied, even if allowed.
+	Deny []*CIDR `json:"deny,omitempty"`
+}
+
+// Validate the fields within the IPAccessControl structure
+func (i IPAccessControl) Validate() error {
+	var errs error
+	if len(i.Allow) == 0 && len(i.Deny) == 0 {
+		errs = multierror.Append(errs, ErrIPAccessControlEmpty)
+	}
+	for _, cidr := range append(append([]*CIDR{}, i.Allow...), i.Deny...) {
+		if err := cidr.Validate(); err != nil {
 			errs = multierror.Append(errs, err)
+			break
 		}
 	}
-	for _, route := range h.Destinations {
-		if err := route.Validate(); err != nil {
+	return errs
+}
+
+// CIDR holds an IPv4 or IPv6 address range.
+// +k8s:deepcopy-gen=true
+type CIDR struct {
+	// Prefix is the address prefix of the range, e.g. 10.0.0.0.
+	Prefix string `json:"prefix,omitempty"`
+	// PrefixLen is the length in bits of the prefix.
+	PrefixLen uint32 `json:"prefixLen,omitempty"`
+}
+
+// Validate the fields within the CIDR structure
+func (c CIDR) Validate() error {
+	ip := net.ParseIP(c.Prefix)
+	if ip == nil {
+		return ErrCIDRInvalid
+	}
+	bits := uint32(net.IPv6len * 8)
+	if ip.To4() != nil {
+		bits = net.IPv4len * 8
+	}
+	if c.PrefixLen > bits {
+		return ErrCIDRInvalid
+	}
+	return nil
+}
+
+// LocalReply holds the customization of the responses generated by Envoy.
+// +k8s:deepcopy-gen=true
+type LocalReply struct {
+	// Mappers rewrite the responses matching their status codes. The first
+	// matching mapper applies.
+	Mappers []*LocalReplyMapper `json:"mappers,omitempty"`
+	// BodyFormat formats the bodies of all the responses.
+	BodyFormat *LocalReplyBodyFormat `json:"bodyFormat,omitempty"`
+}
+
+// Validate the fields within the LocalReply structure
+func (l LocalReply) Validate() error {
+	var errs error
+	if len(l.Mappers) == 0 && l.BodyFormat == nil {
+		errs = multierror.Append(errs, ErrLocalReplyEmpty)
+	}
+	for _, mapper := range l.Mappers {
+		if err := mapper.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if l.BodyFormat != nil {
+		if err := l.BodyFormat.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// LocalReplyMapper holds the rewrite of the responses generated by Envoy
+// matching its status codes.
+// +k8s:deepcopy-gen=true
+type LocalReplyMapper struct {
+	// StatusCodes is the list of status codes of the responses rewritten.
+	StatusCodes []uint32 `json:"statusCodes,omitempty"`
+	// StatusCode replaces the status code of the responses, if set.
+	StatusCode *uint32 `json:"statusCode,omitempty"`
+	// Body replaces the body of the responses, if set.
+	Body *string `json:"body,omitempty"`
+}
+
+// Validate the fields within the LocalReplyMapper structure
+func (l LocalReplyMapper) Validate() error {
+	var errs error
+	if len(l.StatusCodes) == 0 {
+		errs = multierror.Append(errs, ErrLocalReplyStatusCodesEmpty)
+	}
+	for _, code := range l.StatusCodes {
+		if code < 100 || code > 599 {
+			errs = multierror.Append(errs, ErrLocalReplyStatusInvalid)
+		}
+	}
+	if l.StatusCode != nil && (*l.StatusCode < 100 || *l.StatusCode > 599) {
+		errs = multierror.Append(errs, ErrLocalReplyStatusInvalid)
+	}
+	return errs
+}
+
+// LocalReplyBodyFormat holds the format of the bodies of the responses generated
+// by Envoy. Exactly one of Text or JSON must be set.
+// +k8s:deepcopy-gen=true
+type LocalReplyBodyFormat struct {
+	// Text is the format of text bodies.
+	Text *string `json:"text,omitempty"`
+	// JSON is the format of the fields of JSON bodies.
+	JSON map[string]string `json:"json,omitempty"`
+	// ContentType replaces the content type of the responses, if set.
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// Validate the fields within the LocalReplyBodyFormat structure
+func (l LocalReplyBodyFormat) Validate() error {
+	if (l.Text == nil) == (len(l.JSON) == 0) {
+		return ErrLocalReplyBodyFormatInvalid
+	}
+	return nil
+}
+
+// AccessLog holds the access logs of a listener. If both Text and JSON are unset,
+// the default Envoy format is used.
+// +k8s:deepcopy-gen=true
+type AccessLog struct {
+	// Text is the format of text access logs.
+	Text *string `json:"text,omitempty"`
+	// JSON is the format of the fields of JSON access logs.
+	JSON map[string]string `json:"json,omitempty"`
+	// Files are the paths of the files the access logs are written to.
+	Files []string `json:"files,omitempty"`
+	// ALS are the access log services the access logs are streamed to. The
+	// format of the access logs doesn't apply to them.
+	ALS []*ALSAccessLog `json:"als,omitempty"`
+}
+
+// Validate the fields within the AccessLog structure
+func (a AccessLog) Validate() error {
+	var errs error
+	if a.Text != nil && len(a.JSON) > 0 {
+		errs = multierror.Append(errs, ErrAccessLogFormatInvalid)
+	}
+	if len(a.Files) == 0 && len(a.ALS) == 0 {
+		errs = multierror.Append(errs, ErrAccessLogSinksEmpty)
+	}
+	for _, als := range a.ALS {
+		if err := als.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ALSAccessLog holds an access log service the access logs are streamed to over gRPC.
+// +k8s:deepcopy-gen=true
+type ALSAccessLog struct {
+	// LogName identifies the access logs within the access log service.
+	LogName string `json:"logName,omitempty"`
+	// Host is the hostname of the access log service.
+	Host string `json:"host,omitempty"`
+	// Port is the gRPC port of the access log service.
+	Port uint32 `json:"port,omitempty"`
+	// BufferSize is the size of the buffer of the access logs in bytes. If
+	// unset, the Envoy default is used.
+	BufferSize *uint32 `json:"bufferSize,omitempty"`
+	// BufferFlushInterval is the interval at which the buffered access logs are
+	// streamed. If unset, the Envoy default is used.
+	BufferFlushInterval *metav1.Duration `json:"bufferFlushInterval,omitempty"`
+}
+
+// Validate the fields within the ALSAccessLog structure
+func (a ALSAccessLog) Validate() error {
+	var errs error
+	if a.LogName == "" {
+		errs = multierror.Append(errs, ErrALSLogNameEmpty)
+	}
+	if a.Host == "" {
+		errs = multierror.Append(errs, ErrALSHostEmpty)
+	}
+	if a.Port == 0 || a.Port > 65535 {
+		errs = multierror.Append(errs, ErrALSPortInvalid)
+	}
+	return errs
+}
+
+// TracingProviderType is the type of a tracing provider.
+type TracingProviderType string
+
+const (
+	// TracingProviderTypeOpenTelemetry sends the spans to an OpenTelemetry collector.
+	TracingProviderTypeOpenTelemetry TracingProviderType = "OpenTelemetry"
+	// TracingProviderTypeZipkin sends the spans to a Zipkin collector.
+	TracingProviderTypeZipkin TracingProviderType = "Zipkin"
+	// TracingProviderTypeDatadog sends the spans to a Datadog agent.
+	TracingProviderTypeDatadog TracingProviderType = "Datadog"
+)
+
+// Tracing holds the tracing of the requests of a listener.
+// +k8s:deepcopy-gen=true
+type Tracing struct {
+	// Provider is the type of the tracing provider.
+	Provider TracingProviderType `json:"provider,omitempty"`
+	// Host is the hostname of the collector of the tracing provider.
+	Host string `json:"host,omitempty"`
+	// Port is the port of the collector of the tracing provider.
+	Port uint32 `json:"port,omitempty"`
+	// ServiceName is the name of the service of the spans, used by the Zipkin
+	// and Datadog providers.
+	ServiceName string `json:"serviceName,omitempty"`
+	// SamplingRate is the percentage of the requests traced.
+	SamplingRate uint32 `json:"samplingRate,omitempty"`
+	// CustomTags are the tags added to the spans.
+	CustomTags []*CustomTag `json:"customTags,omitempty"`
+}
+
+// Validate the fields within the Tracing structure
+func (t Tracing) Validate() error {
+	var errs error
+	switch t.Provider {
+	case TracingProviderTypeOpenTelemetry:
+	case TracingProviderTypeZipkin, TracingProviderTypeDatadog:
+		if t.ServiceName == "" {
+			errs = multierror.Append(errs, ErrTracingServiceNameEmpty)
+		}
+	default:
+		errs = multierror.Append(errs, ErrTracingProviderInvalid)
+	}
+	if t.Host == "" {
+		errs = multierror.Append(errs, ErrTracingHostEmpty)
+	}
+	if t.Port == 0 || t.Port > 65535 {
+		errs = multierror.Append(errs, ErrTracingPortInvalid)
+	}
+	if t.SamplingRate > 100 {
+		errs = multierror.Append(errs, ErrTracingSamplingRateInvalid)
+	}
+	for _, tag := range t.CustomTags {
+		if err := tag.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// CustomTag holds a tag added to the spans. Exactly one of Literal, Environment,
+// RequestHeader or Metadata is set.
+// +k8s:deepcopy-gen=true
+type CustomTag struct {
+	// Name is the name of the tag.
+	Name string `json:"name"`
+	// Literal is the literal value of the tag.
+	Literal *string `json:"literal,omitempty"`
+	// Environment is the environment variable the value of the tag is read from.
+	Environment *CustomTagSource `json:"environment,omitempty"`
+	// RequestHeader is the request header the value of the tag is read from.
+	RequestHeader *CustomTagSource `json:"requestHeader,omitempty"`
+	// Metadata is the metadata field the value of the tag is read from.
+	Metadata *CustomTagMetadata `json:"metadata,omitempty"`
+}
+
+// Validate the fields within the CustomTag structure
+func (c CustomTag) Validate() error {
+	var errs error
+	if c.Name == "" {
+		errs = multierror.Append(errs, ErrCustomTagNameEmpty)
+	}
+	sources := 0
+	if c.Literal != nil {
+		sources++
+	}
+	if c.Environment != nil {
+		sources++
+		if err := c.Environment.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if c.RequestHeader != nil {
+		sources++
+		if err := c.RequestHeader.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if c.Metadata != nil {
+		sources++
+		if err := c.Metadata.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if sources != 1 {
+		errs = multierror.Append(errs, ErrCustomTagValueInvalid)
+	}
+	return errs
+}
+
+// CustomTagSource holds the environment variable or request header the value of a
+// custom tag is read from.
+// +k8s:deepcopy-gen=true
+type CustomTagSource struct {
+	// Name of the environment variable or request header.
+	Name string `json:"name"`
+	// DefaultValue is the value of the tag if the source is missing. If empty,
+	// the tag is omitted.
+	DefaultValue string `json:"defaultValue,omitempty"`
+}
+
+// Validate the fields within the CustomTagSource structure
+func (c CustomTagSource) Validate() error {
+	if c.Name == "" {
+		return ErrCustomTagSourceNameEmpty
+	}
+	return nil
+}
+
+// CustomTagMetadataKind is the kind of the metadata the value of a custom tag is
+// read from.
+type CustomTagMetadataKind string
+
+const (
+	// CustomTagMetadataKindRequest is the dynamic metadata of the request.
+	CustomTagMetadataKindRequest CustomTagMetadataKind = "Request"
+	// CustomTagMetadataKindRoute is the metadata of the route.
+	CustomTagMetadataKindRoute CustomTagMetadataKind = "Route"
+)
+
+// CustomTagMetadata holds the metadata field the value of a custom tag is read from.
+// +k8s:deepcopy-gen=true
+type CustomTagMetadata struct {
+	// Kind is the kind of the metadata.
+	Kind CustomTagMetadataKind `json:"kind,omitempty"`
+	// Key is the namespace of the metadata.
+	Key string `json:"key,omitempty"`
+	// Path is the path of the field within the namespace of the metadata.
+	Path []string `json:"path,omitempty"`
+	// DefaultValue is the value of the tag if the field is missing. If empty,
+	// the tag is omitted.
+	DefaultValue string `json:"defaultValue,omitempty"`
+}
+
+// Validate the fields within the CustomTagMetadata structure
+func (c CustomTagMetadata) Validate() error {
+	if c.Kind == "" || c.Key == "" || len(c.Path) == 0 {
+		return ErrCustomTagMetadataInvalid
+	}
+	return nil
+}
+
+// Wasm holds a Wasm extension running a plugin of a module fetched by Envoy from
+// an HTTP URL.
+// +k8s:deepcopy-gen=true
+type Wasm struct {
+	// Name identifies the extension within the listener.
+	Name string `json:"name"`
+	// RootID is the root ID of the plugin within the module. If empty, the
+	// module contains a single plugin.
+	RootID string `json:"rootID,omitempty"`
+	// URL is the http or https URL of the module.
+	URL string `json:"url,omitempty"`
+	// SHA256 is the hex-encoded SHA-256 checksum of the module.
+	SHA256 string `json:"sha256,omitempty"`
+	// Config is the configuration passed to the plugin as is.
+	Config string `json:"config,omitempty"`
+	// FailOpen allows the requests when the plugin fails.
+	FailOpen bool `json:"failOpen,omitempty"`
+}
+
+// Validate the fields within the Wasm structure
+func (w Wasm) Validate() error {
+	var errs error
+	if w.Name == "" {
+		errs = multierror.Append(errs, ErrWasmNameEmpty)
+	}
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		errs = multierror.Append(errs, ErrWasmURLInvalid)
+	}
+	if sum, err := hex.DecodeString(w.SHA256); err != nil || len(sum) != sha256.Size {
+		errs = multierror.Append(errs, ErrWasmSHA256Invalid)
+	}
+	return errs
+}
+
+// FaultInjection holds the faults injected into requests.
+// +k8s:deepcopy-gen=true
+type FaultInjection struct {
+	// Delay delays requests before forwarding them.
+	Delay *FaultInjectionDelay `json:"delay,omitempty"`
+	// Abort rejects requests instead of forwarding them.
+	Abort *FaultInjectionAbort `json:"abort,omitempty"`
+}
+
+// Validate the fields within the FaultInjection structure
+func (f FaultInjection) Validate() error {
+	var errs error
+	if f.Delay == nil && f.Abort == nil {
+		errs = multierror.Append(errs, ErrFaultInjectionEmpty)
+	}
+	if f.Delay != nil {
+		if err := f.Delay.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if f.Abort != nil {
+		if err := f.Abort.Validate(); err != nil {
 			errs = multierror.Append(errs, err)
 		}
 	}
 	return errs
 }
 
+// FaultInjectionDelay holds the delay injected into requests.
+// +k8s:deepcopy-gen=true
+type FaultInjectionDelay struct {
+	// FixedDelay is the delay of the requests.
+	FixedDelay metav1.Duration `json:"fixedDelay,omitempty"`
+	// Percentage is the percentage of the requests delayed.
+	Percentage uint32 `json:"percentage,omitempty"`
+}
+
+// Validate the fields within the FaultInjectionDelay structure
+func (f FaultInjectionDelay) Validate() error {
+	var errs error
+	if f.FixedDelay.Duration <= 0 {
+		errs = multierror.Append(errs, ErrFaultInjectionDelayInvalid)
+	}
+	if f.Percentage > 100 {
+		errs = multierror.Append(errs, ErrFaultInjectionPercentInvalid)
+	}
+	return errs
+}
+
+// FaultInjectionAbort holds the abort injected into requests.
+// +k8s:deepcopy-gen=true
+type FaultInjectionAbort struct {
+	// HTTPStatus is the status code of the responses of the aborted requests.
+	HTTPStatus uint32 `json:"httpStatus,omitempty"`
+	// Percentage is the percentage of the requests aborted.
+	Percentage uint32 `json:"percentage,omitempty"`
+}
+
+// Validate the fields within the FaultInjectionAbort structure
+func (f FaultInjectionAbort) Validate() error {
+	var errs error
+	if f.HTTPStatus < 200 || f.HTTPStatus > 599 {
+		errs = multierror.Append(errs, ErrFaultInjectionStatusInvalid)
+	}
+	if f.Percentage > 100 {
+		errs = multierror.Append(errs, ErrFaultInjectionPercentInvalid)
+	}
+	return errs
+}
+
+// Buffer holds the buffering of the request bodies.
+// +k8s:deepcopy-gen=true
+type Buffer struct {
+	// MaxRequestBytes is the maximum size of the request bodies, in bytes.
+	MaxRequestBytes uint32 `json:"maxRequestBytes,omitempty"`
+}
+
+// Validate the fields within the Buffer structure
+func (b Buffer) Validate() error {
+	var errs error
+	if b.MaxRequestBytes == 0 {
+		errs = multierror.Append(errs, ErrBufferMaxRequestBytesInvalid)
+	}
+	return errs
+}
+
+// Upgrade holds the protocol upgrades allowed for the requests of a route.
+// +k8s:deepcopy-gen=true
+type Upgrade struct {
+	// WebSocket allows the requests to be upgraded to the WebSocket protocol.
+	WebSocket bool `json:"webSocket,omitempty"`
+	// Connect allows CONNECT requests, terminated by Envoy and tunneled to the
+	// destinations over TCP connections.
+	Connect bool `json:"connect,omitempty"`
+}
+
+// Validate the fields within the Upgrade structure
+func (u Upgrade) Validate() error {
+	if !u.WebSocket && !u.Connect {
+		return ErrUpgradeEmpty
+	}
+	return nil
+}
+
+// GRPCJSONTranscoder holds the transcoding of JSON requests into gRPC requests.
+// +k8s:deepcopy-gen=true
+type GRPCJSONTranscoder struct {
+	// ProtoDescriptor is the serialized protobuf descriptor set of the gRPC
+	// services.
+	ProtoDescriptor []byte `json:"protoDescriptor,omitempty"`
+	// Services is the list of fully qualified names of the gRPC services
+	// transcoded.
+	Services []string `json:"services,omitempty"`
+}
+
+// Validate the fields within the GRPCJSONTranscoder structure
+func (g GRPCJSONTranscoder) Validate() error {
+	var errs error
+	if len(g.ProtoDescriptor) == 0 {
+		errs = multierror.Append(errs, ErrProtoDescriptorEmpty)
+	}
+	if len(g.Services) == 0 {
+		errs = multierror.Append(errs, ErrGRPCServicesEmpty)
+	}
+	return errs
+}
+
+// Lua holds a Lua script. Routes sharing a script share its name.
+// +k8s:deepcopy-gen=true
+type Lua struct {
+	// Name identifies the script within the listener.
+	Name string `json:"name"`
+	// Code is the source code of the script.
+	Code string `json:"code,omitempty"`
+}
+
+// Validate the fields within the Lua structure
+func (l Lua) Validate() error {
+	var errs error
+	if l.Name == "" {
+		errs = multierror.Append(errs, ErrLuaNameEmpty)
+	}
+	if l.Code == "" {
+		errs = multierror.Append(errs, ErrLuaCodeEmpty)
+	}
+	return errs
+}
+
 // TLSInspectorConfig holds the configuration required for inspecting TLS
 // passthrough connections.
 // +k8s:deepcopy-gen=true
@@ -446,7 +2439,7 @@ type TLSInspectorConfig struct {
 	// Wildcard hosts are supported in the prefix form. Partial wildcards are not
 	// supported, and values like *w.example.com are invalid.
 	// SNIs are used only in case of TLS Passthrough.
-	SNIs []string
+	SNIs []string `json:"snis,omitempty"`
 }
 
 func (t TLSInspectorConfig) Validate() error {
@@ -456,3 +2449,71 @@ func (t TLSInspectorConfig) Validate() error {
 	}
 	return errs
 }
+
+// EnvoyPatchPolicy holds the JSON patches of an EnvoyPatchPolicy applied to the
+// xDS resources generated for the gateway.
+// +k8s:deepcopy-gen=true
+type EnvoyPatchPolicy struct {
+	// Name of the policy.
+	Name string `json:"name"`
+	// Namespace of the policy.
+	Namespace string `json:"namespace,omitempty"`
+	// Generation of the policy, observed by the conditions of its status.
+	Generation int64 `json:"generation,omitempty"`
+	// JSONPatches are the patches applied to the xDS resources, in order.
+	JSONPatches []*JSONPatchConfig `json:"jsonPatches,omitempty"`
+	// Status is the status of the policy, set by the xDS translation.
+	Status *v1alpha1.EnvoyPatchPolicyStatus `json:"status,omitempty"`
+}
+
+// Validate the fields within the EnvoyPatchPolicy structure
+func (e EnvoyPatchPolicy) Validate() error {
+	var errs error
+	if e.Name == "" || e.Namespace == "" {
+		errs = multierror.Append(errs, ErrEnvoyPatchPolicyNameEmpty)
+	}
+	for _, patch := range e.JSONPatches {
+		if err := patch.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// JSONPatchConfig holds a JSON patch operation applied to the JSON representation
+// of an xDS resource.
+// +k8s:deepcopy-gen=true
+type JSONPatchConfig struct {
+	// Type is the type URL of the patched resource.
+	Type string `json:"type,omitempty"`
+	// Name is the name of the patched resource.
+	Name string `json:"name"`
+	// Op is the type of the operation.
+	Op string `json:"op,omitempty"`
+	// Path is the JSON pointer to the location the operation applies to.
+	Path string `json:"path,omitempty"`
+	// From is the JSON pointer to the source location of the move and copy
+	// operations.
+	From string `json:"from,omitempty"`
+	// Value is the JSON encoded value of the add, replace and test operations.
+	Value string `json:"value,omitempty"`
+}
+
+// Validate the fields within the JSONPatchConfig structure
+func (j JSONPatchConfig) Validate() error {
+	var errs error
+	switch j.Type {
+	case resourcev3.ListenerType, resourcev3.RouteType, resourcev3.ClusterType:
+	default:
+		errs = multierror.Append(errs, ErrJSONPatchTypeInvalid)
+	}
+	if j.Name == "" {
+		errs = multierror.Append(errs, ErrJSONPatchNameEmpty)
+	}
+	switch j.Op {
+	case "add", "remove", "replace", "move", "copy", "test":
+	default:
+		errs = multierror.Append(errs, ErrJSONPatchOpInvalid)
+	}
+	return errs
+}
@@ -1,10 +1,13 @@
 package ir
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -233,128 +236,1053 @@ var (
 		},
 	}
 
+	responseHeadersHTTPRoute = HTTPRoute{
+		Name: "responseheaders",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("responseheaders"),
+		},
+		AddResponseHeaders: []AddHeader{
+			{
+				Name:   "x-response-header",
+				Value:  "example-value",
+				Append: true,
+			},
+		},
+		RemoveResponseHeaders: []string{
+			"x-powered-by",
+		},
+	}
+
+	responseHeadersDupeHTTPRoute = HTTPRoute{
+		Name: "duplicateresponseheader",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("duplicateresponseheader"),
+		},
+		AddResponseHeaders: []AddHeader{
+			{
+				Name:  "x-response-header",
+				Value: "example-value",
+			},
+			{
+				Name:  "x-response-header",
+				Value: "example-value-2",
+			},
+		},
+		RemoveResponseHeaders: []string{
+			"x-powered-by",
+			"x-powered-by",
+		},
+	}
+
 	addHeaderEmptyHTTPRoute = HTTPRoute{
 		Name: "addemptyheader",
 		PathMatch: &StringMatch{
 			Exact: ptrTo("addemptyheader"),
 		},
-		AddRequestHeaders: []AddHeader{
-			{
-				Name:   "",
-				Value:  "example-value",
-				Append: true,
+		AddRequestHeaders: []AddHeader{
+			{
+				Name:   "",
+				Value:  "example-value",
+				Append: true,
+			},
+		},
+	}
+
+	timeoutHTTPRoute = HTTPRoute{
+		Name: "timeout",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("timeout"),
+		},
+		Timeout: &HTTPTimeout{
+			Request:        &metav1.Duration{Duration: 10 * time.Second},
+			BackendRequest: &metav1.Duration{Duration: 2 * time.Second},
+		},
+	}
+
+	timeoutBackendRequestExceedsRequestHTTPRoute = HTTPRoute{
+		Name: "timeout",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("timeout"),
+		},
+		Timeout: &HTTPTimeout{
+			Request:        &metav1.Duration{Duration: 2 * time.Second},
+			BackendRequest: &metav1.Duration{Duration: 10 * time.Second},
+		},
+	}
+
+	timeoutNegativeHTTPRoute = HTTPRoute{
+		Name: "timeout",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("timeout"),
+		},
+		Timeout: &HTTPTimeout{
+			Request: &metav1.Duration{Duration: -1 * time.Second},
+		},
+	}
+
+	retryHTTPRoute = HTTPRoute{
+		Name: "retry",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("retry"),
+		},
+		Retry: &Retry{
+			NumRetries:           ptrTo(uint32(2)),
+			RetryOn:              []string{"5xx", "retriable-status-codes"},
+			RetriableStatusCodes: []uint32{429},
+			BackOff: &BackOff{
+				BaseInterval: metav1.Duration{Duration: 100 * time.Millisecond},
+				MaxInterval:  &metav1.Duration{Duration: time.Second},
+			},
+		},
+	}
+
+	retryInvalidHTTPRoute = HTTPRoute{
+		Name: "retry",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("retry"),
+		},
+		Retry: &Retry{
+			RetryOn:              []string{"retriable-status-codes"},
+			RetriableStatusCodes: []uint32{99},
+			BackOff: &BackOff{
+				BaseInterval: metav1.Duration{Duration: time.Second},
+				MaxInterval:  &metav1.Duration{Duration: 100 * time.Millisecond},
+			},
+		},
+	}
+
+	consistentHashHTTPRoute = HTTPRoute{
+		Name: "consistent-hash",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("consistent-hash"),
+		},
+		LoadBalancer: &LoadBalancer{
+			ConsistentHash: &ConsistentHash{
+				Algorithm: MaglevAlgorithm,
+				Cookie: &CookieHash{
+					Name: "session",
+					TTL:  &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		},
+	}
+
+	consistentHashInvalidHTTPRoute = HTTPRoute{
+		Name: "consistent-hash",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("consistent-hash"),
+		},
+		LoadBalancer: &LoadBalancer{
+			ConsistentHash: &ConsistentHash{
+				Algorithm: "Random",
+				SourceIP:  true,
+				Header:    &HeaderHash{},
+			},
+		},
+	}
+
+	leastRequestHTTPRoute = HTTPRoute{
+		Name: "least-request",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("least-request"),
+		},
+		LoadBalancer: &LoadBalancer{
+			LeastRequest: &LeastRequest{
+				ChoiceCount: ptrTo(uint32(3)),
+			},
+		},
+	}
+
+	loadBalancerInvalidHTTPRoute = HTTPRoute{
+		Name: "load-balancer",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("load-balancer"),
+		},
+		LoadBalancer: &LoadBalancer{
+			Random: &Random{},
+			LeastRequest: &LeastRequest{
+				ChoiceCount: ptrTo(uint32(1)),
+			},
+		},
+	}
+
+	outlierDetectionInvalidHTTPRoute = HTTPRoute{
+		Name: "outlier-detection",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("outlier-detection"),
+		},
+		HealthCheck: &HealthCheck{
+			Passive: &OutlierDetection{
+				MaxEjectionPercent: ptrTo(uint32(101)),
+			},
+		},
+	}
+
+	activeHealthCheckHTTPRoute = HTTPRoute{
+		Name: "active-health-check",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("active-health-check"),
+		},
+		HealthCheck: &HealthCheck{
+			Active: &ActiveHealthCheck{
+				Timeout:            metav1.Duration{Duration: time.Second},
+				Interval:           metav1.Duration{Duration: 3 * time.Second},
+				HealthyThreshold:   1,
+				UnhealthyThreshold: 3,
+				HTTP: &HTTPHealthChecker{
+					Path:             "/healthz",
+					ExpectedStatuses: []uint32{200},
+				},
+			},
+		},
+	}
+
+	activeHealthCheckInvalidHTTPRoute = HTTPRoute{
+		Name: "active-health-check",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("active-health-check"),
+		},
+		HealthCheck: &HealthCheck{
+			Active: &ActiveHealthCheck{
+				HTTP: &HTTPHealthChecker{
+					ExpectedStatuses: []uint32{600},
+				},
+				TCP: &TCPHealthChecker{},
+			},
+		},
+	}
+
+	backendTLSHTTPRoute = HTTPRoute{
+		Name: "backend-tls",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("backend-tls"),
+		},
+		BackendTLS: &BackendTLSConfig{
+			SNI:             "backend.example.com",
+			SubjectAltNames: []string{"backend.example.com"},
+			CACertificate:   []byte("ca-cert"),
+		},
+	}
+
+	backendTLSInvalidHTTPRoute = HTTPRoute{
+		Name: "backend-tls",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("backend-tls"),
+		},
+		BackendTLS: &BackendTLSConfig{
+			SubjectAltNames: []string{""},
+		},
+	}
+
+	localRateLimitHTTPRoute = HTTPRoute{
+		Name: "local-rate-limit",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("local-rate-limit"),
+		},
+		RateLimit: &RateLimit{
+			Local: &LocalRateLimit{
+				Default: RateLimitValue{Requests: 10, Unit: RateLimitUnitSecond},
+				Burst:   20,
+				Rules: []*LocalRateLimitRule{{
+					HeaderMatches: []*RateLimitHeaderMatch{{Name: "x-user-id", Value: "one"}},
+					Limit:         RateLimitValue{Requests: 5, Unit: RateLimitUnitMinute},
+				}},
+			},
+		},
+	}
+
+	globalRateLimitHTTPRoute = HTTPRoute{
+		Name: "global-rate-limit",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("global-rate-limit"),
+		},
+		RateLimit: &RateLimit{
+			Global: &GlobalRateLimit{
+				Rules: []*GlobalRateLimitRule{
+					{
+						Limit: RateLimitValue{Requests: 1000, Unit: RateLimitUnitSecond},
+					},
+					{
+						HeaderMatches: []*RateLimitHeaderMatch{{Name: "x-user-id"}},
+						Limit:         RateLimitValue{Requests: 10, Unit: RateLimitUnitMinute},
+					},
+				},
+			},
+		},
+	}
+
+	globalRateLimitInvalidHTTPRoute = HTTPRoute{
+		Name: "global-rate-limit",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("global-rate-limit"),
+		},
+		RateLimit: &RateLimit{
+			Global: &GlobalRateLimit{
+				Rules: []*GlobalRateLimitRule{{
+					HeaderMatches: []*RateLimitHeaderMatch{{Value: "one"}},
+					Limit:         RateLimitValue{Unit: RateLimitUnitSecond},
+				}},
+			},
+		},
+	}
+
+	localRateLimitInvalidHTTPRoute = HTTPRoute{
+		Name: "local-rate-limit",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("local-rate-limit"),
+		},
+		RateLimit: &RateLimit{
+			Local: &LocalRateLimit{
+				Default: RateLimitValue{Requests: 10, Unit: RateLimitUnitMinute},
+				Rules: []*LocalRateLimitRule{
+					{
+						HeaderMatches: []*RateLimitHeaderMatch{{Value: "one"}},
+						Limit:         RateLimitValue{Requests: 5, Unit: "Day"},
+					},
+					{
+						Limit: RateLimitValue{Requests: 5, Unit: RateLimitUnitMinute},
+					},
+					{
+						HeaderMatches: []*RateLimitHeaderMatch{{Name: "x-user-id", Value: "one"}},
+						Limit:         RateLimitValue{Requests: 5, Unit: RateLimitUnitSecond},
+					},
+				},
+			},
+		},
+	}
+
+	ipAccessControlHTTPRoute = HTTPRoute{
+		Name: "ip-access-control",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("ip-access-control"),
+		},
+		IPAccessControl: &IPAccessControl{
+			Allow: []*CIDR{{Prefix: "10.0.0.0", PrefixLen: 8}, {Prefix: "2001:db8::", PrefixLen: 32}},
+			Deny:  []*CIDR{{Prefix: "10.0.0.1", PrefixLen: 32}},
+		},
+	}
+
+	ipAccessControlInvalidHTTPRoute = HTTPRoute{
+		Name: "ip-access-control",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("ip-access-control"),
+		},
+		IPAccessControl: &IPAccessControl{
+			Deny: []*CIDR{{Prefix: "10.0.0.0", PrefixLen: 33}},
+		},
+	}
+
+	luaHTTPRoute = HTTPRoute{
+		Name: "lua",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("lua"),
+		},
+		Lua: &Lua{
+			Name: "default/lua",
+			Code: "function envoy_on_request(request_handle) end",
+		},
+	}
+
+	faultInjectionHTTPRoute = HTTPRoute{
+		Name: "fault-injection",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("fault-injection"),
+		},
+		FaultInjection: &FaultInjection{
+			Delay: &FaultInjectionDelay{FixedDelay: metav1.Duration{Duration: time.Second}, Percentage: 50},
+			Abort: &FaultInjectionAbort{HTTPStatus: 503, Percentage: 10},
+		},
+	}
+
+	faultInjectionInvalidHTTPRoute = HTTPRoute{
+		Name: "fault-injection",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("fault-injection"),
+		},
+		FaultInjection: &FaultInjection{
+			Delay: &FaultInjectionDelay{Percentage: 150},
+			Abort: &FaultInjectionAbort{HTTPStatus: 100},
+		},
+	}
+
+	faultInjectionEmptyHTTPRoute = HTTPRoute{
+		Name: "fault-injection",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("fault-injection"),
+		},
+		FaultInjection: &FaultInjection{},
+	}
+
+	bufferHTTPRoute = HTTPRoute{
+		Name: "buffer",
+		PathMatch: &StringMatch{
+			Prefix: ptrTo("/upload"),
+		},
+		Buffer: &Buffer{MaxRequestBytes: 1024},
+	}
+
+	bufferInvalidHTTPRoute = HTTPRoute{
+		Name: "buffer",
+		PathMatch: &StringMatch{
+			Prefix: ptrTo("/upload"),
+		},
+		Buffer: &Buffer{},
+	}
+
+	grpcJSONTranscoderHTTPRoute = HTTPRoute{
+		Name: "grpc-json-transcoder",
+		PathMatch: &StringMatch{
+			Prefix: ptrTo("/"),
+		},
+		GRPCJSONTranscoder: &GRPCJSONTranscoder{
+			ProtoDescriptor: []byte("descriptor"),
+			Services:        []string{"helloworld.Greeter"},
+		},
+	}
+
+	grpcJSONTranscoderInvalidHTTPRoute = HTTPRoute{
+		Name: "grpc-json-transcoder",
+		PathMatch: &StringMatch{
+			Prefix: ptrTo("/"),
+		},
+		GRPCJSONTranscoder: &GRPCJSONTranscoder{},
+	}
+
+	luaInvalidHTTPRoute = HTTPRoute{
+		Name: "lua",
+		PathMatch: &StringMatch{
+			Exact: ptrTo("lua"),
+		},
+		Lua: &Lua{},
+	}
+
+	// RouteDestination
+	happyRouteDestination = RouteDestination{
+		Host: "10.11.12.13",
+		Port: 8080,
+	}
+)
+
+// Creates a pointer to any type
+func ptrTo[T any](x T) *T {
+	return &x
+}
+
+func TestValidateXds(t *testing.T) {
+	tests := []struct {
+		name  string
+		input Xds
+		want  []error
+	}{
+		{
+			name: "happy",
+			input: Xds{
+				HTTP: []*HTTPListener{&happyHTTPListener},
+			},
+			want: nil,
+		},
+		{
+			name: "happy tls",
+			input: Xds{
+				TCP: []*TCPListener{&happyTCPListenerTLSPassthrough},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid listener",
+			input: Xds{
+				HTTP: []*HTTPListener{&happyHTTPListener, &invalidAddrHTTPListener, &invalidRouteMatchHTTPListener},
+			},
+			want: []error{ErrListenerAddressInvalid, ErrHTTPRouteMatchEmpty},
+		},
+		{
+			name: "invalid backend",
+			input: Xds{
+				HTTP: []*HTTPListener{&happyHTTPListener, &invalidBackendHTTPListener},
+			},
+			want: nil,
+		},
+		{
+			name: "weighted invalid backend",
+			input: Xds{
+				HTTP: []*HTTPListener{&happyHTTPListener, &weightedInvalidBackendsHTTPListener},
+			},
+			want: nil,
+		},
+		{
+			name: "happy envoy patch policy",
+			input: Xds{
+				HTTP: []*HTTPListener{&happyHTTPListener},
+				EnvoyPatchPolicies: []*EnvoyPatchPolicy{{
+					Name:      "policy",
+					Namespace: "default",
+					JSONPatches: []*JSONPatchConfig{{
+						Type:  "type.googleapis.com/envoy.config.listener.v3.Listener",
+						Name:  "listener",
+						Op:    "add",
+						Path:  "/per_connection_buffer_limit_bytes",
+						Value: "32768",
+					}},
+				}},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid envoy patch policy",
+			input: Xds{
+				HTTP: []*HTTPListener{&happyHTTPListener},
+				EnvoyPatchPolicies: []*EnvoyPatchPolicy{{
+					JSONPatches: []*JSONPatchConfig{{
+						Type: "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment",
+						Op:   "merge",
+						Path: "/",
+					}},
+				}},
+			},
+			want: []error{ErrEnvoyPatchPolicyNameEmpty, ErrJSONPatchTypeInvalid, ErrJSONPatchNameEmpty, ErrJSONPatchOpInvalid},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if test.want == nil {
+				require.NoError(t, test.input.Validate())
+			} else {
+				got := test.input.Validate()
+				for _, w := range test.want {
+					assert.ErrorContains(t, got, w.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestValidateHTTPListener(t *testing.T) {
+	tests := []struct {
+		name  string
+		input HTTPListener
+		want  []error
+	}{
+		{
+			name:  "happy",
+			input: happyHTTPListener,
+			want:  nil,
+		},
+		{
+			name: "invalid name",
+			input: HTTPListener{
+				Address:   "0.0.0.0",
+				Port:      80,
+				Hostnames: []string{"example.com"},
+				Routes:    []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrListenerNameEmpty},
+		},
+		{
+			name:  "invalid addr",
+			input: invalidAddrHTTPListener,
+			want:  []error{ErrListenerAddressInvalid},
+		},
+		{
+			name: "invalid port and hostnames",
+			input: HTTPListener{
+				Name:    "invalid-port-and-hostnames",
+				Address: "1.0.0",
+				Routes:  []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrListenerPortInvalid, ErrHTTPListenerHostnamesEmpty},
+		},
+		{
+			name:  "invalid route match",
+			input: invalidRouteMatchHTTPListener,
+			want:  []error{ErrHTTPRouteMatchEmpty},
+		},
+		{
+			name: "http3",
+			input: HTTPListener{
+				Name:      "http3",
+				Address:   "0.0.0.0",
+				Port:      10443,
+				Hostnames: []string{"example.com"},
+				TLS: &TLSListenerConfig{
+					ServerCertificate: []byte("server-cert"),
+					PrivateKey:        []byte("priv-key"),
+				},
+				HTTP3:  &HTTP3Settings{AdvertisedPort: 443},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "http3 without tls",
+			input: HTTPListener{
+				Name:      "http3-without-tls",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				HTTP3:     &HTTP3Settings{},
+				Routes:    []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrHTTP3TLSEmpty, ErrHTTP3AdvertisedPortInvalid},
+		},
+		{
+			name: "client ip detection",
+			input: HTTPListener{
+				Name:      "client-ip-detection",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				ClientIPDetection: &ClientIPDetection{
+					XForwardedFor: &XForwardedForIPDetection{NumTrustedHops: 2},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "client ip detection with both methods",
+			input: HTTPListener{
+				Name:      "client-ip-detection-with-both-methods",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				ClientIPDetection: &ClientIPDetection{
+					XForwardedFor: &XForwardedForIPDetection{NumTrustedHops: 2},
+					CustomHeader:  &CustomHeaderIPDetection{Name: "x-client-ip"},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrClientIPDetectionInvalid},
+		},
+		{
+			name: "client ip detection with empty custom header name",
+			input: HTTPListener{
+				Name:      "client-ip-detection-with-empty-custom-header-name",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				ClientIPDetection: &ClientIPDetection{
+					CustomHeader: &CustomHeaderIPDetection{},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrCustomHeaderNameEmpty},
+		},
+		{
+			name: "connection limit",
+			input: HTTPListener{
+				Name:            "connection-limit",
+				Address:         "0.0.0.0",
+				Port:            10080,
+				Hostnames:       []string{"example.com"},
+				ConnectionLimit: &ConnectionLimit{Value: 1000},
+				Routes:          []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid connection limit",
+			input: HTTPListener{
+				Name:            "invalid-connection-limit",
+				Address:         "0.0.0.0",
+				Port:            10080,
+				Hostnames:       []string{"example.com"},
+				ConnectionLimit: &ConnectionLimit{},
+				Routes:          []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrConnectionLimitInvalid},
+		},
+		{
+			name: "client timeouts",
+			input: HTTPListener{
+				Name:      "client-timeouts",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Timeouts: &ClientTimeouts{
+					Idle:       &metav1.Duration{Duration: time.Minute},
+					StreamIdle: &metav1.Duration{},
+				},
+				MaxRequestHeadersKB: 96,
+				Routes:              []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "negative client timeout",
+			input: HTTPListener{
+				Name:      "negative-client-timeout",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Timeouts: &ClientTimeouts{
+					Request: &metav1.Duration{Duration: -time.Second},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrClientTimeoutNegative},
+		},
+		{
+			name: "tcp keepalive and socket options",
+			input: HTTPListener{
+				Name:      "tcp-keepalive",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				TCPKeepalive: &TCPKeepalive{
+					Probes:   ptrTo(uint32(3)),
+					IdleTime: ptrTo(uint32(60)),
+				},
+				SocketOptions: []*SocketOption{
+					{Level: 6, Name: 1, Value: 1, State: SocketOptionStatePrebind},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid tcp keepalive and socket option",
+			input: HTTPListener{
+				Name:      "invalid-tcp-keepalive",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				TCPKeepalive: &TCPKeepalive{
+					Interval: ptrTo(uint32(0)),
+				},
+				SocketOptions: []*SocketOption{
+					{Level: 6, Name: 1, Value: 1},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrTCPKeepaliveInvalid, ErrSocketOptionStateInvalid},
+		},
+		{
+			name: "compression",
+			input: HTTPListener{
+				Name:      "compression",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Compression: &Compression{
+					Algorithms:       []CompressionAlgorithm{CompressionAlgorithmBrotli, CompressionAlgorithmGzip},
+					ContentTypes:     []string{"application/json"},
+					MinContentLength: ptrTo(uint32(1024)),
+					Level:            CompressionLevelBestSpeed,
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid compression",
+			input: HTTPListener{
+				Name:      "invalid-compression",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Compression: &Compression{
+					Algorithms: []CompressionAlgorithm{CompressionAlgorithmGzip, CompressionAlgorithmGzip},
+					Level:      "Fast",
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrCompressionAlgorithmInvalid, ErrCompressionLevelInvalid},
+		},
+		{
+			name: "compression without algorithms",
+			input: HTTPListener{
+				Name:        "empty-compression",
+				Address:     "0.0.0.0",
+				Port:        10080,
+				Hostnames:   []string{"example.com"},
+				Compression: &Compression{},
+				Routes:      []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrCompressionAlgorithmsEmpty},
+		},
+		{
+			name: "local reply",
+			input: HTTPListener{
+				Name:      "local-reply",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				LocalReply: &LocalReply{
+					Mappers: []*LocalReplyMapper{{
+						StatusCodes: []uint32{503},
+						StatusCode:  ptrTo(uint32(502)),
+						Body:        ptrTo("upstream unavailable"),
+					}},
+					BodyFormat: &LocalReplyBodyFormat{
+						JSON: map[string]string{"message": "%LOCAL_REPLY_BODY%"},
+					},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid local reply",
+			input: HTTPListener{
+				Name:      "invalid-local-reply",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				LocalReply: &LocalReply{
+					Mappers: []*LocalReplyMapper{
+						{StatusCodes: []uint32{600}},
+						{Body: ptrTo("not found")},
+					},
+					BodyFormat: &LocalReplyBodyFormat{
+						Text: ptrTo("%LOCAL_REPLY_BODY%"),
+						JSON: map[string]string{"message": "%LOCAL_REPLY_BODY%"},
+					},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrLocalReplyStatusInvalid, ErrLocalReplyStatusCodesEmpty, ErrLocalReplyBodyFormatInvalid},
+		},
+		{
+			name: "empty local reply",
+			input: HTTPListener{
+				Name:       "empty-local-reply",
+				Address:    "0.0.0.0",
+				Port:       10080,
+				Hostnames:  []string{"example.com"},
+				LocalReply: &LocalReply{},
+				Routes:     []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrLocalReplyEmpty},
+		},
+		{
+			name: "access log",
+			input: HTTPListener{
+				Name:      "access-log",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				AccessLog: &AccessLog{
+					JSON:  map[string]string{"path": "%REQ(:PATH)%"},
+					Files: []string{"/dev/stdout"},
+					ALS: []*ALSAccessLog{{
+						LogName: "default/eg",
+						Host:    "als.monitoring.svc.cluster.local",
+						Port:    9000,
+					}},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid access log",
+			input: HTTPListener{
+				Name:      "invalid-access-log",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				AccessLog: &AccessLog{
+					Text: ptrTo("%REQ(:PATH)%\n"),
+					JSON: map[string]string{"path": "%REQ(:PATH)%"},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrAccessLogFormatInvalid, ErrAccessLogSinksEmpty},
+		},
+		{
+			name: "invalid access log service",
+			input: HTTPListener{
+				Name:      "invalid-access-log-service",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				AccessLog: &AccessLog{
+					ALS: []*ALSAccessLog{{}},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
 			},
+			want: []error{ErrALSLogNameEmpty, ErrALSHostEmpty, ErrALSPortInvalid},
 		},
-	}
-
-	// RouteDestination
-	happyRouteDestination = RouteDestination{
-		Host: "10.11.12.13",
-		Port: 8080,
-	}
-)
-
-// Creates a pointer to any type
-func ptrTo[T any](x T) *T {
-	return &x
-}
-
-func TestValidateXds(t *testing.T) {
-	tests := []struct {
-		name  string
-		input Xds
-		want  []error
-	}{
 		{
-			name: "happy",
-			input: Xds{
-				HTTP: []*HTTPListener{&happyHTTPListener},
+			name: "tracing",
+			input: HTTPListener{
+				Name:      "tracing",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Tracing: &Tracing{
+					Provider:     TracingProviderTypeZipkin,
+					Host:         "zipkin.monitoring.svc.cluster.local",
+					Port:         9411,
+					ServiceName:  "eg.default",
+					SamplingRate: 10,
+					CustomTags: []*CustomTag{
+						{Name: "env", Literal: ptrTo("prod")},
+						{Name: "user", RequestHeader: &CustomTagSource{Name: "x-user"}},
+					},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
 			},
 			want: nil,
 		},
 		{
-			name: "happy tls",
-			input: Xds{
-				TCP: []*TCPListener{&happyTCPListenerTLSPassthrough},
+			name: "invalid tracing",
+			input: HTTPListener{
+				Name:      "invalid-tracing",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Tracing: &Tracing{
+					Provider:     TracingProviderTypeDatadog,
+					SamplingRate: 200,
+					CustomTags: []*CustomTag{
+						{Metadata: &CustomTagMetadata{Kind: CustomTagMetadataKindRequest}},
+						{Name: "env", Literal: ptrTo("prod"), Environment: &CustomTagSource{Name: "ENV"}},
+						{Name: "header", RequestHeader: &CustomTagSource{}},
+					},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{
+				ErrTracingServiceNameEmpty, ErrTracingHostEmpty, ErrTracingPortInvalid, ErrTracingSamplingRateInvalid,
+				ErrCustomTagNameEmpty, ErrCustomTagMetadataInvalid, ErrCustomTagValueInvalid, ErrCustomTagSourceNameEmpty,
+			},
+		},
+		{
+			name: "http1 header case",
+			input: HTTPListener{
+				Name:      "http1",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				HTTP1:     &HTTP1Settings{HeaderCase: HeaderCasePreserve},
+				Routes:    []*HTTPRoute{&happyHTTPRoute},
 			},
 			want: nil,
 		},
 		{
-			name: "invalid listener",
-			input: Xds{
-				HTTP: []*HTTPListener{&happyHTTPListener, &invalidAddrHTTPListener, &invalidRouteMatchHTTPListener},
+			name: "invalid http1 header case",
+			input: HTTPListener{
+				Name:      "invalid-http1",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				HTTP1:     &HTTP1Settings{HeaderCase: "UpperCase"},
+				Routes:    []*HTTPRoute{&happyHTTPRoute},
 			},
-			want: []error{ErrListenerAddressInvalid, ErrHTTPRouteMatchEmpty},
+			want: []error{ErrHTTP1HeaderCaseInvalid},
 		},
 		{
-			name: "invalid backend",
-			input: Xds{
-				HTTP: []*HTTPListener{&happyHTTPListener, &invalidBackendHTTPListener},
+			name: "server header",
+			input: HTTPListener{
+				Name:                  "server-header",
+				Address:               "0.0.0.0",
+				Port:                  10080,
+				Hostnames:             []string{"example.com"},
+				ServerHeader:          &ServerHeader{Transformation: ServerHeaderOverwrite, Value: "gateway"},
+				RemoveResponseHeaders: []string{"x-powered-by"},
+				Routes:                []*HTTPRoute{&happyHTTPRoute},
 			},
 			want: nil,
 		},
 		{
-			name: "weighted invalid backend",
-			input: Xds{
-				HTTP: []*HTTPListener{&happyHTTPListener, &weightedInvalidBackendsHTTPListener},
+			name: "invalid server header",
+			input: HTTPListener{
+				Name:                  "invalid-server-header",
+				Address:               "0.0.0.0",
+				Port:                  10080,
+				Hostnames:             []string{"example.com"},
+				ServerHeader:          &ServerHeader{Value: "gateway"},
+				RemoveResponseHeaders: []string{"x-powered-by", "x-powered-by"},
+				Routes:                []*HTTPRoute{&happyHTTPRoute},
 			},
-			want: nil,
+			want: []error{ErrRemoveHeaderDuplicate, ErrServerHeaderInvalid},
 		},
-	}
-	for _, test := range tests {
-		test := test
-		t.Run(test.name, func(t *testing.T) {
-			if test.want == nil {
-				require.NoError(t, test.input.Validate())
-			} else {
-				got := test.input.Validate()
-				for _, w := range test.want {
-					assert.ErrorContains(t, got, w.Error())
-				}
-			}
-		})
-	}
-}
-
-func TestValidateHTTPListener(t *testing.T) {
-	tests := []struct {
-		name  string
-		input HTTPListener
-		want  []error
-	}{
 		{
-			name:  "happy",
-			input: happyHTTPListener,
-			want:  nil,
+			name: "headers",
+			input: HTTPListener{
+				Name:      "headers",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				AddRequestHeaders: []AddHeader{
+					{Name: "x-gateway", Value: "eg"},
+				},
+				AddResponseHeaders: []AddHeader{
+					{Name: "strict-transport-security", Value: "max-age=31536000"},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
 		},
 		{
-			name: "invalid name",
+			name: "invalid headers",
 			input: HTTPListener{
+				Name:      "invalid-headers",
 				Address:   "0.0.0.0",
-				Port:      80,
+				Port:      10080,
 				Hostnames: []string{"example.com"},
-				Routes:    []*HTTPRoute{&happyHTTPRoute},
+				AddRequestHeaders: []AddHeader{
+					{Value: "eg"},
+				},
+				AddResponseHeaders: []AddHeader{
+					{Name: "strict-transport-security", Value: "max-age=31536000"},
+					{Name: "strict-transport-security", Value: "max-age=0"},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
 			},
-			want: []error{ErrListenerNameEmpty},
+			want: []error{ErrAddHeaderEmptyName, ErrAddHeaderDuplicate},
 		},
 		{
-			name:  "invalid addr",
-			input: invalidAddrHTTPListener,
-			want:  []error{ErrListenerAddressInvalid},
+			name: "empty ip access control",
+			input: HTTPListener{
+				Name:            "empty-ip-access-control",
+				Address:         "0.0.0.0",
+				Port:            10080,
+				Hostnames:       []string{"example.com"},
+				IPAccessControl: &IPAccessControl{},
+				Routes:          []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrIPAccessControlEmpty},
 		},
 		{
-			name: "invalid port and hostnames",
+			name: "wasm",
 			input: HTTPListener{
-				Name:    "invalid-port-and-hostnames",
-				Address: "1.0.0",
-				Routes:  []*HTTPRoute{&happyHTTPRoute},
+				Name:      "wasm",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Wasm: []*Wasm{{
+					Name:   "wasm-1",
+					URL:    "https://example.com/filter.wasm",
+					SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					Config: `{"key":"value"}`,
+				}},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
 			},
-			want: []error{ErrListenerPortInvalid, ErrHTTPListenerHostnamesEmpty},
+			want: nil,
 		},
 		{
-			name:  "invalid route match",
-			input: invalidRouteMatchHTTPListener,
-			want:  []error{ErrHTTPRouteMatchEmpty},
+			name: "invalid wasm",
+			input: HTTPListener{
+				Name:      "invalid-wasm",
+				Address:   "0.0.0.0",
+				Port:      10080,
+				Hostnames: []string{"example.com"},
+				Wasm: []*Wasm{
+					{
+						URL:    "oci://example.com/filter:v1",
+						SHA256: "e3b0c442",
+					},
+					{
+						URL:    "http://example.com/filter.wasm",
+						SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					},
+				},
+				Routes: []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrWasmNameEmpty, ErrWasmURLInvalid, ErrWasmSHA256Invalid, ErrWasmNameDuplicate},
 		},
 	}
 	for _, test := range tests {
@@ -398,6 +1326,18 @@ func TestValidateTCPListener(t *testing.T) {
 			input: invalidSNITCPListenerTLSPassthrough,
 			want:  []error{ErrTCPListenesSNIsEmpty},
 		},
+		{
+			name: "invalid ip access control",
+			input: TCPListener{
+				Name:    "invalid-ip-access-control",
+				Address: "0.0.0.0",
+				Port:    10080,
+				IPAccessControl: &IPAccessControl{
+					Allow: []*CIDR{{Prefix: "10.0.0", PrefixLen: 24}},
+				},
+			},
+			want: []error{ErrCIDRInvalid},
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -442,6 +1382,74 @@ func TestValidateTLSListenerConfig(t *testing.T) {
 			},
 			want: ErrTLSPrivateKey,
 		},
+		{
+			name: "client validation",
+			input: TLSListenerConfig{
+				ServerCertificate:        []byte("server-cert"),
+				PrivateKey:               []byte("priv-key"),
+				ClientCACertificate:      []byte("ca-cert"),
+				ClientCRL:                []byte("ca-crl"),
+				RequireClientCertificate: true,
+				ForwardClientCertDetails: &ForwardClientCertDetails{
+					Mode:    ForwardClientCertSanitizeSet,
+					Subject: true,
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "tls parameters",
+			input: TLSListenerConfig{
+				ServerCertificate: []byte("server-cert"),
+				PrivateKey:        []byte("priv-key"),
+				MinVersion:        TLSv12,
+				MaxVersion:        TLSv13,
+				Ciphers:           []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+				ECDHCurves:        []string{"X25519"},
+				ALPNProtocols:     []string{"h2", "http/1.1"},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid tls version",
+			input: TLSListenerConfig{
+				ServerCertificate: []byte("server-cert"),
+				PrivateKey:        []byte("priv-key"),
+				MinVersion:        "1.4",
+			},
+			want: ErrTLSVersionInvalid,
+		},
+		{
+			name: "min tls version greater than max tls version",
+			input: TLSListenerConfig{
+				ServerCertificate: []byte("server-cert"),
+				PrivateKey:        []byte("priv-key"),
+				MinVersion:        TLSv13,
+				MaxVersion:        TLSv12,
+			},
+			want: ErrTLSVersionRange,
+		},
+		{
+			name: "require client certificate without client ca",
+			input: TLSListenerConfig{
+				ServerCertificate:        []byte("server-cert"),
+				PrivateKey:               []byte("priv-key"),
+				RequireClientCertificate: true,
+			},
+			want: ErrTLSClientCAEmpty,
+		},
+		{
+			name: "invalid forward client cert mode",
+			input: TLSListenerConfig{
+				ServerCertificate:   []byte("server-cert"),
+				PrivateKey:          []byte("priv-key"),
+				ClientCACertificate: []byte("ca-cert"),
+				ForwardClientCertDetails: &ForwardClientCertDetails{
+					Mode: "Forward",
+				},
+			},
+			want: ErrForwardClientCertMode,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -539,11 +1547,178 @@ func TestValidateHTTPRoute(t *testing.T) {
 			input: addRemoveHeadersDupeHTTPRoute,
 			want:  []error{ErrAddHeaderDuplicate, ErrRemoveHeaderDuplicate},
 		},
+		{
+			name:  "response-headers-httproute",
+			input: responseHeadersHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "response-headers-duplicate",
+			input: responseHeadersDupeHTTPRoute,
+			want:  []error{ErrAddHeaderDuplicate, ErrRemoveHeaderDuplicate},
+		},
 		{
 			name:  "add-header-empty",
 			input: addHeaderEmptyHTTPRoute,
 			want:  []error{ErrAddHeaderEmptyName},
 		},
+		{
+			name:  "timeout-httproute",
+			input: timeoutHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "timeout-backend-request-exceeds-request",
+			input: timeoutBackendRequestExceedsRequestHTTPRoute,
+			want:  []error{ErrHTTPTimeoutBackendRequest},
+		},
+		{
+			name:  "timeout-negative",
+			input: timeoutNegativeHTTPRoute,
+			want:  []error{ErrHTTPTimeoutNegative},
+		},
+		{
+			name:  "retry-httproute",
+			input: retryHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "retry-invalid-status-code-and-backoff",
+			input: retryInvalidHTTPRoute,
+			want:  []error{ErrRetryStatusCodeInvalid, ErrRetryBackOffIntervalInvalid},
+		},
+		{
+			name:  "consistent-hash-httproute",
+			input: consistentHashHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "consistent-hash-invalid-algorithm-and-keys",
+			input: consistentHashInvalidHTTPRoute,
+			want:  []error{ErrConsistentHashAlgorithm, ErrHashNameEmpty, ErrConsistentHashKeyInvalid},
+		},
+		{
+			name:  "least-request-httproute",
+			input: leastRequestHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "load-balancer-multiple-types-and-invalid-choice-count",
+			input: loadBalancerInvalidHTTPRoute,
+			want:  []error{ErrLoadBalancerInvalid, ErrLeastRequestChoiceCount},
+		},
+		{
+			name:  "outlier-detection-invalid-max-ejection-percent",
+			input: outlierDetectionInvalidHTTPRoute,
+			want:  []error{ErrMaxEjectionPercentInvalid},
+		},
+		{
+			name:  "active-health-check-httproute",
+			input: activeHealthCheckHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "active-health-check-invalid",
+			input: activeHealthCheckInvalidHTTPRoute,
+			want: []error{ErrHealthCheckTimeoutInvalid, ErrHealthCheckThresholdInvalid, ErrHealthCheckerInvalid,
+				ErrHTTPHealthCheckPathEmpty, ErrHTTPHealthCheckStatusInvalid},
+		},
+		{
+			name: "backend-weights-overflow-and-empty-upgrade",
+			input: HTTPRoute{
+				Name:           "invalid-weights-upgrade",
+				PathMatch:      &StringMatch{Exact: ptrTo("example")},
+				BackendWeights: BackendWeights{Valid: math.MaxUint32, Invalid: 1},
+				Upgrade:        &Upgrade{},
+			},
+			want: []error{ErrBackendWeightsOverflow, ErrUpgradeEmpty},
+		},
+		{
+			name:  "backend-tls-httproute",
+			input: backendTLSHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "backend-tls-empty-subject-alt-name",
+			input: backendTLSInvalidHTTPRoute,
+			want:  []error{ErrSubjectAltNameEmpty},
+		},
+		{
+			name:  "local-rate-limit-httproute",
+			input: localRateLimitHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "local-rate-limit-invalid-rules",
+			input: localRateLimitInvalidHTTPRoute,
+			want: []error{ErrRateLimitHeaderNameEmpty, ErrRateLimitValueInvalid, ErrRateLimitRuleHeadersEmpty,
+				ErrLocalRateLimitRuleUnit},
+		},
+		{
+			name:  "global-rate-limit-httproute",
+			input: globalRateLimitHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "global-rate-limit-invalid-rule",
+			input: globalRateLimitInvalidHTTPRoute,
+			want:  []error{ErrRateLimitHeaderNameEmpty, ErrRateLimitValueInvalid},
+		},
+		{
+			name:  "ip-access-control-httproute",
+			input: ipAccessControlHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "ip-access-control-invalid-cidr",
+			input: ipAccessControlInvalidHTTPRoute,
+			want:  []error{ErrCIDRInvalid},
+		},
+		{
+			name:  "fault-injection-httproute",
+			input: faultInjectionHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "fault-injection-invalid-delay-status-and-percentage",
+			input: faultInjectionInvalidHTTPRoute,
+			want:  []error{ErrFaultInjectionDelayInvalid, ErrFaultInjectionPercentInvalid, ErrFaultInjectionStatusInvalid},
+		},
+		{
+			name:  "fault-injection-empty",
+			input: faultInjectionEmptyHTTPRoute,
+			want:  []error{ErrFaultInjectionEmpty},
+		},
+		{
+			name:  "buffer-httproute",
+			input: bufferHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "buffer-zero-max-request-bytes",
+			input: bufferInvalidHTTPRoute,
+			want:  []error{ErrBufferMaxRequestBytesInvalid},
+		},
+		{
+			name:  "grpc-json-transcoder-httproute",
+			input: grpcJSONTranscoderHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "grpc-json-transcoder-empty",
+			input: grpcJSONTranscoderInvalidHTTPRoute,
+			want:  []error{ErrProtoDescriptorEmpty, ErrGRPCServicesEmpty},
+		},
+		{
+			name:  "lua-httproute",
+			input: luaHTTPRoute,
+			want:  nil,
+		},
+		{
+			name:  "lua-empty-name-and-code",
+			input: luaInvalidHTTPRoute,
+			want:  []error{ErrLuaNameEmpty, ErrLuaCodeEmpty},
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -586,6 +1761,24 @@ func TestValidateRouteDestination(t *testing.T) {
 			},
 			want: ErrRouteDestinationPortInvalid,
 		},
+		{
+			name: "http2 protocol",
+			input: RouteDestination{
+				Host:     "10.11.12.13",
+				Port:     8080,
+				Protocol: HTTP2,
+			},
+			want: nil,
+		},
+		{
+			name: "invalid protocol",
+			input: RouteDestination{
+				Host:     "10.11.12.13",
+				Port:     8080,
+				Protocol: "HTTP3",
+			},
+			want: ErrDestinationProtocolInvalid,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -1,15 +1,60 @@
 package log
 
 import (
+	"fmt"
+
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
 
+// NewLogger returns a logger with the default logging settings of Envoy Gateway.
 func NewLogger() (logr.Logger, error) {
-	zap, err := zap.NewDevelopment()
+	return NewComponentLogger(nil, v1alpha1.LogComponentDefault)
+}
+
+// NewComponentLogger returns a logger for the provided component of Envoy Gateway,
+// logging at the level of the component with the encoding of the provided logging
+// settings.
+func NewComponentLogger(logging *v1alpha1.EnvoyGatewayLogging, component v1alpha1.EnvoyGatewayLogComponent) (logr.Logger, error) {
+	level, err := zapLevel(logging.GetLevel(component))
+	if err != nil {
+		return logr.Logger{}, err
+	}
+
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Level = zap.NewAtomicLevelAt(level)
+	switch encoding := logging.GetEncoding(); encoding {
+	case v1alpha1.EnvoyGatewayLogEncodingConsole:
+	case v1alpha1.EnvoyGatewayLogEncodingJSON:
+		cfg.Encoding = "json"
+		cfg.EncoderConfig = zap.NewProductionEncoderConfig()
+	default:
+		return logr.Logger{}, fmt.Errorf("unsupported log encoding %q", encoding)
+	}
+
+	zap, err := cfg.Build()
 	if err != nil {
 		return logr.Logger{}, err
 	}
 	return zapr.NewLogger(zap), nil
 }
+
+// zapLevel returns the zap level of the provided log level.
+func zapLevel(level v1alpha1.EnvoyGatewayLogLevel) (zapcore.Level, error) {
+	switch level {
+	case v1alpha1.EnvoyGatewayLogLevelDebug:
+		return zapcore.DebugLevel, nil
+	case v1alpha1.EnvoyGatewayLogLevelInfo:
+		return zapcore.InfoLevel, nil
+	case v1alpha1.EnvoyGatewayLogLevelWarn:
+		return zapcore.WarnLevel, nil
+	case v1alpha1.EnvoyGatewayLogLevelError:
+		return zapcore.ErrorLevel, nil
+	default:
+		return 0, fmt.Errorf("unsupported log level %q", level)
+	}
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestNewComponentLogger(t *testing.T) {
+	logging := &v1alpha1.EnvoyGatewayLogging{
+		Level: map[v1alpha1.EnvoyGatewayLogComponent]v1alpha1.EnvoyGatewayLogLevel{
+			v1alpha1.LogComponentDefault: v1alpha1.EnvoyGatewayLogLevelError,
+			v1alpha1.LogComponentXds:     v1alpha1.EnvoyGatewayLogLevelDebug,
+		},
+		Encoding: v1alpha1.EnvoyGatewayLogEncodingJSON,
+	}
+
+	testCases := []struct {
+		name      string
+		logging   *v1alpha1.EnvoyGatewayLogging
+		component v1alpha1.EnvoyGatewayLogComponent
+		info      bool
+		debug     bool
+	}{
+		{
+			name:      "default settings",
+			component: v1alpha1.LogComponentXds,
+			info:      true,
+		},
+		{
+			name:      "component level",
+			logging:   logging,
+			component: v1alpha1.LogComponentXds,
+			info:      true,
+			debug:     true,
+		},
+		{
+			name:      "default component level",
+			logging:   logging,
+			component: v1alpha1.LogComponentProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			logger, err := NewComponentLogger(tc.logging, tc.component)
+			require.NoError(t, err)
+			require.Equal(t, tc.info, logger.Enabled())
+			require.Equal(t, tc.debug, logger.V(1).Enabled())
+		})
+	}
+}
+
+func TestNewComponentLoggerInvalidSettings(t *testing.T) {
+	_, err := NewComponentLogger(&v1alpha1.EnvoyGatewayLogging{
+		Level: map[v1alpha1.EnvoyGatewayLogComponent]v1alpha1.EnvoyGatewayLogLevel{
+			v1alpha1.LogComponentDefault: "verbose",
+		},
+	}, v1alpha1.LogComponentInfra)
+	require.Error(t, err)
+
+	_, err = NewComponentLogger(&v1alpha1.EnvoyGatewayLogging{Encoding: "text"}, v1alpha1.LogComponentInfra)
+	require.Error(t, err)
+}
@@ -7,6 +7,8 @@ import (
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
 	"github.com/envoyproxy/gateway/internal/ir"
 	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
@@ -20,12 +22,29 @@ type ProviderResources struct {
 	Namespaces     watchable.Map[string, *corev1.Namespace]
 	Services       watchable.Map[types.NamespacedName, *corev1.Service]
 	Secrets        watchable.Map[types.NamespacedName, *corev1.Secret]
+	ConfigMaps     watchable.Map[types.NamespacedName, *corev1.ConfigMap]
 
 	ReferenceGrants watchable.Map[types.NamespacedName, *gwapiv1a2.ReferenceGrant]
 
+	// EnvoyProxies is keyed by the name of the GatewayClass that references the EnvoyProxy.
+	EnvoyProxies watchable.Map[string, *v1alpha1.EnvoyProxy]
+
+	BackendTrafficPolicies watchable.Map[types.NamespacedName, *v1alpha1.BackendTrafficPolicy]
+	ClientTrafficPolicies  watchable.Map[types.NamespacedName, *v1alpha1.ClientTrafficPolicy]
+	EnvoyExtensionPolicies watchable.Map[types.NamespacedName, *v1alpha1.EnvoyExtensionPolicy]
+	EnvoyPatchPolicies     watchable.Map[types.NamespacedName, *v1alpha1.EnvoyPatchPolicy]
+
 	GatewayStatuses   watchable.Map[types.NamespacedName, *gwapiv1b1.Gateway]
 	HTTPRouteStatuses watchable.Map[types.NamespacedName, *gwapiv1b1.HTTPRoute]
 	TLSRouteStatuses  watchable.Map[types.NamespacedName, *gwapiv1a2.TLSRoute]
+	// BackendTrafficPolicyStatuses is written by the gatewayapi translator.
+	BackendTrafficPolicyStatuses watchable.Map[types.NamespacedName, *v1alpha1.BackendTrafficPolicyStatus]
+	// EnvoyPatchPolicyStatuses is written by the gatewayapi translator for the
+	// rejected policies and by the xds translator for the applied policies.
+	EnvoyPatchPolicyStatuses watchable.Map[types.NamespacedName, *v1alpha1.EnvoyPatchPolicyStatus]
+	// InfraStatuses is written by the infra runner with the status of the
+	// proxy infra of each Gateway.
+	InfraStatuses watchable.Map[types.NamespacedName, infrastructure.Status]
 }
 
 func (p *ProviderResources) GetGatewayClasses() []*gwapiv1b1.GatewayClass {
@@ -107,6 +126,17 @@ func (p *ProviderResources) GetSecrets() []*corev1.Secret {
 	return res
 }
 
+func (p *ProviderResources) GetConfigMaps() []*corev1.ConfigMap {
+	if p.ConfigMaps.Len() == 0 {
+		return nil
+	}
+	res := make([]*corev1.ConfigMap, 0, p.ConfigMaps.Len())
+	for _, v := range p.ConfigMaps.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
 func (p *ProviderResources) GetReferenceGrants() []*gwapiv1a2.ReferenceGrant {
 	if p.ReferenceGrants.Len() == 0 {
 		return nil
@@ -118,6 +148,60 @@ func (p *ProviderResources) GetReferenceGrants() []*gwapiv1a2.ReferenceGrant {
 	return res
 }
 
+// GetEnvoyProxy returns the EnvoyProxy referenced by the GatewayClass
+// with the provided name, or nil if no EnvoyProxy is referenced.
+func (p *ProviderResources) GetEnvoyProxy(gatewayClassName string) *v1alpha1.EnvoyProxy {
+	ep, ok := p.EnvoyProxies.Load(gatewayClassName)
+	if !ok {
+		return nil
+	}
+	return ep
+}
+
+func (p *ProviderResources) GetBackendTrafficPolicies() []*v1alpha1.BackendTrafficPolicy {
+	if p.BackendTrafficPolicies.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.BackendTrafficPolicy, 0, p.BackendTrafficPolicies.Len())
+	for _, v := range p.BackendTrafficPolicies.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func (p *ProviderResources) GetClientTrafficPolicies() []*v1alpha1.ClientTrafficPolicy {
+	if p.ClientTrafficPolicies.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.ClientTrafficPolicy, 0, p.ClientTrafficPolicies.Len())
+	for _, v := range p.ClientTrafficPolicies.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func (p *ProviderResources) GetEnvoyExtensionPolicies() []*v1alpha1.EnvoyExtensionPolicy {
+	if p.EnvoyExtensionPolicies.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.EnvoyExtensionPolicy, 0, p.EnvoyExtensionPolicies.Len())
+	for _, v := range p.EnvoyExtensionPolicies.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func (p *ProviderResources) GetEnvoyPatchPolicies() []*v1alpha1.EnvoyPatchPolicy {
+	if p.EnvoyPatchPolicies.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.EnvoyPatchPolicy, 0, p.EnvoyPatchPolicies.Len())
+	for _, v := range p.EnvoyPatchPolicies.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
 // XdsIR message
 type XdsIR struct {
 	watchable.Map[string, *ir.Xds]
@@ -0,0 +1,129 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/tracing"
+)
+
+// Provider is the File provider. It loads the resources of Envoy Gateway from
+// files instead of the Kubernetes API, and reloads them when the files change.
+type Provider struct {
+	paths      []string
+	controller gwapiv1b1.GatewayController
+	resources  *message.ProviderResources
+	log        logr.Logger
+}
+
+// New creates a new Provider from the provided EnvoyGateway.
+func New(svr *config.Server, resources *message.ProviderResources) (*Provider, error) {
+	file := svr.EnvoyGateway.Provider.File
+	if file == nil || len(file.Paths) == 0 {
+		return nil, errors.New("the file provider requires at least one path")
+	}
+
+	paths := make([]string, 0, len(file.Paths))
+	for _, path := range file.Paths {
+		paths = append(paths, filepath.Clean(path))
+	}
+	return &Provider{
+		paths:      paths,
+		controller: gwapiv1b1.GatewayController(svr.EnvoyGateway.Gateway.ControllerName),
+		resources:  resources,
+		log:        svr.Logger,
+	}, nil
+}
+
+// Start loads the resources and reloads them when the files change, until a
+// message is received from ctx.
+func (p *Provider) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// The directory of a file is watched rather than the file itself, since
+	// editors and ConfigMap volumes replace files instead of writing them.
+	for _, path := range p.paths {
+		dir, err := watchedDir(path)
+		if err != nil {
+			return err
+		}
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	p.reload(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op != fsnotify.Chmod && p.isWatched(event.Name) {
+				p.log.Info("file changed", "name", event.Name, "op", event.Op.String())
+				p.reload(ctx)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			p.log.Error(err, "failed to watch files")
+		}
+	}
+}
+
+// reload loads the resources of the files and stores them in the resource maps.
+// The previously loaded resources are kept if the files can't be loaded.
+func (p *Provider) reload(ctx context.Context) {
+	_, span := tracing.Tracer().Start(ctx, "provider.reload")
+	res, err := loadResources(p.paths, false)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		p.log.Error(err, "failed to load resources, keeping the previous resources")
+		return
+	}
+	res.store(p.controller, p.resources)
+	p.log.Info("loaded resources", "paths", p.paths)
+}
+
+// isWatched returns true if the file with the provided name is one of the paths
+// of the provider, or a file holding resources in one of its directories.
+func (p *Provider) isWatched(name string) bool {
+	name = filepath.Clean(name)
+	for _, path := range p.paths {
+		if name == path {
+			return true
+		}
+		if filepath.Dir(name) == path && isResourceFile(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchedDir returns the directory to watch for the provided path.
+func watchedDir(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return path, nil
+	}
+	return filepath.Dir(path), nil
+}
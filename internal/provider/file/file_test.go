@@ -0,0 +1,145 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func newTestProvider(t *testing.T, paths ...string) (*Provider, *message.ProviderResources) {
+	t.Helper()
+	svr, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	svr.EnvoyGateway.Provider = &v1alpha1.Provider{
+		Type: v1alpha1.ProviderTypeFile,
+		File: &v1alpha1.FileProvider{Paths: paths},
+	}
+	resources := new(message.ProviderResources)
+	p, err := New(svr, resources)
+	require.NoError(t, err)
+	return p, resources
+}
+
+func TestNewWithoutPaths(t *testing.T) {
+	svr, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	svr.EnvoyGateway.Provider = &v1alpha1.Provider{Type: v1alpha1.ProviderTypeFile}
+	_, err = New(svr, new(message.ProviderResources))
+	require.Error(t, err)
+}
+
+func TestReload(t *testing.T) {
+	p, resources := newTestProvider(t, "testdata")
+	p.reload(context.Background())
+
+	// Only the GatewayClass managed by Envoy Gateway is stored, with the
+	// EnvoyProxy it references.
+	require.Equal(t, 1, resources.GatewayClasses.Len())
+	gc, ok := resources.GatewayClasses.Load("eg")
+	require.True(t, ok)
+	ep := resources.GetEnvoyProxy(gc.Name)
+	require.NotNil(t, ep)
+	require.Equal(t, "proxy-config", ep.Name)
+
+	// The namespaced resources default to the "default" namespace.
+	_, ok = resources.Gateways.Load(types.NamespacedName{Namespace: "default", Name: "eg"})
+	require.True(t, ok)
+	_, ok = resources.HTTPRoutes.Load(types.NamespacedName{Namespace: "apps", Name: "backend"})
+	require.True(t, ok)
+	_, ok = resources.Services.Load(types.NamespacedName{Namespace: "apps", Name: "backend"})
+	require.True(t, ok)
+
+	// The namespaces of the Gateways and routes are implicitly declared.
+	_, ok = resources.Namespaces.Load("default")
+	require.True(t, ok)
+	_, ok = resources.Namespaces.Load("apps")
+	require.True(t, ok)
+}
+
+func TestReloadInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "gateway.yaml")
+	data, err := os.ReadFile("testdata/gateway.yaml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	p, resources := newTestProvider(t, file)
+	p.reload(context.Background())
+	require.Equal(t, 1, resources.Gateways.Len())
+
+	// The previous resources are kept if a file is invalid.
+	require.NoError(t, os.WriteFile(file, []byte("kind: Unknown\napiVersion: v1\n"), 0o600))
+	p.reload(context.Background())
+	require.Equal(t, 1, resources.Gateways.Len())
+}
+
+func TestStartWatchesFiles(t *testing.T) {
+	dir := t.TempDir()
+	p, resources := newTestProvider(t, dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		require.NoError(t, p.Start(ctx))
+	}()
+
+	// Resources are loaded when a file is added to the directory.
+	data, err := os.ReadFile("testdata/routes.yml")
+	require.NoError(t, err)
+	file := filepath.Join(dir, "routes.yaml")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+	require.Eventually(t, func() bool {
+		return resources.HTTPRoutes.Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Resources are deleted when their file is removed.
+	require.NoError(t, os.Remove(file))
+	require.Eventually(t, func() bool {
+		return resources.HTTPRoutes.Len() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLoadGatewayAPIResources(t *testing.T) {
+	in, gc, err := LoadGatewayAPIResources([]string{"testdata"}, "gateway.envoyproxy.io/gatewayclass-controller")
+	require.NoError(t, err)
+	require.NotNil(t, gc)
+	require.Equal(t, "eg", gc.Name)
+	require.NotNil(t, in.EnvoyProxy)
+	require.Len(t, in.Gateways, 1)
+	require.Len(t, in.HTTPRoutes, 1)
+	require.Len(t, in.Services, 1)
+	// The namespaces are sorted by name.
+	require.Len(t, in.Namespaces, 2)
+	require.Equal(t, "apps", in.Namespaces[0].Name)
+	require.Equal(t, "default", in.Namespaces[1].Name)
+
+	// No GatewayClass is accepted for other controllers.
+	_, gc, err = LoadGatewayAPIResources([]string{"testdata"}, "example.com/unknown-controller")
+	require.NoError(t, err)
+	require.Nil(t, gc)
+
+	// The resources that the translation doesn't use are skipped.
+	file := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+`), 0o600))
+	in, _, err = LoadGatewayAPIResources([]string{"testdata", file}, "gateway.envoyproxy.io/gatewayclass-controller")
+	require.NoError(t, err)
+	require.Len(t, in.Gateways, 1)
+}
@@ -0,0 +1,366 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/telepresenceio/watchable"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+// defaultNamespace is the namespace of the namespaced resources that don't
+// specify one.
+const defaultNamespace = "default"
+
+// resources holds the resources loaded from the files of the provider.
+type resources struct {
+	gatewayClasses         []*gwapiv1b1.GatewayClass
+	gateways               map[types.NamespacedName]*gwapiv1b1.Gateway
+	httpRoutes             map[types.NamespacedName]*gwapiv1b1.HTTPRoute
+	tlsRoutes              map[types.NamespacedName]*gwapiv1a2.TLSRoute
+	referenceGrants        map[types.NamespacedName]*gwapiv1a2.ReferenceGrant
+	namespaces             map[string]*corev1.Namespace
+	services               map[types.NamespacedName]*corev1.Service
+	secrets                map[types.NamespacedName]*corev1.Secret
+	configMaps             map[types.NamespacedName]*corev1.ConfigMap
+	envoyProxies           map[types.NamespacedName]*v1alpha1.EnvoyProxy
+	backendTrafficPolicies map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy
+	clientTrafficPolicies  map[types.NamespacedName]*v1alpha1.ClientTrafficPolicy
+	envoyExtensionPolicies map[types.NamespacedName]*v1alpha1.EnvoyExtensionPolicy
+	envoyPatchPolicies     map[types.NamespacedName]*v1alpha1.EnvoyPatchPolicy
+
+	// skipUnsupported skips the resources that the translation doesn't use,
+	// instead of failing to load them.
+	skipUnsupported bool
+}
+
+func newResources() *resources {
+	return &resources{
+		gateways:               map[types.NamespacedName]*gwapiv1b1.Gateway{},
+		httpRoutes:             map[types.NamespacedName]*gwapiv1b1.HTTPRoute{},
+		tlsRoutes:              map[types.NamespacedName]*gwapiv1a2.TLSRoute{},
+		referenceGrants:        map[types.NamespacedName]*gwapiv1a2.ReferenceGrant{},
+		namespaces:             map[string]*corev1.Namespace{},
+		services:               map[types.NamespacedName]*corev1.Service{},
+		secrets:                map[types.NamespacedName]*corev1.Secret{},
+		configMaps:             map[types.NamespacedName]*corev1.ConfigMap{},
+		envoyProxies:           map[types.NamespacedName]*v1alpha1.EnvoyProxy{},
+		backendTrafficPolicies: map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy{},
+		clientTrafficPolicies:  map[types.NamespacedName]*v1alpha1.ClientTrafficPolicy{},
+		envoyExtensionPolicies: map[types.NamespacedName]*v1alpha1.EnvoyExtensionPolicy{},
+		envoyPatchPolicies:     map[types.NamespacedName]*v1alpha1.EnvoyPatchPolicy{},
+	}
+}
+
+// errUnsupportedResource is the error of the resources that the translation
+// doesn't use.
+var errUnsupportedResource = errors.New("unsupported resource")
+
+// loadResources loads the resources of the provided paths. A path is either a
+// file or a directory whose YAML and JSON files are loaded, non-recursively. The
+// resources that the translation doesn't use are either skipped, or fail the
+// loading.
+func loadResources(paths []string, skipUnsupported bool) (*resources, error) {
+	res := newResources()
+	res.skipUnsupported = skipUnsupported
+	for _, path := range paths {
+		files, err := resourceFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if err := res.loadFile(file); err != nil {
+				return nil, fmt.Errorf("failed to load %s: %w", file, err)
+			}
+		}
+	}
+	return res, nil
+}
+
+// LoadGatewayAPIResources loads the resources of the provided paths like the
+// File provider, and returns them as the input of the Gateway API translator,
+// with the GatewayClass accepted by the provided controller, or nil if there is
+// none. Unlike the File provider, the resources that the translation doesn't use,
+// e.g. the Deployments of the backends, are skipped.
+func LoadGatewayAPIResources(paths []string, controller gwapiv1b1.GatewayController) (*gatewayapi.Resources, *gwapiv1b1.GatewayClass, error) {
+	res, err := loadResources(paths, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	res.implicitNamespaces()
+
+	in := &gatewayapi.Resources{
+		Gateways:               sortedValues(res.gateways),
+		HTTPRoutes:             sortedValues(res.httpRoutes),
+		TLSRoutes:              sortedValues(res.tlsRoutes),
+		ReferenceGrants:        sortedValues(res.referenceGrants),
+		Namespaces:             sortedValues(res.namespaces),
+		Services:               sortedValues(res.services),
+		Secrets:                sortedValues(res.secrets),
+		ConfigMaps:             sortedValues(res.configMaps),
+		BackendTrafficPolicies: sortedValues(res.backendTrafficPolicies),
+		ClientTrafficPolicies:  sortedValues(res.clientTrafficPolicies),
+		EnvoyExtensionPolicies: sortedValues(res.envoyExtensionPolicies),
+		EnvoyPatchPolicies:     sortedValues(res.envoyPatchPolicies),
+	}
+	gc := res.acceptedGatewayClass(controller)
+	if gc != nil {
+		in.EnvoyProxy = res.envoyProxy(gc)
+	}
+	return in, gc, nil
+}
+
+// sortedValues returns the values of m, sorted by key.
+func sortedValues[K comparable, V any](m map[K]V) []V {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	values := make([]V, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, m[key])
+	}
+	return values
+}
+
+// resourceFiles returns the files holding resources at the provided path, sorted.
+func resourceFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isResourceFile(entry.Name()) {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// isResourceFile returns true if the file with the provided name holds resources.
+func isResourceFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// loadFile loads the resources of the provided YAML or JSON file, which may hold
+// several documents.
+func (r *resources) loadFile(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	decoder := serializer.NewCodecFactory(envoygateway.GetScheme()).UniversalDeserializer()
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			if r.skipUnsupported && runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			return err
+		}
+		if err := r.add(obj); err != nil {
+			if r.skipUnsupported && errors.Is(err, errUnsupportedResource) {
+				continue
+			}
+			return err
+		}
+	}
+}
+
+// add adds the provided object to the resources.
+func (r *resources) add(obj runtime.Object) error {
+	switch obj := obj.(type) {
+	case *gwapiv1b1.GatewayClass:
+		r.gatewayClasses = append(r.gatewayClasses, obj)
+	case *corev1.Namespace:
+		r.namespaces[obj.Name] = obj
+	case *gwapiv1b1.Gateway:
+		r.gateways[namespacedName(obj)] = obj
+	case *gwapiv1b1.HTTPRoute:
+		r.httpRoutes[namespacedName(obj)] = obj
+	case *gwapiv1a2.TLSRoute:
+		r.tlsRoutes[namespacedName(obj)] = obj
+	case *gwapiv1a2.ReferenceGrant:
+		r.referenceGrants[namespacedName(obj)] = obj
+	case *corev1.Service:
+		r.services[namespacedName(obj)] = obj
+	case *corev1.Secret:
+		r.secrets[namespacedName(obj)] = obj
+	case *corev1.ConfigMap:
+		r.configMaps[namespacedName(obj)] = obj
+	case *v1alpha1.EnvoyProxy:
+		r.envoyProxies[namespacedName(obj)] = obj
+	case *v1alpha1.BackendTrafficPolicy:
+		r.backendTrafficPolicies[namespacedName(obj)] = obj
+	case *v1alpha1.ClientTrafficPolicy:
+		r.clientTrafficPolicies[namespacedName(obj)] = obj
+	case *v1alpha1.EnvoyExtensionPolicy:
+		r.envoyExtensionPolicies[namespacedName(obj)] = obj
+	case *v1alpha1.EnvoyPatchPolicy:
+		r.envoyPatchPolicies[namespacedName(obj)] = obj
+	default:
+		return fmt.Errorf("%w %s", errUnsupportedResource, obj.GetObjectKind().GroupVersionKind())
+	}
+	return nil
+}
+
+// namespacedObject is a namespaced resource.
+type namespacedObject interface {
+	GetNamespace() string
+	SetNamespace(string)
+	GetName() string
+}
+
+// namespacedName defaults the namespace of the provided object and returns its key.
+func namespacedName(obj namespacedObject) types.NamespacedName {
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(defaultNamespace)
+	}
+	return types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// acceptedGatewayClass returns the oldest GatewayClass managed by the provided
+// controller, or the first one loaded if they share a creation timestamp, or nil
+// if there is none.
+func (r *resources) acceptedGatewayClass(controller gwapiv1b1.GatewayController) *gwapiv1b1.GatewayClass {
+	var accepted *gwapiv1b1.GatewayClass
+	for _, gc := range r.gatewayClasses {
+		if gc.Spec.ControllerName != controller {
+			continue
+		}
+		if accepted == nil || gc.CreationTimestamp.Before(&accepted.CreationTimestamp) {
+			accepted = gc
+		}
+	}
+	return accepted
+}
+
+// envoyProxy returns the EnvoyProxy referenced by the parametersRef of the
+// provided GatewayClass, or nil if it doesn't reference a loaded EnvoyProxy.
+func (r *resources) envoyProxy(gc *gwapiv1b1.GatewayClass) *v1alpha1.EnvoyProxy {
+	ref := gc.Spec.ParametersRef
+	if ref == nil || string(ref.Group) != v1alpha1.GroupVersion.Group ||
+		string(ref.Kind) != v1alpha1.KindEnvoyProxy || ref.Namespace == nil {
+		return nil
+	}
+	return r.envoyProxies[types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}]
+}
+
+// implicitNamespaces adds the namespaces of the loaded resources that aren't
+// declared, so that the routes of a namespace can be attached to the Gateways
+// allowing the routes of the same namespace or of all namespaces.
+func (r *resources) implicitNamespaces() {
+	add := func(key types.NamespacedName) {
+		if _, ok := r.namespaces[key.Namespace]; !ok {
+			ns := new(corev1.Namespace)
+			ns.Name = key.Namespace
+			r.namespaces[key.Namespace] = ns
+		}
+	}
+	for key := range r.gateways {
+		add(key)
+	}
+	for key := range r.httpRoutes {
+		add(key)
+	}
+	for key := range r.tlsRoutes {
+		add(key)
+	}
+}
+
+// store stores the resources in the provided resource maps, only updating the
+// changed resources and deleting the resources that are no longer loaded.
+func (r *resources) store(controller gwapiv1b1.GatewayController, dst *message.ProviderResources) {
+	r.implicitNamespaces()
+
+	// The EnvoyProxy referenced by the accepted GatewayClass is stored before the
+	// GatewayClass, so that the first translation uses the proxy config.
+	gatewayClasses := map[string]*gwapiv1b1.GatewayClass{}
+	envoyProxies := map[string]*v1alpha1.EnvoyProxy{}
+	if gc := r.acceptedGatewayClass(controller); gc != nil {
+		gatewayClasses[gc.Name] = gc
+		if ep := r.envoyProxy(gc); ep != nil {
+			envoyProxies[gc.Name] = ep
+		}
+	}
+	storeAll(&dst.EnvoyProxies, envoyProxies)
+
+	storeAll(&dst.Namespaces, r.namespaces)
+	storeAll(&dst.Services, r.services)
+	storeAll(&dst.Secrets, r.secrets)
+	storeAll(&dst.ConfigMaps, r.configMaps)
+	storeAll(&dst.ReferenceGrants, r.referenceGrants)
+	storeAll(&dst.BackendTrafficPolicies, r.backendTrafficPolicies)
+	storeAll(&dst.ClientTrafficPolicies, r.clientTrafficPolicies)
+	storeAll(&dst.EnvoyExtensionPolicies, r.envoyExtensionPolicies)
+	storeAll(&dst.EnvoyPatchPolicies, r.envoyPatchPolicies)
+	storeAll(&dst.HTTPRoutes, r.httpRoutes)
+	storeAll(&dst.TLSRoutes, r.tlsRoutes)
+	storeAll(&dst.Gateways, r.gateways)
+	storeAll(&dst.GatewayClasses, gatewayClasses)
+}
+
+// storeAll stores the provided values in m, skipping the unchanged values, and
+// deletes the values of m that aren't provided.
+func storeAll[K comparable, V any](m *watchable.Map[K, V], values map[K]V) {
+	current := m.LoadAll()
+	for key := range current {
+		if _, ok := values[key]; !ok {
+			m.Delete(key)
+		}
+	}
+	for key, value := range values {
+		if cur, ok := current[key]; !ok || !apiequality.Semantic.DeepEqual(cur, value) {
+			m.Store(key, value)
+		}
+	}
+}
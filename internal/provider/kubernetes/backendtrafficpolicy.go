@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/status"
+)
+
+// newBackendTrafficPolicyController creates the backendtrafficpolicy controller from mgr.
+// The controller will be pre-configured to watch for BackendTrafficPolicy objects across
+// all namespaces, and for the ConfigMaps and Secrets they reference as a CA bundle.
+func newBackendTrafficPolicyController(mgr manager.Manager, cfg *config.Server, su status.Updater, resources *message.ProviderResources) error {
+	r := newBackendTrafficPolicyReconciler(mgr.GetClient(), cfg.Logger, resources)
+	if err := newPolicyController(mgr, r, gatewayapi.KindConfigMap, gatewayapi.KindSecret); err != nil {
+		return err
+	}
+
+	// Subscribe to status updates
+	go subscribeAndUpdateBackendTrafficPolicyStatus(context.Background(), r.log, su, resources)
+
+	return nil
+}
+
+// newBackendTrafficPolicyReconciler returns the reconciler storing the
+// BackendTrafficPolicy objects in resources.
+func newBackendTrafficPolicyReconciler(cli client.Client, log logr.Logger, resources *message.ProviderResources) *policyReconciler[*v1alpha1.BackendTrafficPolicy] {
+	return &policyReconciler[*v1alpha1.BackendTrafficPolicy]{
+		client:        cli,
+		log:           log,
+		resources:     resources,
+		name:          "backendtrafficpolicy",
+		policies:      &resources.BackendTrafficPolicies,
+		newPolicy:     func() *v1alpha1.BackendTrafficPolicy { return new(v1alpha1.BackendTrafficPolicy) },
+		newPolicyList: func() client.ObjectList { return new(v1alpha1.BackendTrafficPolicyList) },
+		objectRef:     backendTrafficPolicyCACertificateRef,
+		onDelete:      resources.BackendTrafficPolicyStatuses.Delete,
+	}
+}
+
+// subscribeAndUpdateBackendTrafficPolicyStatus subscribes to backendtrafficpolicy status
+// updates and writes it into the Kubernetes API Server
+func subscribeAndUpdateBackendTrafficPolicyStatus(ctx context.Context, log logr.Logger, su status.Updater, resources *message.ProviderResources) {
+	// Subscribe to resources
+	message.HandleSubscription(resources.BackendTrafficPolicyStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *v1alpha1.BackendTrafficPolicyStatus]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			su.Send(status.Update{
+				NamespacedName: update.Key,
+				Resource:       new(v1alpha1.BackendTrafficPolicy),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					p, ok := obj.(*v1alpha1.BackendTrafficPolicy)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					pCopy := p.DeepCopy()
+					pCopy.Status.Conditions = status.MergeConditions(pCopy.Status.Conditions, val.Conditions...)
+					return pCopy
+				}),
+			})
+		},
+	)
+	log.Info("backendtrafficpolicy status subscriber shutting down")
+}
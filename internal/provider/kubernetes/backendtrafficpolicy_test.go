@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestBackendTrafficPolicyReconcile(t *testing.T) {
+	policy := &v1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-policy",
+		},
+		Spec: v1alpha1.BackendTrafficPolicySpec{
+			TargetRef: gwapiv1a2.PolicyTargetReference{
+				Group: gwapiv1a2.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  "test-route",
+			},
+			Retry: &v1alpha1.Retry{},
+		},
+	}
+	key := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	r := newBackendTrafficPolicyReconciler(
+		fakeclient.NewClientBuilder().
+			WithScheme(envoygateway.GetScheme()).
+			WithObjects(policy).
+			Build(),
+		logger,
+		new(message.ProviderResources),
+	)
+
+	// The policy exists, so it's stored in the resource map.
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	got, ok := r.resources.BackendTrafficPolicies.Load(key)
+	require.True(t, ok)
+	require.Equal(t, policy.Spec, got.Spec)
+	r.resources.BackendTrafficPolicyStatuses.Store(key, &v1alpha1.BackendTrafficPolicyStatus{})
+
+	// The policy is deleted, so it's removed from the resource map with its status.
+	require.NoError(t, r.client.Delete(context.Background(), policy))
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	_, ok = r.resources.BackendTrafficPolicies.Load(key)
+	require.False(t, ok)
+	_, ok = r.resources.BackendTrafficPolicyStatuses.Load(key)
+	require.False(t, ok)
+}
+
+func TestBackendTrafficPolicyReconcileCACertificateRef(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-ca",
+		},
+		Data: map[string]string{"ca.crt": "ca-cert"},
+	}
+	policy := &v1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-policy",
+		},
+		Spec: v1alpha1.BackendTrafficPolicySpec{
+			TargetRef: gwapiv1a2.PolicyTargetReference{
+				Group: gwapiv1a2.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  "test-route",
+			},
+			TLS: &v1alpha1.BackendTLS{
+				CACertificateRef: &v1alpha1.CACertificateReference{
+					Name: configMap.Name,
+				},
+			},
+		},
+	}
+	key := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}
+	caKey := types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	r := newBackendTrafficPolicyReconciler(
+		fakeclient.NewClientBuilder().
+			WithScheme(envoygateway.GetScheme()).
+			WithObjects(policy, configMap).
+			Build(),
+		logger,
+		new(message.ProviderResources),
+	)
+
+	// The referenced ConfigMap is stored in the resource map with the policy.
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	got, ok := r.resources.ConfigMaps.Load(caKey)
+	require.True(t, ok)
+	require.Equal(t, configMap.Data, got.Data)
+
+	ref, ok := backendTrafficPolicyCACertificateRef(policy)
+	require.True(t, ok)
+	require.Equal(t, policyObjectRef{NamespacedName: caKey, kind: gatewayapi.KindConfigMap}, ref)
+
+	// The policy is deleted, so the ConfigMap is removed from the resource map.
+	require.NoError(t, r.client.Delete(context.Background(), policy))
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	_, ok = r.resources.ConfigMaps.Load(caKey)
+	require.False(t, ok)
+}
@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+// policyObjectRef identifies a ConfigMap or Secret referenced by a policy, e.g.
+// as a CA bundle.
+type policyObjectRef struct {
+	types.NamespacedName
+	kind string
+}
+
+// newCACertificateObjectRef returns the ConfigMap or Secret identified by ref in
+// the namespace of the referencing policy.
+func newCACertificateObjectRef(namespace string, ref *v1alpha1.CACertificateReference) policyObjectRef {
+	kind := gatewayapi.KindConfigMap
+	if ref.Kind != nil {
+		kind = *ref.Kind
+	}
+	return policyObjectRef{
+		NamespacedName: types.NamespacedName{Namespace: namespace, Name: ref.Name},
+		kind:           kind,
+	}
+}
+
+// backendTrafficPolicyCACertificateRef returns the CA bundle referenced by the
+// provided policy, and false if the policy doesn't reference a CA bundle.
+func backendTrafficPolicyCACertificateRef(policy *v1alpha1.BackendTrafficPolicy) (policyObjectRef, bool) {
+	if policy.Spec.TLS == nil || policy.Spec.TLS.CACertificateRef == nil {
+		return policyObjectRef{}, false
+	}
+	return newCACertificateObjectRef(policy.Namespace, policy.Spec.TLS.CACertificateRef), true
+}
+
+// clientTrafficPolicyCACertificateRef returns the CA bundle referenced by the
+// provided policy, and false if the policy doesn't reference a CA bundle.
+func clientTrafficPolicyCACertificateRef(policy *v1alpha1.ClientTrafficPolicy) (policyObjectRef, bool) {
+	if policy.Spec.TLS == nil || policy.Spec.TLS.ClientValidation == nil {
+		return policyObjectRef{}, false
+	}
+	return newCACertificateObjectRef(policy.Namespace, &policy.Spec.TLS.ClientValidation.CACertificateRef), true
+}
+
+// storePolicyObjectRef stores the ConfigMap or Secret identified by ref in the resource
+// map, or deletes it from the resource map if it doesn't exist.
+func storePolicyObjectRef(ctx context.Context, c client.Client, resources *message.ProviderResources, ref policyObjectRef) error {
+	switch ref.kind {
+	case gatewayapi.KindConfigMap:
+		return storeConfigMap(ctx, c, resources, ref.NamespacedName)
+	case gatewayapi.KindSecret:
+		secret := new(corev1.Secret)
+		if err := c.Get(ctx, ref.NamespacedName, secret); err != nil {
+			if kerrors.IsNotFound(err) {
+				resources.Secrets.Delete(ref.NamespacedName)
+				return nil
+			}
+			return fmt.Errorf("failed to get secret %s: %w", ref.NamespacedName, err)
+		}
+		resources.Secrets.Store(ref.NamespacedName, secret)
+	}
+	return nil
+}
+
+// storeConfigMap stores the ConfigMap identified by name in the resource map, or
+// deletes it from the resource map if it doesn't exist.
+func storeConfigMap(ctx context.Context, c client.Client, resources *message.ProviderResources, name types.NamespacedName) error {
+	configMap := new(corev1.ConfigMap)
+	if err := c.Get(ctx, name, configMap); err != nil {
+		if kerrors.IsNotFound(err) {
+			resources.ConfigMaps.Delete(name)
+			return nil
+		}
+		return fmt.Errorf("failed to get configmap %s: %w", name, err)
+	}
+	resources.ConfigMaps.Store(name, configMap)
+	return nil
+}
+
+// deletePolicyObjectRef deletes the ConfigMap identified by ref from the resource map,
+// unless a policy in the resource map still references it. Secrets may also be
+// referenced by Gateways, so they are left in the resource map.
+func deletePolicyObjectRef(resources *message.ProviderResources, ref policyObjectRef) {
+	if ref.kind != gatewayapi.KindConfigMap {
+		return
+	}
+	deleteConfigMap(resources, ref.NamespacedName)
+}
+
+// deleteConfigMap deletes the ConfigMap identified by name from the resource map,
+// unless a policy in the resource map still references it.
+func deleteConfigMap(resources *message.ProviderResources, name types.NamespacedName) {
+	ref := policyObjectRef{NamespacedName: name, kind: gatewayapi.KindConfigMap}
+	for _, policy := range resources.BackendTrafficPolicies.LoadAll() {
+		if other, ok := backendTrafficPolicyCACertificateRef(policy); ok && other == ref {
+			return
+		}
+	}
+	for _, policy := range resources.ClientTrafficPolicies.LoadAll() {
+		if other, ok := clientTrafficPolicyCACertificateRef(policy); ok && other == ref {
+			return
+		}
+	}
+	for _, policy := range resources.EnvoyExtensionPolicies.LoadAll() {
+		if other, ok := envoyExtensionPolicyProtoDescriptorRef(policy); ok && other == ref {
+			return
+		}
+	}
+	resources.ConfigMaps.Delete(name)
+}
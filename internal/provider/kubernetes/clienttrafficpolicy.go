@@ -0,0 +1,35 @@
+package kubernetes
+
+import (
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+// newClientTrafficPolicyController creates the clienttrafficpolicy controller from mgr.
+// The controller will be pre-configured to watch for ClientTrafficPolicy objects across
+// all namespaces, and for the ConfigMaps and Secrets they reference as a CA bundle.
+func newClientTrafficPolicyController(mgr manager.Manager, cfg *config.Server, resources *message.ProviderResources) error {
+	r := newClientTrafficPolicyReconciler(mgr.GetClient(), cfg.Logger, resources)
+	return newPolicyController(mgr, r, gatewayapi.KindConfigMap, gatewayapi.KindSecret)
+}
+
+// newClientTrafficPolicyReconciler returns the reconciler storing the
+// ClientTrafficPolicy objects in resources.
+func newClientTrafficPolicyReconciler(cli client.Client, log logr.Logger, resources *message.ProviderResources) *policyReconciler[*v1alpha1.ClientTrafficPolicy] {
+	return &policyReconciler[*v1alpha1.ClientTrafficPolicy]{
+		client:        cli,
+		log:           log,
+		resources:     resources,
+		name:          "clienttrafficpolicy",
+		policies:      &resources.ClientTrafficPolicies,
+		newPolicy:     func() *v1alpha1.ClientTrafficPolicy { return new(v1alpha1.ClientTrafficPolicy) },
+		newPolicyList: func() client.ObjectList { return new(v1alpha1.ClientTrafficPolicyList) },
+		objectRef:     clientTrafficPolicyCACertificateRef,
+	}
+}
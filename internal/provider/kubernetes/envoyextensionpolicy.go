@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+// newEnvoyExtensionPolicyController creates the envoyextensionpolicy controller from mgr.
+// The controller will be pre-configured to watch for EnvoyExtensionPolicy objects across
+// all namespaces, and for the ConfigMaps they reference as a proto descriptor set.
+func newEnvoyExtensionPolicyController(mgr manager.Manager, cfg *config.Server, resources *message.ProviderResources) error {
+	r := newEnvoyExtensionPolicyReconciler(mgr.GetClient(), cfg.Logger, resources)
+	return newPolicyController(mgr, r, gatewayapi.KindConfigMap)
+}
+
+// newEnvoyExtensionPolicyReconciler returns the reconciler storing the
+// EnvoyExtensionPolicy objects in resources.
+func newEnvoyExtensionPolicyReconciler(cli client.Client, log logr.Logger, resources *message.ProviderResources) *policyReconciler[*v1alpha1.EnvoyExtensionPolicy] {
+	return &policyReconciler[*v1alpha1.EnvoyExtensionPolicy]{
+		client:        cli,
+		log:           log,
+		resources:     resources,
+		name:          "envoyextensionpolicy",
+		policies:      &resources.EnvoyExtensionPolicies,
+		newPolicy:     func() *v1alpha1.EnvoyExtensionPolicy { return new(v1alpha1.EnvoyExtensionPolicy) },
+		newPolicyList: func() client.ObjectList { return new(v1alpha1.EnvoyExtensionPolicyList) },
+		objectRef:     envoyExtensionPolicyProtoDescriptorRef,
+	}
+}
+
+// envoyExtensionPolicyProtoDescriptorRef returns the ConfigMap referenced as a proto
+// descriptor set by the provided policy, and false if the policy doesn't reference one.
+func envoyExtensionPolicyProtoDescriptorRef(policy *v1alpha1.EnvoyExtensionPolicy) (policyObjectRef, bool) {
+	if policy.Spec.GRPCJSONTranscoder == nil {
+		return policyObjectRef{}, false
+	}
+	return policyObjectRef{
+		NamespacedName: types.NamespacedName{
+			Namespace: policy.Namespace,
+			Name:      policy.Spec.GRPCJSONTranscoder.ProtoDescriptorRef.Name,
+		},
+		kind: gatewayapi.KindConfigMap,
+	}, true
+}
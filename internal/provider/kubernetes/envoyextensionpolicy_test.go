@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestEnvoyExtensionPolicyReconcile(t *testing.T) {
+	policy := &v1alpha1.EnvoyExtensionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-policy",
+		},
+		Spec: v1alpha1.EnvoyExtensionPolicySpec{
+			TargetRef: gwapiv1a2.PolicyTargetReference{
+				Group: gwapiv1a2.GroupName,
+				Kind:  "Gateway",
+				Name:  "test-gateway",
+			},
+			Wasm: []v1alpha1.Wasm{{
+				Name: "test-wasm",
+				Code: v1alpha1.WasmCodeSource{
+					Type: v1alpha1.WasmCodeSourceTypeHTTP,
+					HTTP: &v1alpha1.HTTPWasmCodeSource{
+						URL:    "https://example.com/filter.wasm",
+						SHA256: "2d7e9c3f2b0b0a4d1e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d",
+					},
+				},
+			}},
+		},
+	}
+	key := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	r := newEnvoyExtensionPolicyReconciler(
+		fakeclient.NewClientBuilder().
+			WithScheme(envoygateway.GetScheme()).
+			WithObjects(policy).
+			Build(),
+		logger,
+		new(message.ProviderResources),
+	)
+
+	// The policy exists, so it's stored in the resource map.
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	got, ok := r.resources.EnvoyExtensionPolicies.Load(key)
+	require.True(t, ok)
+	require.Equal(t, policy.Spec, got.Spec)
+
+	// The policy is deleted, so it's removed from the resource map.
+	require.NoError(t, r.client.Delete(context.Background(), policy))
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	_, ok = r.resources.EnvoyExtensionPolicies.Load(key)
+	require.False(t, ok)
+}
+
+func TestEnvoyExtensionPolicyReconcileProtoDescriptorRef(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-descriptor",
+		},
+		BinaryData: map[string][]byte{"descriptor.pb": []byte("descriptor")},
+	}
+	policy := &v1alpha1.EnvoyExtensionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-policy",
+		},
+		Spec: v1alpha1.EnvoyExtensionPolicySpec{
+			TargetRef: gwapiv1a2.PolicyTargetReference{
+				Group: gwapiv1a2.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  "test-route",
+			},
+			GRPCJSONTranscoder: &v1alpha1.GRPCJSONTranscoder{
+				ProtoDescriptorRef: v1alpha1.ProtoDescriptorReference{
+					Name: configMap.Name,
+				},
+				Services: []string{"helloworld.Greeter"},
+			},
+		},
+	}
+	key := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}
+	descriptorKey := types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	r := newEnvoyExtensionPolicyReconciler(
+		fakeclient.NewClientBuilder().
+			WithScheme(envoygateway.GetScheme()).
+			WithObjects(policy, configMap).
+			Build(),
+		logger,
+		new(message.ProviderResources),
+	)
+
+	// The referenced ConfigMap is stored in the resource map with the policy.
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	got, ok := r.resources.ConfigMaps.Load(descriptorKey)
+	require.True(t, ok)
+	require.Equal(t, configMap.BinaryData, got.BinaryData)
+
+	ref, ok := envoyExtensionPolicyProtoDescriptorRef(policy)
+	require.True(t, ok)
+	require.Equal(t, policyObjectRef{NamespacedName: descriptorKey, kind: gatewayapi.KindConfigMap}, ref)
+
+	// The policy is deleted, so the ConfigMap is removed from the resource map.
+	require.NoError(t, r.client.Delete(context.Background(), policy))
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	_, ok = r.resources.ConfigMaps.Load(descriptorKey)
+	require.False(t, ok)
+}
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/status"
+)
+
+type envoyPatchPolicyReconciler struct {
+	client        client.Client
+	log           logr.Logger
+	statusUpdater status.Updater
+	resources     *message.ProviderResources
+}
+
+// newEnvoyPatchPolicyController creates the envoypatchpolicy controller from mgr.
+// The controller will be pre-configured to watch for EnvoyPatchPolicy objects across
+// all namespaces.
+func newEnvoyPatchPolicyController(mgr manager.Manager, cfg *config.Server, su status.Updater, resources *message.ProviderResources) error {
+	r := &envoyPatchPolicyReconciler{
+		client:        mgr.GetClient(),
+		log:           cfg.Logger,
+		statusUpdater: su,
+		resources:     resources,
+	}
+
+	c, err := controller.New("envoypatchpolicy", mgr, controller.Options{Reconciler: tracedReconciler("envoypatchpolicy", r)})
+	if err != nil {
+		return err
+	}
+	r.log.Info("created envoypatchpolicy controller")
+
+	if err := c.Watch(
+		&source.Kind{Type: &v1alpha1.EnvoyPatchPolicy{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return err
+	}
+	r.log.Info("watching envoypatchpolicy objects")
+
+	// Subscribe to status updates
+	go r.subscribeAndUpdateStatus(context.Background())
+
+	return nil
+}
+
+func (r *envoyPatchPolicyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("namespace", request.Namespace, "name", request.Name)
+	log.Info("reconciling envoypatchpolicy")
+
+	policy := new(v1alpha1.EnvoyPatchPolicy)
+	if err := r.client.Get(ctx, request.NamespacedName, policy); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.resources.EnvoyPatchPolicies.Delete(request.NamespacedName)
+			r.resources.EnvoyPatchPolicyStatuses.Delete(request.NamespacedName)
+			log.Info("deleted envoypatchpolicy from resource map")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get envoypatchpolicy %s: %w", request.NamespacedName, err)
+	}
+
+	r.resources.EnvoyPatchPolicies.Store(request.NamespacedName, policy)
+	log.Info("added envoypatchpolicy to resource map")
+
+	log.Info("reconciled envoypatchpolicy")
+	return reconcile.Result{}, nil
+}
+
+// subscribeAndUpdateStatus subscribes to envoypatchpolicy status updates and writes it
+// into the Kubernetes API Server
+func (r *envoyPatchPolicyReconciler) subscribeAndUpdateStatus(ctx context.Context) {
+	// Subscribe to resources
+	message.HandleSubscription(r.resources.EnvoyPatchPolicyStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *v1alpha1.EnvoyPatchPolicyStatus]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			r.statusUpdater.Send(status.Update{
+				NamespacedName: update.Key,
+				Resource:       new(v1alpha1.EnvoyPatchPolicy),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					p, ok := obj.(*v1alpha1.EnvoyPatchPolicy)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					pCopy := p.DeepCopy()
+					pCopy.Status.Conditions = status.MergeConditions(pCopy.Status.Conditions, val.Conditions...)
+					return pCopy
+				}),
+			})
+		},
+	)
+	r.log.Info("status subscriber shutting down")
+}
@@ -0,0 +1,74 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestEnvoyPatchPolicyReconcile(t *testing.T) {
+	policy := &v1alpha1.EnvoyPatchPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-policy",
+		},
+		Spec: v1alpha1.EnvoyPatchPolicySpec{
+			TargetRef: gwapiv1a2.PolicyTargetReference{
+				Group: gwapiv1a2.GroupName,
+				Kind:  "Gateway",
+				Name:  "test-gateway",
+			},
+			JSONPatches: []v1alpha1.EnvoyJSONPatchConfig{
+				{
+					Type: v1alpha1.ListenerEnvoyResourceType,
+					Name: "test-listener",
+					Operation: v1alpha1.JSONPatchOperation{
+						Op:   "remove",
+						Path: "/per_connection_buffer_limit_bytes",
+					},
+				},
+			},
+		},
+	}
+	key := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	r := envoyPatchPolicyReconciler{
+		client: fakeclient.NewClientBuilder().
+			WithScheme(envoygateway.GetScheme()).
+			WithObjects(policy).
+			Build(),
+		log:       logger,
+		resources: new(message.ProviderResources),
+	}
+
+	// The policy exists, so it's stored in the resource map.
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	got, ok := r.resources.EnvoyPatchPolicies.Load(key)
+	require.True(t, ok)
+	require.Equal(t, policy.Spec, got.Spec)
+	r.resources.EnvoyPatchPolicyStatuses.Store(key, &v1alpha1.EnvoyPatchPolicyStatus{})
+
+	// The policy is deleted, so it's removed from the resource map with its status.
+	require.NoError(t, r.client.Delete(context.Background(), policy))
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+	_, ok = r.resources.EnvoyPatchPolicies.Load(key)
+	require.False(t, ok)
+	_, ok = r.resources.EnvoyPatchPolicyStatuses.Load(key)
+	require.False(t, ok)
+}
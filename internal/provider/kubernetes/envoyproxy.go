@@ -0,0 +1,259 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/status"
+)
+
+type envoyProxyReconciler struct {
+	client        client.Client
+	controller    gwapiv1b1.GatewayController
+	statusUpdater status.Updater
+	log           logr.Logger
+}
+
+// newEnvoyProxyController creates the envoyproxy controller, which keeps the status
+// of EnvoyProxy objects referenced by managed GatewayClasses up to date.
+func newEnvoyProxyController(mgr manager.Manager, cfg *config.Server, su status.Updater) error {
+	r := &envoyProxyReconciler{
+		client:        mgr.GetClient(),
+		controller:    gwapiv1b1.GatewayController(cfg.EnvoyGateway.Gateway.ControllerName),
+		statusUpdater: su,
+		log:           cfg.Logger,
+	}
+
+	c, err := controller.New("envoyproxy", mgr, controller.Options{Reconciler: tracedReconciler("envoyproxy", r)})
+	if err != nil {
+		return err
+	}
+	r.log.Info("created envoyproxy controller")
+
+	// Skip status-only updates, since the status is written by this controller.
+	if err := c.Watch(
+		&source.Kind{Type: &v1alpha1.EnvoyProxy{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return err
+	}
+	r.log.Info("watching envoyproxy objects")
+
+	// Trigger envoyproxy reconciliation when a managed GatewayClass referencing
+	// an EnvoyProxy has changed, e.g. its parametersRef or Accepted condition.
+	if err := c.Watch(
+		&source.Kind{Type: &gwapiv1b1.GatewayClass{}},
+		handler.EnqueueRequestsFromMapFunc(r.getEnvoyProxyForGatewayClass),
+	); err != nil {
+		return err
+	}
+	r.log.Info("watching gatewayclass objects")
+
+	// Trigger envoyproxy reconciliation when an Envoy workload has changed.
+	if err := c.Watch(
+		&source.Kind{Type: &appsv1.Deployment{}},
+		handler.EnqueueRequestsFromMapFunc(r.getEnvoyProxyForOwningGateway),
+	); err != nil {
+		return err
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &appsv1.DaemonSet{}},
+		handler.EnqueueRequestsFromMapFunc(r.getEnvoyProxyForOwningGateway),
+	); err != nil {
+		return err
+	}
+	r.log.Info("watching envoy workload objects")
+
+	return nil
+}
+
+// envoyProxyRef returns the key of the EnvoyProxy referenced by the parametersRef
+// of the provided GatewayClass, and false if gc doesn't reference an EnvoyProxy.
+func envoyProxyRef(gc *gwapiv1b1.GatewayClass) (types.NamespacedName, bool) {
+	if !refsEnvoyProxy(gc) || gc.Spec.ParametersRef.Namespace == nil {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{
+		Namespace: string(*gc.Spec.ParametersRef.Namespace),
+		Name:      gc.Spec.ParametersRef.Name,
+	}, true
+}
+
+// getEnvoyProxyForGatewayClass creates a reconciliation request for the EnvoyProxy
+// referenced by the provided GatewayClass, if it's managed by this Envoy Gateway.
+func (r *envoyProxyReconciler) getEnvoyProxyForGatewayClass(obj client.Object) []reconcile.Request {
+	gc, ok := obj.(*gwapiv1b1.GatewayClass)
+	if !ok {
+		r.log.Info("bypassing reconciliation due to unexpected object type", "type", obj)
+		return nil
+	}
+
+	if gc.Spec.ControllerName != r.controller {
+		return nil
+	}
+	key, ok := envoyProxyRef(gc)
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: key}}
+}
+
+// getEnvoyProxyForOwningGateway creates a reconciliation request for the EnvoyProxy
+// used by the Gateway owning the provided Envoy workload, if any.
+func (r *envoyProxyReconciler) getEnvoyProxyForOwningGateway(obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	key := types.NamespacedName{
+		Namespace: labels[gatewayapi.OwningGatewayNamespaceLabel],
+		Name:      labels[gatewayapi.OwningGatewayNameLabel],
+	}
+	if len(key.Namespace) == 0 || len(key.Name) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	gw := new(gwapiv1b1.Gateway)
+	if err := r.client.Get(ctx, key, gw); err != nil {
+		return nil
+	}
+	gc := new(gwapiv1b1.GatewayClass)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gc); err != nil {
+		return nil
+	}
+
+	return r.getEnvoyProxyForGatewayClass(gc)
+}
+
+func (r *envoyProxyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithName(request.Namespace).WithName(request.Name)
+	log.Info("reconciling envoyproxy")
+
+	ep := new(v1alpha1.EnvoyProxy)
+	if err := r.client.Get(ctx, request.NamespacedName, ep); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get envoyproxy %s: %w", request.NamespacedName, err)
+	}
+
+	var gatewayClasses gwapiv1b1.GatewayClassList
+	if err := r.client.List(ctx, &gatewayClasses); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error listing gatewayclasses: %w", err)
+	}
+
+	// Find the managed GatewayClasses referencing the EnvoyProxy.
+	var classNames []string
+	accepted := map[string]bool{}
+	for i := range gatewayClasses.Items {
+		gc := &gatewayClasses.Items[i]
+		if gc.Spec.ControllerName != r.controller {
+			continue
+		}
+		if key, ok := envoyProxyRef(gc); !ok || key != request.NamespacedName {
+			continue
+		}
+		classNames = append(classNames, gc.Name)
+		if isAccepted(gc) {
+			accepted[gc.Name] = true
+		}
+	}
+	sort.Strings(classNames)
+
+	// Collect the Envoy workloads of the Gateways of the accepted GatewayClasses.
+	var workloads []status.EnvoyProxyWorkload
+	if len(accepted) > 0 {
+		var gateways gwapiv1b1.GatewayList
+		if err := r.client.List(ctx, &gateways); err != nil {
+			return reconcile.Result{}, fmt.Errorf("error listing gateways: %w", err)
+		}
+		for i := range gateways.Items {
+			gw := &gateways.Items[i]
+			if !accepted[string(gw.Spec.GatewayClassName)] {
+				continue
+			}
+			gwWorkloads, err := r.envoyWorkloadsForGateway(ctx, gw)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			workloads = append(workloads, gwWorkloads...)
+		}
+	}
+
+	if r.statusUpdater != nil {
+		r.statusUpdater.Send(status.Update{
+			NamespacedName: request.NamespacedName,
+			Resource:       new(v1alpha1.EnvoyProxy),
+			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+				ep, ok := obj.(*v1alpha1.EnvoyProxy)
+				if !ok {
+					panic(fmt.Sprintf("unsupported object type %T", obj))
+				}
+
+				return status.SetEnvoyProxyStatus(ep.DeepCopy(), classNames, len(accepted) > 0, workloads)
+			}),
+		})
+	} else {
+		// this branch makes testing easier by not going through the status.Updater.
+		copy := status.SetEnvoyProxyStatus(ep.DeepCopy(), classNames, len(accepted) > 0, workloads)
+
+		if err := r.client.Status().Update(ctx, copy); err != nil {
+			return reconcile.Result{}, fmt.Errorf("error updating status of envoyproxy %s: %w", request.NamespacedName, err)
+		}
+	}
+
+	log.Info("reconciled envoyproxy")
+	return reconcile.Result{}, nil
+}
+
+// envoyWorkloadsForGateway returns the replica state of the Envoy Deployments and
+// DaemonSets owned by the provided Gateway.
+func (r *envoyProxyReconciler) envoyWorkloadsForGateway(ctx context.Context, gw *gwapiv1b1.Gateway) ([]status.EnvoyProxyWorkload, error) {
+	owned := client.MatchingLabels{
+		gatewayapi.OwningGatewayNamespaceLabel: gw.Namespace,
+		gatewayapi.OwningGatewayNameLabel:      gw.Name,
+	}
+
+	var workloads []status.EnvoyProxyWorkload
+
+	var deployments appsv1.DeploymentList
+	if err := r.client.List(ctx, &deployments, owned); err != nil {
+		return nil, fmt.Errorf("error listing deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, status.EnvoyProxyWorkload{
+			Replicas:          d.Status.Replicas,
+			AvailableReplicas: d.Status.AvailableReplicas,
+		})
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := r.client.List(ctx, &daemonSets, owned); err != nil {
+		return nil, fmt.Errorf("error listing daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		workloads = append(workloads, status.EnvoyProxyWorkload{
+			Replicas:          ds.Status.DesiredNumberScheduled,
+			AvailableReplicas: ds.Status.NumberAvailable,
+		})
+	}
+
+	return workloads, nil
+}
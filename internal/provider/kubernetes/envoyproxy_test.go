@@ -0,0 +1,233 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/log"
+)
+
+func TestEnvoyProxyReconcile(t *testing.T) {
+	ep := &v1alpha1.EnvoyProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-ep",
+		},
+	}
+	ref := &gwapiv1b1.ParametersReference{
+		Group:     gwapiv1b1.Group(v1alpha1.GroupVersion.Group),
+		Kind:      v1alpha1.KindEnvoyProxy,
+		Name:      ep.Name,
+		Namespace: gatewayapi.NamespacePtr(ep.Namespace),
+	}
+	acceptedStatus := gwapiv1b1.GatewayClassStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type:   string(gwapiv1b1.GatewayClassConditionStatusAccepted),
+				Status: metav1.ConditionTrue,
+			},
+		},
+	}
+	gw := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-gw",
+		},
+		Spec: gwapiv1b1.GatewaySpec{
+			GatewayClassName: "test-gc",
+		},
+	}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "envoy-gateway-system",
+			Name:      "envoy-test",
+			Labels: map[string]string{
+				gatewayapi.OwningGatewayNamespaceLabel: gw.Namespace,
+				gatewayapi.OwningGatewayNameLabel:      gw.Name,
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          2,
+			AvailableReplicas: 1,
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		gc             *gwapiv1b1.GatewayClass
+		gatewayClasses []string
+		accepted       metav1.ConditionStatus
+		available      metav1.ConditionStatus
+		replicas       int32
+	}{
+		{
+			name: "referenced by accepted gatewayclass",
+			gc: &gwapiv1b1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-gc"},
+				Spec: gwapiv1b1.GatewayClassSpec{
+					ControllerName: v1alpha1.GatewayControllerName,
+					ParametersRef:  ref,
+				},
+				Status: acceptedStatus,
+			},
+			gatewayClasses: []string{"test-gc"},
+			accepted:       metav1.ConditionTrue,
+			available:      metav1.ConditionTrue,
+			replicas:       2,
+		},
+		{
+			name: "referenced by gatewayclass that is not accepted",
+			gc: &gwapiv1b1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-gc"},
+				Spec: gwapiv1b1.GatewayClassSpec{
+					ControllerName: v1alpha1.GatewayControllerName,
+					ParametersRef:  ref,
+				},
+			},
+			gatewayClasses: []string{"test-gc"},
+			accepted:       metav1.ConditionFalse,
+			available:      metav1.ConditionFalse,
+		},
+		{
+			name: "referenced by gatewayclass of another controller",
+			gc: &gwapiv1b1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-gc"},
+				Spec: gwapiv1b1.GatewayClassSpec{
+					ControllerName: "example.com/unmanaged",
+					ParametersRef:  ref,
+				},
+				Status: acceptedStatus,
+			},
+			accepted:  metav1.ConditionFalse,
+			available: metav1.ConditionFalse,
+		},
+	}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := envoyProxyReconciler{
+				client: fakeclient.NewClientBuilder().
+					WithScheme(envoygateway.GetScheme()).
+					WithObjects(ep.DeepCopy(), tc.gc, gw.DeepCopy(), deploy.DeepCopy()).
+					Build(),
+				controller: v1alpha1.GatewayControllerName,
+				log:        logger,
+			}
+			key := types.NamespacedName{Namespace: ep.Namespace, Name: ep.Name}
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+			require.NoError(t, err)
+
+			got := new(v1alpha1.EnvoyProxy)
+			require.NoError(t, r.client.Get(context.Background(), key, got))
+			require.Equal(t, tc.gatewayClasses, got.Status.GatewayClasses)
+			require.Equal(t, tc.replicas, got.Status.Replicas)
+			require.Len(t, got.Status.Conditions, 2)
+			for _, cond := range got.Status.Conditions {
+				switch cond.Type {
+				case string(v1alpha1.EnvoyProxyConditionAccepted):
+					require.Equal(t, tc.accepted, cond.Status)
+				case string(v1alpha1.EnvoyProxyConditionAvailable):
+					require.Equal(t, tc.available, cond.Status)
+				default:
+					t.Errorf("unexpected condition type %s", cond.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestGetEnvoyProxyForOwningGateway(t *testing.T) {
+	gc := &gwapiv1b1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gc"},
+		Spec: gwapiv1b1.GatewayClassSpec{
+			ControllerName: v1alpha1.GatewayControllerName,
+			ParametersRef: &gwapiv1b1.ParametersReference{
+				Group:     gwapiv1b1.Group(v1alpha1.GroupVersion.Group),
+				Kind:      v1alpha1.KindEnvoyProxy,
+				Name:      "test-ep",
+				Namespace: gatewayapi.NamespacePtr("test"),
+			},
+		},
+	}
+	gw := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-gw",
+		},
+		Spec: gwapiv1b1.GatewaySpec{
+			GatewayClassName: gwapiv1b1.ObjectName(gc.Name),
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		obj    client.Object
+		expect []reconcile.Request
+	}{
+		{
+			name: "deployment of gateway using envoyproxy",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "envoy-test",
+					Labels: map[string]string{
+						gatewayapi.OwningGatewayNamespaceLabel: gw.Namespace,
+						gatewayapi.OwningGatewayNameLabel:      gw.Name,
+					},
+				},
+			},
+			expect: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "test", Name: "test-ep"}},
+			},
+		},
+		{
+			name: "deployment without owning gateway labels",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			},
+		},
+		{
+			name: "deployment of non-existent gateway",
+			obj: &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "envoy-test",
+					Labels: map[string]string{
+						gatewayapi.OwningGatewayNamespaceLabel: gw.Namespace,
+						gatewayapi.OwningGatewayNameLabel:      "non-existent",
+					},
+				},
+			},
+		},
+	}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	r := envoyProxyReconciler{
+		client:     fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(gc, gw).Build(),
+		controller: v1alpha1.GatewayControllerName,
+		log:        logger,
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, r.getEnvoyProxyForOwningGateway(tc.obj))
+		})
+	}
+}
@@ -8,9 +8,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +23,7 @@ import (
 
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/provider/utils"
 	"github.com/envoyproxy/gateway/internal/status"
@@ -55,7 +54,7 @@ func newGatewayController(mgr manager.Manager, cfg *config.Server, su status.Upd
 		resources:       resources,
 	}
 
-	c, err := controller.New("gateway", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("gateway", mgr, controller.Options{Reconciler: tracedReconciler("gateway", r)})
 	if err != nil {
 		return err
 	}
@@ -63,24 +62,25 @@ func newGatewayController(mgr manager.Manager, cfg *config.Server, su status.Upd
 
 	// Subscribe to status updates
 	go r.subscribeAndUpdateStatus(context.Background())
+	go r.subscribeAndUpdateInfraStatus(context.Background())
 
-	// Only enqueue Gateway objects that match this Envoy Gateway's controller name.
+	// Only enqueue Gateway objects that match this Envoy Gateway's controller name,
+	// skipping status-only updates.
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1b1.Gateway{}},
 		&handler.EnqueueRequestForObject{},
+		specOrLabelsChanged(),
 		predicate.NewPredicateFuncs(r.hasMatchingController),
 	); err != nil {
 		return err
 	}
 	r.log.Info("watching gateway objects")
 
-	// Trigger gateway reconciliation when the Envoy Service or Deployment has changed.
+	// Trigger gateway reconciliation when the Envoy Service has changed. The
+	// readiness of the Envoy workload is published by the infra runner.
 	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, r.enqueueRequestForOwningGateway()); err != nil {
 		return err
 	}
-	if err := c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, r.enqueueRequestForOwningGateway()); err != nil {
-		return err
-	}
 	// Trigger gateway reconciliation when a Secret that is referenced
 	// by a managed Gateway has changed.
 	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, r.enqueueRequestForGatewaySecrets()); err != nil {
@@ -295,13 +295,6 @@ func (r *gatewayReconciler) Reconcile(ctx context.Context, request reconcile.Req
 	for i := range acceptedGateways {
 		gw := acceptedGateways[i]
 
-		// Get the status of the Gateway's associated Envoy Deployment.
-		deployment, err := r.envoyDeploymentForGateway(ctx, &gw)
-		if err != nil {
-			r.log.Info("failed to get deployment for gateway",
-				"namespace", gw.Namespace, "name", gw.Name)
-		}
-
 		// Get the status address of the Gateway's associated Envoy Service.
 		svc, err := r.envoyServiceForGateway(ctx, &gw)
 		if err != nil {
@@ -342,10 +335,15 @@ func (r *gatewayReconciler) Reconcile(ctx context.Context, request reconcile.Req
 
 		// update scheduled condition
 		status.UpdateGatewayStatusScheduledCondition(&gw, true)
-		// update address field and ready condition
-		status.UpdateGatewayStatusReadyCondition(&gw, svc, deployment)
-
 		key := utils.NamespacedName(&gw)
+		// update address field and ready condition, based on the status of the
+		// Gateway's Envoy proxy infra published by the infra runner.
+		var infraStatus *infrastructure.Status
+		if s, ok := r.resources.InfraStatuses.Load(key); ok {
+			infraStatus = &s
+		}
+		status.UpdateGatewayStatusReadyCondition(&gw, svc, infraStatus)
+
 		// publish status
 		// do it inline since this code flow updates the
 		// Status.Addresses field whereas the message bus / subscriber
@@ -447,19 +445,20 @@ func gatewaysOfClass(gc *gwapiv1b1.GatewayClass, gwList *gwapiv1b1.GatewayList)
 }
 
 // envoyServiceForGateway returns the Envoy service, returning nil if the service doesn't exist.
+// The service is looked up by its Gateway owner labels, since it's created in the namespace
+// of the EnvoyProxy of the GatewayClass, if set, rather than the Envoy Gateway namespace.
 func (r *gatewayReconciler) envoyServiceForGateway(ctx context.Context, gateway *gwapiv1b1.Gateway) (*corev1.Service, error) {
-	key := types.NamespacedName{
-		Namespace: config.EnvoyGatewayNamespace,
-		Name:      infraServiceName(gateway),
+	svcs := new(corev1.ServiceList)
+	if err := r.client.List(ctx, svcs, client.MatchingLabels(gatewayapi.GatewayOwnerLabels(gateway.Namespace, gateway.Name))); err != nil {
+		return nil, err
 	}
-	svc := new(corev1.Service)
-	if err := r.client.Get(ctx, key, svc); err != nil {
-		if kerrors.IsNotFound(err) {
-			return nil, nil
+	name := infraServiceName(gateway)
+	for i := range svcs.Items {
+		if svcs.Items[i].Name == name {
+			return &svcs.Items[i], nil
 		}
-		return nil, err
 	}
-	return svc, nil
+	return nil, nil
 }
 
 // gatewaysRefSecret returns true if a managed Gateway references the provided secret.
@@ -602,22 +601,6 @@ func (r *gatewayReconciler) removeFinalizer(ctx context.Context, gc *gwapiv1b1.G
 	return nil
 }
 
-// envoyDeploymentForGateway returns the Envoy Deployment, returning nil if the Deployment doesn't exist.
-func (r *gatewayReconciler) envoyDeploymentForGateway(ctx context.Context, gateway *gwapiv1b1.Gateway) (*appsv1.Deployment, error) {
-	key := types.NamespacedName{
-		Namespace: config.EnvoyGatewayNamespace,
-		Name:      infraDeploymentName(gateway),
-	}
-	deployment := new(appsv1.Deployment)
-	if err := r.client.Get(ctx, key, deployment); err != nil {
-		if kerrors.IsNotFound(err) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return deployment, nil
-}
-
 // subscribeAndUpdateStatus subscribes to gateway status updates and writes it into the
 // Kubernetes API Server
 func (r *gatewayReconciler) subscribeAndUpdateStatus(ctx context.Context) {
@@ -648,12 +631,35 @@ func (r *gatewayReconciler) subscribeAndUpdateStatus(ctx context.Context) {
 	r.log.Info("status subscriber shutting down")
 }
 
-func infraServiceName(gateway *gwapiv1b1.Gateway) string {
-	infraName := utils.GetHashedName(fmt.Sprintf("%s-%s", gateway.Namespace, gateway.Name))
-	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, infraName)
+// subscribeAndUpdateInfraStatus subscribes to the status updates of the Envoy
+// proxy infra of the Gateways, and updates their ready condition accordingly.
+func (r *gatewayReconciler) subscribeAndUpdateInfraStatus(ctx context.Context) {
+	message.HandleSubscription(r.resources.InfraStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, infrastructure.Status]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			r.statusUpdater.Send(status.Update{
+				NamespacedName: update.Key,
+				Resource:       new(gwapiv1b1.Gateway),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					g, ok := obj.(*gwapiv1b1.Gateway)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					gCopy := g.DeepCopy()
+					status.UpdateGatewayStatusReadyCondition(gCopy, nil, &val)
+					return gCopy
+				}),
+			})
+		},
+	)
+	r.log.Info("infra status subscriber shutting down")
 }
 
-func infraDeploymentName(gateway *gwapiv1b1.Gateway) string {
+func infraServiceName(gateway *gwapiv1b1.Gateway) string {
 	infraName := utils.GetHashedName(fmt.Sprintf("%s-%s", gateway.Namespace, gateway.Name))
 	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, infraName)
 }
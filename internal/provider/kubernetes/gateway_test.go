@@ -7,17 +7,23 @@ import (
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
 	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+	"github.com/envoyproxy/gateway/internal/status"
 )
 
 func TestGatewayHasMatchingController(t *testing.T) {
@@ -858,3 +864,70 @@ func TestSecretsAndRefGrantsForGateway(t *testing.T) {
 		})
 	}
 }
+
+// recordingStatusUpdater records the status updates sent to it.
+type recordingStatusUpdater struct {
+	updates []status.Update
+}
+
+func (u *recordingStatusUpdater) Send(update status.Update) {
+	u.updates = append(u.updates, update)
+}
+
+func TestReconcileGatewayProxyNamespace(t *testing.T) {
+	gc := &gwapiv1b1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       gwapiv1b1.GatewayClassSpec{ControllerName: v1alpha1.GatewayControllerName},
+		Status: gwapiv1b1.GatewayClassStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(gwapiv1b1.GatewayClassConditionStatusAccepted),
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+	gw := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec:       gwapiv1b1.GatewaySpec{GatewayClassName: "test"},
+	}
+	// The Envoy Service runs in the namespace of the EnvoyProxy of the GatewayClass.
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "envoy-proxies",
+			Name:      infraServiceName(gw),
+			Labels:    gatewayapi.GatewayOwnerLabels(gw.Namespace, gw.Name),
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "1.1.1.1"}},
+			},
+		},
+	}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	su := new(recordingStatusUpdater)
+	resources := new(message.ProviderResources)
+	resources.InfraStatuses.Store(utils.NamespacedName(gw), infrastructure.Status{Ready: true, Message: "1/1 replicas ready"})
+	r := &gatewayReconciler{
+		client:          fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(gc, gw, svc).Build(),
+		classController: v1alpha1.GatewayControllerName,
+		statusUpdater:   su,
+		log:             logger,
+		resources:       resources,
+	}
+
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: utils.NamespacedName(gw)})
+	require.NoError(t, err)
+
+	require.Len(t, su.updates, 1)
+	updated, ok := su.updates[0].Mutator.Mutate(gw.DeepCopy()).(*gwapiv1b1.Gateway)
+	require.True(t, ok)
+	require.Equal(t, []gwapiv1b1.GatewayAddress{
+		{Type: gatewayapi.GatewayAddressTypePtr(gwapiv1b1.IPAddressType), Value: "1.1.1.1"},
+	}, updated.Status.Addresses)
+	ready := meta.FindStatusCondition(updated.Status.Conditions, string(gwapiv1b1.GatewayConditionReady))
+	require.NotNil(t, ready)
+	require.Equal(t, metav1.ConditionTrue, ready.Status)
+}
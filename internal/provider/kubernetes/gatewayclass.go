@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/status"
@@ -45,22 +46,101 @@ func newGatewayClassController(mgr manager.Manager, cfg *config.Server, su statu
 		resources:     resources,
 	}
 
-	c, err := controller.New("gatewayclass", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("gatewayclass", mgr, controller.Options{Reconciler: tracedReconciler("gatewayclass", r)})
 	if err != nil {
 		return err
 	}
 	r.log.Info("created gatewayclass controller")
 
-	// Only enqueue GatewayClass objects that match this Envoy Gateway's controller name.
+	// Only enqueue GatewayClass objects that match this Envoy Gateway's controller name,
+	// skipping status-only updates.
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1b1.GatewayClass{}},
 		&handler.EnqueueRequestForObject{},
 		predicate.NewPredicateFuncs(r.hasMatchingController),
+		specOrLabelsChanged(),
 	); err != nil {
 		return err
 	}
 	r.log.Info("watching gatewayclass objects")
 
+	// Trigger gatewayclass reconciliation when an EnvoyProxy referenced
+	// by a managed GatewayClass has changed.
+	if err := c.Watch(
+		&source.Kind{Type: &v1alpha1.EnvoyProxy{}},
+		handler.EnqueueRequestsFromMapFunc(r.getGatewayClassesForEnvoyProxy),
+	); err != nil {
+		return err
+	}
+	r.log.Info("watching envoyproxy objects")
+
+	return nil
+}
+
+// getGatewayClassesForEnvoyProxy uses an EnvoyProxy obj to fetch GatewayClasses,
+// creating a reconciliation request for each managed GatewayClass that references obj.
+func (r *gatewayClassReconciler) getGatewayClassesForEnvoyProxy(obj client.Object) []reconcile.Request {
+	ep, ok := obj.(*v1alpha1.EnvoyProxy)
+	if !ok {
+		r.log.Info("bypassing reconciliation due to unexpected object type", "type", obj)
+		return nil
+	}
+
+	var gatewayClasses gwapiv1b1.GatewayClassList
+	if err := r.client.List(context.Background(), &gatewayClasses); err != nil {
+		r.log.Error(err, "failed to list gatewayclasses")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for i := range gatewayClasses.Items {
+		gc := gatewayClasses.Items[i]
+		if gc.Spec.ControllerName != r.controller || !refsEnvoyProxy(&gc) {
+			continue
+		}
+		ref := gc.Spec.ParametersRef
+		if ref.Name == ep.Name && ref.Namespace != nil && string(*ref.Namespace) == ep.Namespace {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: gc.Name}})
+		}
+	}
+
+	return reqs
+}
+
+// refsEnvoyProxy returns true if the parametersRef of the provided GatewayClass
+// refers to an EnvoyProxy.
+func refsEnvoyProxy(gc *gwapiv1b1.GatewayClass) bool {
+	if gc == nil || gc.Spec.ParametersRef == nil {
+		return false
+	}
+	return string(gc.Spec.ParametersRef.Group) == v1alpha1.GroupVersion.Group &&
+		string(gc.Spec.ParametersRef.Kind) == v1alpha1.KindEnvoyProxy
+}
+
+// processParamsRef stores the EnvoyProxy referenced by the parametersRef of the
+// provided GatewayClass in the resource map. A previously stored EnvoyProxy is
+// removed from the resource map if gc no longer references a valid EnvoyProxy.
+func (r *gatewayClassReconciler) processParamsRef(ctx context.Context, gc *gwapiv1b1.GatewayClass) error {
+	if !refsEnvoyProxy(gc) {
+		r.resources.EnvoyProxies.Delete(gc.Name)
+		return nil
+	}
+
+	ref := gc.Spec.ParametersRef
+	if ref.Namespace == nil {
+		r.resources.EnvoyProxies.Delete(gc.Name)
+		return fmt.Errorf("parametersRef of gatewayclass %s must specify a namespace", gc.Name)
+	}
+
+	ep := new(v1alpha1.EnvoyProxy)
+	key := types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}
+	if err := r.client.Get(ctx, key, ep); err != nil {
+		r.resources.EnvoyProxies.Delete(gc.Name)
+		return fmt.Errorf("failed to get envoyproxy %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	r.resources.EnvoyProxies.Store(gc.Name, ep)
+
 	return nil
 }
 
@@ -103,8 +183,9 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, request reconcil
 				!slice.ContainsString(gatewayClasses.Items[i].Finalizers, gatewayClassFinalizer) {
 				r.log.Info("gatewayclass marked for deletion")
 				cc.removeMatch(&gatewayClasses.Items[i])
-				// Delete the gatewayclass from the watchable map.
+				// Delete the gatewayclass and its EnvoyProxy from the watchable maps.
 				r.resources.GatewayClasses.Delete(request.Name)
+				r.resources.EnvoyProxies.Delete(request.Name)
 				continue
 			}
 
@@ -119,6 +200,12 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, request reconcil
 		return reconcile.Result{}, nil
 	}
 
+	// Store the EnvoyProxy referenced by the accepted gatewayclass in the resource
+	// map before the gatewayclass, so the first translation uses the proxy config.
+	if err := r.processParamsRef(ctx, acceptedGC); err != nil {
+		r.log.Error(err, "failed to process parametersRef of gatewayclass", "name", acceptedGC.Name)
+	}
+
 	// Store the accepted gatewayclass in the resource map.
 	r.resources.GatewayClasses.Store(acceptedGC.GetName(), acceptedGC)
 
@@ -1,16 +1,21 @@
 package kubernetes
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/message"
 )
 
 func TestGatewayClassHasMatchingController(t *testing.T) {
@@ -155,3 +160,105 @@ func TestGatewayOldestClass(t *testing.T) {
 		require.Equal(t, tc.oldest, cc.oldestClass.Name)
 	}
 }
+
+func TestProcessParamsRef(t *testing.T) {
+	ep := &v1alpha1.EnvoyProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-ep",
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		ref    *gwapiv1b1.ParametersReference
+		stored bool
+		valid  bool
+	}{
+		{
+			name:   "no parametersRef",
+			ref:    nil,
+			stored: false,
+			valid:  true,
+		},
+		{
+			name: "valid envoyproxy reference",
+			ref: &gwapiv1b1.ParametersReference{
+				Group:     gwapiv1b1.Group(v1alpha1.GroupVersion.Group),
+				Kind:      v1alpha1.KindEnvoyProxy,
+				Name:      ep.Name,
+				Namespace: gatewayapi.NamespacePtr(ep.Namespace),
+			},
+			stored: true,
+			valid:  true,
+		},
+		{
+			name: "envoyproxy reference without namespace",
+			ref: &gwapiv1b1.ParametersReference{
+				Group: gwapiv1b1.Group(v1alpha1.GroupVersion.Group),
+				Kind:  v1alpha1.KindEnvoyProxy,
+				Name:  ep.Name,
+			},
+			stored: false,
+			valid:  false,
+		},
+		{
+			name: "non-existent envoyproxy",
+			ref: &gwapiv1b1.ParametersReference{
+				Group:     gwapiv1b1.Group(v1alpha1.GroupVersion.Group),
+				Kind:      v1alpha1.KindEnvoyProxy,
+				Name:      "non-existent",
+				Namespace: gatewayapi.NamespacePtr(ep.Namespace),
+			},
+			stored: false,
+			valid:  false,
+		},
+		{
+			name: "unsupported parameters kind",
+			ref: &gwapiv1b1.ParametersReference{
+				Group: "example.com",
+				Kind:  "Unsupported",
+				Name:  ep.Name,
+			},
+			stored: false,
+			valid:  true,
+		},
+	}
+
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gc := &gwapiv1b1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-gc",
+				},
+				Spec: gwapiv1b1.GatewayClassSpec{
+					ControllerName: v1alpha1.GatewayControllerName,
+					ParametersRef:  tc.ref,
+				},
+			}
+			r := gatewayClassReconciler{
+				client:     fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(ep).Build(),
+				controller: v1alpha1.GatewayControllerName,
+				log:        logger,
+				resources:  new(message.ProviderResources),
+			}
+			err := r.processParamsRef(context.Background(), gc)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			got := r.resources.GetEnvoyProxy(gc.Name)
+			if tc.stored {
+				require.NotNil(t, got)
+				require.Equal(t, ep.Name, got.Name)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
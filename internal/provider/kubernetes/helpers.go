@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/tracing"
 )
 
 // validateParentRefs validates the provided routeParentReferences, returning the
@@ -74,3 +79,18 @@ func isRoutePresentInNamespace(ctx context.Context, c client.Client, ns string)
 	}
 	return false, nil
 }
+
+// tracedReconciler returns r, recording each of its reconciles of the provided
+// controller in a span.
+func tracedReconciler(controller string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		ctx, span := tracing.Tracer().Start(ctx, "provider.reconcile", trace.WithAttributes(
+			attribute.String("controller", controller),
+			attribute.String("namespace", request.Namespace),
+			attribute.String("name", request.Name),
+		))
+		result, err := r.Reconcile(ctx, request)
+		tracing.EndSpan(span, err)
+		return result, err
+	})
+}
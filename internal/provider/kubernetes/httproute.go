@@ -51,13 +51,18 @@ func newHTTPRouteController(mgr manager.Manager, cfg *config.Server, su status.U
 		resources:       resources,
 	}
 
-	c, err := controller.New("httproute", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("httproute", mgr, controller.Options{Reconciler: tracedReconciler("httproute", r)})
 	if err != nil {
 		return err
 	}
 	r.log.Info("created httproute controller")
 
-	if err := c.Watch(&source.Kind{Type: &gwapiv1b1.HTTPRoute{}}, &handler.EnqueueRequestForObject{}); err != nil {
+	// Skip status-only updates since the HTTPRoute status is written by Envoy Gateway.
+	if err := c.Watch(
+		&source.Kind{Type: &gwapiv1b1.HTTPRoute{}},
+		&handler.EnqueueRequestForObject{},
+		specOrLabelsChanged(),
+	); err != nil {
 		return err
 	}
 
@@ -93,6 +98,7 @@ func newHTTPRouteController(mgr manager.Manager, cfg *config.Server, su status.U
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1b1.Gateway{}},
 		handler.EnqueueRequestsFromMapFunc(r.getHTTPRoutesForGateway),
+		specOrLabelsChanged(),
 	); err != nil {
 		return err
 	}
@@ -2,7 +2,9 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -61,6 +63,26 @@ func New(cfg *rest.Config, svr *config.Server, resources *message.ProviderResour
 		return nil, fmt.Errorf("failed to create tlsroute controller: %w", err)
 	}
 
+	if err := newEnvoyProxyController(mgr, svr, updateHandler.Writer()); err != nil {
+		return nil, fmt.Errorf("failed to create envoyproxy controller: %w", err)
+	}
+
+	if err := newBackendTrafficPolicyController(mgr, svr, updateHandler.Writer(), resources); err != nil {
+		return nil, fmt.Errorf("failed to create backendtrafficpolicy controller: %w", err)
+	}
+
+	if err := newClientTrafficPolicyController(mgr, svr, resources); err != nil {
+		return nil, fmt.Errorf("failed to create clienttrafficpolicy controller: %w", err)
+	}
+
+	if err := newEnvoyExtensionPolicyController(mgr, svr, resources); err != nil {
+		return nil, fmt.Errorf("failed to create envoyextensionpolicy controller: %w", err)
+	}
+
+	if err := newEnvoyPatchPolicyController(mgr, svr, updateHandler.Writer(), resources); err != nil {
+		return nil, fmt.Errorf("failed to create envoypatchpolicy controller: %w", err)
+	}
+
 	// Add health check health probes.
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return nil, fmt.Errorf("unable to set up health check: %w", err)
@@ -71,12 +93,39 @@ func New(cfg *rest.Config, svr *config.Server, resources *message.ProviderResour
 		return nil, fmt.Errorf("unable to set up ready check: %w", err)
 	}
 
+	// Envoy Gateway isn't ready until the resources are loaded in the informer caches,
+	// so that the Envoy proxies don't get a partial configuration.
+	if err := mgr.AddReadyzCheck("informer-cache-sync", func(req *http.Request) error {
+		if !mgr.GetCache().WaitForCacheSync(req.Context()) {
+			return errors.New("informer caches are not synced")
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("unable to set up informer cache sync check: %w", err)
+	}
+
 	return &Provider{
 		manager: mgr,
 		client:  mgr.GetClient(),
 	}, nil
 }
 
+// AddHealthChecks adds the provided liveness and readiness checks, keyed by name,
+// to the health probes served by the provider. It must be called before Start.
+func (p *Provider) AddHealthChecks(healthzChecks, readyzChecks map[string]healthz.Checker) error {
+	for name, check := range healthzChecks {
+		if err := p.manager.AddHealthzCheck(name, check); err != nil {
+			return fmt.Errorf("unable to set up %s health check: %w", name, err)
+		}
+	}
+	for name, check := range readyzChecks {
+		if err := p.manager.AddReadyzCheck(name, check); err != nil {
+			return fmt.Errorf("unable to set up %s ready check: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // Start starts the Provider synchronously until a message is received from ctx.
 func (p *Provider) Start(ctx context.Context) error {
 	errChan := make(chan error)
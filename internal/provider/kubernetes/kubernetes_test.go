@@ -75,8 +75,9 @@ func TestProvider(t *testing.T) {
 func startEnv() (*envtest.Environment, *rest.Config, error) {
 	log.SetLogger(zap.New(zap.WriteTo(os.Stderr), zap.UseDevMode(true)))
 	crd := filepath.Join(".", "testdata", "in")
+	egCRD := filepath.Join(".", "config", "crd", "bases")
 	env := &envtest.Environment{
-		CRDDirectoryPaths: []string{crd},
+		CRDDirectoryPaths: []string{crd, egCRD},
 	}
 	cfg, err := env.Start()
 	if err != nil {
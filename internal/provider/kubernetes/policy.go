@@ -0,0 +1,154 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/telepresenceio/watchable"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+// policyReconciler reconciles the policies of type P, storing them in their
+// resource map along with the ConfigMap or Secret they reference, if any.
+type policyReconciler[P client.Object] struct {
+	client    client.Client
+	log       logr.Logger
+	resources *message.ProviderResources
+
+	// name is the lowercase kind of the policies, e.g. "backendtrafficpolicy".
+	name string
+	// policies is the resource map of the policies.
+	policies *watchable.Map[types.NamespacedName, P]
+	// newPolicy and newPolicyList return an empty policy and policy list.
+	newPolicy     func() P
+	newPolicyList func() client.ObjectList
+	// objectRef returns the ConfigMap or Secret referenced by a policy, and
+	// false if the policy doesn't reference one.
+	objectRef func(P) (policyObjectRef, bool)
+	// onDelete, if set, is called with the name of the deleted policies, e.g. to
+	// delete their status from the resource map.
+	onDelete func(types.NamespacedName)
+}
+
+// newPolicyController creates the controller of the policies reconciled by r
+// from mgr. The controller will be pre-configured to watch for the policies
+// across all namespaces, and for the objects of the provided kinds they
+// reference.
+func newPolicyController[P client.Object](mgr manager.Manager, r *policyReconciler[P], refKinds ...string) error {
+	c, err := controller.New(r.name, mgr, controller.Options{Reconciler: tracedReconciler(r.name, r)})
+	if err != nil {
+		return err
+	}
+	r.log.Info(fmt.Sprintf("created %s controller", r.name))
+
+	if err := c.Watch(
+		&source.Kind{Type: r.newPolicy()},
+		&handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return err
+	}
+	r.log.Info(fmt.Sprintf("watching %s objects", r.name))
+
+	// Trigger the reconciliation of the policies when an object they
+	// reference has changed.
+	for _, kind := range refKinds {
+		var obj client.Object
+		switch kind {
+		case gatewayapi.KindConfigMap:
+			obj = &corev1.ConfigMap{}
+		case gatewayapi.KindSecret:
+			obj = &corev1.Secret{}
+		default:
+			return fmt.Errorf("unsupported kind %s referenced by %s objects", kind, r.name)
+		}
+		if err := c.Watch(&source.Kind{Type: obj}, r.enqueueRequestForObjectRef(kind)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enqueueRequestForObjectRef returns an event handler that maps events for
+// objects of the provided kind to reconcile requests for the policies
+// referencing them.
+func (r *policyReconciler[P]) enqueueRequestForObjectRef(kind string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+		list := r.newPolicyList()
+		if err := r.client.List(context.Background(), list, client.InNamespace(a.GetNamespace())); err != nil {
+			r.log.Error(err, "failed to list "+r.name+" objects", "namespace", a.GetNamespace())
+			return nil
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			r.log.Error(err, "failed to extract "+r.name+" objects", "namespace", a.GetNamespace())
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, item := range items {
+			policy, ok := item.(P)
+			if !ok {
+				continue
+			}
+			if ref, ok := r.objectRef(policy); ok && ref.kind == kind && ref.Name == a.GetName() {
+				reqs = append(reqs, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: policy.GetNamespace(), Name: policy.GetName()},
+				})
+			}
+		}
+
+		return reqs
+	})
+}
+
+func (r *policyReconciler[P]) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("namespace", request.Namespace, "name", request.Name)
+	log.Info("reconciling " + r.name)
+
+	policy := r.newPolicy()
+	if err := r.client.Get(ctx, request.NamespacedName, policy); err != nil {
+		if kerrors.IsNotFound(err) {
+			deleted, ok := r.policies.Load(request.NamespacedName)
+			r.policies.Delete(request.NamespacedName)
+			if ok {
+				if ref, ok := r.objectRef(deleted); ok {
+					deletePolicyObjectRef(r.resources, ref)
+				}
+			}
+			if r.onDelete != nil {
+				r.onDelete(request.NamespacedName)
+			}
+			log.Info("deleted " + r.name + " from resource map")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get %s %s: %w", r.name, request.NamespacedName, err)
+	}
+
+	if ref, ok := r.objectRef(policy); ok {
+		if err := storePolicyObjectRef(ctx, r.client, r.resources, ref); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.policies.Store(request.NamespacedName, policy)
+	log.Info("added " + r.name + " to resource map")
+
+	log.Info("reconciled " + r.name)
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,17 @@
+package kubernetes
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// specOrLabelsChanged returns a predicate that filters out update events which
+// change neither the generation nor the labels of an object. Envoy Gateway writes
+// status for the resources it watches, and status-only updates would otherwise
+// requeue a full reconciliation and trigger a new xDS push. Create, delete and
+// generic events are always passed through.
+func specOrLabelsChanged() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+	)
+}
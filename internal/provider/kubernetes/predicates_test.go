@@ -0,0 +1,57 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestSpecOrLabelsChanged(t *testing.T) {
+	gw := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Namespace:  "test",
+			Generation: 1,
+			Labels:     map[string]string{"foo": "bar"},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(gw *gwapiv1b1.Gateway)
+		expect bool
+	}{
+		{
+			name:   "status only update",
+			mutate: func(gw *gwapiv1b1.Gateway) { gw.Status.Addresses = []gwapiv1b1.GatewayAddress{{Value: "1.2.3.4"}} },
+			expect: false,
+		},
+		{
+			name:   "spec update",
+			mutate: func(gw *gwapiv1b1.Gateway) { gw.Generation = 2 },
+			expect: true,
+		},
+		{
+			name:   "label update",
+			mutate: func(gw *gwapiv1b1.Gateway) { gw.Labels["foo"] = "baz" },
+			expect: true,
+		},
+	}
+
+	p := specOrLabelsChanged()
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			updated := gw.DeepCopy()
+			tc.mutate(updated)
+			res := p.Update(event.UpdateEvent{ObjectOld: gw, ObjectNew: updated})
+			require.Equal(t, tc.expect, res)
+		})
+	}
+
+	require.True(t, p.Create(event.CreateEvent{Object: gw}))
+	require.True(t, p.Delete(event.DeleteEvent{Object: gw}))
+}
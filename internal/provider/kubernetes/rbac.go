@@ -3,6 +3,14 @@ package kubernetes
 // +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses;gateways;httproutes;tlsroutes;referencepolicies;referencegrants,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses/status;gateways/status;httproutes/status;tlsroutes/status,verbs=update
 
+// RBAC for EnvoyProxy resources referenced by a GatewayClass parametersRef.
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=envoyproxies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=envoyproxies/status,verbs=update
+
 // RBAC for watched resources of Gateway API controllers.
-// +kubebuilder:rbac:groups="",resources=secrets;services;namespaces,verbs=get;list;watch
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets;configmaps;services;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets,verbs=get;list;watch
+
+// RBAC for policies attached to Gateway API resources.
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=backendtrafficpolicies;clienttrafficpolicies;envoyextensionpolicies;envoypatchpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=backendtrafficpolicies/status;envoypatchpolicies/status,verbs=update
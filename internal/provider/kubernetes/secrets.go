@@ -12,11 +12,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 )
 
-// caCertificateKey is the key name for accessing TLS CA certificate bundles
-// in Kubernetes Secrets.
-const caCertificateKey = "ca.crt"
+const (
+	// caCertificateKey is the key name for accessing TLS CA certificate bundles
+	// in Kubernetes Secrets.
+	caCertificateKey = "ca.crt"
+	// envoyGatewaySecretName is the name of the Secret holding the certificates
+	// of the xDS server.
+	envoyGatewaySecretName = "envoy-gateway"
+	// envoySecretName is the name of the Secret holding the certificates used by
+	// Envoy to connect to the xDS server.
+	envoySecretName = "envoy"
+)
 
 func newSecret(secretType corev1.SecretType, name string, namespace string, data map[string][]byte) corev1.Secret {
 	return corev1.Secret{
@@ -41,7 +50,7 @@ func CertsToSecret(namespace string, certs *crypto.Certificates) []corev1.Secret
 	return []corev1.Secret{
 		newSecret(
 			corev1.SecretTypeTLS,
-			"envoy-gateway",
+			envoyGatewaySecretName,
 			namespace,
 			map[string][]byte{
 				caCertificateKey:        certs.CACertificate,
@@ -50,16 +59,45 @@ func CertsToSecret(namespace string, certs *crypto.Certificates) []corev1.Secret
 			}),
 		newSecret(
 			corev1.SecretTypeTLS,
-			"envoy",
+			envoySecretName,
 			namespace,
-			map[string][]byte{
-				caCertificateKey:        certs.CACertificate,
-				corev1.TLSCertKey:       certs.EnvoyCertificate,
-				corev1.TLSPrivateKeyKey: certs.EnvoyPrivateKey,
-			}),
+			envoySecretData(certs)),
+	}
+}
+
+// envoySecretData returns the data of the Secret holding the certificates used by
+// Envoy from the provided certs.
+func envoySecretData(certs *crypto.Certificates) map[string][]byte {
+	return map[string][]byte{
+		caCertificateKey:        certs.CACertificate,
+		corev1.TLSCertKey:       certs.EnvoyCertificate,
+		corev1.TLSPrivateKeyKey: certs.EnvoyPrivateKey,
 	}
 }
 
+// GetCerts returns the certs held by the secrets of the provided namespace, or nil
+// if the secrets don't exist.
+func GetCerts(ctx context.Context, client client.Client, namespace string) (*crypto.Certificates, error) {
+	egSecret, envoySecret := new(corev1.Secret), new(corev1.Secret)
+	for name, secret := range map[string]*corev1.Secret{envoyGatewaySecretName: egSecret, envoySecretName: envoySecret} {
+		key := types.NamespacedName{Namespace: namespace, Name: name}
+		if err := client.Get(ctx, key, secret); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+		}
+	}
+
+	return &crypto.Certificates{
+		CACertificate:           egSecret.Data[caCertificateKey],
+		EnvoyGatewayCertificate: egSecret.Data[corev1.TLSCertKey],
+		EnvoyGatewayPrivateKey:  egSecret.Data[corev1.TLSPrivateKeyKey],
+		EnvoyCertificate:        envoySecret.Data[corev1.TLSCertKey],
+		EnvoyPrivateKey:         envoySecret.Data[corev1.TLSPrivateKeyKey],
+	}, nil
+}
+
 // CreateOrUpdateSecrets creates the provided secrets if they don't exist or updates
 // them if they do.
 func CreateOrUpdateSecrets(ctx context.Context, client client.Client, secrets []corev1.Secret) ([]corev1.Secret, error) {
@@ -91,3 +129,29 @@ func CreateOrUpdateSecrets(ctx context.Context, client client.Client, secrets []
 
 	return ret, nil
 }
+
+// UpdateEnvoySecretCopies updates the copies of the Envoy certificates Secret, made in
+// the namespaces of the managed Envoy resources, with the provided certs. It returns
+// the updated copies.
+func UpdateEnvoySecretCopies(ctx context.Context, cli client.Client, certs *crypto.Certificates) ([]corev1.Secret, error) {
+	copies := new(corev1.SecretList)
+	if err := cli.List(ctx, copies, client.MatchingLabels{config.EnvoyCertsCopyLabel: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list the copies of secret %s: %w", envoySecretName, err)
+	}
+
+	data := envoySecretData(certs)
+	var ret []corev1.Secret
+	for i := range copies.Items {
+		secret := copies.Items[i]
+		if secret.Name != envoySecretName || reflect.DeepEqual(secret.Data, data) {
+			continue
+		}
+		secret.Data = data
+		if err := cli.Update(ctx, &secret); err != nil {
+			return nil, fmt.Errorf("failed to update secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		ret = append(ret, secret)
+	}
+
+	return ret, nil
+}
@@ -52,15 +52,17 @@ func newTLSRouteController(mgr manager.Manager, cfg *config.Server, su status.Up
 		resources:       resources,
 	}
 
-	c, err := controller.New("tlsroute", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("tlsroute", mgr, controller.Options{Reconciler: tracedReconciler("tlsroute", r)})
 	if err != nil {
 		return err
 	}
 	r.log.Info("created tlsroute controller")
 
+	// Skip status-only updates since the TLSRoute status is written by Envoy Gateway.
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1a2.TLSRoute{}},
 		&handler.EnqueueRequestForObject{},
+		specOrLabelsChanged(),
 	); err != nil {
 		return err
 	}
@@ -97,6 +99,7 @@ func newTLSRouteController(mgr manager.Manager, cfg *config.Server, su status.Up
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1b1.Gateway{}},
 		handler.EnqueueRequestsFromMapFunc(r.getTLSRoutesForGateway),
+		specOrLabelsChanged(),
 	); err != nil {
 		return err
 	}
@@ -5,16 +5,22 @@ import (
 	"fmt"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/file"
 	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
 )
 
 type Config struct {
 	config.Server
 	ProviderResources *message.ProviderResources
+	// HealthzChecks and ReadyzChecks are the liveness and readiness checks of the
+	// other runners, keyed by name, served along with the checks of the provider.
+	HealthzChecks map[string]healthz.Checker
+	ReadyzChecks  map[string]healthz.Checker
 }
 
 type Runner struct {
@@ -31,7 +37,11 @@ func (r *Runner) Name() string {
 
 // Start the provider runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	logger, err := r.ComponentLogger(v1alpha1.LogComponentProvider)
+	if err != nil {
+		return err
+	}
+	r.Logger = logger.WithValues("runner", r.Name())
 	if r.EnvoyGateway.Provider.Type == v1alpha1.ProviderTypeKubernetes {
 		r.Logger.Info("Using provider", "type", v1alpha1.ProviderTypeKubernetes)
 		cfg, err := ctrl.GetConfig()
@@ -42,6 +52,23 @@ func (r *Runner) Start(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create provider %s: %w", v1alpha1.ProviderTypeKubernetes, err)
 		}
+		if err := p.AddHealthChecks(r.HealthzChecks, r.ReadyzChecks); err != nil {
+			return fmt.Errorf("failed to add health checks to provider %s: %w", v1alpha1.ProviderTypeKubernetes, err)
+		}
+		go func() {
+			err := p.Start(ctx)
+			if err != nil {
+				r.Logger.Error(err, "unable to start provider")
+			}
+		}()
+		return nil
+	}
+	if r.EnvoyGateway.Provider.Type == v1alpha1.ProviderTypeFile {
+		r.Logger.Info("Using provider", "type", v1alpha1.ProviderTypeFile)
+		p, err := file.New(&r.Config.Server, r.ProviderResources)
+		if err != nil {
+			return fmt.Errorf("failed to create provider %s: %w", v1alpha1.ProviderTypeFile, err)
+		}
 		go func() {
 			err := p.Start(ctx)
 			if err != nil {
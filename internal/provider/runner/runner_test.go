@@ -23,7 +23,7 @@ func TestStart(t *testing.T) {
 		expect bool
 	}{
 		{
-			name: "file provider",
+			name: "file provider without paths",
 			cfg: &config.Server{
 				EnvoyGateway: &v1alpha1.EnvoyGateway{
 					TypeMeta: metav1.TypeMeta{
@@ -40,6 +40,28 @@ func TestStart(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			name: "file provider with paths",
+			cfg: &config.Server{
+				EnvoyGateway: &v1alpha1.EnvoyGateway{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: v1alpha1.GroupVersion.String(),
+						Kind:       v1alpha1.KindEnvoyGateway,
+					},
+					EnvoyGatewaySpec: v1alpha1.EnvoyGatewaySpec{
+						Gateway: v1alpha1.DefaultGateway(),
+						Provider: &v1alpha1.Provider{
+							Type: v1alpha1.ProviderTypeFile,
+							File: &v1alpha1.FileProvider{
+								Paths: []string{t.TempDir()},
+							},
+						},
+					},
+				},
+				Logger: logger,
+			},
+			expect: true,
+		},
 	}
 
 	for _, tc := range testCases {
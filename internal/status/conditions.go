@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"time"
 
-	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
 )
 
 const ReasonOlderGatewayClassExists gwapiv1b1.GatewayClassConditionReason = "OlderGatewayClassExists"
@@ -53,29 +56,104 @@ func computeGatewayScheduledCondition(gw *gwapiv1b1.Gateway, scheduled bool) met
 }
 
 // computeGatewayReadyCondition computes the Gateway Ready status condition.
-// Ready condition surfaces true when the Envoy Deployment status is ready.
-func computeGatewayReadyCondition(gw *gwapiv1b1.Gateway, deployment *appsv1.Deployment) metav1.Condition {
+// Ready condition surfaces true when the Envoy proxy infrastructure is ready.
+func computeGatewayReadyCondition(gw *gwapiv1b1.Gateway, infra *infrastructure.Status) metav1.Condition {
+	for _, addr := range gw.Spec.Addresses {
+		if err := gatewayapi.ValidateGatewayAddress(addr); err != nil {
+			return newCondition(string(gwapiv1b1.GatewayConditionReady), metav1.ConditionFalse,
+				string(gwapiv1b1.GatewayReasonAddressNotAssigned),
+				fmt.Sprintf("The Gateway address is invalid: %v", err), time.Now(), gw.Generation)
+		}
+	}
+
 	if len(gw.Status.Addresses) == 0 {
 		return newCondition(string(gwapiv1b1.GatewayConditionReady), metav1.ConditionFalse,
 			string(gwapiv1b1.GatewayReasonAddressNotAssigned),
 			"No addresses have been assigned to the Gateway", time.Now(), gw.Generation)
 	}
 
-	// If there are no available replicas for the Envoy Deployment, don't
-	// mark the Gateway as ready yet.
+	for _, addr := range gw.Spec.Addresses {
+		if !hasStatusAddress(gw, addr.Value) {
+			return newCondition(string(gwapiv1b1.GatewayConditionReady), metav1.ConditionFalse,
+				string(gwapiv1b1.GatewayReasonAddressNotAssigned),
+				fmt.Sprintf("Address %s has not been assigned to the Gateway", addr.Value), time.Now(), gw.Generation)
+		}
+	}
 
-	if deployment == nil || deployment.Status.AvailableReplicas == 0 {
+	// If the Envoy proxy infrastructure isn't ready, don't mark the Gateway as
+	// ready yet.
+	if infra == nil {
 		return newCondition(string(gwapiv1b1.GatewayConditionReady), metav1.ConditionFalse,
 			string(gwapiv1b1.GatewayReasonNoResources),
-			"Deployment replicas unavailable", time.Now(), gw.Generation)
+			"Envoy proxy infrastructure status unknown", time.Now(), gw.Generation)
+	}
+	if !infra.Ready {
+		return newCondition(string(gwapiv1b1.GatewayConditionReady), metav1.ConditionFalse,
+			string(gwapiv1b1.GatewayReasonNoResources),
+			fmt.Sprintf("Envoy proxy infrastructure not ready: %s", infra.Message), time.Now(), gw.Generation)
 	}
 
-	message := fmt.Sprintf("Address assigned to the Gateway, %d/%d envoy Deployment replicas available",
-		deployment.Status.AvailableReplicas, deployment.Status.Replicas)
+	message := fmt.Sprintf("Address assigned to the Gateway, envoy proxy infrastructure ready: %s", infra.Message)
 	return newCondition(string(gwapiv1b1.GatewayConditionReady), metav1.ConditionTrue,
 		string(gwapiv1b1.GatewayReasonReady), message, time.Now(), gw.Generation)
 }
 
+// computeEnvoyProxyAcceptedCondition computes the EnvoyProxy Accepted status condition.
+func computeEnvoyProxyAcceptedCondition(ep *v1alpha1.EnvoyProxy, referenced, accepted bool) metav1.Condition {
+	switch {
+	case !referenced:
+		return newCondition(string(v1alpha1.EnvoyProxyConditionAccepted), metav1.ConditionFalse,
+			string(v1alpha1.EnvoyProxyReasonNotReferenced),
+			"The EnvoyProxy is not referenced by a GatewayClass managed by Envoy Gateway", time.Now(), ep.Generation)
+	case !accepted:
+		return newCondition(string(v1alpha1.EnvoyProxyConditionAccepted), metav1.ConditionFalse,
+			string(v1alpha1.EnvoyProxyReasonGatewayClassNotAccepted),
+			"None of the GatewayClasses referencing the EnvoyProxy are accepted", time.Now(), ep.Generation)
+	default:
+		return newCondition(string(v1alpha1.EnvoyProxyConditionAccepted), metav1.ConditionTrue,
+			string(v1alpha1.EnvoyProxyReasonAccepted),
+			"The EnvoyProxy is referenced by the accepted GatewayClass", time.Now(), ep.Generation)
+	}
+}
+
+// computeEnvoyProxyAvailableCondition computes the EnvoyProxy Available status condition.
+// Available condition surfaces true when every Envoy workload has available replicas.
+func computeEnvoyProxyAvailableCondition(ep *v1alpha1.EnvoyProxy, workloads []EnvoyProxyWorkload) metav1.Condition {
+	if len(workloads) == 0 {
+		return newCondition(string(v1alpha1.EnvoyProxyConditionAvailable), metav1.ConditionFalse,
+			string(v1alpha1.EnvoyProxyReasonNoResources),
+			"No Envoy workloads exist for the EnvoyProxy", time.Now(), ep.Generation)
+	}
+
+	var replicas, available int32
+	unavailable := false
+	for _, w := range workloads {
+		replicas += w.Replicas
+		available += w.AvailableReplicas
+		if w.AvailableReplicas == 0 {
+			unavailable = true
+		}
+	}
+
+	message := fmt.Sprintf("%d/%d Envoy replicas available", available, replicas)
+	if unavailable {
+		return newCondition(string(v1alpha1.EnvoyProxyConditionAvailable), metav1.ConditionFalse,
+			string(v1alpha1.EnvoyProxyReasonUnavailable), message, time.Now(), ep.Generation)
+	}
+	return newCondition(string(v1alpha1.EnvoyProxyConditionAvailable), metav1.ConditionTrue,
+		string(v1alpha1.EnvoyProxyReasonAvailable), message, time.Now(), ep.Generation)
+}
+
+// hasStatusAddress returns true if the provided address is one of the status addresses of gw.
+func hasStatusAddress(gw *gwapiv1b1.Gateway, address string) bool {
+	for _, addr := range gw.Status.Addresses {
+		if addr.Value == address {
+			return true
+		}
+	}
+	return false
+}
+
 // MergeConditions adds or updates matching conditions, and updates the transition
 // time if details of a condition have changed. Returns the updated condition array.
 func MergeConditions(conditions []metav1.Condition, updates ...metav1.Condition) []metav1.Condition {
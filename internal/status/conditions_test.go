@@ -7,9 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
 	"github.com/stretchr/testify/assert"
-	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilclock "k8s.io/utils/clock"
 	fakeclock "k8s.io/utils/clock/testing"
@@ -237,33 +238,74 @@ func TestMergeConditions(t *testing.T) {
 
 func TestGatewayReadyCondition(t *testing.T) {
 	testCases := []struct {
-		name             string
-		serviceAddress   bool
-		deploymentStatus appsv1.DeploymentStatus
-		expect           metav1.Condition
+		name           string
+		serviceAddress bool
+		specAddresses  []gwapiv1b1.GatewayAddress
+		infra          *infrastructure.Status
+		expect         metav1.Condition
 	}{
 		{
-			name:             "ready gateway",
-			serviceAddress:   true,
-			deploymentStatus: appsv1.DeploymentStatus{AvailableReplicas: 1},
+			name:           "ready gateway",
+			serviceAddress: true,
+			infra:          &infrastructure.Status{Ready: true, Message: "1/1 replicas ready"},
 			expect: metav1.Condition{
 				Status: metav1.ConditionTrue,
 				Reason: string(gwapiv1b1.GatewayReasonReady),
 			},
 		},
 		{
-			name:             "not ready gateway without address",
-			serviceAddress:   false,
-			deploymentStatus: appsv1.DeploymentStatus{AvailableReplicas: 1},
+			name:           "not ready gateway without address",
+			serviceAddress: false,
+			infra:          &infrastructure.Status{Ready: true, Message: "1/1 replicas ready"},
 			expect: metav1.Condition{
 				Status: metav1.ConditionFalse,
 				Reason: string(gwapiv1b1.GatewayReasonAddressNotAssigned),
 			},
 		},
 		{
-			name:             "not ready gateway with address unavailable pods",
-			serviceAddress:   true,
-			deploymentStatus: appsv1.DeploymentStatus{AvailableReplicas: 0},
+			name:           "ready gateway with assigned spec address",
+			serviceAddress: true,
+			specAddresses:  []gwapiv1b1.GatewayAddress{{Value: "1.1.1.1"}},
+			infra:          &infrastructure.Status{Ready: true, Message: "1/1 replicas ready"},
+			expect: metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: string(gwapiv1b1.GatewayReasonReady),
+			},
+		},
+		{
+			name:           "not ready gateway with unassigned spec address",
+			serviceAddress: true,
+			specAddresses:  []gwapiv1b1.GatewayAddress{{Value: "2.2.2.2"}},
+			infra:          &infrastructure.Status{Ready: true, Message: "1/1 replicas ready"},
+			expect: metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: string(gwapiv1b1.GatewayReasonAddressNotAssigned),
+			},
+		},
+		{
+			name:           "not ready gateway with unsupported spec address type",
+			serviceAddress: true,
+			specAddresses: []gwapiv1b1.GatewayAddress{
+				{Type: gatewayapi.GatewayAddressTypePtr(gwapiv1b1.HostnameAddressType), Value: "foo.example.com"},
+			},
+			infra: &infrastructure.Status{Ready: true, Message: "1/1 replicas ready"},
+			expect: metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: string(gwapiv1b1.GatewayReasonAddressNotAssigned),
+			},
+		},
+		{
+			name:           "not ready gateway with address unavailable pods",
+			serviceAddress: true,
+			infra:          &infrastructure.Status{Message: "0/1 replicas ready"},
+			expect: metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: string(gwapiv1b1.GatewayReasonNoResources),
+			},
+		},
+		{
+			name:           "not ready gateway with unknown infra status",
+			serviceAddress: true,
 			expect: metav1.Condition{
 				Status: metav1.ConditionFalse,
 				Reason: string(gwapiv1b1.GatewayReasonNoResources),
@@ -276,6 +318,7 @@ func TestGatewayReadyCondition(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			gtw := &gwapiv1b1.Gateway{}
+			gtw.Spec.Addresses = tc.specAddresses
 			if tc.serviceAddress {
 				gtw.Status = gwapiv1b1.GatewayStatus{
 					Addresses: []gwapiv1b1.GatewayAddress{
@@ -287,8 +330,7 @@ func TestGatewayReadyCondition(t *testing.T) {
 				}
 			}
 
-			deployment := &appsv1.Deployment{Status: tc.deploymentStatus}
-			got := computeGatewayReadyCondition(gtw, deployment)
+			got := computeGatewayReadyCondition(gtw, tc.infra)
 
 			assert.Equal(t, string(gwapiv1b1.GatewayConditionReady), got.Type)
 			assert.Equal(t, tc.expect.Status, got.Status)
@@ -296,3 +338,57 @@ func TestGatewayReadyCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeEnvoyProxyAvailableCondition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		workloads []EnvoyProxyWorkload
+		expect    metav1.Condition
+	}{
+		{
+			name: "no workloads",
+			expect: metav1.Condition{
+				Type:   string(v1alpha1.EnvoyProxyConditionAvailable),
+				Status: metav1.ConditionFalse,
+				Reason: string(v1alpha1.EnvoyProxyReasonNoResources),
+			},
+		},
+		{
+			name:      "workload without available replicas",
+			workloads: []EnvoyProxyWorkload{{Replicas: 2, AvailableReplicas: 2}, {Replicas: 1}},
+			expect: metav1.Condition{
+				Type:    string(v1alpha1.EnvoyProxyConditionAvailable),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(v1alpha1.EnvoyProxyReasonUnavailable),
+				Message: "2/3 Envoy replicas available",
+			},
+		},
+		{
+			name:      "available workloads",
+			workloads: []EnvoyProxyWorkload{{Replicas: 2, AvailableReplicas: 1}},
+			expect: metav1.Condition{
+				Type:    string(v1alpha1.EnvoyProxyConditionAvailable),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(v1alpha1.EnvoyProxyReasonAvailable),
+				Message: "1/2 Envoy replicas available",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ep := &v1alpha1.EnvoyProxy{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+
+			got := computeEnvoyProxyAvailableCondition(ep, tc.workloads)
+
+			assert.Equal(t, tc.expect.Type, got.Type)
+			assert.Equal(t, tc.expect.Status, got.Status)
+			assert.Equal(t, tc.expect.Reason, got.Reason)
+			if tc.expect.Message != "" {
+				assert.Equal(t, tc.expect.Message, got.Message)
+			}
+			assert.Equal(t, ep.Generation, got.ObservedGeneration)
+		})
+	}
+}
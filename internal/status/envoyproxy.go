@@ -0,0 +1,32 @@
+package status
+
+import (
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// EnvoyProxyWorkload is the replica state of an Envoy workload resource, i.e.
+// a Deployment or DaemonSet, of a Gateway using an EnvoyProxy.
+type EnvoyProxyWorkload struct {
+	// Replicas is the desired number of replicas of the workload.
+	Replicas int32
+	// AvailableReplicas is the number of available replicas of the workload.
+	AvailableReplicas int32
+}
+
+// SetEnvoyProxyStatus updates the status of the provided EnvoyProxy based on the
+// names of the GatewayClasses referencing it, whether one of them is accepted and
+// the Envoy workloads of the Gateways using it.
+func SetEnvoyProxyStatus(ep *v1alpha1.EnvoyProxy, gatewayClasses []string, accepted bool, workloads []EnvoyProxyWorkload) *v1alpha1.EnvoyProxy {
+	ep.Status.GatewayClasses = gatewayClasses
+	ep.Status.Replicas = 0
+	ep.Status.AvailableReplicas = 0
+	for _, w := range workloads {
+		ep.Status.Replicas += w.Replicas
+		ep.Status.AvailableReplicas += w.AvailableReplicas
+	}
+
+	ep.Status.Conditions = MergeConditions(ep.Status.Conditions,
+		computeEnvoyProxyAcceptedCondition(ep, len(gatewayClasses) > 0, accepted),
+		computeEnvoyProxyAvailableCondition(ep, workloads))
+	return ep
+}
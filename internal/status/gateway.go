@@ -1,11 +1,11 @@
 package status
 
 import (
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure"
 )
 
 // UpdateGatewayScheduledCondition updates the status condition for the provided Gateway based on the scheduled state.
@@ -15,12 +15,14 @@ func UpdateGatewayStatusScheduledCondition(gw *gwapiv1b1.Gateway, scheduled bool
 }
 
 // UpdateGatewayStatusAddrs updates the status addresses for the provided gateway
-// based on the status IP/Hostname of svc and updates the Ready condition based on the
-// service and deployment state.
-func UpdateGatewayStatusReadyCondition(gw *gwapiv1b1.Gateway, svc *corev1.Service, deployment *appsv1.Deployment) {
+// based on the status IP/Hostname of svc, if not nil, and updates the Ready condition
+// based on the addresses and the status of the Envoy proxy infrastructure.
+func UpdateGatewayStatusReadyCondition(gw *gwapiv1b1.Gateway, svc *corev1.Service, infra *infrastructure.Status) {
 	var addrs, hostnames []string
 	// Update the status addresses field.
 	if svc != nil {
+		// External IPs assigned from the Gateway addresses are routable as well.
+		addrs = append(addrs, svc.Spec.ExternalIPs...)
 		for i := range svc.Status.LoadBalancer.Ingress {
 			switch {
 			case len(svc.Status.LoadBalancer.Ingress[i].IP) > 0:
@@ -55,5 +57,5 @@ func UpdateGatewayStatusReadyCondition(gw *gwapiv1b1.Gateway, svc *corev1.Servic
 		gw.Status.Addresses = gwAddrs
 	}
 	// Update the ready condition.
-	gw.Status.Conditions = MergeConditions(gw.Status.Conditions, computeGatewayReadyCondition(gw, deployment))
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions, computeGatewayReadyCondition(gw, infra))
 }
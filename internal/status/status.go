@@ -15,6 +15,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
 
 // Update contains an all the information needed to update an object's status.
@@ -145,6 +147,8 @@ func (u *UpdateWriter) Send(update Update) {
 //  Gateway
 //  HTTPRoute
 //  TLSRoute
+//  EnvoyProxy
+//  BackendTrafficPolicy
 func isStatusEqual(objA, objB interface{}) bool {
 	opts := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration")
 	switch a := objA.(type) {
@@ -172,6 +176,18 @@ func isStatusEqual(objA, objB interface{}) bool {
 				return true
 			}
 		}
+	case *v1alpha1.EnvoyProxy:
+		if b, ok := objB.(*v1alpha1.EnvoyProxy); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
+	case *v1alpha1.BackendTrafficPolicy:
+		if b, ok := objB.(*v1alpha1.BackendTrafficPolicy); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
 	}
 	return false
 }
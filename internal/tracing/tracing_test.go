@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestSetup(t *testing.T) {
+	provider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	testCases := []struct {
+		name      string
+		cfg       *v1alpha1.EnvoyGatewayTracing
+		expectErr bool
+		recording bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:      "missing endpoint",
+			cfg:       &v1alpha1.EnvoyGatewayTracing{},
+			expectErr: true,
+		},
+		{
+			name:      "enabled",
+			cfg:       &v1alpha1.EnvoyGatewayTracing{Endpoint: "localhost:4318", Insecure: true},
+			recording: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), tc.cfg)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			_, span := Tracer().Start(context.Background(), "test")
+			require.Equal(t, tc.recording, span.IsRecording())
+			if tc.recording {
+				require.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+			}
+			span.End()
+			// Shut down without waiting for the export to the unreachable collector.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_ = shutdown(ctx)
+		})
+	}
+}
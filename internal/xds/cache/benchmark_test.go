@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/go-logr/logr"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// BenchmarkGenerateNewSnapshot measures the generation of the snapshots of a
+// connected node with an increasing number of clusters. Run it with:
+//
+//	make benchmark
+func BenchmarkGenerateNewSnapshot(b *testing.B) {
+	for _, clusters := range []int{100, 1000, 10000} {
+		clusters := clusters
+		b.Run(fmt.Sprintf("clusters-%d", clusters), func(b *testing.B) {
+			c := NewSnapshotCache(true, logr.Discard())
+			const irKey = "envoy-gateway-gateway-1"
+			if err := c.OnDeltaStreamOpen(context.Background(), 1, resourcev3.ClusterType); err != nil {
+				b.Fatal(err)
+			}
+			node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+			if err := c.OnStreamDeltaRequest(1, &discoveryv3.DeltaDiscoveryRequest{Node: node}); err != nil {
+				b.Fatal(err)
+			}
+
+			// The snapshots alternate between two sets of resources, so that
+			// each of them is pushed to the node.
+			var resources [2]types.XdsResources
+			for i := range resources {
+				timeouts := map[string]time.Duration{}
+				for j := 0; j < clusters; j++ {
+					timeouts[fmt.Sprintf("cluster-%d", j)] = time.Duration(i+1) * time.Second
+				}
+				resources[i] = testClusters(timeouts)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := c.GenerateNewSnapshot(irKey, resources[i%2]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
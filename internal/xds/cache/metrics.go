@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "envoy_gateway"
+	metricsSubsystem = "xds"
+	// typeURLLabel is the label holding the type URL of the xDS resources.
+	typeURLLabel = "type_url"
+)
+
+var (
+	// xdsConnectedNodes is the number of Envoy nodes connected to the xDS server.
+	xdsConnectedNodes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "connected_nodes",
+		Help:      "Number of Envoy nodes connected to the xDS server.",
+	})
+
+	// xdsPushes counts the responses sent to the Envoy nodes.
+	xdsPushes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "pushes_total",
+		Help:      "Total number of xDS responses sent to Envoy, by resource type.",
+	}, []string{typeURLLabel})
+
+	// xdsAcks counts the responses accepted by the Envoy nodes.
+	xdsAcks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "acks_total",
+		Help:      "Total number of xDS responses accepted by Envoy, by resource type.",
+	}, []string{typeURLLabel})
+
+	// xdsNacks counts the responses rejected by the Envoy nodes.
+	xdsNacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "nacks_total",
+		Help:      "Total number of xDS responses rejected by Envoy, by resource type.",
+	}, []string{typeURLLabel})
+)
+
+func init() {
+	// The metrics are served by the metrics endpoint of the controller manager.
+	metrics.Registry.MustRegister(xdsConnectedNodes, xdsPushes, xdsAcks, xdsNacks)
+}
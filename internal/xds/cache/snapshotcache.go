@@ -5,16 +5,20 @@ package cache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
-	"math"
-	"strconv"
+	"sort"
 	"sync"
 
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_types "github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	envoy_server_v3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	"github.com/go-logr/logr"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
 
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
@@ -23,6 +27,9 @@ var Hash = envoy_cache_v3.IDHash{}
 
 // SnapshotCacheWithCallbacks uses the go-control-plane SimpleCache to store snapshots of
 // Envoy resources, sliced by Node ID so that we can do incremental xDS properly.
+// Each Envoy proxy identifies the Gateway it serves by the cluster of its node, and
+// only receives the snapshot generated for that Gateway, so that a configuration
+// rejected by the proxies of a Gateway doesn't affect the proxies of the others.
 // It does this by also implementing callbacks to make sure that the cache is kept
 // up to date for each new node.
 //
@@ -34,6 +41,13 @@ type SnapshotCacheWithCallbacks interface {
 	envoy_cache_v3.SnapshotCache
 	envoy_server_v3.Callbacks
 	GenerateNewSnapshot(string, types.XdsResources) error
+	NodeSnapshots() []NodeSnapshot
+}
+
+// NodeSnapshot is the snapshot of a connected Envoy node.
+type NodeSnapshot struct {
+	Node     *envoy_config_core_v3.Node
+	Snapshot envoy_cache_v3.ResourceSnapshot
 }
 
 type snapshotMap map[string]*envoy_cache_v3.Snapshot
@@ -43,30 +57,31 @@ type nodeInfoMap map[int64]*envoy_config_core_v3.Node
 type snapshotcache struct {
 	envoy_cache_v3.SnapshotCache
 	streamIDNodeInfo nodeInfoMap
-	snapshotVersion  int64
 	lastSnapshot     snapshotMap
 	log              *LogrWrapper
 	mu               sync.Mutex
 }
 
 // GenerateNewSnapshot takes a table of resources (the output from the IR->xDS
-// translator) and updates the snapshot version.
+// translator) and updates the snapshot of the nodes of the provided IR key. The
+// version of each resource type is the hash of its resources, so regenerating
+// identical resources doesn't push anything to Envoy.
 func (s *snapshotcache) GenerateNewSnapshot(irKey string, resources types.XdsResources) error {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	version := s.newSnapshotVersion()
-
 	// Create a snapshot with all xDS resources.
-	snapshot, err := envoy_cache_v3.NewSnapshot(
-		version,
-		resources,
-	)
+	snapshot, err := newSnapshot(resources)
 	if err != nil {
 		return err
 	}
 
+	if last := s.lastSnapshot[irKey]; last != nil && sameVersions(last, snapshot) {
+		s.log.Debugf("Skipping the snapshot of %s, its resources are unchanged", irKey)
+		return nil
+	}
+
 	s.lastSnapshot[irKey] = snapshot
 
 	for _, node := range s.getNodeIDs(irKey) {
@@ -81,18 +96,78 @@ func (s *snapshotcache) GenerateNewSnapshot(irKey string, resources types.XdsRes
 
 }
 
-// newSnapshotVersion increments the current snapshotVersion
-// and returns as a string.
-func (s *snapshotcache) newSnapshotVersion() string {
+// NodeSnapshots returns the snapshots of the connected Envoy nodes, sorted by node ID.
+func (s *snapshotcache) NodeSnapshots() []NodeSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodes := map[string]*envoy_config_core_v3.Node{}
+	for _, node := range s.streamIDNodeInfo {
+		if node != nil {
+			nodes[node.Id] = node
+		}
+	}
+
+	snapshots := make([]NodeSnapshot, 0, len(nodes))
+	for id, node := range nodes {
+		snapshot, err := s.GetSnapshot(id)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, NodeSnapshot{Node: node, Snapshot: snapshot})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Node.Id < snapshots[j].Node.Id
+	})
+	return snapshots
+}
+
+// newSnapshot creates a snapshot of the provided resources, versioning each
+// resource type with the hash of its resources.
+func newSnapshot(resources types.XdsResources) (*envoy_cache_v3.Snapshot, error) {
+	snapshot := new(envoy_cache_v3.Snapshot)
+	for typ, items := range resources {
+		index := envoy_cache_v3.GetResponseType(typ)
+		if index == envoy_types.UnknownType {
+			return nil, fmt.Errorf("unknown resource type %s", typ)
+		}
+		version, err := resourcesVersion(items)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Resources[index] = envoy_cache_v3.NewResources(version, items)
+	}
+	return snapshot, nil
+}
 
-	// Reset the snapshotVersion if it ever hits max size.
-	if s.snapshotVersion == math.MaxInt64 {
-		s.snapshotVersion = 0
+// resourcesVersion returns the hash of the provided resources, which the translator
+// sorts by name so that identical resources have the same version.
+func resourcesVersion(items []envoy_types.Resource) (string, error) {
+	h := sha256.New()
+	for _, item := range items {
+		marshaled, err := envoy_cache_v3.MarshalResource(item)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal resource %s: %w", envoy_cache_v3.GetResourceName(item), err)
+		}
+		// Prefix each resource with its length, so that the boundaries between
+		// the resources are part of the hash.
+		var size [8]byte
+		binary.BigEndian.PutUint64(size[:], uint64(len(marshaled)))
+		h.Write(size[:])
+		h.Write(marshaled)
 	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
 
-	// Increment the snapshot version & return as string.
-	s.snapshotVersion++
-	return strconv.FormatInt(s.snapshotVersion, 10)
+// sameVersions returns whether the provided snapshots have the same versions for
+// every resource type.
+func sameVersions(a, b *envoy_cache_v3.Snapshot) bool {
+	for i := range a.Resources {
+		if a.Resources[i].Version != b.Resources[i].Version {
+			return false
+		}
+	}
+	return true
 }
 
 // NewSnapshotCache gives you a fresh SnapshotCache.
@@ -110,11 +185,12 @@ func NewSnapshotCache(ads bool, logger logr.Logger) SnapshotCacheWithCallbacks {
 }
 
 // getNodeIDs retrieves the node ids from the node info map whose
-// cluster field matches the ir key
+// cluster field matches the ir key. The streams that haven't sent their
+// first request yet have no node, and are skipped.
 func (s *snapshotcache) getNodeIDs(irKey string) []string {
 	var nodeIDs []string
 	for _, node := range s.streamIDNodeInfo {
-		if node.Cluster == irKey {
+		if node != nil && node.Cluster == irKey {
 			nodeIDs = append(nodeIDs, node.Id)
 		}
 	}
@@ -123,6 +199,55 @@ func (s *snapshotcache) getNodeIDs(irKey string) []string {
 
 }
 
+// removeStream forgets the node of the provided stream, and clears the snapshot of
+// the node once it has no stream left, so that the snapshots of the Envoy proxies
+// that went away aren't kept. A reconnecting proxy gets the last snapshot of its
+// Gateway on its first request.
+func (s *snapshotcache) removeStream(streamID int64) {
+	node := s.streamIDNodeInfo[streamID]
+	delete(s.streamIDNodeInfo, streamID)
+	if node == nil {
+		return
+	}
+
+	for _, other := range s.streamIDNodeInfo {
+		if other != nil && other.Id == node.Id {
+			return
+		}
+	}
+	s.log.Debugf("Clearing the snapshot of Node %s", node.Id)
+	s.ClearSnapshot(node.Id)
+	s.updateConnectedNodes()
+}
+
+// updateConnectedNodes updates the metric of the number of nodes connected to the
+// xDS server.
+func (s *snapshotcache) updateConnectedNodes() {
+	nodes := map[string]bool{}
+	for _, node := range s.streamIDNodeInfo {
+		if node != nil {
+			nodes[node.Id] = true
+		}
+	}
+	xdsConnectedNodes.Set(float64(len(nodes)))
+}
+
+// observeRequest records whether the request acknowledges the previous response
+// sent on its stream, and logs the details of the rejected responses.
+func (s *snapshotcache) observeRequest(nodeID, typeURL, responseNonce string, errorDetail *rpcstatus.Status) {
+	// The first request of a stream doesn't acknowledge any response.
+	if responseNonce == "" {
+		return
+	}
+	if errorDetail == nil {
+		xdsAcks.WithLabelValues(typeURL).Inc()
+		return
+	}
+	xdsNacks.WithLabelValues(typeURL).Inc()
+	s.log.Errorf("Envoy rejected the %s response with nonce %s sent to node %s, error code %d: %s",
+		typeURL, responseNonce, nodeID, errorDetail.Code, errorDetail.Message)
+}
+
 // OnStreamOpen and the other OnStream* functions implement the callbacks for the
 // state-of-the-world stream types.
 func (s *snapshotcache) OnStreamOpen(ctx context.Context, streamID int64, typeURL string) error {
@@ -137,11 +262,10 @@ func (s *snapshotcache) OnStreamOpen(ctx context.Context, streamID int64, typeUR
 
 func (s *snapshotcache) OnStreamClosed(streamID int64, node *envoy_config_core_v3.Node) {
 
-	// TODO: something with the node?
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.streamIDNodeInfo, streamID)
+	s.removeStream(streamID)
 
 }
 
@@ -161,9 +285,11 @@ func (s *snapshotcache) OnStreamRequest(streamID int64, req *envoy_service_disco
 		}
 		s.log.Debugf("First discovery request on stream %d, got nodeID %s", streamID, req.Node.Id)
 		s.streamIDNodeInfo[streamID] = req.Node
+		s.updateConnectedNodes()
 	}
 	nodeID := s.streamIDNodeInfo[streamID].Id
 	cluster := s.streamIDNodeInfo[streamID].Cluster
+	s.observeRequest(nodeID, req.GetTypeUrl(), req.ResponseNonce, req.ErrorDetail)
 
 	var nodeVersion string
 
@@ -193,8 +319,7 @@ func (s *snapshotcache) OnStreamRequest(streamID int64, req *envoy_service_disco
 	s.log.Debugf("Got a new request, version_info %s, response_nonce %s, nodeID %s, node_version %s", req.VersionInfo, req.ResponseNonce, nodeID, nodeVersion)
 
 	if status := req.ErrorDetail; status != nil {
-		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
+		// The details of the rejected update are logged by observeRequest.
 		errorCode = status.Code
 		errorMessage = status.Message
 	}
@@ -216,6 +341,7 @@ func (s *snapshotcache) OnStreamResponse(ctx context.Context, streamID int64, re
 	} else {
 		s.log.Debugf("Sending Response on stream %d to node %s", streamID, node.Id)
 	}
+	xdsPushes.WithLabelValues(resp.GetTypeUrl()).Inc()
 }
 
 // OnDeltaStreamOpen and the other OnDeltaStream*/OnStreamDelta* functions implement
@@ -234,11 +360,10 @@ func (s *snapshotcache) OnDeltaStreamOpen(ctx context.Context, streamID int64, t
 
 func (s *snapshotcache) OnDeltaStreamClosed(streamID int64, node *envoy_config_core_v3.Node) {
 
-	// TODO: something with the node?
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.streamIDNodeInfo, streamID)
+	s.removeStream(streamID)
 
 }
 
@@ -262,9 +387,11 @@ func (s *snapshotcache) OnStreamDeltaRequest(streamID int64, req *envoy_service_
 		}
 		s.log.Debugf("First incremental discovery request on stream %d, got nodeID %s", streamID, req.Node.Id)
 		s.streamIDNodeInfo[streamID] = req.Node
+		s.updateConnectedNodes()
 	}
 	nodeID := s.streamIDNodeInfo[streamID].Id
 	cluster := s.streamIDNodeInfo[streamID].Cluster
+	s.observeRequest(nodeID, req.GetTypeUrl(), req.ResponseNonce, req.ErrorDetail)
 
 	// If no snapshot has been written into the snapshotcache yet, we can't do anything, so don't mess with
 	// this request. go-control-plane will respond with an empty response, then send an update when a
@@ -290,8 +417,7 @@ func (s *snapshotcache) OnStreamDeltaRequest(streamID int64, req *envoy_service_
 	s.log.Debugf("Got a new request, response_nonce %s, nodeID %s, node_version %s",
 		req.ResponseNonce, nodeID, nodeVersion)
 	if status := req.ErrorDetail; status != nil {
-		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
+		// The details of the rejected update are logged by observeRequest.
 		errorCode = status.Code
 		errorMessage = status.Message
 	}
@@ -313,6 +439,7 @@ func (s *snapshotcache) OnStreamDeltaResponse(streamID int64, req *envoy_service
 	} else {
 		s.log.Debugf("Sending Incremental Response on stream %d to node %s", streamID, node.Id)
 	}
+	xdsPushes.WithLabelValues(resp.GetTypeUrl()).Inc()
 }
 
 func (s *snapshotcache) OnFetchRequest(ctx context.Context, req *envoy_service_discovery_v3.DiscoveryRequest) error {
@@ -0,0 +1,239 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	streamv3 "github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func testClusters(timeouts map[string]time.Duration) types.XdsResources {
+	var clusters []cachetypes.Resource
+	for name, timeout := range timeouts {
+		clusters = append(clusters, &clusterv3.Cluster{
+			Name:           name,
+			ConnectTimeout: durationpb.New(timeout),
+		})
+	}
+	return types.XdsResources{resourcev3.ClusterType: clusters}
+}
+
+// deltaResourceNames returns the names of the resources of the response received
+// on responses and the resource versions known by Envoy once it applies it, or nil
+// if no response was received.
+func deltaResourceNames(t *testing.T, responses chan cachev3.DeltaResponse) ([]string, map[string]string) {
+	t.Helper()
+	select {
+	case resp := <-responses:
+		out, err := resp.GetDeltaDiscoveryResponse()
+		require.NoError(t, err)
+		var names []string
+		for _, res := range out.Resources {
+			names = append(names, res.Name)
+		}
+		return names, resp.GetNextVersionMap()
+	case <-time.After(100 * time.Millisecond):
+		return nil, nil
+	}
+}
+
+func TestDeltaSnapshotPushesChangedResources(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	c := NewSnapshotCache(true, logger)
+
+	const irKey = "envoy-gateway-gateway-1"
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	require.NoError(t, c.GenerateNewSnapshot(irKey, testClusters(map[string]time.Duration{
+		"cluster-a": time.Second,
+		"cluster-b": time.Second,
+	})))
+
+	// The first request of the stream sets the snapshot of the node.
+	require.NoError(t, c.OnDeltaStreamOpen(context.Background(), 1, resourcev3.ClusterType))
+	req := &discoveryv3.DeltaDiscoveryRequest{Node: node, TypeUrl: resourcev3.ClusterType}
+	require.NoError(t, c.OnStreamDeltaRequest(1, req))
+
+	// All the resources are pushed to a new stream, with their version.
+	state := streamv3.NewStreamState(true, nil)
+	responses := make(chan cachev3.DeltaResponse, 1)
+	c.CreateDeltaWatch(req, state, responses)
+	names, versions := deltaResourceNames(t, responses)
+	require.ElementsMatch(t, []string{"cluster-a", "cluster-b"}, names)
+	require.Len(t, versions, 2)
+
+	// Nothing is pushed when the resources known by Envoy are unchanged.
+	state.SetResourceVersions(versions)
+	c.CreateDeltaWatch(req, state, responses)
+	names, _ = deltaResourceNames(t, responses)
+	require.Empty(t, names)
+
+	// Only the changed resource is pushed by the next snapshot.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, testClusters(map[string]time.Duration{
+		"cluster-a": 2 * time.Second,
+		"cluster-b": time.Second,
+	})))
+	names, newVersions := deltaResourceNames(t, responses)
+	require.Equal(t, []string{"cluster-a"}, names)
+	require.NotEqual(t, versions["cluster-a"], newVersions["cluster-a"])
+	require.Equal(t, versions["cluster-b"], newVersions["cluster-b"])
+}
+
+func TestSnapshotPerGateway(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	c := NewSnapshotCache(true, logger)
+
+	// Each proxy identifies its Gateway by the cluster of its node.
+	nodes := map[string]*corev3.Node{
+		"gateway-1": {Id: "envoy-1", Cluster: "gateway-1"},
+		"gateway-2": {Id: "envoy-2", Cluster: "gateway-2"},
+	}
+	for i, irKey := range []string{"gateway-1", "gateway-2"} {
+		streamID, node := int64(i+1), nodes[irKey]
+		require.NoError(t, c.GenerateNewSnapshot(irKey, testClusters(map[string]time.Duration{"cluster-" + irKey: time.Second})))
+		require.NoError(t, c.OnDeltaStreamOpen(context.Background(), streamID, resourcev3.ClusterType))
+		require.NoError(t, c.OnStreamDeltaRequest(streamID, &discoveryv3.DeltaDiscoveryRequest{Node: node}))
+	}
+
+	// The proxies only get the resources of their Gateway.
+	for irKey, node := range nodes {
+		snapshot, err := c.GetSnapshot(node.Id)
+		require.NoError(t, err)
+		clusters := snapshot.GetResources(resourcev3.ClusterType)
+		require.Len(t, clusters, 1)
+		require.Contains(t, clusters, "cluster-"+irKey)
+	}
+
+	// Updating a Gateway doesn't update the proxies of the other.
+	before, err := c.GetSnapshot("envoy-2")
+	require.NoError(t, err)
+	require.NoError(t, c.GenerateNewSnapshot("gateway-1", testClusters(map[string]time.Duration{"cluster-gateway-1": 2 * time.Second})))
+	after, err := c.GetSnapshot("envoy-2")
+	require.NoError(t, err)
+	require.Equal(t, before.GetVersion(resourcev3.ClusterType), after.GetVersion(resourcev3.ClusterType))
+
+	// The snapshot of a proxy is cleared once its stream is closed, and set
+	// again when it reconnects.
+	c.OnDeltaStreamClosed(1, nodes["gateway-1"])
+	c.OnDeltaStreamClosed(2, nodes["gateway-2"])
+	_, err = c.GetSnapshot("envoy-1")
+	require.Error(t, err)
+	_, err = c.GetSnapshot("envoy-2")
+	require.Error(t, err)
+	require.NoError(t, c.OnDeltaStreamOpen(context.Background(), 3, resourcev3.ClusterType))
+	require.NoError(t, c.OnStreamDeltaRequest(3, &discoveryv3.DeltaDiscoveryRequest{Node: nodes["gateway-1"]}))
+	snapshot, err := c.GetSnapshot("envoy-1")
+	require.NoError(t, err)
+	require.Contains(t, snapshot.GetResources(resourcev3.ClusterType), "cluster-gateway-1")
+}
+
+func TestSnapshotBeforeFirstRequest(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	c := NewSnapshotCache(true, logger)
+
+	// The streams have no node until their first request, and generating a
+	// snapshot in the meantime skips them.
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.ClusterType))
+	require.NoError(t, c.OnDeltaStreamOpen(context.Background(), 2, resourcev3.ClusterType))
+	require.NoError(t, c.GenerateNewSnapshot("gateway-1", testClusters(map[string]time.Duration{"cluster-a": time.Second})))
+
+	// The node gets the last snapshot of its Gateway on its first request.
+	node := &corev3.Node{Id: "envoy-1", Cluster: "gateway-1"}
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node}))
+	snapshot, err := c.GetSnapshot(node.Id)
+	require.NoError(t, err)
+	require.Contains(t, snapshot.GetResources(resourcev3.ClusterType), "cluster-a")
+	require.NoError(t, c.GenerateNewSnapshot("gateway-1", testClusters(map[string]time.Duration{"cluster-a": 2 * time.Second})))
+}
+
+func TestSnapshotVersionIsContentHash(t *testing.T) {
+	listeners := types.XdsResources{resourcev3.ListenerType: []cachetypes.Resource{&listenerv3.Listener{Name: "listener-1"}}}
+	resources := func(timeout time.Duration) types.XdsResources {
+		res := testClusters(map[string]time.Duration{"cluster-a": timeout})
+		res[resourcev3.ListenerType] = listeners[resourcev3.ListenerType]
+		return res
+	}
+
+	first, err := newSnapshot(resources(time.Second))
+	require.NoError(t, err)
+	same, err := newSnapshot(resources(time.Second))
+	require.NoError(t, err)
+	changed, err := newSnapshot(resources(2 * time.Second))
+	require.NoError(t, err)
+
+	// Identical resources have the same versions.
+	require.True(t, sameVersions(first, same))
+	require.NotEmpty(t, first.GetVersion(resourcev3.ClusterType))
+
+	// Changing a cluster only changes the version of the clusters.
+	require.False(t, sameVersions(first, changed))
+	require.NotEqual(t, first.GetVersion(resourcev3.ClusterType), changed.GetVersion(resourcev3.ClusterType))
+	require.Equal(t, first.GetVersion(resourcev3.ListenerType), changed.GetVersion(resourcev3.ListenerType))
+
+	// Regenerating identical resources keeps the current snapshot.
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	c := NewSnapshotCache(true, logger).(*snapshotcache)
+	const irKey = "envoy-gateway-gateway-1"
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources(time.Second)))
+	last := c.lastSnapshot[irKey]
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources(time.Second)))
+	require.Same(t, last, c.lastSnapshot[irKey])
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources(2*time.Second)))
+	require.NotSame(t, last, c.lastSnapshot[irKey])
+
+	_, err = newSnapshot(types.XdsResources{"unknown": nil})
+	require.Error(t, err)
+}
+
+func TestXdsMetrics(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	c := NewSnapshotCache(true, logger)
+
+	const irKey = "envoy-gateway-gateway-1"
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	require.NoError(t, c.GenerateNewSnapshot(irKey, testClusters(map[string]time.Duration{"cluster-a": time.Second})))
+	acks := testutil.ToFloat64(xdsAcks.WithLabelValues(resourcev3.ClusterType))
+	nacks := testutil.ToFloat64(xdsNacks.WithLabelValues(resourcev3.ClusterType))
+	pushes := testutil.ToFloat64(xdsPushes.WithLabelValues(resourcev3.ClusterType))
+
+	// The node is connected once it sends its first request.
+	require.NoError(t, c.OnDeltaStreamOpen(context.Background(), 1, resourcev3.ClusterType))
+	req := &discoveryv3.DeltaDiscoveryRequest{Node: node, TypeUrl: resourcev3.ClusterType}
+	require.NoError(t, c.OnStreamDeltaRequest(1, req))
+	require.Equal(t, float64(1), testutil.ToFloat64(xdsConnectedNodes))
+
+	c.OnStreamDeltaResponse(1, req, &discoveryv3.DeltaDiscoveryResponse{TypeUrl: resourcev3.ClusterType, Nonce: "1"})
+	require.Equal(t, pushes+1, testutil.ToFloat64(xdsPushes.WithLabelValues(resourcev3.ClusterType)))
+
+	// The requests following a response acknowledge or reject it.
+	require.NoError(t, c.OnStreamDeltaRequest(1, &discoveryv3.DeltaDiscoveryRequest{TypeUrl: resourcev3.ClusterType, ResponseNonce: "1"}))
+	require.Equal(t, acks+1, testutil.ToFloat64(xdsAcks.WithLabelValues(resourcev3.ClusterType)))
+	require.NoError(t, c.OnStreamDeltaRequest(1, &discoveryv3.DeltaDiscoveryRequest{
+		TypeUrl:       resourcev3.ClusterType,
+		ResponseNonce: "2",
+		ErrorDetail:   &status.Status{Code: 3, Message: "invalid cluster"},
+	}))
+	require.Equal(t, nacks+1, testutil.ToFloat64(xdsNacks.WithLabelValues(resourcev3.ClusterType)))
+
+	c.OnDeltaStreamClosed(1, node)
+	require.Equal(t, float64(0), testutil.ToFloat64(xdsConnectedNodes))
+}
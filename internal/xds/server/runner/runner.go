@@ -5,16 +5,23 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"strconv"
+	"sync/atomic"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/tracing"
 	"github.com/envoyproxy/gateway/internal/xds/cache"
 	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 	controlplane_service_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
@@ -52,8 +59,19 @@ type Config struct {
 
 type Runner struct {
 	Config
+	// state is the serving state of the xDS server, accessed atomically.
+	state int32
 }
 
+const (
+	// xdsServerStarting is the state of the xDS server until it listens.
+	xdsServerStarting int32 = iota
+	// xdsServerServing is the state of the xDS server listening for connections.
+	xdsServerServing
+	// xdsServerStopped is the state of the xDS server once it stopped serving.
+	xdsServerStopped
+)
+
 func New(cfg *Config) *Runner {
 	return &Runner{Config: *cfg}
 }
@@ -64,7 +82,14 @@ func (r *Runner) Name() string {
 
 // Start starts the xds-server runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	logger, err := r.ComponentLogger(v1alpha1.LogComponentXds)
+	if err != nil {
+		return err
+	}
+	r.Logger = logger.WithValues("runner", r.Name())
+	// Envoy fetches the resources on a single delta ADS stream, so that only
+	// the changed resources are pushed and their updates are ordered.
+	r.cache = cache.NewSnapshotCache(true, r.Logger)
 	go r.subscribeAndTranslate(ctx)
 	go r.setupXdsServer(ctx)
 	r.Logger.Info("started")
@@ -76,15 +101,18 @@ func (r *Runner) setupXdsServer(ctx context.Context) {
 	cfg := r.tlsConfig(xdsTLSCertFilename, xdsTLSKeyFilename, xdsTLSCaFilename)
 	r.grpc = grpc.NewServer(grpc.Creds(credentials.NewTLS(cfg)))
 
-	r.cache = cache.NewSnapshotCache(false, r.Logger)
 	registerServer(controlplane_server_v3.NewServer(ctx, r.cache, r.cache), r.grpc)
 
 	addr := net.JoinHostPort(XdsServerAddress, strconv.Itoa(XdsServerPort))
 	l, err := net.Listen("tcp", addr)
 	if err != nil {
-		r.Logger.Error(err, "failed to listen on address", addr)
+		r.Logger.Error(err, "failed to listen on address", "address", addr)
+		atomic.StoreInt32(&r.state, xdsServerStopped)
+		return
 	}
+	atomic.StoreInt32(&r.state, xdsServerServing)
 	err = r.grpc.Serve(l)
+	atomic.StoreInt32(&r.state, xdsServerStopped)
 	if err != nil {
 		r.Logger.Error(err, "failed to start grpc based xds server")
 	}
@@ -98,6 +126,29 @@ func (r *Runner) setupXdsServer(ctx context.Context) {
 	r.grpc.Stop()
 }
 
+// ReadyzCheck returns an error if the xDS server isn't listening for connections.
+func (r *Runner) ReadyzCheck(_ *http.Request) error {
+	if atomic.LoadInt32(&r.state) != xdsServerServing {
+		return errors.New("xds server is not listening")
+	}
+	return nil
+}
+
+// HealthzCheck returns an error if the xDS server stopped serving, so that the
+// Envoy Gateway container is restarted.
+func (r *Runner) HealthzCheck(_ *http.Request) error {
+	if atomic.LoadInt32(&r.state) == xdsServerStopped {
+		return errors.New("xds server stopped serving")
+	}
+	return nil
+}
+
+// NodeSnapshots returns the xDS snapshots of the connected Envoy nodes. The runner
+// must be started.
+func (r *Runner) NodeSnapshots() []cache.NodeSnapshot {
+	return r.cache.NodeSnapshots()
+}
+
 // registerServer registers the given xDS protocol Server with the gRPC
 // runtime.
 func registerServer(srv controlplane_server_v3.Server, g *grpc.Server) {
@@ -118,6 +169,10 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 			key := update.Key
 			val := update.Value
 
+			_, span := tracing.Tracer().Start(ctx, "xds.snapshot", trace.WithAttributes(
+				attribute.String("key", key),
+				attribute.Bool("delete", update.Delete),
+			))
 			var err error
 			if update.Delete {
 				err = r.cache.GenerateNewSnapshot(key, nil)
@@ -125,6 +180,7 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 				// Update snapshot cache
 				err = r.cache.GenerateNewSnapshot(key, val.XdsResources)
 			}
+			tracing.EndSpan(span, err)
 			if err != nil {
 				r.Logger.Error(err, "failed to generate a snapshot")
 			}
@@ -177,3 +177,19 @@ func peekError(conn net.Conn) error {
 	}
 	return nil
 }
+
+func TestHealthChecks(t *testing.T) {
+	r := New(&Config{})
+
+	// The xDS server is alive but not ready until it listens.
+	require.NoError(t, r.HealthzCheck(nil))
+	require.Error(t, r.ReadyzCheck(nil))
+
+	r.state = xdsServerServing
+	require.NoError(t, r.HealthzCheck(nil))
+	require.NoError(t, r.ReadyzCheck(nil))
+
+	r.state = xdsServerStopped
+	require.Error(t, r.HealthzCheck(nil))
+	require.Error(t, r.ReadyzCheck(nil))
+}
@@ -0,0 +1,142 @@
+package translator
+
+import (
+	"fmt"
+	"time"
+
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	fileaccesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
+	grpcaccesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// tcpGRPCAccessLogName is the name of the access logger streaming the access
+	// logs of TCP connections to an access log service.
+	tcpGRPCAccessLogName = "envoy.access_loggers.tcp_grpc"
+)
+
+// buildXdsAccessLogs builds an access log per sink of the provided access logs of
+// a listener, or returns nil if the listener writes no access logs. The access logs
+// of TCP listeners are streamed to the access log services as TCP entries.
+func buildXdsAccessLogs(irAccessLog *ir.AccessLog, tcp bool) ([]*accesslog.AccessLog, error) {
+	if irAccessLog == nil {
+		return nil, nil
+	}
+
+	accessLogs := make([]*accesslog.AccessLog, 0, len(irAccessLog.Files)+len(irAccessLog.ALS))
+	for _, path := range irAccessLog.Files {
+		fileAccessLog := &fileaccesslog.FileAccessLog{
+			Path: path,
+		}
+		// The default Envoy format is used if no format is set.
+		if irAccessLog.Text != nil || len(irAccessLog.JSON) > 0 {
+			logFormat, err := buildXdsSubstitutionFormatString(irAccessLog.Text, irAccessLog.JSON)
+			if err != nil {
+				return nil, err
+			}
+			fileAccessLog.AccessLogFormat = &fileaccesslog.FileAccessLog_LogFormat{
+				LogFormat: logFormat,
+			}
+		}
+
+		accessLog, err := buildXdsAccessLog(wellknown.FileAccessLog, fileAccessLog)
+		if err != nil {
+			return nil, err
+		}
+		accessLogs = append(accessLogs, accessLog)
+	}
+
+	for _, als := range irAccessLog.ALS {
+		commonConfig := &grpcaccesslog.CommonGrpcAccessLogConfig{
+			LogName: als.LogName,
+			GrpcService: &core.GrpcService{
+				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{
+						ClusterName: getALSClusterName(als),
+					},
+				},
+			},
+			TransportApiVersion: core.ApiVersion_V3,
+		}
+		if als.BufferSize != nil {
+			commonConfig.BufferSizeBytes = wrapperspb.UInt32(*als.BufferSize)
+		}
+		if als.BufferFlushInterval != nil {
+			commonConfig.BufferFlushInterval = durationpb.New(als.BufferFlushInterval.Duration)
+		}
+
+		var accessLog *accesslog.AccessLog
+		var err error
+		if tcp {
+			accessLog, err = buildXdsAccessLog(tcpGRPCAccessLogName, &grpcaccesslog.TcpGrpcAccessLogConfig{
+				CommonConfig: commonConfig,
+			})
+		} else {
+			accessLog, err = buildXdsAccessLog(wellknown.HTTPGRPCAccessLog, &grpcaccesslog.HttpGrpcAccessLogConfig{
+				CommonConfig: commonConfig,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		accessLogs = append(accessLogs, accessLog)
+	}
+
+	return accessLogs, nil
+}
+
+func buildXdsAccessLog(name string, config proto.Message) (*accesslog.AccessLog, error) {
+	configAny, err := anypb.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return &accesslog.AccessLog{
+		Name:       name,
+		ConfigType: &accesslog.AccessLog_TypedConfig{TypedConfig: configAny},
+	}, nil
+}
+
+// buildXdsALSCluster builds the cluster of the provided access log service.
+func buildXdsALSCluster(als *ir.ALSAccessLog) (*cluster.Cluster, error) {
+	options, err := buildXdsHTTP2ProtocolOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterName := getALSClusterName(als)
+	return &cluster.Cluster{
+		Name:                 clusterName,
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
+		DnsLookupFamily:      cluster.Cluster_V4_PREFERRED,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*endpoint.LbEndpoint{{
+					HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+						Endpoint: &endpoint.Endpoint{
+							Address: buildXdsSocketAddress(als.Host, als.Port, core.SocketAddress_TCP),
+						},
+					},
+				}},
+			}},
+		},
+		TypedExtensionProtocolOptions: options,
+	}, nil
+}
+
+// getALSClusterName returns the name of the cluster of the provided access log
+// service, shared by the listeners streaming access logs to the same service.
+func getALSClusterName(als *ir.ALSAccessLog) string {
+	return fmt.Sprintf("als_%s_%d", als.Host, als.Port)
+}
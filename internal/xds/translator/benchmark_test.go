@@ -0,0 +1,63 @@
+package translator
+
+import (
+	"fmt"
+	"testing"
+
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// BenchmarkTranslate measures the translation of a listener with an increasing
+// number of routes. Run it with:
+//
+//	make benchmark
+func BenchmarkTranslate(b *testing.B) {
+	for _, routes := range []int{100, 1000, 10000} {
+		routes := routes
+		b.Run(fmt.Sprintf("routes-%d", routes), func(b *testing.B) {
+			xdsIR := benchmarkXdsIR(routes)
+			tCtx, err := new(Translator).Translate(xdsIR)
+			if err != nil {
+				b.Fatal(err)
+			}
+			// Each route has its own cluster.
+			if got := len(tCtx.XdsResources[resource.ClusterType]); got != routes {
+				b.Fatalf("translated %d clusters, expected %d", got, routes)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := new(Translator).Translate(xdsIR); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkXdsIR returns an IR with a HTTP listener, and the provided number of
+// routes, each with its own hostname, path and destination.
+func benchmarkXdsIR(routes int) *ir.Xds {
+	listener := &ir.HTTPListener{
+		Name:      "first-listener",
+		Address:   "0.0.0.0",
+		Port:      10080,
+		Hostnames: []string{"*"},
+	}
+	for i := 0; i < routes; i++ {
+		prefix := fmt.Sprintf("/route-%d", i)
+		listener.Routes = append(listener.Routes, &ir.HTTPRoute{
+			Name:      fmt.Sprintf("route-%d", i),
+			Hostname:  fmt.Sprintf("route-%d.example.com", i),
+			PathMatch: &ir.StringMatch{Prefix: &prefix},
+			Destinations: []*ir.RouteDestination{{
+				Host: "1.2.3.4",
+				Port: 50000,
+			}},
+		})
+	}
+	return &ir.Xds{HTTP: []*ir.HTTPListener{listener}}
+}
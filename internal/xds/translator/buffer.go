@@ -0,0 +1,68 @@
+package translator
+
+import (
+	buffer "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// bufferFilterName is the name of the buffer HTTP filter.
+	bufferFilterName = "envoy.filters.http.buffer"
+)
+
+// listenerContainsBuffer returns true if the request bodies of any route of the
+// provided listener are buffered.
+func listenerContainsBuffer(httpListener *ir.HTTPListener) bool {
+	for _, route := range httpListener.Routes {
+		if route.Buffer != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildXdsBufferFilter builds the buffer HTTP filter. The filter applies to all the
+// routes of the listener, so it's disabled on the routes not buffering requests, and
+// the limit of the filter is overridden by the routes buffering requests.
+func buildXdsBufferFilter(httpListener *ir.HTTPListener) (*hcm.HttpFilter, error) {
+	var maxRequestBytes uint32
+	for _, route := range httpListener.Routes {
+		if route.Buffer != nil && route.Buffer.MaxRequestBytes > maxRequestBytes {
+			maxRequestBytes = route.Buffer.MaxRequestBytes
+		}
+	}
+
+	bufferAny, err := anypb.New(&buffer.Buffer{
+		MaxRequestBytes: wrapperspb.UInt32(maxRequestBytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       bufferFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: bufferAny},
+	}, nil
+}
+
+// buildXdsBufferPerRouteConfig builds the buffer configuration of a route, which
+// disables the filter if the request bodies of the route aren't buffered.
+func buildXdsBufferPerRouteConfig(irBuffer *ir.Buffer) (*anypb.Any, error) {
+	if irBuffer == nil {
+		return anypb.New(&buffer.BufferPerRoute{
+			Override: &buffer.BufferPerRoute_Disabled{Disabled: true},
+		})
+	}
+
+	return anypb.New(&buffer.BufferPerRoute{
+		Override: &buffer.BufferPerRoute_Buffer{
+			Buffer: &buffer.Buffer{
+				MaxRequestBytes: wrapperspb.UInt32(irBuffer.MaxRequestBytes),
+			},
+		},
+	})
+}
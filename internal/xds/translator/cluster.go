@@ -1,42 +1,265 @@
 package translator
 
 import (
+	"strings"
 	"time"
 
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	httpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
-func buildXdsCluster(routeName string, destinations []*ir.RouteDestination) (*cluster.Cluster, error) {
+// xdsClusterArgs holds the inputs of a cluster built for an IR route or TCP listener.
+type xdsClusterArgs struct {
+	// name is the name of the IR route or TCP listener.
+	name           string
+	destinations   []*ir.RouteDestination
+	loadBalancer   *ir.LoadBalancer
+	circuitBreaker *ir.CircuitBreaker
+	healthCheck    *ir.HealthCheck
+	backendTLS     *ir.BackendTLSConfig
+	tcpKeepalive   *ir.TCPKeepalive
+	// http1 holds the HTTP/1.1 settings of the listener of the IR route, if any.
+	http1 *ir.HTTP1Settings
+}
+
+// systemCACertificatePath is the path of the system CA bundle in the Envoy proxy image,
+// used to verify the certificates of destinations when no CA bundle is configured.
+const systemCACertificatePath = "/etc/ssl/certs/ca-certificates.crt"
+
+func buildXdsCluster(args *xdsClusterArgs) (*cluster.Cluster, error) {
 	localities := make([]*endpoint.LocalityLbEndpoints, 0, 1)
 	locality := &endpoint.LocalityLbEndpoints{
 		Locality:    &core.Locality{},
-		LbEndpoints: buildXdsEndpoints(destinations),
+		LbEndpoints: buildXdsEndpoints(args.destinations),
 		Priority:    0,
 		// Each locality gets the same weight 1. There is a single locality
 		// per priority, so the weight value does not really matter, but some
 		// load balancers need the value to be set.
 		LoadBalancingWeight: &wrapperspb.UInt32Value{Value: 1}}
 	localities = append(localities, locality)
-	clusterName := getXdsClusterName(routeName)
-	return &cluster.Cluster{
+	clusterName := getXdsClusterName(args.name)
+	xdsCluster := &cluster.Cluster{
 		Name:                 clusterName,
 		ConnectTimeout:       durationpb.New(5 * time.Second),
 		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STATIC},
-		LbPolicy:             cluster.Cluster_ROUND_ROBIN,
+		LbPolicy:             buildXdsLbPolicy(args.loadBalancer),
 		LoadAssignment:       &endpoint.ClusterLoadAssignment{ClusterName: clusterName, Endpoints: localities},
-		DnsLookupFamily:      cluster.Cluster_V4_ONLY,
+		DnsLookupFamily:      cluster.Cluster_V4_PREFERRED,
 		CommonLbConfig: &cluster.Cluster_CommonLbConfig{
 			LocalityConfigSpecifier: &cluster.Cluster_CommonLbConfig_LocalityWeightedLbConfig_{
 				LocalityWeightedLbConfig: &cluster.Cluster_CommonLbConfig_LocalityWeightedLbConfig{}}},
 		OutlierDetection: &cluster.OutlierDetection{},
+	}
+
+	if lb := args.loadBalancer; lb != nil && lb.LeastRequest != nil && lb.LeastRequest.ChoiceCount != nil {
+		xdsCluster.LbConfig = &cluster.Cluster_LeastRequestLbConfig_{
+			LeastRequestLbConfig: &cluster.Cluster_LeastRequestLbConfig{
+				ChoiceCount: &wrapperspb.UInt32Value{Value: *lb.LeastRequest.ChoiceCount},
+			},
+		}
+	}
+
+	if isHTTP2Destinations(args.destinations) {
+		options, err := buildXdsHTTP2ProtocolOptions()
+		if err != nil {
+			return nil, err
+		}
+		xdsCluster.TypedExtensionProtocolOptions = options
+	} else if args.http1 != nil {
+		options, err := buildXdsHTTP1ClusterProtocolOptions(args.http1)
+		if err != nil {
+			return nil, err
+		}
+		xdsCluster.TypedExtensionProtocolOptions = options
+	}
+
+	if args.backendTLS != nil {
+		tSocket, err := buildXdsUpstreamTLSSocket(args.name, args.backendTLS)
+		if err != nil {
+			return nil, err
+		}
+		xdsCluster.TransportSocket = tSocket
+	}
+
+	if args.tcpKeepalive != nil {
+		xdsCluster.UpstreamConnectionOptions = buildXdsUpstreamConnectionOptions(args.tcpKeepalive)
+	}
+
+	if args.circuitBreaker != nil {
+		xdsCluster.CircuitBreakers = buildXdsCircuitBreakers(args.circuitBreaker)
+	}
+	if args.healthCheck != nil && args.healthCheck.Active != nil {
+		xdsCluster.HealthChecks = []*core.HealthCheck{buildXdsHealthCheck(args.healthCheck.Active)}
+	}
+	if args.healthCheck != nil && args.healthCheck.Passive != nil {
+		xdsCluster.OutlierDetection = buildXdsOutlierDetection(args.healthCheck.Passive)
+	}
+
+	return xdsCluster, nil
+}
+
+func buildXdsUpstreamConnectionOptions(keepalive *ir.TCPKeepalive) *cluster.UpstreamConnectionOptions {
+	tcpKeepalive := &core.TcpKeepalive{}
+	if keepalive.Probes != nil {
+		tcpKeepalive.KeepaliveProbes = wrapperspb.UInt32(*keepalive.Probes)
+	}
+	if keepalive.IdleTime != nil {
+		tcpKeepalive.KeepaliveTime = wrapperspb.UInt32(*keepalive.IdleTime)
+	}
+	if keepalive.Interval != nil {
+		tcpKeepalive.KeepaliveInterval = wrapperspb.UInt32(*keepalive.Interval)
+	}
+	return &cluster.UpstreamConnectionOptions{TcpKeepalive: tcpKeepalive}
+}
+
+// isHTTP2Destinations returns true if all destinations are connected to using HTTP/2.
+// The destinations share a single cluster, so HTTP/1.1 is used unless every destination
+// supports HTTP/2.
+func isHTTP2Destinations(destinations []*ir.RouteDestination) bool {
+	if len(destinations) == 0 {
+		return false
+	}
+	for _, destination := range destinations {
+		if destination.Protocol != ir.HTTP2 {
+			return false
+		}
+	}
+	return true
+}
+
+func buildXdsHTTP2ProtocolOptions() (map[string]*anypb.Any, error) {
+	options := &httpv3.HttpProtocolOptions{
+		UpstreamProtocolOptions: &httpv3.HttpProtocolOptions_ExplicitHttpConfig_{
+			ExplicitHttpConfig: &httpv3.HttpProtocolOptions_ExplicitHttpConfig{
+				ProtocolConfig: &httpv3.HttpProtocolOptions_ExplicitHttpConfig_Http2ProtocolOptions{
+					Http2ProtocolOptions: &core.Http2ProtocolOptions{},
+				},
+			},
+		},
+	}
+	optionsAny, err := anypb.New(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]*anypb.Any{
+		"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": optionsAny,
 	}, nil
+}
 
+func buildXdsUpstreamTLSSocket(name string, tlsConfig *ir.BackendTLSConfig) (*core.TransportSocket, error) {
+	validationCtx := &tls.CertificateValidationContext{
+		MatchTypedSubjectAltNames: buildXdsSubjectAltNameMatchers(tlsConfig.SubjectAltNames),
+	}
+
+	tlsCtx := &tls.UpstreamTlsContext{
+		Sni:              tlsConfig.SNI,
+		CommonTlsContext: &tls.CommonTlsContext{},
+	}
+	if len(tlsConfig.CACertificate) > 0 {
+		// The CA bundle is delivered to Envoy via SDS, so that it can be
+		// rotated without updating the cluster.
+		tlsCtx.CommonTlsContext.ValidationContextType = &tls.CommonTlsContext_CombinedValidationContext{
+			CombinedValidationContext: &tls.CommonTlsContext_CombinedCertificateValidationContext{
+				DefaultValidationContext: validationCtx,
+				ValidationContextSdsSecretConfig: &tls.SdsSecretConfig{
+					Name:      getXdsCASecretName(name),
+					SdsConfig: makeConfigSource(),
+				},
+			},
+		}
+	} else {
+		validationCtx.TrustedCa = &core.DataSource{
+			Specifier: &core.DataSource_Filename{Filename: systemCACertificatePath},
+		}
+		tlsCtx.CommonTlsContext.ValidationContextType = &tls.CommonTlsContext_ValidationContext{
+			ValidationContext: validationCtx,
+		}
+	}
+
+	tlsCtxAny, err := anypb.New(tlsCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &core.TransportSocket_TypedConfig{
+			TypedConfig: tlsCtxAny,
+		},
+	}, nil
+}
+
+// buildXdsSubjectAltNameMatchers matches names containing a scheme, e.g. SPIFFE IDs,
+// against the URI subject alternative names of a certificate and all other names
+// against the DNS subject alternative names.
+func buildXdsSubjectAltNameMatchers(names []string) []*tls.SubjectAltNameMatcher {
+	var matchers []*tls.SubjectAltNameMatcher
+	for _, name := range names {
+		sanType := tls.SubjectAltNameMatcher_DNS
+		if strings.Contains(name, "://") {
+			sanType = tls.SubjectAltNameMatcher_URI
+		}
+		matchers = append(matchers, &tls.SubjectAltNameMatcher{
+			SanType: sanType,
+			Matcher: &matcher.StringMatcher{
+				MatchPattern: &matcher.StringMatcher_Exact{Exact: name},
+			},
+		})
+	}
+	return matchers
+}
+
+func buildXdsCircuitBreakers(circuitBreaker *ir.CircuitBreaker) *cluster.CircuitBreakers {
+	thresholds := &cluster.CircuitBreakers_Thresholds{
+		Priority: core.RoutingPriority_DEFAULT,
+	}
+	if circuitBreaker.MaxConnections != nil {
+		thresholds.MaxConnections = &wrapperspb.UInt32Value{Value: *circuitBreaker.MaxConnections}
+	}
+	if circuitBreaker.MaxPendingRequests != nil {
+		thresholds.MaxPendingRequests = &wrapperspb.UInt32Value{Value: *circuitBreaker.MaxPendingRequests}
+	}
+	if circuitBreaker.MaxParallelRequests != nil {
+		thresholds.MaxRequests = &wrapperspb.UInt32Value{Value: *circuitBreaker.MaxParallelRequests}
+	}
+	if circuitBreaker.MaxParallelRetries != nil {
+		thresholds.MaxRetries = &wrapperspb.UInt32Value{Value: *circuitBreaker.MaxParallelRetries}
+	}
+
+	return &cluster.CircuitBreakers{
+		Thresholds: []*cluster.CircuitBreakers_Thresholds{thresholds},
+	}
+}
+
+// buildXdsLbPolicy returns the cluster load balancing policy of the provided
+// IR load balancer, which defaults to round robin.
+func buildXdsLbPolicy(lb *ir.LoadBalancer) cluster.Cluster_LbPolicy {
+	switch {
+	case lb == nil:
+		return cluster.Cluster_ROUND_ROBIN
+	case lb.LeastRequest != nil:
+		return cluster.Cluster_LEAST_REQUEST
+	case lb.Random != nil:
+		return cluster.Cluster_RANDOM
+	case lb.ConsistentHash != nil && lb.ConsistentHash.Algorithm == ir.MaglevAlgorithm:
+		return cluster.Cluster_MAGLEV
+	case lb.ConsistentHash != nil:
+		return cluster.Cluster_RING_HASH
+	default:
+		return cluster.Cluster_ROUND_ROBIN
+	}
 }
 
 func buildXdsEndpoints(destinations []*ir.RouteDestination) []*endpoint.LbEndpoint {
@@ -66,3 +289,50 @@ func buildXdsEndpoints(destinations []*ir.RouteDestination) []*endpoint.LbEndpoi
 	}
 	return endpoints
 }
+
+func buildXdsHealthCheck(healthCheck *ir.ActiveHealthCheck) *core.HealthCheck {
+	ret := &core.HealthCheck{
+		Timeout:            durationpb.New(healthCheck.Timeout.Duration),
+		Interval:           durationpb.New(healthCheck.Interval.Duration),
+		HealthyThreshold:   &wrapperspb.UInt32Value{Value: healthCheck.HealthyThreshold},
+		UnhealthyThreshold: &wrapperspb.UInt32Value{Value: healthCheck.UnhealthyThreshold},
+	}
+
+	switch {
+	case healthCheck.HTTP != nil:
+		httpHealthCheck := &core.HealthCheck_HttpHealthCheck{
+			Path: healthCheck.HTTP.Path,
+		}
+		for _, status := range healthCheck.HTTP.ExpectedStatuses {
+			// Ranges are half-open, so each status code is its own range.
+			httpHealthCheck.ExpectedStatuses = append(httpHealthCheck.ExpectedStatuses, &xdstype.Int64Range{
+				Start: int64(status),
+				End:   int64(status) + 1,
+			})
+		}
+		ret.HealthChecker = &core.HealthCheck_HttpHealthCheck_{HttpHealthCheck: httpHealthCheck}
+	case healthCheck.TCP != nil:
+		ret.HealthChecker = &core.HealthCheck_TcpHealthCheck_{TcpHealthCheck: &core.HealthCheck_TcpHealthCheck{}}
+	}
+
+	return ret
+}
+
+func buildXdsOutlierDetection(outlierDetection *ir.OutlierDetection) *cluster.OutlierDetection {
+	ret := &cluster.OutlierDetection{}
+
+	if outlierDetection.Consecutive5xxErrors != nil {
+		ret.Consecutive_5Xx = &wrapperspb.UInt32Value{Value: *outlierDetection.Consecutive5xxErrors}
+	}
+	if outlierDetection.Interval != nil {
+		ret.Interval = durationpb.New(outlierDetection.Interval.Duration)
+	}
+	if outlierDetection.BaseEjectionTime != nil {
+		ret.BaseEjectionTime = durationpb.New(outlierDetection.BaseEjectionTime.Duration)
+	}
+	if outlierDetection.MaxEjectionPercent != nil {
+		ret.MaxEjectionPercent = &wrapperspb.UInt32Value{Value: *outlierDetection.MaxEjectionPercent}
+	}
+
+	return ret
+}
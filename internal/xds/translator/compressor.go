@@ -0,0 +1,96 @@
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	brotli "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/brotli/compressor/v3"
+	gzip "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
+	compressor "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// compressorFilterName is the name of the compressor HTTP filter, suffixed
+	// with the algorithm since a filter is added per algorithm.
+	compressorFilterName = "envoy.filters.http.compressor"
+)
+
+// buildXdsCompressorFilters builds a compressor HTTP filter per compression algorithm,
+// in order of preference. When a client accepts several algorithms equally, the
+// first filter compresses the response.
+func buildXdsCompressorFilters(compression *ir.Compression) ([]*hcm.HttpFilter, error) {
+	filters := make([]*hcm.HttpFilter, 0, len(compression.Algorithms))
+	for _, algorithm := range compression.Algorithms {
+		library, err := buildXdsCompressorLibrary(algorithm, compression.Level)
+		if err != nil {
+			return nil, err
+		}
+
+		commonConfig := &compressor.Compressor_CommonDirectionConfig{
+			ContentType: compression.ContentTypes,
+		}
+		if compression.MinContentLength != nil {
+			commonConfig.MinContentLength = wrapperspb.UInt32(*compression.MinContentLength)
+		}
+
+		compressorAny, err := anypb.New(&compressor.Compressor{
+			CompressorLibrary: library,
+			ResponseDirectionConfig: &compressor.Compressor_ResponseDirectionConfig{
+				CommonConfig: commonConfig,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, &hcm.HttpFilter{
+			Name:       fmt.Sprintf("%s.%s", compressorFilterName, strings.ToLower(string(algorithm))),
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: compressorAny},
+		})
+	}
+	return filters, nil
+}
+
+// buildXdsCompressorLibrary builds the compressor library of the provided algorithm,
+// mapping the compression level to the levels of the algorithm.
+func buildXdsCompressorLibrary(algorithm ir.CompressionAlgorithm, level ir.CompressionLevel) (*core.TypedExtensionConfig, error) {
+	var (
+		name    string
+		library proto.Message
+	)
+	switch algorithm {
+	case ir.CompressionAlgorithmGzip:
+		gzipLibrary := &gzip.Gzip{}
+		switch level {
+		case ir.CompressionLevelBestSpeed:
+			gzipLibrary.CompressionLevel = gzip.Gzip_BEST_SPEED
+		case ir.CompressionLevelBestCompression:
+			gzipLibrary.CompressionLevel = gzip.Gzip_BEST_COMPRESSION
+		}
+		name, library = "envoy.compression.gzip.compressor", gzipLibrary
+	case ir.CompressionAlgorithmBrotli:
+		brotliLibrary := &brotli.Brotli{}
+		switch level {
+		case ir.CompressionLevelBestSpeed:
+			brotliLibrary.Quality = wrapperspb.UInt32(0)
+		case ir.CompressionLevelBestCompression:
+			brotliLibrary.Quality = wrapperspb.UInt32(11)
+		}
+		name, library = "envoy.compression.brotli.compressor", brotliLibrary
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm %s", algorithm)
+	}
+
+	libraryAny, err := anypb.New(library)
+	if err != nil {
+		return nil, err
+	}
+	return &core.TypedExtensionConfig{Name: name, TypedConfig: libraryAny}, nil
+}
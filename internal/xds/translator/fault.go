@@ -0,0 +1,72 @@
+package translator
+
+import (
+	commonfault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
+	fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// faultFilterName is the name of the fault injection HTTP filter.
+	faultFilterName = "envoy.filters.http.fault"
+)
+
+// listenerContainsFaultInjection returns true if faults are injected into the
+// requests of any route of the provided listener.
+func listenerContainsFaultInjection(httpListener *ir.HTTPListener) bool {
+	for _, route := range httpListener.Routes {
+		if route.FaultInjection != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildXdsFaultFilter builds the fault injection HTTP filter. The filter injects no
+// faults, so it only affects the routes configuring some.
+func buildXdsFaultFilter() (*hcm.HttpFilter, error) {
+	faultAny, err := anypb.New(&fault.HTTPFault{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       faultFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: faultAny},
+	}, nil
+}
+
+// buildXdsFaultPerRouteConfig builds the fault injection configuration of a route.
+func buildXdsFaultPerRouteConfig(faultInjection *ir.FaultInjection) (*anypb.Any, error) {
+	httpFault := &fault.HTTPFault{}
+	if delay := faultInjection.Delay; delay != nil {
+		httpFault.Delay = &commonfault.FaultDelay{
+			FaultDelaySecifier: &commonfault.FaultDelay_FixedDelay{
+				FixedDelay: durationpb.New(delay.FixedDelay.Duration),
+			},
+			Percentage: buildXdsFractionalPercent(delay.Percentage),
+		}
+	}
+	if abort := faultInjection.Abort; abort != nil {
+		httpFault.Abort = &fault.FaultAbort{
+			ErrorType: &fault.FaultAbort_HttpStatus{
+				HttpStatus: abort.HTTPStatus,
+			},
+			Percentage: buildXdsFractionalPercent(abort.Percentage),
+		}
+	}
+
+	return anypb.New(httpFault)
+}
+
+func buildXdsFractionalPercent(percentage uint32) *xdstype.FractionalPercent {
+	return &xdstype.FractionalPercent{
+		Numerator:   percentage,
+		Denominator: xdstype.FractionalPercent_HUNDRED,
+	}
+}
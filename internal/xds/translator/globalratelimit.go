@@ -0,0 +1,222 @@
+package translator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	ratelimitconfig "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// globalRateLimitFilterName is the name of the global rate limit HTTP filter.
+	globalRateLimitFilterName = "envoy.filters.http.ratelimit"
+	// rateLimitClusterName is the name of the cluster of the global rate limit service.
+	rateLimitClusterName = "ratelimit_cluster"
+	// globalRateLimitStage is the stage of the rate limits of routes sent to the
+	// global rate limit service. It differs from the default stage used by the local
+	// rate limit filter, so that each filter only generates its own descriptors.
+	globalRateLimitStage = 1
+)
+
+// GlobalRateLimitSettings holds the settings of the global rate limit service.
+type GlobalRateLimitSettings struct {
+	// ServiceHost is the hostname of the global rate limit service.
+	ServiceHost string
+	// ServicePort is the gRPC port of the global rate limit service.
+	ServicePort uint32
+}
+
+// irContainsGlobalRateLimit returns true if any route of the provided IR is rate
+// limited globally.
+func irContainsGlobalRateLimit(xdsIR *ir.Xds) bool {
+	for _, httpListener := range xdsIR.HTTP {
+		if listenerContainsGlobalRateLimit(httpListener) {
+			return true
+		}
+	}
+	return false
+}
+
+// listenerContainsGlobalRateLimit returns true if any route of the provided listener
+// is rate limited globally.
+func listenerContainsGlobalRateLimit(httpListener *ir.HTTPListener) bool {
+	for _, route := range httpListener.Routes {
+		if route.RateLimit != nil && route.RateLimit.Global != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildXdsGlobalRateLimitFilter builds the global rate limit HTTP filter of the
+// provided listener. The rate limit domain of the listener is its name.
+func buildXdsGlobalRateLimitFilter(httpListener *ir.HTTPListener) (*hcm.HttpFilter, error) {
+	rateLimitAny, err := anypb.New(&ratelimit.RateLimit{
+		Domain: getRateLimitDomain(httpListener),
+		Stage:  globalRateLimitStage,
+		RateLimitService: &ratelimitconfig.RateLimitServiceConfig{
+			GrpcService: &core.GrpcService{
+				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: rateLimitClusterName},
+				},
+			},
+			TransportApiVersion: core.ApiVersion_V3,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       globalRateLimitFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rateLimitAny},
+	}, nil
+}
+
+// buildXdsGlobalRateLimits builds the rate limit actions of a route generating the
+// descriptors of the global rate limit rules. The descriptor of a rule starts with
+// an entry identifying the route and the rule, followed by an entry per header. A
+// descriptor is only generated when all of the headers of its rule are present.
+func buildXdsGlobalRateLimits(routeName string, global *ir.GlobalRateLimit) []*route.RateLimit {
+	rateLimits := make([]*route.RateLimit, 0, len(global.Rules))
+	for i, rule := range global.Rules {
+		rateLimit := &route.RateLimit{
+			Stage: wrapperspb.UInt32(globalRateLimitStage),
+			Actions: []*route.RateLimit_Action{{
+				ActionSpecifier: &route.RateLimit_Action_GenericKey_{
+					GenericKey: &route.RateLimit_Action_GenericKey{
+						DescriptorKey:   routeName,
+						DescriptorValue: getRateLimitRuleValue(i),
+					},
+				},
+			}},
+		}
+		for j, match := range rule.HeaderMatches {
+			rateLimit.Actions = append(rateLimit.Actions, &route.RateLimit_Action{
+				ActionSpecifier: &route.RateLimit_Action_RequestHeaders_{
+					RequestHeaders: &route.RateLimit_Action_RequestHeaders{
+						HeaderName:    match.Name,
+						DescriptorKey: getRateLimitDescriptorKey(i, j),
+					},
+				},
+			})
+		}
+		rateLimits = append(rateLimits, rateLimit)
+	}
+	return rateLimits
+}
+
+// buildXdsRateLimitCluster builds the cluster of the global rate limit service.
+func buildXdsRateLimitCluster(settings *GlobalRateLimitSettings) (*cluster.Cluster, error) {
+	options, err := buildXdsHTTP2ProtocolOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cluster.Cluster{
+		Name:                 rateLimitClusterName,
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
+		DnsLookupFamily:      cluster.Cluster_V4_PREFERRED,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: rateLimitClusterName,
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*endpoint.LbEndpoint{{
+					HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+						Endpoint: &endpoint.Endpoint{
+							Address: buildXdsSocketAddress(settings.ServiceHost, settings.ServicePort, core.SocketAddress_TCP),
+						},
+					},
+				}},
+			}},
+		},
+		TypedExtensionProtocolOptions: options,
+	}, nil
+}
+
+// rateLimitServiceConfig is the configuration of a domain of the global rate limit
+// service, see https://github.com/envoyproxy/ratelimit#configuration.
+type rateLimitServiceConfig struct {
+	Domain      string                 `json:"domain"`
+	Descriptors []*rateLimitDescriptor `json:"descriptors"`
+}
+
+type rateLimitDescriptor struct {
+	Key         string                 `json:"key"`
+	Value       string                 `json:"value,omitempty"`
+	RateLimit   *rateLimitPolicy       `json:"rate_limit,omitempty"`
+	Descriptors []*rateLimitDescriptor `json:"descriptors,omitempty"`
+}
+
+type rateLimitPolicy struct {
+	Unit            string `json:"unit"`
+	RequestsPerUnit uint32 `json:"requests_per_unit"`
+}
+
+// BuildRateLimitServiceConfigs returns the configurations of the global rate limit
+// service for the HTTP listeners of the provided IR with global rate limits, keyed
+// by rate limit domain. The descriptors match the rate limit actions of the routes.
+func BuildRateLimitServiceConfigs(xdsIR *ir.Xds) (map[string]string, error) {
+	configs := map[string]string{}
+	for _, httpListener := range xdsIR.HTTP {
+		if !listenerContainsGlobalRateLimit(httpListener) {
+			continue
+		}
+
+		domain := getRateLimitDomain(httpListener)
+		config := &rateLimitServiceConfig{Domain: domain}
+		for _, httpRoute := range httpListener.Routes {
+			if httpRoute.RateLimit == nil || httpRoute.RateLimit.Global == nil {
+				continue
+			}
+			for i, rule := range httpRoute.RateLimit.Global.Rules {
+				descriptor := &rateLimitDescriptor{
+					Key:   httpRoute.Name,
+					Value: getRateLimitRuleValue(i),
+				}
+				config.Descriptors = append(config.Descriptors, descriptor)
+				// Nest a descriptor per header, the last one holding the limit.
+				for j, match := range rule.HeaderMatches {
+					child := &rateLimitDescriptor{
+						Key:   getRateLimitDescriptorKey(i, j),
+						Value: match.Value,
+					}
+					descriptor.Descriptors = []*rateLimitDescriptor{child}
+					descriptor = child
+				}
+				descriptor.RateLimit = &rateLimitPolicy{
+					Unit:            strings.ToLower(string(rule.Limit.Unit)),
+					RequestsPerUnit: rule.Limit.Requests,
+				}
+			}
+		}
+
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		configs[domain] = string(out)
+	}
+	return configs, nil
+}
+
+func getRateLimitDomain(httpListener *ir.HTTPListener) string {
+	return httpListener.Name
+}
+
+func getRateLimitRuleValue(ruleIndex int) string {
+	return fmt.Sprintf("rule-%d", ruleIndex)
+}
@@ -0,0 +1,56 @@
+package translator
+
+import (
+	grpcjsontranscoder "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_json_transcoder/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// grpcJSONTranscoderFilterName is the name of the gRPC-JSON transcoder HTTP filter.
+	grpcJSONTranscoderFilterName = "envoy.filters.http.grpc_json_transcoder"
+)
+
+// listenerContainsGRPCJSONTranscoder returns true if the requests of any route of
+// the provided listener are transcoded into gRPC requests.
+func listenerContainsGRPCJSONTranscoder(httpListener *ir.HTTPListener) bool {
+	for _, route := range httpListener.Routes {
+		if route.GRPCJSONTranscoder != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildXdsGRPCJSONTranscoderFilter builds the gRPC-JSON transcoder HTTP filter. The
+// descriptor set of the filter is empty, so it only transcodes the requests of the
+// routes configuring a descriptor set.
+func buildXdsGRPCJSONTranscoderFilter() (*hcm.HttpFilter, error) {
+	transcoderAny, err := anypb.New(&grpcjsontranscoder.GrpcJsonTranscoder{
+		DescriptorSet: &grpcjsontranscoder.GrpcJsonTranscoder_ProtoDescriptorBin{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       grpcJSONTranscoderFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: transcoderAny},
+	}, nil
+}
+
+// buildXdsGRPCJSONTranscoderPerRouteConfig builds the gRPC-JSON transcoder configuration
+// of a route. The transcoded requests keep the route matched by the JSON request, and
+// the gRPC status of the responses is converted into JSON.
+func buildXdsGRPCJSONTranscoderPerRouteConfig(transcoder *ir.GRPCJSONTranscoder) (*anypb.Any, error) {
+	return anypb.New(&grpcjsontranscoder.GrpcJsonTranscoder{
+		DescriptorSet: &grpcjsontranscoder.GrpcJsonTranscoder_ProtoDescriptorBin{
+			ProtoDescriptorBin: transcoder.ProtoDescriptor,
+		},
+		Services:                  transcoder.Services,
+		MatchIncomingRequestRoute: true,
+		ConvertGrpcStatus:         true,
+	})
+}
@@ -0,0 +1,66 @@
+package translator
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	preservecase "github.com/envoyproxy/go-control-plane/envoy/extensions/http/header_formatters/preserve_case/v3"
+	httpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// preserveCaseFormatterName is the name of the stateful header formatter preserving
+// the casing of the header names.
+const preserveCaseFormatterName = "envoy.http.stateful_header_formatters.preserve_case"
+
+// buildXdsHTTP1ProtocolOptions builds the HTTP/1.1 protocol options of the provided
+// settings, shared by the HTTP connection manager and the clusters of its routes.
+func buildXdsHTTP1ProtocolOptions(http1 *ir.HTTP1Settings) (*core.Http1ProtocolOptions, error) {
+	format := &core.Http1ProtocolOptions_HeaderKeyFormat{}
+	switch http1.HeaderCase {
+	case ir.HeaderCasePreserve:
+		formatterAny, err := anypb.New(&preservecase.PreserveCaseFormatterConfig{})
+		if err != nil {
+			return nil, err
+		}
+		format.HeaderFormat = &core.Http1ProtocolOptions_HeaderKeyFormat_StatefulFormatter{
+			StatefulFormatter: &core.TypedExtensionConfig{
+				Name:        preserveCaseFormatterName,
+				TypedConfig: formatterAny,
+			},
+		}
+	case ir.HeaderCaseProper:
+		format.HeaderFormat = &core.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords_{
+			ProperCaseWords: &core.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords{},
+		}
+	}
+
+	return &core.Http1ProtocolOptions{HeaderKeyFormat: format}, nil
+}
+
+// buildXdsHTTP1ClusterProtocolOptions builds the typed extension protocol options of a
+// cluster connecting to its destinations using HTTP/1.1 with the provided settings.
+func buildXdsHTTP1ClusterProtocolOptions(http1 *ir.HTTP1Settings) (map[string]*anypb.Any, error) {
+	http1Options, err := buildXdsHTTP1ProtocolOptions(http1)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &httpv3.HttpProtocolOptions{
+		UpstreamProtocolOptions: &httpv3.HttpProtocolOptions_ExplicitHttpConfig_{
+			ExplicitHttpConfig: &httpv3.HttpProtocolOptions_ExplicitHttpConfig{
+				ProtocolConfig: &httpv3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+					HttpProtocolOptions: http1Options,
+				},
+			},
+		},
+	}
+	optionsAny, err := anypb.New(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]*anypb.Any{
+		"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": optionsAny,
+	}, nil
+}
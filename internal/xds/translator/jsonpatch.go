@@ -0,0 +1,152 @@
+package translator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	jsonpatch "github.com/evanphx/json-patch"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// applyEnvoyPatchPolicies applies the JSON patches of the provided policies to the
+// resources of tCtx, in order, and sets the status of the policies. The patches of
+// a policy are applied atomically: if one of them fails, the resources are left as
+// patched by the previous policies.
+func applyEnvoyPatchPolicies(tCtx *types.ResourceVersionTable, policies []*ir.EnvoyPatchPolicy) {
+	for _, policy := range policies {
+		err := applyJSONPatches(tCtx, policy.JSONPatches)
+		setEnvoyPatchPolicyStatus(policy, err)
+	}
+}
+
+// patchedResourceKey identifies a patched resource.
+type patchedResourceKey struct {
+	typ  string
+	name string
+}
+
+// applyJSONPatches applies the provided patches to copies of the resources of tCtx,
+// which replace the resources once all the patches are applied.
+func applyJSONPatches(tCtx *types.ResourceVersionTable, patches []*ir.JSONPatchConfig) error {
+	patched := map[patchedResourceKey]cachetypes.Resource{}
+	for i, patch := range patches {
+		key := patchedResourceKey{typ: patch.Type, name: patch.Name}
+		res, ok := patched[key]
+		if !ok {
+			idx := findXdsResource(tCtx, patch.Type, patch.Name)
+			if idx < 0 {
+				return fmt.Errorf("patch %d: resource %s of type %s not found", i, patch.Name, patch.Type)
+			}
+			res = proto.Clone(tCtx.XdsResources[patch.Type][idx])
+		}
+
+		res, err := applyJSONPatch(res, patch)
+		if err != nil {
+			return fmt.Errorf("patch %d: %w", i, err)
+		}
+		patched[key] = res
+	}
+
+	for key, res := range patched {
+		tCtx.XdsResources[key.typ][findXdsResource(tCtx, key.typ, key.name)] = res
+	}
+	return nil
+}
+
+// findXdsResource returns the index of the resource with the provided type and name
+// in tCtx, or -1 if it doesn't exist.
+func findXdsResource(tCtx *types.ResourceVersionTable, typ, name string) int {
+	for i, res := range tCtx.XdsResources[typ] {
+		if cachev3.GetResourceName(res) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyJSONPatch applies the provided patch to the JSON representation of res, and
+// returns the patched resource. The patched resource must be valid and keep its
+// name.
+func applyJSONPatch(res cachetypes.Resource, patch *ir.JSONPatchConfig) (cachetypes.Resource, error) {
+	doc, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource: %w", err)
+	}
+
+	op := map[string]interface{}{
+		"op":   patch.Op,
+		"path": patch.Path,
+	}
+	if patch.From != "" {
+		op["from"] = patch.From
+	}
+	if patch.Value != "" {
+		op["value"] = json.RawMessage(patch.Value)
+	}
+	patchJSON, err := json.Marshal([]interface{}{op})
+	if err != nil {
+		return nil, fmt.Errorf("invalid json patch: %w", err)
+	}
+	jsonPatch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json patch: %w", err)
+	}
+	doc, err = jsonPatch.Apply(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply json patch: %w", err)
+	}
+
+	out := res.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(doc, out); err != nil {
+		return nil, fmt.Errorf("invalid patched resource: %w", err)
+	}
+	if v, ok := out.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid patched resource: %w", err)
+		}
+	}
+	if cachev3.GetResourceName(out) != patch.Name {
+		return nil, errors.New("invalid patched resource: the name must not be changed")
+	}
+	return out, nil
+}
+
+// setEnvoyPatchPolicyStatus sets the conditions of the status of the provided policy
+// from the error applying its patches, if any.
+func setEnvoyPatchPolicyStatus(policy *ir.EnvoyPatchPolicy, err error) {
+	if policy.Status == nil {
+		policy.Status = new(v1alpha1.EnvoyPatchPolicyStatus)
+	}
+
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               string(v1alpha1.EnvoyPatchPolicyConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(v1alpha1.EnvoyPatchPolicyReasonAccepted),
+		Message:            "Policy has been accepted",
+		ObservedGeneration: policy.Generation,
+	})
+
+	programmed := metav1.Condition{
+		Type:               string(v1alpha1.EnvoyPatchPolicyConditionProgrammed),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(v1alpha1.EnvoyPatchPolicyReasonProgrammed),
+		Message:            "Patches have been applied",
+		ObservedGeneration: policy.Generation,
+	}
+	if err != nil {
+		programmed.Status = metav1.ConditionFalse
+		programmed.Reason = string(v1alpha1.EnvoyPatchPolicyReasonInvalid)
+		programmed.Message = err.Error()
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, programmed)
+}
@@ -2,64 +2,190 @@ package translator
 
 import (
 	"errors"
+	"net"
 
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	router "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	tls_inspector "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
+	connection_limit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/connection_limit/v3"
 	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	customheader "github.com/envoyproxy/go-control-plane/envoy/extensions/http/original_ip_detection/custom_header/v3"
+	quic "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
 	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
+// connectionLimitFilterName is the name of the connection limit network filter.
+const connectionLimitFilterName = "envoy.filters.network.connection_limit"
+
+// Linux socket option levels and names used to enable TCP keepalive. The Envoy
+// proxy image is Linux based, so the values are not taken from the platform the
+// control plane runs on.
+const (
+	solSocket    = 1
+	soKeepalive  = 9
+	ipprotoTCP   = 6
+	tcpKeepidle  = 4
+	tcpKeepintvl = 5
+	tcpKeepcnt   = 6
+)
+
 func buildXdsListener(httpListener *ir.HTTPListener) (*listener.Listener, error) {
 	if httpListener == nil {
 		return nil, errors.New("http listener is nil")
 	}
 
-	routerAny, err := anypb.New(&router.Router{})
+	mgr, err := buildXdsHTTPConnectionManager(httpListener)
 	if err != nil {
 		return nil, err
 	}
 
-	// HTTP filter configuration
-	mgr := &hcm.HttpConnectionManager{
-		CodecType:  hcm.HttpConnectionManager_AUTO,
-		StatPrefix: "http",
-		RouteSpecifier: &hcm.HttpConnectionManager_Rds{
-			Rds: &hcm.Rds{
-				ConfigSource: makeConfigSource(),
-				// Configure route name to be found via RDS.
-				RouteConfigName: getXdsRouteName(httpListener.Name),
-			},
+	mgrAny, err := anypb.New(mgr)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []*listener.Filter{{
+		Name: wellknown.HTTPConnectionManager,
+		ConfigType: &listener.Filter_TypedConfig{
+			TypedConfig: mgrAny,
 		},
-		// Use only router.
-		HttpFilters: []*hcm.HttpFilter{{
-			Name:       wellknown.Router,
-			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerAny},
-		}},
+	}}
+	if httpListener.ConnectionLimit != nil {
+		limitFilter, err := buildXdsConnectionLimitFilter("http", httpListener.ConnectionLimit)
+		if err != nil {
+			return nil, err
+		}
+		filters = append([]*listener.Filter{limitFilter}, filters...)
+	}
+
+	return &listener.Listener{
+		Name:          getXdsListenerName(httpListener.Name, httpListener.Port),
+		Address:       buildXdsSocketAddress(httpListener.Address, httpListener.Port, core.SocketAddress_TCP),
+		FilterChains:  []*listener.FilterChain{{Filters: filters}},
+		SocketOptions: buildXdsSocketOptions(httpListener.TCPKeepalive, httpListener.SocketOptions),
+	}, nil
+}
+
+// buildXdsSocketOptions returns the options of the listening socket enabling the
+// provided TCP keepalive, followed by the provided socket options. The keepalive
+// options are inherited by the accepted connections.
+func buildXdsSocketOptions(keepalive *ir.TCPKeepalive, options []*ir.SocketOption) []*core.SocketOption {
+	var socketOptions []*core.SocketOption
+	if keepalive != nil {
+		socketOptions = append(socketOptions, buildXdsIntSocketOption("SO_KEEPALIVE", solSocket, soKeepalive, 1))
+		if keepalive.Probes != nil {
+			socketOptions = append(socketOptions,
+				buildXdsIntSocketOption("TCP_KEEPCNT", ipprotoTCP, tcpKeepcnt, int64(*keepalive.Probes)))
+		}
+		if keepalive.IdleTime != nil {
+			socketOptions = append(socketOptions,
+				buildXdsIntSocketOption("TCP_KEEPIDLE", ipprotoTCP, tcpKeepidle, int64(*keepalive.IdleTime)))
+		}
+		if keepalive.Interval != nil {
+			socketOptions = append(socketOptions,
+				buildXdsIntSocketOption("TCP_KEEPINTVL", ipprotoTCP, tcpKeepintvl, int64(*keepalive.Interval)))
+		}
 	}
 
+	for _, option := range options {
+		socketOptions = append(socketOptions, &core.SocketOption{
+			Level: option.Level,
+			Name:  option.Name,
+			Value: &core.SocketOption_IntValue{IntValue: option.Value},
+			State: buildXdsSocketState(option.State),
+		})
+	}
+	return socketOptions
+}
+
+func buildXdsIntSocketOption(description string, level, name, value int64) *core.SocketOption {
+	return &core.SocketOption{
+		Description: description,
+		Level:       level,
+		Name:        name,
+		Value:       &core.SocketOption_IntValue{IntValue: value},
+		State:       core.SocketOption_STATE_LISTENING,
+	}
+}
+
+func buildXdsSocketState(state ir.SocketOptionState) core.SocketOption_SocketState {
+	switch state {
+	case ir.SocketOptionStateBound:
+		return core.SocketOption_STATE_BOUND
+	case ir.SocketOptionStateListening:
+		return core.SocketOption_STATE_LISTENING
+	default:
+		return core.SocketOption_STATE_PREBIND
+	}
+}
+
+// buildXdsConnectionLimitFilter builds the network filter closing the connections of
+// a listener above the provided limit. It must precede the terminal filter.
+func buildXdsConnectionLimitFilter(statPrefix string, limit *ir.ConnectionLimit) (*listener.Filter, error) {
+	limitConfig := &connection_limit.ConnectionLimit{
+		StatPrefix:     statPrefix,
+		MaxConnections: wrapperspb.UInt64(limit.Value),
+	}
+	if limit.CloseDelay != nil {
+		limitConfig.Delay = durationpb.New(limit.CloseDelay.Duration)
+	}
+
+	limitAny, err := anypb.New(limitConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listener.Filter{
+		Name: connectionLimitFilterName,
+		ConfigType: &listener.Filter_TypedConfig{
+			TypedConfig: limitAny,
+		},
+	}, nil
+}
+
+// buildXdsQUICListener builds the UDP listener serving the routes of the provided
+// HTTPS listener over HTTP/3.
+func buildXdsQUICListener(httpListener *ir.HTTPListener) (*listener.Listener, error) {
+	if httpListener == nil || httpListener.TLS == nil {
+		return nil, errors.New("https listener is nil")
+	}
+
+	mgr, err := buildXdsHTTPConnectionManager(httpListener)
+	if err != nil {
+		return nil, err
+	}
+	mgr.CodecType = hcm.HttpConnectionManager_HTTP3
+	mgr.HttpProtocolOptions = nil
+	mgr.Http2ProtocolOptions = nil
+	mgr.StatPrefix = "http3"
+	mgr.Http3ProtocolOptions = &core.Http3ProtocolOptions{}
+
 	mgrAny, err := anypb.New(mgr)
 	if err != nil {
 		return nil, err
 	}
 
+	tSocket, err := buildXdsQUICTransportSocket(httpListener.Name, httpListener.TLS)
+	if err != nil {
+		return nil, err
+	}
+
 	return &listener.Listener{
-		Name: getXdsListenerName(httpListener.Name, httpListener.Port),
-		Address: &core.Address{
-			Address: &core.Address_SocketAddress{
-				SocketAddress: &core.SocketAddress{
-					Protocol: core.SocketAddress_TCP,
-					Address:  httpListener.Address,
-					PortSpecifier: &core.SocketAddress_PortValue{
-						PortValue: httpListener.Port,
-					},
-				},
+		Name:    getXdsQUICListenerName(httpListener.Name, httpListener.Port),
+		Address: buildXdsSocketAddress(httpListener.Address, httpListener.Port, core.SocketAddress_UDP),
+		UdpListenerConfig: &listener.UdpListenerConfig{
+			QuicOptions: &listener.QuicProtocolOptions{},
+			DownstreamSocketConfig: &core.UdpSocketConfig{
+				PreferGro: wrapperspb.Bool(true),
 			},
 		},
 		FilterChains: []*listener.FilterChain{{
@@ -69,10 +195,251 @@ func buildXdsListener(httpListener *ir.HTTPListener) (*listener.Listener, error)
 					TypedConfig: mgrAny,
 				},
 			}},
+			TransportSocket: tSocket,
 		}},
 	}, nil
 }
 
+// buildXdsHTTPConnectionManager builds the HTTP connection manager of the provided
+// listener, routing requests with the route configuration of the listener.
+func buildXdsHTTPConnectionManager(httpListener *ir.HTTPListener) (*hcm.HttpConnectionManager, error) {
+	routerAny, err := anypb.New(&router.Router{})
+	if err != nil {
+		return nil, err
+	}
+
+	// HTTP filter configuration. The router must be the last filter.
+	var httpFilters []*hcm.HttpFilter
+	if httpListener.Compression != nil {
+		// The compressors are first so that they compress the responses as
+		// returned by the other filters, including local replies.
+		compressorFilters, err := buildXdsCompressorFilters(httpListener.Compression)
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, compressorFilters...)
+	}
+	if httpListener.IPAccessControl != nil {
+		rbacFilter, err := buildXdsListenerRBACFilter(httpListener.IPAccessControl)
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, rbacFilter)
+	}
+	if listenerContainsRouteIPAccessControl(httpListener) {
+		rbacFilter, err := buildXdsRouteRBACFilter()
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, rbacFilter)
+	}
+	if len(httpListener.Wasm) > 0 {
+		wasmFilters, err := buildXdsWasmFilters(httpListener.Wasm)
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, wasmFilters...)
+	}
+	if listenerContainsLua(httpListener) {
+		luaFilter, err := buildXdsLuaFilter(httpListener)
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, luaFilter)
+	}
+	if listenerContainsLocalRateLimit(httpListener) {
+		rateLimitFilter, err := buildXdsLocalRateLimitFilter()
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, rateLimitFilter)
+	}
+	if listenerContainsGlobalRateLimit(httpListener) {
+		rateLimitFilter, err := buildXdsGlobalRateLimitFilter(httpListener)
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, rateLimitFilter)
+	}
+	if listenerContainsBuffer(httpListener) {
+		// The buffer filter precedes the filters transforming request bodies, so
+		// that the limit applies to the bodies sent by the clients.
+		bufferFilter, err := buildXdsBufferFilter(httpListener)
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, bufferFilter)
+	}
+	if listenerContainsGRPCJSONTranscoder(httpListener) {
+		transcoderFilter, err := buildXdsGRPCJSONTranscoderFilter()
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, transcoderFilter)
+	}
+	if listenerContainsFaultInjection(httpListener) {
+		// The fault filter precedes the router, so that the faults are only
+		// injected into the requests allowed by the other filters.
+		faultFilter, err := buildXdsFaultFilter()
+		if err != nil {
+			return nil, err
+		}
+		httpFilters = append(httpFilters, faultFilter)
+	}
+	httpFilters = append(httpFilters, &hcm.HttpFilter{
+		Name:       wellknown.Router,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerAny},
+	})
+
+	mgr := &hcm.HttpConnectionManager{
+		CodecType:  hcm.HttpConnectionManager_AUTO,
+		StatPrefix: "http",
+		RouteSpecifier: &hcm.HttpConnectionManager_Rds{
+			Rds: &hcm.Rds{
+				ConfigSource: makeConfigSource(),
+				// Configure route name to be found via RDS.
+				RouteConfigName: getXdsRouteName(httpListener.Name),
+			},
+		},
+		HttpFilters: httpFilters,
+	}
+	if httpListener.TLS != nil && httpListener.TLS.ForwardClientCertDetails != nil {
+		buildXdsForwardClientCertDetails(mgr, httpListener.TLS.ForwardClientCertDetails)
+	}
+	buildXdsUpgradeConfigs(mgr, httpListener)
+	if httpListener.HTTP1 != nil {
+		http1Options, err := buildXdsHTTP1ProtocolOptions(httpListener.HTTP1)
+		if err != nil {
+			return nil, err
+		}
+		mgr.HttpProtocolOptions = http1Options
+	}
+	if httpListener.ClientIPDetection != nil {
+		if err := buildXdsClientIPDetection(mgr, httpListener.ClientIPDetection); err != nil {
+			return nil, err
+		}
+	}
+	if httpListener.Timeouts != nil {
+		buildXdsClientTimeouts(mgr, httpListener.Timeouts)
+	}
+	if httpListener.LocalReply != nil {
+		localReplyConfig, err := buildXdsLocalReplyConfig(httpListener.LocalReply)
+		if err != nil {
+			return nil, err
+		}
+		mgr.LocalReplyConfig = localReplyConfig
+	}
+	if httpListener.ServerHeader != nil {
+		buildXdsServerHeader(mgr, httpListener.ServerHeader)
+	}
+	if httpListener.MaxRequestHeadersKB > 0 {
+		mgr.MaxRequestHeadersKb = wrapperspb.UInt32(httpListener.MaxRequestHeadersKB)
+	}
+	accessLogs, err := buildXdsAccessLogs(httpListener.AccessLog, false)
+	if err != nil {
+		return nil, err
+	}
+	mgr.AccessLog = accessLogs
+	if httpListener.Tracing != nil {
+		tracing, err := buildXdsTracing(httpListener.Tracing)
+		if err != nil {
+			return nil, err
+		}
+		mgr.Tracing = tracing
+	}
+
+	return mgr, nil
+}
+
+// buildXdsServerHeader configures the Server header of the responses of the HTTP
+// connection manager.
+func buildXdsServerHeader(mgr *hcm.HttpConnectionManager, serverHeader *ir.ServerHeader) {
+	switch serverHeader.Transformation {
+	case ir.ServerHeaderAppendIfAbsent:
+		mgr.ServerHeaderTransformation = hcm.HttpConnectionManager_APPEND_IF_ABSENT
+	case ir.ServerHeaderPassThrough:
+		mgr.ServerHeaderTransformation = hcm.HttpConnectionManager_PASS_THROUGH
+	default:
+		mgr.ServerHeaderTransformation = hcm.HttpConnectionManager_OVERWRITE
+	}
+	mgr.ServerName = serverHeader.Value
+}
+
+// buildXdsClientTimeouts configures the timeouts of the connections and requests of
+// the clients of the HTTP connection manager.
+func buildXdsClientTimeouts(mgr *hcm.HttpConnectionManager, timeouts *ir.ClientTimeouts) {
+	if timeouts.Idle != nil {
+		mgr.CommonHttpProtocolOptions = &core.HttpProtocolOptions{
+			IdleTimeout: durationpb.New(timeouts.Idle.Duration),
+		}
+	}
+	if timeouts.StreamIdle != nil {
+		mgr.StreamIdleTimeout = durationpb.New(timeouts.StreamIdle.Duration)
+	}
+	if timeouts.Request != nil {
+		mgr.RequestTimeout = durationpb.New(timeouts.Request.Duration)
+	}
+}
+
+// buildXdsClientIPDetection configures the detection of the client address of the
+// HTTP connection manager. Envoy doesn't allow mixing the original IP detection
+// extensions with the X-Forwarded-For settings.
+func buildXdsClientIPDetection(mgr *hcm.HttpConnectionManager, detection *ir.ClientIPDetection) error {
+	if xff := detection.XForwardedFor; xff != nil {
+		mgr.UseRemoteAddress = wrapperspb.Bool(true)
+		mgr.XffNumTrustedHops = xff.NumTrustedHops
+		mgr.SkipXffAppend = xff.SkipAppend
+	}
+
+	if header := detection.CustomHeader; header != nil {
+		headerConfig := &customheader.CustomHeaderConfig{
+			HeaderName: header.Name,
+		}
+		if header.FailClosed {
+			headerConfig.RejectWithStatus = &xdstype.HttpStatus{Code: xdstype.StatusCode_Forbidden}
+		}
+		headerConfigAny, err := anypb.New(headerConfig)
+		if err != nil {
+			return err
+		}
+		mgr.OriginalIpDetectionExtensions = []*core.TypedExtensionConfig{{
+			Name:        "envoy.extensions.http.original_ip_detection.custom_header",
+			TypedConfig: headerConfigAny,
+		}}
+	}
+
+	return nil
+}
+
+// buildXdsForwardClientCertDetails configures the x-forwarded-client-cert header
+// handling of the HTTP connection manager.
+func buildXdsForwardClientCertDetails(mgr *hcm.HttpConnectionManager, details *ir.ForwardClientCertDetails) {
+	switch details.Mode {
+	case ir.ForwardClientCertSanitize:
+		mgr.ForwardClientCertDetails = hcm.HttpConnectionManager_SANITIZE
+	case ir.ForwardClientCertForwardOnly:
+		mgr.ForwardClientCertDetails = hcm.HttpConnectionManager_FORWARD_ONLY
+	case ir.ForwardClientCertAppendForward:
+		mgr.ForwardClientCertDetails = hcm.HttpConnectionManager_APPEND_FORWARD
+	case ir.ForwardClientCertSanitizeSet:
+		mgr.ForwardClientCertDetails = hcm.HttpConnectionManager_SANITIZE_SET
+	case ir.ForwardClientCertAlwaysForwardOnly:
+		mgr.ForwardClientCertDetails = hcm.HttpConnectionManager_ALWAYS_FORWARD_ONLY
+	}
+
+	if details.Mode == ir.ForwardClientCertAppendForward || details.Mode == ir.ForwardClientCertSanitizeSet {
+		mgr.SetCurrentClientCertDetails = &hcm.HttpConnectionManager_SetCurrentClientCertDetails{
+			Cert:  details.Cert,
+			Chain: details.Chain,
+			Dns:   details.DNS,
+			Uri:   details.URI,
+		}
+		if details.Subject {
+			mgr.SetCurrentClientCertDetails.Subject = wrapperspb.Bool(true)
+		}
+	}
+}
+
 func buildXdsTCPListener(clusterName string, tcpListener *ir.TCPListener) (*listener.Listener, error) {
 	if tcpListener == nil {
 		return nil, errors.New("http listener is nil")
@@ -82,11 +449,16 @@ func buildXdsTCPListener(clusterName string, tcpListener *ir.TCPListener) (*list
 	if tcpListener.TLS != nil {
 		statPrefix = "passthrough"
 	}
+	accessLogs, err := buildXdsAccessLogs(tcpListener.AccessLog, true)
+	if err != nil {
+		return nil, err
+	}
 	mgr := &tcp.TcpProxy{
 		StatPrefix: statPrefix,
 		ClusterSpecifier: &tcp.TcpProxy_Cluster{
 			Cluster: clusterName,
 		},
+		AccessLog: accessLogs,
 	}
 	mgrAny, err := anypb.New(mgr)
 	if err != nil {
@@ -101,6 +473,21 @@ func buildXdsTCPListener(clusterName string, tcpListener *ir.TCPListener) (*list
 			},
 		}},
 	}
+	if tcpListener.ConnectionLimit != nil {
+		limitFilter, err := buildXdsConnectionLimitFilter(statPrefix, tcpListener.ConnectionLimit)
+		if err != nil {
+			return nil, err
+		}
+		filterChain.Filters = append([]*listener.Filter{limitFilter}, filterChain.Filters...)
+	}
+	if tcpListener.IPAccessControl != nil {
+		// Denied connections are closed before counting against the limit.
+		rbacFilter, err := buildXdsNetworkRBACFilter(statPrefix, tcpListener.IPAccessControl)
+		if err != nil {
+			return nil, err
+		}
+		filterChain.Filters = append([]*listener.Filter{rbacFilter}, filterChain.Filters...)
+	}
 	if tcpListener.TLS != nil {
 		filterChain.FilterChainMatch = &listener.FilterChainMatch{
 			ServerNames: tcpListener.TLS.SNIs,
@@ -108,19 +495,10 @@ func buildXdsTCPListener(clusterName string, tcpListener *ir.TCPListener) (*list
 	}
 
 	xdsListener := &listener.Listener{
-		Name: getXdsListenerName(tcpListener.Name, tcpListener.Port),
-		Address: &core.Address{
-			Address: &core.Address_SocketAddress{
-				SocketAddress: &core.SocketAddress{
-					Protocol: core.SocketAddress_TCP,
-					Address:  tcpListener.Address,
-					PortSpecifier: &core.SocketAddress_PortValue{
-						PortValue: tcpListener.Port,
-					},
-				},
-			},
-		},
-		FilterChains: []*listener.FilterChain{filterChain},
+		Name:          getXdsListenerName(tcpListener.Name, tcpListener.Port),
+		Address:       buildXdsSocketAddress(tcpListener.Address, tcpListener.Port, core.SocketAddress_TCP),
+		FilterChains:  []*listener.FilterChain{filterChain},
+		SocketOptions: buildXdsSocketOptions(tcpListener.TCPKeepalive, tcpListener.SocketOptions),
 	}
 
 	if tcpListener.TLS != nil {
@@ -141,37 +519,149 @@ func buildXdsTCPListener(clusterName string, tcpListener *ir.TCPListener) (*list
 	return xdsListener, nil
 }
 
+// buildXdsSocketAddress returns a socket address for the provided address, port and
+// protocol. IPv4 connections are accepted on the IPv6 unspecified address so that
+// dual-stack proxies serve both address families.
+func buildXdsSocketAddress(address string, port uint32, protocol core.SocketAddress_Protocol) *core.Address {
+	socketAddress := &core.SocketAddress{
+		Protocol: protocol,
+		Address:  address,
+		PortSpecifier: &core.SocketAddress_PortValue{
+			PortValue: port,
+		},
+	}
+	if ip := net.ParseIP(address); ip != nil && ip.To4() == nil && ip.IsUnspecified() {
+		socketAddress.Ipv4Compat = true
+	}
+
+	return &core.Address{
+		Address: &core.Address_SocketAddress{
+			SocketAddress: socketAddress,
+		},
+	}
+}
+
 func buildXdsDownstreamTLSSocket(listenerName string,
 	tlsConfig *ir.TLSListenerConfig) (*core.TransportSocket, error) {
+	tlsCtxAny, err := anypb.New(buildXdsDownstreamTLSContext(listenerName, tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &core.TransportSocket_TypedConfig{
+			TypedConfig: tlsCtxAny,
+		},
+	}, nil
+}
+
+// buildXdsQUICTransportSocket builds the QUIC transport socket of the HTTP/3
+// listener, which shares the TLS configuration of the HTTPS listener but only
+// negotiates HTTP/3.
+func buildXdsQUICTransportSocket(listenerName string, tlsConfig *ir.TLSListenerConfig) (*core.TransportSocket, error) {
+	tlsCtx := buildXdsDownstreamTLSContext(listenerName, tlsConfig)
+	tlsCtx.CommonTlsContext.AlpnProtocols = []string{"h3"}
+
+	quicAny, err := anypb.New(&quic.QuicDownstreamTransport{
+		DownstreamTlsContext: tlsCtx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.TransportSocket{
+		Name: wellknown.TransportSocketQuic,
+		ConfigType: &core.TransportSocket_TypedConfig{
+			TypedConfig: quicAny,
+		},
+	}, nil
+}
+
+func buildXdsDownstreamTLSContext(listenerName string, tlsConfig *ir.TLSListenerConfig) *tls.DownstreamTlsContext {
 	tlsCtx := &tls.DownstreamTlsContext{
 		CommonTlsContext: &tls.CommonTlsContext{
 			TlsCertificateSdsSecretConfigs: []*tls.SdsSecretConfig{{
 				// Generate key name for this listener. The actual key will be
 				// delivered to Envoy via SDS.
-				Name:      getXdsSecretName(listenerName),
+				Name:      getXdsTLSSecretName(listenerName, tlsConfig),
 				SdsConfig: makeConfigSource(),
 			}},
+			TlsParams:     buildXdsTLSParams(tlsConfig),
+			AlpnProtocols: tlsConfig.ALPNProtocols,
 		},
 	}
+	if len(tlsConfig.ClientCACertificate) > 0 {
+		// The client CA bundle is delivered to Envoy via SDS as well.
+		tlsCtx.CommonTlsContext.ValidationContextType = &tls.CommonTlsContext_ValidationContextSdsSecretConfig{
+			ValidationContextSdsSecretConfig: &tls.SdsSecretConfig{
+				Name:      getXdsCASecretName(listenerName),
+				SdsConfig: makeConfigSource(),
+			},
+		}
+		tlsCtx.RequireClientCertificate = wrapperspb.Bool(tlsConfig.RequireClientCertificate)
+	}
 
-	tlsCtxAny, err := anypb.New(tlsCtx)
-	if err != nil {
-		return nil, err
+	return tlsCtx
+}
+
+// buildXdsTLSParams builds the TLS parameters of the listener, or returns nil if
+// the Envoy defaults are used.
+func buildXdsTLSParams(tlsConfig *ir.TLSListenerConfig) *tls.TlsParameters {
+	if tlsConfig.MinVersion == "" && tlsConfig.MaxVersion == "" &&
+		len(tlsConfig.Ciphers) == 0 && len(tlsConfig.ECDHCurves) == 0 {
+		return nil
+	}
+	return &tls.TlsParameters{
+		TlsMinimumProtocolVersion: buildXdsTLSVersion(tlsConfig.MinVersion),
+		TlsMaximumProtocolVersion: buildXdsTLSVersion(tlsConfig.MaxVersion),
+		CipherSuites:              tlsConfig.Ciphers,
+		EcdhCurves:                tlsConfig.ECDHCurves,
+	}
+}
+
+func buildXdsTLSVersion(version ir.TLSVersion) tls.TlsParameters_TlsProtocol {
+	switch version {
+	case ir.TLSv10:
+		return tls.TlsParameters_TLSv1_0
+	case ir.TLSv11:
+		return tls.TlsParameters_TLSv1_1
+	case ir.TLSv12:
+		return tls.TlsParameters_TLSv1_2
+	case ir.TLSv13:
+		return tls.TlsParameters_TLSv1_3
+	default:
+		return tls.TlsParameters_TLS_AUTO
 	}
+}
 
-	return &core.TransportSocket{
-		Name: wellknown.TransportSocketTls,
-		ConfigType: &core.TransportSocket_TypedConfig{
-			TypedConfig: tlsCtxAny,
+// buildXdsCASecret builds the secret holding the CA bundle, and optionally the
+// certificate revocation list, used to verify peer certificates.
+func buildXdsCASecret(name string, caCertificate, crl []byte) *tls.Secret {
+	validationCtx := &tls.CertificateValidationContext{
+		TrustedCa: &core.DataSource{
+			Specifier: &core.DataSource_InlineBytes{InlineBytes: caCertificate},
 		},
-	}, nil
+	}
+	if len(crl) > 0 {
+		validationCtx.Crl = &core.DataSource{
+			Specifier: &core.DataSource_InlineBytes{InlineBytes: crl},
+		}
+	}
+
+	return &tls.Secret{
+		Name: getXdsCASecretName(name),
+		Type: &tls.Secret_ValidationContext{
+			ValidationContext: validationCtx,
+		},
+	}
 }
 
 func buildXdsDownstreamTLSSecret(listenerName string,
 	tlsConfig *ir.TLSListenerConfig) (*tls.Secret, error) {
 	// Build the tls secret
 	return &tls.Secret{
-		Name: getXdsSecretName(listenerName),
+		Name: getXdsTLSSecretName(listenerName, tlsConfig),
 		Type: &tls.Secret_TlsCertificate{
 			TlsCertificate: &tls.TlsCertificate{
 				CertificateChain: &core.DataSource{
@@ -0,0 +1,125 @@
+package translator
+
+import (
+	"fmt"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	common "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	localratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// localRateLimitFilterName is the name of the local rate limit HTTP filter.
+	localRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+	// localRateLimitStatPrefix is the stat prefix of the local rate limit HTTP filter.
+	localRateLimitStatPrefix = "http_local_rate_limiter"
+)
+
+// listenerContainsLocalRateLimit returns true if any route of the provided listener
+// is rate limited locally.
+func listenerContainsLocalRateLimit(httpListener *ir.HTTPListener) bool {
+	for _, route := range httpListener.Routes {
+		if route.RateLimit != nil && route.RateLimit.Local != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildXdsLocalRateLimitFilter builds the local rate limit HTTP filter. The filter
+// has no token bucket, so it only limits the routes configuring one.
+func buildXdsLocalRateLimitFilter() (*hcm.HttpFilter, error) {
+	rateLimitAny, err := anypb.New(&localratelimit.LocalRateLimit{
+		StatPrefix: localRateLimitStatPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       localRateLimitFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rateLimitAny},
+	}, nil
+}
+
+// buildXdsLocalRateLimitPerRouteConfig builds the local rate limit configuration of a
+// route. Each rule is a descriptor matched by the actions of buildXdsLocalRateLimits.
+func buildXdsLocalRateLimitPerRouteConfig(local *ir.LocalRateLimit) (*anypb.Any, error) {
+	maxTokens := local.Burst
+	if maxTokens == 0 {
+		maxTokens = local.Default.Requests
+	}
+
+	rateLimit := &localratelimit.LocalRateLimit{
+		StatPrefix:     localRateLimitStatPrefix,
+		TokenBucket:    buildXdsTokenBucket(local.Default, maxTokens),
+		FilterEnabled:  buildXdsRuntimePercent("local_rate_limit_enabled"),
+		FilterEnforced: buildXdsRuntimePercent("local_rate_limit_enforced"),
+	}
+	for i, rule := range local.Rules {
+		descriptor := &common.LocalRateLimitDescriptor{
+			TokenBucket: buildXdsTokenBucket(rule.Limit, rule.Limit.Requests),
+		}
+		for j, match := range rule.HeaderMatches {
+			descriptor.Entries = append(descriptor.Entries, &common.RateLimitDescriptor_Entry{
+				Key:   getRateLimitDescriptorKey(i, j),
+				Value: match.Value,
+			})
+		}
+		rateLimit.Descriptors = append(rateLimit.Descriptors, descriptor)
+	}
+
+	return anypb.New(rateLimit)
+}
+
+// buildXdsLocalRateLimits builds the rate limit actions of a route generating the
+// descriptors of the local rate limit rules. A descriptor is only generated when
+// all of the headers of its rule are present.
+func buildXdsLocalRateLimits(local *ir.LocalRateLimit) []*route.RateLimit {
+	rateLimits := make([]*route.RateLimit, 0, len(local.Rules))
+	for i, rule := range local.Rules {
+		rateLimit := &route.RateLimit{}
+		for j, match := range rule.HeaderMatches {
+			rateLimit.Actions = append(rateLimit.Actions, &route.RateLimit_Action{
+				ActionSpecifier: &route.RateLimit_Action_RequestHeaders_{
+					RequestHeaders: &route.RateLimit_Action_RequestHeaders{
+						HeaderName:    match.Name,
+						DescriptorKey: getRateLimitDescriptorKey(i, j),
+					},
+				},
+			})
+		}
+		rateLimits = append(rateLimits, rateLimit)
+	}
+	return rateLimits
+}
+
+func buildXdsTokenBucket(limit ir.RateLimitValue, maxTokens uint32) *xdstype.TokenBucket {
+	return &xdstype.TokenBucket{
+		MaxTokens:     maxTokens,
+		TokensPerFill: wrapperspb.UInt32(limit.Requests),
+		FillInterval:  durationpb.New(limit.Unit.Duration()),
+	}
+}
+
+func buildXdsRuntimePercent(runtimeKey string) *core.RuntimeFractionalPercent {
+	return &core.RuntimeFractionalPercent{
+		DefaultValue: &xdstype.FractionalPercent{
+			Numerator:   100,
+			Denominator: xdstype.FractionalPercent_HUNDRED,
+		},
+		RuntimeKey: runtimeKey,
+	}
+}
+
+func getRateLimitDescriptorKey(ruleIndex, headerIndex int) string {
+	return fmt.Sprintf("rule-%d-header-%d", ruleIndex, headerIndex)
+}
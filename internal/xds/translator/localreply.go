@@ -0,0 +1,101 @@
+package translator
+
+import (
+	"fmt"
+
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// buildXdsLocalReplyConfig builds the configuration of the responses generated by
+// the HTTP connection manager.
+func buildXdsLocalReplyConfig(localReply *ir.LocalReply) (*hcm.LocalReplyConfig, error) {
+	config := &hcm.LocalReplyConfig{}
+	for _, mapper := range localReply.Mappers {
+		responseMapper := &hcm.ResponseMapper{
+			Filter: buildXdsStatusCodesFilter(mapper.StatusCodes),
+		}
+		if mapper.StatusCode != nil {
+			responseMapper.StatusCode = wrapperspb.UInt32(*mapper.StatusCode)
+		}
+		if mapper.Body != nil {
+			responseMapper.Body = &core.DataSource{
+				Specifier: &core.DataSource_InlineString{InlineString: *mapper.Body},
+			}
+		}
+		config.Mappers = append(config.Mappers, responseMapper)
+	}
+
+	if format := localReply.BodyFormat; format != nil {
+		bodyFormat, err := buildXdsSubstitutionFormatString(format.Text, format.JSON)
+		if err != nil {
+			return nil, err
+		}
+		bodyFormat.ContentType = format.ContentType
+		config.BodyFormat = bodyFormat
+	}
+
+	return config, nil
+}
+
+// buildXdsSubstitutionFormatString builds the text format, or the JSON format if text
+// is nil, of the strings substituting the command operators of the access logs.
+func buildXdsSubstitutionFormatString(text *string, json map[string]string) (*core.SubstitutionFormatString, error) {
+	if text != nil {
+		return &core.SubstitutionFormatString{
+			Format: &core.SubstitutionFormatString_TextFormatSource{
+				TextFormatSource: &core.DataSource{
+					Specifier: &core.DataSource_InlineString{InlineString: *text},
+				},
+			},
+		}, nil
+	}
+
+	fields := make(map[string]interface{}, len(json))
+	for key, value := range json {
+		fields[key] = value
+	}
+	jsonFormat, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &core.SubstitutionFormatString{
+		Format: &core.SubstitutionFormatString_JsonFormat{JsonFormat: jsonFormat},
+	}, nil
+}
+
+// buildXdsStatusCodesFilter builds the access log filter matching the responses with
+// any of the provided status codes.
+func buildXdsStatusCodesFilter(codes []uint32) *accesslog.AccessLogFilter {
+	filters := make([]*accesslog.AccessLogFilter, 0, len(codes))
+	for _, code := range codes {
+		filters = append(filters, &accesslog.AccessLogFilter{
+			FilterSpecifier: &accesslog.AccessLogFilter_StatusCodeFilter{
+				StatusCodeFilter: &accesslog.StatusCodeFilter{
+					Comparison: &accesslog.ComparisonFilter{
+						Op: accesslog.ComparisonFilter_EQ,
+						Value: &core.RuntimeUInt32{
+							DefaultValue: code,
+							RuntimeKey:   fmt.Sprintf("local_reply_status_code_%d", code),
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// An or filter requires at least two filters.
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	return &accesslog.AccessLogFilter{
+		FilterSpecifier: &accesslog.AccessLogFilter_OrFilter{
+			OrFilter: &accesslog.OrFilter{Filters: filters},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package translator
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// luaFilterName is the name of the Lua HTTP filter.
+	luaFilterName = "envoy.filters.http.lua"
+)
+
+// listenerContainsLua returns true if any route of the provided listener runs a
+// Lua script.
+func listenerContainsLua(httpListener *ir.HTTPListener) bool {
+	for _, route := range httpListener.Routes {
+		if route.Lua != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildXdsLuaFilter builds the Lua HTTP filter holding the scripts of the routes of
+// the provided listener, keyed by name. The filter has no default script, so it only
+// runs the script selected by the configuration of a route.
+func buildXdsLuaFilter(httpListener *ir.HTTPListener) (*hcm.HttpFilter, error) {
+	sourceCodes := map[string]*core.DataSource{}
+	for _, route := range httpListener.Routes {
+		if route.Lua == nil {
+			continue
+		}
+		sourceCodes[route.Lua.Name] = &core.DataSource{
+			Specifier: &core.DataSource_InlineString{InlineString: route.Lua.Code},
+		}
+	}
+
+	luaAny, err := anypb.New(&lua.Lua{SourceCodes: sourceCodes})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       luaFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: luaAny},
+	}, nil
+}
+
+// buildXdsLuaPerRouteConfig builds the Lua configuration of a route selecting the
+// provided script.
+func buildXdsLuaPerRouteConfig(script *ir.Lua) (*anypb.Any, error) {
+	return anypb.New(&lua.LuaPerRoute{
+		Override: &lua.LuaPerRoute_Name{Name: script.Name},
+	})
+}
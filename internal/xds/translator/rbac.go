@@ -0,0 +1,156 @@
+package translator
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	rbacconfig "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	httprbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	networkrbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// listenerRBACFilterName is the name of the RBAC HTTP filter enforcing the IP
+	// access control of a listener.
+	listenerRBACFilterName = "envoy.filters.http.rbac"
+	// routeRBACFilterName is the name of the RBAC HTTP filter enforcing the IP
+	// access control of the routes. It's distinct from the filter of the listener
+	// so that the access control of a route adds to the one of the listener
+	// instead of replacing it.
+	routeRBACFilterName = "envoy.filters.http.rbac.route"
+	// networkRBACFilterName is the name of the RBAC network filter enforcing the
+	// IP access control of a TCP listener.
+	networkRBACFilterName = "envoy.filters.network.rbac"
+	// ipAccessControlPolicyName is the name of the RBAC policy of IP access control.
+	ipAccessControlPolicyName = "ip-access-control"
+)
+
+// listenerContainsRouteIPAccessControl returns true if any route of the provided
+// listener restricts its clients by address.
+func listenerContainsRouteIPAccessControl(httpListener *ir.HTTPListener) bool {
+	for _, route := range httpListener.Routes {
+		if route.IPAccessControl != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildXdsListenerRBACFilter builds the RBAC HTTP filter enforcing the provided IP
+// access control on all the requests of a listener.
+func buildXdsListenerRBACFilter(accessControl *ir.IPAccessControl) (*hcm.HttpFilter, error) {
+	rbacAny, err := anypb.New(&httprbac.RBAC{
+		Rules: buildXdsIPAccessControlRBAC(accessControl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       listenerRBACFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rbacAny},
+	}, nil
+}
+
+// buildXdsRouteRBACFilter builds the RBAC HTTP filter enforcing the IP access control
+// of the routes. The filter has no rules, so it only restricts the routes
+// configuring one.
+func buildXdsRouteRBACFilter() (*hcm.HttpFilter, error) {
+	rbacAny, err := anypb.New(&httprbac.RBAC{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       routeRBACFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rbacAny},
+	}, nil
+}
+
+// buildXdsRBACPerRouteConfig builds the RBAC configuration of a route enforcing the
+// provided IP access control.
+func buildXdsRBACPerRouteConfig(accessControl *ir.IPAccessControl) (*anypb.Any, error) {
+	return anypb.New(&httprbac.RBACPerRoute{
+		Rbac: &httprbac.RBAC{
+			Rules: buildXdsIPAccessControlRBAC(accessControl),
+		},
+	})
+}
+
+// buildXdsNetworkRBACFilter builds the RBAC network filter closing the connections
+// of a TCP listener denied by the provided IP access control. It must precede the
+// terminal filter.
+func buildXdsNetworkRBACFilter(statPrefix string, accessControl *ir.IPAccessControl) (*listener.Filter, error) {
+	rbacAny, err := anypb.New(&networkrbac.RBAC{
+		StatPrefix: statPrefix,
+		Rules:      buildXdsIPAccessControlRBAC(accessControl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &listener.Filter{
+		Name: networkRBACFilterName,
+		ConfigType: &listener.Filter_TypedConfig{
+			TypedConfig: rbacAny,
+		},
+	}, nil
+}
+
+// buildXdsIPAccessControlRBAC builds the RBAC rules allowing the clients whose address
+// is in the allowed ranges, if any, and not in the denied ranges.
+func buildXdsIPAccessControlRBAC(accessControl *ir.IPAccessControl) *rbacconfig.RBAC {
+	var principals []*rbacconfig.Principal
+	if len(accessControl.Allow) > 0 {
+		principals = append(principals, buildXdsRemoteIPPrincipal(accessControl.Allow))
+	}
+	if len(accessControl.Deny) > 0 {
+		principals = append(principals, &rbacconfig.Principal{
+			Identifier: &rbacconfig.Principal_NotId{
+				NotId: buildXdsRemoteIPPrincipal(accessControl.Deny),
+			},
+		})
+	}
+
+	return &rbacconfig.RBAC{
+		Action: rbacconfig.RBAC_ALLOW,
+		Policies: map[string]*rbacconfig.Policy{
+			ipAccessControlPolicyName: {
+				Permissions: []*rbacconfig.Permission{{
+					Rule: &rbacconfig.Permission_Any{Any: true},
+				}},
+				Principals: []*rbacconfig.Principal{{
+					Identifier: &rbacconfig.Principal_AndIds{
+						AndIds: &rbacconfig.Principal_Set{Ids: principals},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// buildXdsRemoteIPPrincipal returns a principal matching the clients whose address,
+// as detected by the HTTP connection manager, is in any of the provided ranges.
+func buildXdsRemoteIPPrincipal(cidrs []*ir.CIDR) *rbacconfig.Principal {
+	ids := make([]*rbacconfig.Principal, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		ids = append(ids, &rbacconfig.Principal{
+			Identifier: &rbacconfig.Principal_RemoteIp{
+				RemoteIp: &core.CidrRange{
+					AddressPrefix: cidr.Prefix,
+					PrefixLen:     wrapperspb.UInt32(cidr.PrefixLen),
+				},
+			},
+		})
+	}
+
+	return &rbacconfig.Principal{
+		Identifier: &rbacconfig.Principal_OrIds{
+			OrIds: &rbacconfig.Principal_Set{Ids: ids},
+		},
+	}
+}
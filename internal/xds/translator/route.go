@@ -1,9 +1,14 @@
 package translator
 
 import (
+	"fmt"
+	"strings"
+
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -15,11 +20,17 @@ func buildXdsRoute(httpRoute *ir.HTTPRoute) (*route.Route, error) {
 	}
 
 	if len(httpRoute.AddRequestHeaders) > 0 {
-		ret.RequestHeadersToAdd = buildXdsAddedRequestHeaders(httpRoute.AddRequestHeaders)
+		ret.RequestHeadersToAdd = buildXdsAddedHeaders(httpRoute.AddRequestHeaders)
 	}
 	if len(httpRoute.RemoveRequestHeaders) > 0 {
 		ret.RequestHeadersToRemove = httpRoute.RemoveRequestHeaders
 	}
+	if len(httpRoute.AddResponseHeaders) > 0 {
+		ret.ResponseHeadersToAdd = buildXdsAddedHeaders(httpRoute.AddResponseHeaders)
+	}
+	if len(httpRoute.RemoveResponseHeaders) > 0 {
+		ret.ResponseHeadersToRemove = httpRoute.RemoveResponseHeaders
+	}
 
 	switch {
 	case httpRoute.DirectResponse != nil:
@@ -33,6 +44,79 @@ func buildXdsRoute(httpRoute *ir.HTTPRoute) (*route.Route, error) {
 		} else {
 			ret.Action = &route.Route_Route{Route: buildXdsRouteAction(httpRoute.Name)}
 		}
+		if httpRoute.Timeout != nil && httpRoute.Timeout.Request != nil {
+			ret.GetRoute().Timeout = durationpb.New(httpRoute.Timeout.Request.Duration)
+		}
+		if httpRoute.Retry != nil || (httpRoute.Timeout != nil && httpRoute.Timeout.BackendRequest != nil) {
+			ret.GetRoute().RetryPolicy = buildXdsRetryPolicy(httpRoute.Retry, httpRoute.Timeout)
+		}
+		if httpRoute.LoadBalancer != nil && httpRoute.LoadBalancer.ConsistentHash != nil {
+			ret.GetRoute().HashPolicy = buildXdsHashPolicy(httpRoute.LoadBalancer.ConsistentHash)
+		}
+		if httpRoute.RateLimit != nil && httpRoute.RateLimit.Local != nil && len(httpRoute.RateLimit.Local.Rules) > 0 {
+			ret.GetRoute().RateLimits = buildXdsLocalRateLimits(httpRoute.RateLimit.Local)
+		}
+		if httpRoute.RateLimit != nil && httpRoute.RateLimit.Global != nil {
+			ret.GetRoute().RateLimits = append(ret.GetRoute().RateLimits,
+				buildXdsGlobalRateLimits(httpRoute.Name, httpRoute.RateLimit.Global)...)
+		}
+		if httpRoute.Upgrade != nil {
+			ret.GetRoute().UpgradeConfigs = buildXdsRouteUpgradeConfigs(httpRoute.Upgrade)
+		}
+	}
+
+	if httpRoute.RateLimit != nil && httpRoute.RateLimit.Local != nil {
+		rateLimitAny, err := buildXdsLocalRateLimitPerRouteConfig(httpRoute.RateLimit.Local)
+		if err != nil {
+			return nil, err
+		}
+		ret.TypedPerFilterConfig = map[string]*anypb.Any{
+			localRateLimitFilterName: rateLimitAny,
+		}
+	}
+
+	if httpRoute.IPAccessControl != nil {
+		rbacAny, err := buildXdsRBACPerRouteConfig(httpRoute.IPAccessControl)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[routeRBACFilterName] = rbacAny
+	}
+
+	if httpRoute.Lua != nil {
+		luaAny, err := buildXdsLuaPerRouteConfig(httpRoute.Lua)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[luaFilterName] = luaAny
+	}
+
+	if httpRoute.FaultInjection != nil {
+		faultAny, err := buildXdsFaultPerRouteConfig(httpRoute.FaultInjection)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[faultFilterName] = faultAny
+	}
+
+	if httpRoute.GRPCJSONTranscoder != nil {
+		transcoderAny, err := buildXdsGRPCJSONTranscoderPerRouteConfig(httpRoute.GRPCJSONTranscoder)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[grpcJSONTranscoderFilterName] = transcoderAny
 	}
 
 	return ret, nil
@@ -164,6 +248,78 @@ func buildXdsWeightedRouteAction(httpRoute *ir.HTTPRoute) *route.RouteAction {
 	}
 }
 
+// buildXdsRetryPolicy builds the retry policy of a route from its retry
+// configuration and its backend request timeout, which is used as the per-try
+// timeout unless the retry configuration sets one. Without a retry
+// configuration, retries are disabled explicitly so that the per-try timeout
+// only bounds the single backend request.
+func buildXdsRetryPolicy(retry *ir.Retry, timeout *ir.HTTPTimeout) *route.RetryPolicy {
+	ret := &route.RetryPolicy{}
+
+	if timeout != nil && timeout.BackendRequest != nil {
+		ret.PerTryTimeout = durationpb.New(timeout.BackendRequest.Duration)
+	}
+	if retry == nil {
+		ret.NumRetries = &wrapperspb.UInt32Value{Value: 0}
+		return ret
+	}
+
+	ret.RetryOn = strings.Join(retry.RetryOn, ",")
+	if retry.NumRetries != nil {
+		ret.NumRetries = &wrapperspb.UInt32Value{Value: *retry.NumRetries}
+	}
+	if len(retry.RetriableStatusCodes) > 0 {
+		ret.RetriableStatusCodes = retry.RetriableStatusCodes
+	}
+	if retry.PerTryTimeout != nil {
+		ret.PerTryTimeout = durationpb.New(retry.PerTryTimeout.Duration)
+	}
+	if retry.BackOff != nil {
+		ret.RetryBackOff = &route.RetryPolicy_RetryBackOff{
+			BaseInterval: durationpb.New(retry.BackOff.BaseInterval.Duration),
+		}
+		if retry.BackOff.MaxInterval != nil {
+			ret.RetryBackOff.MaxInterval = durationpb.New(retry.BackOff.MaxInterval.Duration)
+		}
+	}
+
+	return ret
+}
+
+// buildXdsHashPolicy builds the route hash policy computing the hash key of
+// requests for a consistent hash load balancer.
+func buildXdsHashPolicy(consistentHash *ir.ConsistentHash) []*route.RouteAction_HashPolicy {
+	hashPolicy := &route.RouteAction_HashPolicy{}
+
+	switch {
+	case consistentHash.Header != nil:
+		hashPolicy.PolicySpecifier = &route.RouteAction_HashPolicy_Header_{
+			Header: &route.RouteAction_HashPolicy_Header{
+				HeaderName: consistentHash.Header.Name,
+			},
+		}
+	case consistentHash.Cookie != nil:
+		cookie := &route.RouteAction_HashPolicy_Cookie{
+			Name: consistentHash.Cookie.Name,
+		}
+		if consistentHash.Cookie.TTL != nil {
+			cookie.Ttl = durationpb.New(consistentHash.Cookie.TTL.Duration)
+		}
+		if consistentHash.Cookie.Path != nil {
+			cookie.Path = *consistentHash.Cookie.Path
+		}
+		hashPolicy.PolicySpecifier = &route.RouteAction_HashPolicy_Cookie_{Cookie: cookie}
+	case consistentHash.SourceIP:
+		hashPolicy.PolicySpecifier = &route.RouteAction_HashPolicy_ConnectionProperties_{
+			ConnectionProperties: &route.RouteAction_HashPolicy_ConnectionProperties{
+				SourceIp: true,
+			},
+		}
+	}
+
+	return []*route.RouteAction_HashPolicy{hashPolicy}
+}
+
 func buildXdsRedirectAction(redirection *ir.Redirect) *route.RedirectAction {
 	ret := &route.RedirectAction{}
 
@@ -212,7 +368,21 @@ func buildXdsDirectResponseAction(res *ir.DirectResponse) *route.DirectResponseA
 	return ret
 }
 
-func buildXdsAddedRequestHeaders(headersToAdd []ir.AddHeader) []*core.HeaderValueOption {
+// buildXdsAltSvcHeader builds the alt-svc response header advertising the HTTP/3
+// listener to clients.
+func buildXdsAltSvcHeader(http3 *ir.HTTP3Settings) *core.HeaderValueOption {
+	return &core.HeaderValueOption{
+		Header: &core.HeaderValue{
+			Key:   "alt-svc",
+			Value: fmt.Sprintf(`h3=":%d"; ma=86400`, http3.AdvertisedPort),
+		},
+		Append: &wrapperspb.BoolValue{Value: false},
+	}
+}
+
+// buildXdsAddedHeaders builds the header value options of the provided headers to
+// be added to requests or responses.
+func buildXdsAddedHeaders(headersToAdd []ir.AddHeader) []*core.HeaderValueOption {
 	ret := make([]*core.HeaderValueOption, len(headersToAdd))
 
 	for i, header := range headersToAdd {
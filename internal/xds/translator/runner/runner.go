@@ -3,16 +3,22 @@ package runner
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/utils/env"
 	"github.com/envoyproxy/gateway/internal/xds/translator"
 )
 
 type Config struct {
 	config.Server
-	XdsIR *message.XdsIR
-	Xds   *message.Xds
+	ProviderResources *message.ProviderResources
+	XdsIR             *message.XdsIR
+	Xds               *message.Xds
 }
 
 type Runner struct {
@@ -29,13 +35,26 @@ func (r *Runner) Name() string {
 
 // Start starts the xds-translator runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	logger, err := r.ComponentLogger(v1alpha1.LogComponentXds)
+	if err != nil {
+		return err
+	}
+	r.Logger = logger.WithValues("runner", r.Name())
 	go r.subscribeAndTranslate(ctx)
 	r.Logger.Info("started")
 	return nil
 }
 
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
+	t := new(translator.Translator)
+	if r.EnvoyGateway.RateLimit != nil {
+		namespace := env.Lookup("ENVOY_GATEWAY_NAMESPACE", config.EnvoyGatewayNamespace)
+		t.GlobalRateLimit = &translator.GlobalRateLimitSettings{
+			ServiceHost: kubernetes.RateLimitServiceHost(namespace),
+			ServicePort: uint32(kubernetes.RateLimitGRPCPort),
+		}
+	}
+
 	// Subscribe to resources
 	message.HandleSubscription(r.XdsIR.Subscribe(ctx),
 		func(update message.Update[string, *ir.Xds]) {
@@ -46,16 +65,35 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 			if update.Delete {
 				r.Xds.Delete(key)
 			} else {
+				// Translate a copy of the xds ir, since the translation sets
+				// the status of its EnvoyPatchPolicies.
+				val = val.DeepCopy()
 				// Translate to xds resources
-				result, err := translator.Translate(val)
+				result, err := t.Translate(val)
 				if err != nil {
 					r.Logger.Error(err, "failed to translate xds ir")
 				} else {
 					// Publish
 					r.Xds.Store(key, result)
+					r.updateEnvoyPatchPolicyStatuses(val)
 				}
 			}
 		},
 	)
 	r.Logger.Info("subscriber shutting down")
 }
+
+// updateEnvoyPatchPolicyStatuses publishes the status of the EnvoyPatchPolicies
+// applied by the translation of the provided xds ir.
+func (r *Runner) updateEnvoyPatchPolicyStatuses(xdsIR *ir.Xds) {
+	if r.ProviderResources == nil {
+		return
+	}
+	for _, policy := range xdsIR.EnvoyPatchPolicies {
+		if policy.Status == nil {
+			continue
+		}
+		key := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}
+		r.ProviderResources.EnvoyPatchPolicyStatuses.Store(key, policy.Status)
+	}
+}
@@ -0,0 +1,38 @@
+package translator
+
+import (
+	"sort"
+
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// sortXdsResources sorts the resources of tCtx by name, so that the same resources
+// always produce the same snapshot regardless of the order they were translated in.
+// The filter chains of the listeners and the virtual hosts of the route
+// configurations are sorted by name too, since Envoy selects them by their match
+// rather than their position. The routes of a virtual host are left in order,
+// since Envoy selects the first route matching a request.
+func sortXdsResources(tCtx *types.ResourceVersionTable) {
+	for _, resources := range tCtx.XdsResources {
+		sort.SliceStable(resources, func(i, j int) bool {
+			return cachev3.GetResourceName(resources[i]) < cachev3.GetResourceName(resources[j])
+		})
+
+		for _, res := range resources {
+			switch res := res.(type) {
+			case *listener.Listener:
+				sort.SliceStable(res.FilterChains, func(i, j int) bool {
+					return res.FilterChains[i].Name < res.FilterChains[j].Name
+				})
+			case *route.RouteConfiguration:
+				sort.SliceStable(res.VirtualHosts, func(i, j int) bool {
+					return res.VirtualHosts[i].Name < res.VirtualHosts[j].Name
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package translator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	trace "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	metadata "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
+	tracing "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// openTelemetryTracerName is the name of the OpenTelemetry tracer.
+	openTelemetryTracerName = "envoy.tracers.opentelemetry"
+	// zipkinCollectorEndpoint is the path of the Zipkin v2 JSON API.
+	zipkinCollectorEndpoint = "/api/v2/spans"
+)
+
+// buildXdsTracing builds the tracing configuration of the HTTP connection manager,
+// sending the spans to the cluster of the collector of the tracing provider.
+func buildXdsTracing(irTracing *ir.Tracing) (*hcm.HttpConnectionManager_Tracing, error) {
+	clusterName := getTracingClusterName(irTracing)
+
+	var tracerName string
+	var tracerConfig proto.Message
+	switch irTracing.Provider {
+	case ir.TracingProviderTypeOpenTelemetry:
+		tracerName = openTelemetryTracerName
+		tracerConfig = &trace.OpenTelemetryConfig{
+			GrpcService: &core.GrpcService{
+				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{
+						ClusterName: clusterName,
+					},
+				},
+			},
+		}
+	case ir.TracingProviderTypeZipkin:
+		tracerName = wellknown.Zipkin
+		tracerConfig = &trace.ZipkinConfig{
+			CollectorCluster:         clusterName,
+			CollectorEndpoint:        zipkinCollectorEndpoint,
+			CollectorEndpointVersion: trace.ZipkinConfig_HTTP_JSON,
+			CollectorHostname:        irTracing.Host,
+		}
+	case ir.TracingProviderTypeDatadog:
+		tracerName = wellknown.Datadog
+		tracerConfig = &trace.DatadogConfig{
+			CollectorCluster: clusterName,
+			ServiceName:      irTracing.ServiceName,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tracing provider %s", irTracing.Provider)
+	}
+
+	tracerAny, err := anypb.New(tracerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpConnectionManager_Tracing{
+		RandomSampling: &xdstype.Percent{Value: float64(irTracing.SamplingRate)},
+		CustomTags:     buildXdsCustomTags(irTracing.CustomTags),
+		Provider: &trace.Tracing_Http{
+			Name:       tracerName,
+			ConfigType: &trace.Tracing_Http_TypedConfig{TypedConfig: tracerAny},
+		},
+	}, nil
+}
+
+func buildXdsCustomTags(tags []*ir.CustomTag) []*tracing.CustomTag {
+	customTags := make([]*tracing.CustomTag, 0, len(tags))
+	for _, tag := range tags {
+		customTag := &tracing.CustomTag{Tag: tag.Name}
+		switch {
+		case tag.Literal != nil:
+			customTag.Type = &tracing.CustomTag_Literal_{
+				Literal: &tracing.CustomTag_Literal{Value: *tag.Literal},
+			}
+		case tag.Environment != nil:
+			customTag.Type = &tracing.CustomTag_Environment_{
+				Environment: &tracing.CustomTag_Environment{
+					Name:         tag.Environment.Name,
+					DefaultValue: tag.Environment.DefaultValue,
+				},
+			}
+		case tag.RequestHeader != nil:
+			customTag.Type = &tracing.CustomTag_RequestHeader{
+				RequestHeader: &tracing.CustomTag_Header{
+					Name:         tag.RequestHeader.Name,
+					DefaultValue: tag.RequestHeader.DefaultValue,
+				},
+			}
+		case tag.Metadata != nil:
+			customTag.Type = &tracing.CustomTag_Metadata_{
+				Metadata: buildXdsMetadataCustomTag(tag.Metadata),
+			}
+		}
+		customTags = append(customTags, customTag)
+	}
+	return customTags
+}
+
+func buildXdsMetadataCustomTag(tagMetadata *ir.CustomTagMetadata) *tracing.CustomTag_Metadata {
+	kind := &metadata.MetadataKind{}
+	switch tagMetadata.Kind {
+	case ir.CustomTagMetadataKindRoute:
+		kind.Kind = &metadata.MetadataKind_Route_{Route: &metadata.MetadataKind_Route{}}
+	default:
+		kind.Kind = &metadata.MetadataKind_Request_{Request: &metadata.MetadataKind_Request{}}
+	}
+
+	path := make([]*metadata.MetadataKey_PathSegment, 0, len(tagMetadata.Path))
+	for _, key := range tagMetadata.Path {
+		path = append(path, &metadata.MetadataKey_PathSegment{
+			Segment: &metadata.MetadataKey_PathSegment_Key{Key: key},
+		})
+	}
+
+	return &tracing.CustomTag_Metadata{
+		Kind: kind,
+		MetadataKey: &metadata.MetadataKey{
+			Key:  tagMetadata.Key,
+			Path: path,
+		},
+		DefaultValue: tagMetadata.DefaultValue,
+	}
+}
+
+// buildXdsTracingCluster builds the cluster of the collector of the tracing provider.
+// OpenTelemetry collectors are reached over gRPC, the others over HTTP/1.1.
+func buildXdsTracingCluster(irTracing *ir.Tracing) (*cluster.Cluster, error) {
+	clusterName := getTracingClusterName(irTracing)
+	xdsCluster := &cluster.Cluster{
+		Name:                 clusterName,
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
+		DnsLookupFamily:      cluster.Cluster_V4_PREFERRED,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*endpoint.LbEndpoint{{
+					HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+						Endpoint: &endpoint.Endpoint{
+							Address: buildXdsSocketAddress(irTracing.Host, irTracing.Port, core.SocketAddress_TCP),
+						},
+					},
+				}},
+			}},
+		},
+	}
+
+	if irTracing.Provider == ir.TracingProviderTypeOpenTelemetry {
+		options, err := buildXdsHTTP2ProtocolOptions()
+		if err != nil {
+			return nil, err
+		}
+		xdsCluster.TypedExtensionProtocolOptions = options
+	}
+
+	return xdsCluster, nil
+}
+
+// getTracingClusterName returns the name of the cluster of the collector of the
+// provided tracing provider, shared by the listeners sending spans to it.
+func getTracingClusterName(irTracing *ir.Tracing) string {
+	return fmt.Sprintf("tracing_%s_%s_%d", strings.ToLower(string(irTracing.Provider)), irTracing.Host, irTracing.Port)
+}
@@ -3,101 +3,396 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/tetratelabs/multierror"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
-// Translate translates the XDS IR into xDS resources
-func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
+// Translator translates the xDS IR into xDS resources.
+type Translator struct {
+	// GlobalRateLimit holds the settings of the global rate limit service. If
+	// unset, the translation of routes with global rate limits fails.
+	GlobalRateLimit *GlobalRateLimitSettings
+}
+
+// Translate translates the XDS IR into xDS resources. The status of the
+// EnvoyPatchPolicies of the IR is set from the result of their patches.
+func (t *Translator) Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 	if ir == nil {
 		return nil, errors.New("ir is nil")
 	}
 
 	tCtx := new(types.ResourceVersionTable)
 
-	for _, httpListener := range ir.HTTP {
-		// 1:1 between IR HTTPListener and xDS Listener
-		xdsListener, err := buildXdsListener(httpListener)
+	// The global rate limit service is shared by all the listeners.
+	if irContainsGlobalRateLimit(ir) {
+		if t.GlobalRateLimit == nil {
+			return nil, errors.New("global rate limit service is not configured")
+		}
+		rateLimitCluster, err := buildXdsRateLimitCluster(t.GlobalRateLimit)
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds rate limit cluster"))
+		}
+		tCtx.AddXdsResource(resource.ClusterType, rateLimitCluster)
+	}
+
+	// The listeners are translated in parallel, and their resources merged in
+	// order, so that the translation of large configurations scales with the
+	// number of CPUs.
+	httpResources, err := translateHTTPListeners(ir.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	// The resources shared by several listeners, e.g. the clusters of the
+	// access log services, are only added once.
+	added := map[resource.Type]map[string]bool{}
+	for _, res := range httpResources {
+		res.mergeInto(tCtx, added)
+	}
+
+	for _, tcpListener := range ir.TCP {
+		// 1:1 between IR TCPListener and xDS Cluster
+		xdsCluster, err := buildXdsCluster(&xdsClusterArgs{
+			name:         tcpListener.Name,
+			destinations: tcpListener.Destinations,
+		})
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds cluster"))
+		}
+		tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+
+		// 1:1 between IR TCPListener and xDS Listener
+		xdsListener, err := buildXdsTCPListener(xdsCluster.Name, tcpListener)
 		if err != nil {
 			return nil, multierror.Append(err, errors.New("error building xds listener"))
 		}
 
-		// 1:1 between IR TLSListenerConfig and xDS Secret
-		if httpListener.TLS != nil {
-			// Build downstream TLS details.
-			tSocket, err := buildXdsDownstreamTLSSocket(httpListener.Name, httpListener.TLS)
-			if err != nil {
-				return nil, multierror.Append(err, errors.New("error building xds listener tls socket"))
+		if err := addXdsALSClusters(tCtx, tcpListener.AccessLog, sharedNames(added, resource.ClusterType)); err != nil {
+			return nil, err
+		}
+
+		tCtx.AddXdsResource(resource.ListenerType, xdsListener)
+	}
+
+	// Sort the resources, so that the patches of the EnvoyPatchPolicies apply
+	// to the resources in the order they are published.
+	sortXdsResources(tCtx)
+
+	// The EnvoyPatchPolicies patch the resources once they are all translated.
+	applyEnvoyPatchPolicies(tCtx, ir.EnvoyPatchPolicies)
+
+	return tCtx, nil
+}
+
+// httpListenerResources holds the xDS resources of an IR HTTPListener.
+type httpListenerResources struct {
+	// table holds the resources owned by the listener.
+	table *types.ResourceVersionTable
+	// shared holds the resources that may be shared with other listeners, which
+	// are deduplicated by name.
+	shared *types.ResourceVersionTable
+}
+
+// mergeInto adds the resources to tCtx, skipping the shared resources already
+// added as tracked by added.
+func (r *httpListenerResources) mergeInto(tCtx *types.ResourceVersionTable, added map[resource.Type]map[string]bool) {
+	for rType, resources := range r.table.XdsResources {
+		for _, res := range resources {
+			tCtx.AddXdsResource(rType, res)
+		}
+	}
+	for rType, resources := range r.shared.XdsResources {
+		names := sharedNames(added, rType)
+		for _, res := range resources {
+			if name := cachev3.GetResourceName(res); !names[name] {
+				names[name] = true
+				tCtx.AddXdsResource(rType, res)
 			}
-			xdsListener.FilterChains[0].TransportSocket = tSocket
+		}
+	}
+}
 
-			secret, err := buildXdsDownstreamTLSSecret(httpListener.Name, httpListener.TLS)
-			if err != nil {
-				return nil, multierror.Append(err, errors.New("error building xds listener tls secret"))
+// sharedNames returns the names of the shared resources of type rType already
+// added, as tracked by added.
+func sharedNames(added map[resource.Type]map[string]bool, rType resource.Type) map[string]bool {
+	if added[rType] == nil {
+		added[rType] = map[string]bool{}
+	}
+	return added[rType]
+}
+
+// translateHTTPListeners translates the provided IR HTTPListeners with a pool of
+// workers, and returns their resources in the order of the listeners. The error
+// of the first listener failing to translate is returned.
+func translateHTTPListeners(httpListeners []*ir.HTTPListener) ([]*httpListenerResources, error) {
+	results := make([]*httpListenerResources, len(httpListeners))
+	errs := make([]error, len(httpListeners))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(httpListeners) {
+		workers = len(httpListeners)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = translateHTTPListener(httpListeners[i])
 			}
-			tCtx.AddXdsResource(resource.SecretType, secret)
+		}()
+	}
+	for i := range httpListeners {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
 		}
+	}
+	return results, nil
+}
 
-		// Allocate virtual host for this httpListener.
-		// 1:1 between IR HTTPListener and xDS VirtualHost
-		routeName := getXdsRouteName(httpListener.Name)
-		vHost := &route.VirtualHost{
-			Name:    routeName,
-			Domains: httpListener.Hostnames,
+// translateHTTPListener translates the provided IR HTTPListener into xDS resources.
+func translateHTTPListener(httpListener *ir.HTTPListener) (*httpListenerResources, error) {
+	res := &httpListenerResources{
+		table:  new(types.ResourceVersionTable),
+		shared: new(types.ResourceVersionTable),
+	}
+
+	// 1:1 between IR HTTPListener and xDS Listener
+	xdsListener, err := buildXdsListener(httpListener)
+	if err != nil {
+		return nil, multierror.Append(err, errors.New("error building xds listener"))
+	}
+
+	// 1:1 between the Secret of IR TLSListenerConfig and xDS Secret
+	if httpListener.TLS != nil {
+		// Build downstream TLS details.
+		tSocket, err := buildXdsDownstreamTLSSocket(httpListener.Name, httpListener.TLS)
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds listener tls socket"))
 		}
+		xdsListener.FilterChains[0].TransportSocket = tSocket
 
-		for _, httpRoute := range httpListener.Routes {
-			// 1:1 between IR HTTPRoute and xDS config.route.v3.Route
-			xdsRoute, err := buildXdsRoute(httpRoute)
-			if err != nil {
-				return nil, multierror.Append(err, errors.New("error building xds route"))
-			}
-			vHost.Routes = append(vHost.Routes, xdsRoute)
+		secret, err := buildXdsDownstreamTLSSecret(httpListener.Name, httpListener.TLS)
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds listener tls secret"))
+		}
+		res.shared.AddXdsResource(resource.SecretType, secret)
 
-			// Skip trying to build an IR cluster if the httpRoute only has invalid backends
-			if len(httpRoute.Destinations) == 0 && httpRoute.BackendWeights.Invalid > 0 {
-				continue
-			}
-			xdsCluster, err := buildXdsCluster(httpRoute.Name, httpRoute.Destinations)
+		// 1:1 between IR TLSListenerConfig client CA bundle and xDS Secret
+		if len(httpListener.TLS.ClientCACertificate) > 0 {
+			res.table.AddXdsResource(resource.SecretType,
+				buildXdsCASecret(httpListener.Name, httpListener.TLS.ClientCACertificate, httpListener.TLS.ClientCRL))
+		}
+	}
+
+	if err := addXdsALSClusters(res.shared, httpListener.AccessLog, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	if httpListener.Tracing != nil {
+		xdsCluster, err := buildXdsTracingCluster(httpListener.Tracing)
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds tracing cluster"))
+		}
+		res.shared.AddXdsResource(resource.ClusterType, xdsCluster)
+	}
+
+	for _, wasm := range httpListener.Wasm {
+		xdsCluster, err := buildXdsWasmCluster(wasm.URL)
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds wasm cluster"))
+		}
+		res.shared.AddXdsResource(resource.ClusterType, xdsCluster)
+	}
+
+	// Allocate virtual hosts for this httpListener.
+	// 1:1 between IR HTTPRoute hostname and xDS VirtualHost, routes without
+	// a hostname use a virtual host matching the httpListener hostnames.
+	routeName := getXdsRouteName(httpListener.Name)
+	var hostnames []string
+	routesByHostname := map[string][]*route.Route{}
+
+	containsBuffer := listenerContainsBuffer(httpListener)
+
+	for _, httpRoute := range httpListener.Routes {
+		// 1:1 between IR HTTPRoute and xDS config.route.v3.Route
+		xdsRoute, err := buildXdsRoute(httpRoute)
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds route"))
+		}
+		if containsBuffer {
+			// The buffer filter of the listener applies to all of its routes,
+			// so each route configures its own limit or disables the filter.
+			bufferAny, err := buildXdsBufferPerRouteConfig(httpRoute.Buffer)
 			if err != nil {
-				return nil, multierror.Append(err, errors.New("error building xds cluster"))
+				return nil, multierror.Append(err, errors.New("error building xds route buffer"))
 			}
-			tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+			if xdsRoute.TypedPerFilterConfig == nil {
+				xdsRoute.TypedPerFilterConfig = map[string]*anypb.Any{}
+			}
+			xdsRoute.TypedPerFilterConfig[bufferFilterName] = bufferAny
+		}
+		if _, ok := routesByHostname[httpRoute.Hostname]; !ok {
+			hostnames = append(hostnames, httpRoute.Hostname)
+		}
+		routesByHostname[httpRoute.Hostname] = append(routesByHostname[httpRoute.Hostname], xdsRoute)
+		if httpRoute.Upgrade != nil && httpRoute.Upgrade.Connect && xdsRoute.GetRoute() != nil {
+			routesByHostname[httpRoute.Hostname] = append(routesByHostname[httpRoute.Hostname], buildXdsConnectRoute(xdsRoute))
+		}
+
+		// Skip trying to build an IR cluster if the httpRoute only has invalid backends
+		if len(httpRoute.Destinations) == 0 && httpRoute.BackendWeights.Invalid > 0 {
+			continue
+		}
+		xdsCluster, err := buildXdsCluster(&xdsClusterArgs{
+			name:           httpRoute.Name,
+			destinations:   httpRoute.Destinations,
+			loadBalancer:   httpRoute.LoadBalancer,
+			circuitBreaker: httpRoute.CircuitBreaker,
+			healthCheck:    httpRoute.HealthCheck,
+			backendTLS:     httpRoute.BackendTLS,
+			tcpKeepalive:   httpRoute.TCPKeepalive,
+			http1:          httpListener.HTTP1,
+		})
+		if err != nil {
+			return nil, multierror.Append(err, errors.New("error building xds cluster"))
+		}
+		res.table.AddXdsResource(resource.ClusterType, xdsCluster)
 
+		// 1:1 between IR BackendTLSConfig CA bundle and xDS Secret
+		if httpRoute.BackendTLS != nil && len(httpRoute.BackendTLS.CACertificate) > 0 {
+			res.table.AddXdsResource(resource.SecretType, buildXdsCASecret(httpRoute.Name, httpRoute.BackendTLS.CACertificate, nil))
 		}
+	}
 
-		xdsRouteCfg := &route.RouteConfiguration{
-			Name: routeName,
+	var vHosts []*route.VirtualHost
+	for _, hostname := range hostnames {
+		vHost := buildXdsVirtualHost(routeName, hostname, httpListener.Hostnames)
+		vHost.Routes = routesByHostname[hostname]
+		// Envoy selects a single virtual host per request, so the virtual host
+		// of a hostname also holds the routes of the wildcard hostnames covering it.
+		for _, wildcard := range coveringWildcardHostnames(hostname, hostnames) {
+			vHost.Routes = append(vHost.Routes, routesByHostname[wildcard]...)
 		}
-		xdsRouteCfg.VirtualHosts = append(xdsRouteCfg.VirtualHosts, vHost)
+		vHosts = append(vHosts, vHost)
+	}
 
-		tCtx.AddXdsResource(resource.ListenerType, xdsListener)
-		tCtx.AddXdsResource(resource.RouteType, xdsRouteCfg)
+	if len(vHosts) == 0 {
+		vHosts = append(vHosts, buildXdsVirtualHost(routeName, "", httpListener.Hostnames))
 	}
 
-	for _, tcpListener := range ir.TCP {
-		// 1:1 between IR TCPListener and xDS Cluster
-		xdsCluster, err := buildXdsCluster(tcpListener.Name, tcpListener.Destinations)
+	// The headers of the listener apply to all its routes, so they are added
+	// by every virtual host.
+	for _, vHost := range vHosts {
+		if len(httpListener.AddRequestHeaders) > 0 {
+			vHost.RequestHeadersToAdd = buildXdsAddedHeaders(httpListener.AddRequestHeaders)
+		}
+		if len(httpListener.AddResponseHeaders) > 0 {
+			vHost.ResponseHeadersToAdd = buildXdsAddedHeaders(httpListener.AddResponseHeaders)
+		}
+		if len(httpListener.RemoveResponseHeaders) > 0 {
+			vHost.ResponseHeadersToRemove = httpListener.RemoveResponseHeaders
+		}
+	}
+
+	xdsRouteCfg := &route.RouteConfiguration{
+		Name:         routeName,
+		VirtualHosts: vHosts,
+	}
+
+	res.table.AddXdsResource(resource.ListenerType, xdsListener)
+
+	// The HTTP/3 listener shares the route configuration of the httpListener,
+	// which advertises it to clients in the alt-svc header.
+	if httpListener.HTTP3 != nil {
+		quicListener, err := buildXdsQUICListener(httpListener)
 		if err != nil {
-			return nil, multierror.Append(err, errors.New("error building xds cluster"))
+			return nil, multierror.Append(err, errors.New("error building xds quic listener"))
 		}
-		tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+		res.table.AddXdsResource(resource.ListenerType, quicListener)
+		xdsRouteCfg.ResponseHeadersToAdd = append(xdsRouteCfg.ResponseHeadersToAdd, buildXdsAltSvcHeader(httpListener.HTTP3))
+	}
 
-		// 1:1 between IR TCPListener and xDS Listener
-		xdsListener, err := buildXdsTCPListener(xdsCluster.Name, tcpListener)
+	res.table.AddXdsResource(resource.RouteType, xdsRouteCfg)
+
+	return res, nil
+}
+
+// addXdsALSClusters adds the clusters of the access log services of the provided
+// access logs to the table, unless already added as tracked by added.
+func addXdsALSClusters(tCtx *types.ResourceVersionTable, accessLog *ir.AccessLog, added map[string]bool) error {
+	if accessLog == nil {
+		return nil
+	}
+	for _, als := range accessLog.ALS {
+		xdsCluster, err := buildXdsALSCluster(als)
 		if err != nil {
-			return nil, multierror.Append(err, errors.New("error building xds listener"))
+			return multierror.Append(err, errors.New("error building xds access log service cluster"))
 		}
+		if !added[xdsCluster.Name] {
+			added[xdsCluster.Name] = true
+			tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+		}
+	}
+	return nil
+}
 
-		tCtx.AddXdsResource(resource.ListenerType, xdsListener)
+// buildXdsVirtualHost returns a virtual host of the route configuration routeName,
+// matching hostname if specified, or else the provided listener hostnames.
+func buildXdsVirtualHost(routeName, hostname string, listenerHostnames []string) *route.VirtualHost {
+	if hostname == "" {
+		return &route.VirtualHost{
+			Name:    routeName,
+			Domains: listenerHostnames,
+		}
 	}
-	return tCtx, nil
+	return &route.VirtualHost{
+		Name:    fmt.Sprintf("%s-%s", routeName, hostname),
+		Domains: []string{hostname},
+	}
+}
+
+// coveringWildcardHostnames returns the wildcard hostnames of the provided hostnames
+// matching hostname, ordered from the most to the least specific.
+func coveringWildcardHostnames(hostname string, hostnames []string) []string {
+	if hostname == "" {
+		return nil
+	}
+
+	var wildcards []string
+	for _, wildcard := range hostnames {
+		if wildcard == hostname || !strings.HasPrefix(wildcard, "*") {
+			continue
+		}
+		suffix := strings.TrimPrefix(wildcard, "*")
+		if wildcard == "*" || (strings.HasSuffix(hostname, suffix) && len(hostname) > len(suffix)) {
+			wildcards = append(wildcards, wildcard)
+		}
+	}
+
+	// A longer wildcard hostname is more specific.
+	sort.SliceStable(wildcards, func(i, j int) bool {
+		return len(wildcards[i]) > len(wildcards[j])
+	})
+	return wildcards
 }
 
 func getXdsRouteName(listenerName string) string {
@@ -108,29 +403,39 @@ func getXdsListenerName(listenerName string, listenerPort uint32) string {
 	return fmt.Sprintf("listener_%s_%d", listenerName, listenerPort)
 }
 
+func getXdsQUICListenerName(listenerName string, listenerPort uint32) string {
+	return fmt.Sprintf("quic_listener_%s_%d", listenerName, listenerPort)
+}
+
 func getXdsSecretName(listenerName string) string {
 	return fmt.Sprintf("secret_%s", listenerName)
 }
 
+// getXdsTLSSecretName returns the name of the secret serving the certificate of
+// the provided listener over SDS.
+func getXdsTLSSecretName(listenerName string, tlsConfig *ir.TLSListenerConfig) string {
+	if tlsConfig.SecretName != "" {
+		return getXdsSecretName(tlsConfig.SecretName)
+	}
+	return getXdsSecretName(listenerName)
+}
+
+func getXdsCASecretName(routeName string) string {
+	return fmt.Sprintf("ca_secret_%s", routeName)
+}
+
 func getXdsClusterName(routeName string) string {
 	return fmt.Sprintf("cluster_%s", routeName)
 }
 
 // Point to xds cluster.
 func makeConfigSource() *core.ConfigSource {
-	source := &core.ConfigSource{}
-	source.ResourceApiVersion = resource.DefaultAPIVersion
-	source.ConfigSourceSpecifier = &core.ConfigSource_ApiConfigSource{
-		ApiConfigSource: &core.ApiConfigSource{
-			TransportApiVersion:       resource.DefaultAPIVersion,
-			ApiType:                   core.ApiConfigSource_DELTA_GRPC,
-			SetNodeOnFirstMessageOnly: true,
-			GrpcServices: []*core.GrpcService{{
-				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
-					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: "xds_cluster"},
-				},
-			}},
+	// The resources are fetched on the delta ADS stream of the bootstrap
+	// configuration, so that Envoy receives only the changed resources.
+	return &core.ConfigSource{
+		ResourceApiVersion: resource.DefaultAPIVersion,
+		ConfigSourceSpecifier: &core.ConfigSource_Ads{
+			Ads: &core.AggregatedConfigSource{},
 		},
 	}
-	return source
 }
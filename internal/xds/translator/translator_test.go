@@ -3,16 +3,21 @@ package translator
 import (
 	"bytes"
 	"embed"
+	"fmt"
 	"path/filepath"
 	"testing"
 
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
@@ -34,6 +39,12 @@ func TestTranslate(t *testing.T) {
 		{
 			name: "http-route",
 		},
+		{
+			name: "http-route-ipv6",
+		},
+		{
+			name: "http-route-hostnames",
+		},
 		{
 			name: "http-route-redirect",
 		},
@@ -46,10 +57,123 @@ func TestTranslate(t *testing.T) {
 		{
 			name: "http-route-weighted-invalid-backend",
 		},
+		{
+			name: "http-route-timeout",
+		},
+		{
+			name: "http-route-retry",
+		},
+		{
+			name: "http-route-consistent-hash",
+		},
+		{
+			name: "http-route-load-balancer",
+		},
+		{
+			name: "http-route-circuit-breaker",
+		},
+		{
+			name: "http-route-outlier-detection",
+		},
+		{
+			name: "http-route-health-check",
+		},
+		{
+			name: "http-route-http2",
+		},
+		{
+			name:           "http-route-backend-tls",
+			requireSecrets: true,
+		},
+		{
+			name:           "mutual-tls",
+			requireSecrets: true,
+		},
+		{
+			name:           "tls-params",
+			requireSecrets: true,
+		},
+		{
+			name:           "http3",
+			requireSecrets: true,
+		},
+		{
+			name: "client-ip-detection",
+		},
+		{
+			name: "connection-limit",
+		},
+		{
+			name: "client-timeouts",
+		},
+		{
+			name: "tcp-keepalive",
+		},
+		{
+			name: "http-route-local-rate-limit",
+		},
+		{
+			name: "http-route-global-rate-limit",
+		},
+		{
+			name: "compression",
+		},
+		{
+			name: "ip-access-control",
+		},
+		{
+			name: "wasm",
+		},
+		{
+			name: "lua",
+		},
+		{
+			name: "http-route-fault-injection",
+		},
+		{
+			name: "local-reply",
+		},
+		{
+			name: "http-route-grpc-json-transcoder",
+		},
+		{
+			name: "http-route-buffer",
+		},
+		{
+			name: "access-log",
+		},
+		{
+			name: "access-log-als",
+		},
+		{
+			name: "tracing",
+		},
+		{
+			name: "tracing-zipkin",
+		},
+		{
+			name: "http-route-response-headers",
+		},
+		{
+			name: "http1-header-case",
+		},
+		{
+			name: "server-header",
+		},
+		{
+			name: "http-route-upgrade",
+		},
+		{
+			name: "envoy-patch-policy",
+		},
 		{
 			name:           "simple-tls",
 			requireSecrets: true,
 		},
+		{
+			name:           "tls-shared-secret",
+			requireSecrets: true,
+		},
 		{
 			name: "tls-route-passthrough",
 		},
@@ -59,7 +183,13 @@ func TestTranslate(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			ir := requireXdsIRFromInputTestData(t, "xds-ir", tc.name+".yaml")
-			tCtx, err := Translate(ir)
+			tr := &Translator{
+				GlobalRateLimit: &GlobalRateLimitSettings{
+					ServiceHost: "envoy-ratelimit.envoy-gateway-system.svc.cluster.local",
+					ServicePort: 8081,
+				},
+			}
+			tCtx, err := tr.Translate(ir)
 			require.NoError(t, err)
 			listeners := tCtx.XdsResources[resource.ListenerType]
 			routes := tCtx.XdsResources[resource.RouteType]
@@ -75,6 +205,125 @@ func TestTranslate(t *testing.T) {
 	}
 }
 
+func TestTranslateGlobalRateLimitNotConfigured(t *testing.T) {
+	ir := requireXdsIRFromInputTestData(t, "xds-ir", "http-route-global-rate-limit.yaml")
+	_, err := new(Translator).Translate(ir)
+	require.Error(t, err)
+}
+
+func TestTranslateDeterministic(t *testing.T) {
+	for _, name := range []string{"http-route-hostnames", "tracing", "wasm", "access-log-als", "tls-shared-secret"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			translate := func(ir *ir.Xds) []byte {
+				tCtx, err := new(Translator).Translate(ir)
+				require.NoError(t, err)
+				var out []byte
+				for _, typ := range []resource.Type{resource.ListenerType, resource.RouteType, resource.ClusterType, resource.SecretType} {
+					data, err := marshalResourcesToJSON(tCtx.XdsResources[typ])
+					require.NoError(t, err)
+					out = append(out, data...)
+				}
+				return out
+			}
+
+			// Listeners translated in the reverse order produce the same resources.
+			ir := requireXdsIRFromInputTestData(t, "xds-ir", name+".yaml")
+			want := translate(ir)
+			for i, j := 0, len(ir.HTTP)-1; i < j; i, j = i+1, j-1 {
+				ir.HTTP[i], ir.HTTP[j] = ir.HTTP[j], ir.HTTP[i]
+			}
+			require.Equal(t, string(want), string(translate(ir)))
+		})
+	}
+}
+
+func TestTranslateManyListeners(t *testing.T) {
+	xdsIR := requireXdsIRFromInputTestData(t, "xds-ir", "access-log-als.yaml")
+	listener := xdsIR.HTTP[0]
+	xdsIR.HTTP = nil
+	for i := 0; i < 100; i++ {
+		l := listener.DeepCopy()
+		l.Name = fmt.Sprintf("%s-%d", listener.Name, i)
+		l.Port = listener.Port + uint32(i)
+		for _, route := range l.Routes {
+			route.Name = fmt.Sprintf("%s-%d", route.Name, i)
+		}
+		xdsIR.HTTP = append(xdsIR.HTTP, l)
+	}
+
+	tCtx, err := new(Translator).Translate(xdsIR)
+	require.NoError(t, err)
+	// The TCP listener has a listener and a cluster too.
+	require.Len(t, tCtx.XdsResources[resource.ListenerType], 101)
+	require.Len(t, tCtx.XdsResources[resource.RouteType], 100)
+	// The cluster of the access log service shared by the listeners is only
+	// added once.
+	names := map[string]bool{}
+	for _, cluster := range tCtx.XdsResources[resource.ClusterType] {
+		name := cachev3.GetResourceName(cluster)
+		require.False(t, names[name], "duplicate cluster %s", name)
+		names[name] = true
+	}
+	require.Len(t, names, 102)
+
+	// A listener failing to translate fails the translation.
+	xdsIR.HTTP[50].Wasm = []*ir.Wasm{{Name: "invalid", URL: "https://example.com:99999/module.wasm"}}
+	_, err = new(Translator).Translate(xdsIR)
+	require.Error(t, err)
+}
+
+func TestTranslateSecretRotation(t *testing.T) {
+	translate := func(cert string) map[resource.Type][]types.Resource {
+		ir := requireXdsIRFromInputTestData(t, "xds-ir", "tls-shared-secret.yaml")
+		for _, listener := range ir.HTTP {
+			listener.TLS.ServerCertificate = []byte(cert)
+		}
+		tCtx, err := new(Translator).Translate(ir)
+		require.NoError(t, err)
+		return tCtx.XdsResources
+	}
+	before, after := translate("cert-data"), translate("rotated-cert-data")
+
+	// Rotating the certificate only updates the secret served over SDS.
+	for _, typ := range []resource.Type{resource.ListenerType, resource.RouteType, resource.ClusterType} {
+		require.Equal(t, requireResourcesToYAMLString(t, before[typ]), requireResourcesToYAMLString(t, after[typ]))
+	}
+	require.Len(t, after[resource.SecretType], 1)
+	require.NotEqual(t, requireResourcesToYAMLString(t, before[resource.SecretType]), requireResourcesToYAMLString(t, after[resource.SecretType]))
+}
+
+func TestTranslateEnvoyPatchPolicyStatus(t *testing.T) {
+	ir := requireXdsIRFromInputTestData(t, "xds-ir", "envoy-patch-policy.yaml")
+	_, err := new(Translator).Translate(ir)
+	require.NoError(t, err)
+
+	programmed := func(policy int) *metav1.Condition {
+		require.NotNil(t, ir.EnvoyPatchPolicies[policy].Status)
+		require.True(t, meta.IsStatusConditionTrue(ir.EnvoyPatchPolicies[policy].Status.Conditions, string(v1alpha1.EnvoyPatchPolicyConditionAccepted)))
+		return meta.FindStatusCondition(ir.EnvoyPatchPolicies[policy].Status.Conditions, string(v1alpha1.EnvoyPatchPolicyConditionProgrammed))
+	}
+	require.Equal(t, metav1.ConditionTrue, programmed(0).Status)
+	require.Equal(t, metav1.ConditionFalse, programmed(1).Status)
+	require.Equal(t, string(v1alpha1.EnvoyPatchPolicyReasonInvalid), programmed(1).Reason)
+	require.Contains(t, programmed(1).Message, "cluster_second-route")
+}
+
+func TestBuildRateLimitServiceConfigs(t *testing.T) {
+	ir := requireXdsIRFromInputTestData(t, "xds-ir", "http-route-global-rate-limit.yaml")
+	configs, err := BuildRateLimitServiceConfigs(ir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"first-listener": requireTestDataOutFile(t, "ratelimit", "http-route-global-rate-limit.yaml"),
+	}, configs)
+
+	// Listeners without global rate limits have no configuration.
+	ir = requireXdsIRFromInputTestData(t, "xds-ir", "http-route-local-rate-limit.yaml")
+	configs, err = BuildRateLimitServiceConfigs(ir)
+	require.NoError(t, err)
+	require.Empty(t, configs)
+}
+
 func requireXdsIRFromInputTestData(t *testing.T, name ...string) *ir.Xds {
 	t.Helper()
 	elems := append([]string{"testdata", "in"}, name...)
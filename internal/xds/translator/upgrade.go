@@ -0,0 +1,88 @@
+package translator
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// webSocketUpgradeType is the upgrade type of the WebSocket upgrades.
+	webSocketUpgradeType = "websocket"
+	// connectUpgradeType is the upgrade type of the CONNECT requests.
+	connectUpgradeType = "CONNECT"
+)
+
+// listenerUpgrades returns whether any route of the provided listener allows
+// WebSocket upgrades and CONNECT requests, respectively.
+func listenerUpgrades(httpListener *ir.HTTPListener) (bool, bool) {
+	var webSocket, connect bool
+	for _, route := range httpListener.Routes {
+		if route.Upgrade != nil {
+			webSocket = webSocket || route.Upgrade.WebSocket
+			connect = connect || route.Upgrade.Connect
+		}
+	}
+	return webSocket, connect
+}
+
+// buildXdsUpgradeConfigs configures the upgrades of the HTTP connection manager.
+// Upgrades are disabled by default, and enabled by the routes allowing them.
+func buildXdsUpgradeConfigs(mgr *hcm.HttpConnectionManager, httpListener *ir.HTTPListener) {
+	webSocket, connect := listenerUpgrades(httpListener)
+	if webSocket {
+		mgr.UpgradeConfigs = append(mgr.UpgradeConfigs, &hcm.HttpConnectionManager_UpgradeConfig{
+			UpgradeType: webSocketUpgradeType,
+			Enabled:     wrapperspb.Bool(false),
+		})
+	}
+	if connect {
+		mgr.UpgradeConfigs = append(mgr.UpgradeConfigs, &hcm.HttpConnectionManager_UpgradeConfig{
+			UpgradeType: connectUpgradeType,
+			Enabled:     wrapperspb.Bool(false),
+		})
+	}
+	// HTTP/2 WebSocket upgrades use extended CONNECT requests.
+	if webSocket || connect {
+		mgr.Http2ProtocolOptions = &core.Http2ProtocolOptions{AllowConnect: true}
+	}
+}
+
+// buildXdsRouteUpgradeConfigs builds the upgrade configs of the route action enabling
+// the provided upgrades. CONNECT requests are terminated by Envoy, which tunnels their
+// payload to the destinations.
+func buildXdsRouteUpgradeConfigs(upgrade *ir.Upgrade) []*route.RouteAction_UpgradeConfig {
+	var configs []*route.RouteAction_UpgradeConfig
+	if upgrade.WebSocket {
+		configs = append(configs, &route.RouteAction_UpgradeConfig{
+			UpgradeType: webSocketUpgradeType,
+			Enabled:     wrapperspb.Bool(true),
+		})
+	}
+	if upgrade.Connect {
+		configs = append(configs, &route.RouteAction_UpgradeConfig{
+			UpgradeType:   connectUpgradeType,
+			Enabled:       wrapperspb.Bool(true),
+			ConnectConfig: &route.RouteAction_UpgradeConfig_ConnectConfig{},
+		})
+	}
+	return configs
+}
+
+// buildXdsConnectRoute builds the route matching the CONNECT requests of the provided
+// route. CONNECT requests have no path, so they only match routes with a connect
+// matcher, which keep the header matches of the route.
+func buildXdsConnectRoute(xdsRoute *route.Route) *route.Route {
+	connectRoute := proto.Clone(xdsRoute).(*route.Route)
+	connectRoute.Match = &route.RouteMatch{
+		PathSpecifier: &route.RouteMatch_ConnectMatcher_{
+			ConnectMatcher: &route.RouteMatch_ConnectMatcher{},
+		},
+		Headers: xdsRoute.GetMatch().GetHeaders(),
+	}
+	return connectRoute
+}
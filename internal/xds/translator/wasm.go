@@ -0,0 +1,153 @@
+package translator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	wasmfilter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/wasm/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// wasmFilterName is the name of the Wasm HTTP filter, suffixed with the name
+	// of the extension since a filter is added per extension.
+	wasmFilterName = "envoy.filters.http.wasm"
+	// wasmRuntime is the runtime running the Wasm modules.
+	wasmRuntime = "envoy.wasm.runtime.v8"
+	// wasmFetchTimeout is the timeout of the fetches of the Wasm modules.
+	wasmFetchTimeout = 10 * time.Second
+)
+
+// buildXdsWasmFilters builds a Wasm HTTP filter per Wasm extension, in order.
+func buildXdsWasmFilters(wasms []*ir.Wasm) ([]*hcm.HttpFilter, error) {
+	filters := make([]*hcm.HttpFilter, 0, len(wasms))
+	for _, w := range wasms {
+		clusterName, _, _, err := getWasmCluster(w.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		pluginConfig := &wasm.PluginConfig{
+			Name:   w.Name,
+			RootId: w.RootID,
+			Vm: &wasm.PluginConfig_VmConfig{
+				VmConfig: &wasm.VmConfig{
+					VmId:    w.Name,
+					Runtime: wasmRuntime,
+					Code: &core.AsyncDataSource{
+						Specifier: &core.AsyncDataSource_Remote{
+							Remote: &core.RemoteDataSource{
+								HttpUri: &core.HttpUri{
+									Uri: w.URL,
+									HttpUpstreamType: &core.HttpUri_Cluster{
+										Cluster: clusterName,
+									},
+									Timeout: durationpb.New(wasmFetchTimeout),
+								},
+								Sha256: w.SHA256,
+							},
+						},
+					},
+				},
+			},
+			FailOpen: w.FailOpen,
+		}
+		if w.Config != "" {
+			// A StringValue configuration is passed to the plugin as is.
+			configAny, err := anypb.New(wrapperspb.String(w.Config))
+			if err != nil {
+				return nil, err
+			}
+			pluginConfig.Configuration = configAny
+		}
+
+		wasmAny, err := anypb.New(&wasmfilter.Wasm{Config: pluginConfig})
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, &hcm.HttpFilter{
+			Name:       fmt.Sprintf("%s.%s", wasmFilterName, w.Name),
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: wasmAny},
+		})
+	}
+	return filters, nil
+}
+
+// buildXdsWasmCluster builds the cluster fetching the Wasm modules served at the
+// host of the provided URL. The certificate of an https server is verified against
+// the system CA bundle.
+func buildXdsWasmCluster(wasmURL string) (*cluster.Cluster, error) {
+	clusterName, host, port, err := getWasmCluster(wasmURL)
+	if err != nil {
+		return nil, err
+	}
+
+	xdsCluster := &cluster.Cluster{
+		Name:                 clusterName,
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
+		DnsLookupFamily:      cluster.Cluster_V4_PREFERRED,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*endpoint.LbEndpoint{{
+					HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+						Endpoint: &endpoint.Endpoint{
+							Address: buildXdsSocketAddress(host, port, core.SocketAddress_TCP),
+						},
+					},
+				}},
+			}},
+		},
+	}
+
+	if u, _ := url.Parse(wasmURL); u.Scheme == "https" {
+		tSocket, err := buildXdsUpstreamTLSSocket(clusterName, &ir.BackendTLSConfig{
+			SNI:             host,
+			SubjectAltNames: []string{host},
+		})
+		if err != nil {
+			return nil, err
+		}
+		xdsCluster.TransportSocket = tSocket
+	}
+
+	return xdsCluster, nil
+}
+
+// getWasmCluster returns the name of the cluster fetching the Wasm modules served
+// at the host of the provided URL, along with the host and port.
+func getWasmCluster(wasmURL string) (string, string, uint32, error) {
+	u, err := url.Parse(wasmURL)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	var port uint32
+	switch {
+	case u.Port() != "":
+		p, err := strconv.ParseUint(u.Port(), 10, 16)
+		if err != nil {
+			return "", "", 0, fmt.Errorf("invalid port in wasm url %s: %w", wasmURL, err)
+		}
+		port = uint32(p)
+	case u.Scheme == "https":
+		port = 443
+	default:
+		port = 80
+	}
+
+	return fmt.Sprintf("wasm_%s_%s_%d", u.Scheme, u.Hostname(), port), u.Hostname(), port, nil
+}
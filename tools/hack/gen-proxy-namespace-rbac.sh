@@ -0,0 +1,53 @@
+#!/usr/bin/env bash
+
+# Generates the Namespace, Role and RoleBinding permitting Envoy Gateway to manage
+# the Envoy resources in each of the provided proxy namespaces.
+#
+# Usage: gen-proxy-namespace-rbac.sh <infra rbac dir> <output file> <namespace>...
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+readonly KUSTOMIZE=${KUSTOMIZE:-tools/bin/kustomize}
+readonly ENVOY_GATEWAY_NAMESPACE=${ENVOY_GATEWAY_NAMESPACE:-"envoy-gateway-system"}
+
+readonly RBAC_DIR="$1"
+readonly OUTPUT="$2"
+shift 2
+
+: > "${OUTPUT}"
+
+for ns in "$@" ; do
+  dir=$(mktemp -d)
+  cp "${RBAC_DIR}/role.yaml" "${RBAC_DIR}/role_binding.yaml" "${dir}"
+
+  cat > "${dir}/namespace.yaml" <<EOT
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ${ns}
+EOT
+
+  # The subject of the RoleBinding stays the Envoy Gateway ServiceAccount.
+  cat > "${dir}/kustomization.yaml" <<EOT
+namespace: ${ns}
+resources:
+- namespace.yaml
+- role.yaml
+- role_binding.yaml
+patches:
+- target:
+    kind: RoleBinding
+    name: infra-manager
+  patch: |-
+    - op: replace
+      path: /subjects/0/namespace
+      value: ${ENVOY_GATEWAY_NAMESPACE}
+EOT
+
+  echo "Generating RBAC for proxy namespace ${ns}..."
+  echo "---" >> "${OUTPUT}"
+  ${KUSTOMIZE} build "${dir}" >> "${OUTPUT}"
+  rm -rf "${dir}"
+done
@@ -39,6 +39,10 @@ go.test.unit: ## Run go unit tests
 go.test.coverage: $(tools/setup-envtest) ## Run go unit and integration tests in GitHub Actions
 	KUBEBUILDER_ASSETS="$(shell $(tools/setup-envtest) use $(ENVTEST_K8S_VERSION) -p path)" go test ./... --tags=integration -race -coverprofile=coverage.xml -covermode=atomic
 
+.PHONY: go.test.benchmark
+go.test.benchmark: ## Run go benchmarks of the translation and snapshot generation
+	go test ./internal/gatewayapi/... ./internal/xds/... -run '^$$' -bench . -benchmem
+
 .PHONY: go.clean
 go.clean: ## Clean the building output files
 	@$(call log, "Cleaning all build output")
@@ -70,6 +74,10 @@ build-multiarch: go.build.multiarch
 test: ## Run all Go test of code sources.
 test: go.test.unit
 
+.PHONY: benchmark
+benchmark: ## Run the Go benchmarks of the translation and snapshot generation.
+benchmark: go.test.benchmark
+
 .PHONY: format
 format: ## Update dependences with mod tidy.
 format: go.tidy
@@ -18,6 +18,11 @@ ifeq ($(origin KUBE_INFRA_DIR),undefined)
 KUBE_INFRA_DIR := $(ROOT_DIR)/internal/infrastructure/kubernetes/config
 endif
 
+# PROXY_NAMESPACES are the custom namespaces of the managed Envoy resources selected
+# by EnvoyProxy resources, separated by spaces. Envoy Gateway is only permitted to
+# manage the Envoy resources in its own namespace and in these namespaces.
+PROXY_NAMESPACES ?=
+
 ##@ Kubernetes Development
 
 .PHONY: manifests
@@ -101,6 +106,10 @@ generate-manifests: $(tools/kustomize) ## Generate Kubernetes release manifests.
 	touch $(OUTPUT_DIR)/kustomization.yaml
 	cd $(OUTPUT_DIR) && $(ROOT_DIR)/$(tools/kustomize) edit add resource ./envoy-gateway.yaml
 	cd $(OUTPUT_DIR) && $(ROOT_DIR)/$(tools/kustomize) edit add resource ./infra-manager-rbac.yaml
+ifneq ($(strip $(PROXY_NAMESPACES)),)
+	KUSTOMIZE=$(tools/kustomize) tools/hack/gen-proxy-namespace-rbac.sh $(OUTPUT_DIR)/manifests/infra/config/rbac $(OUTPUT_DIR)/proxy-namespaces-rbac.yaml $(PROXY_NAMESPACES)
+	cd $(OUTPUT_DIR) && $(ROOT_DIR)/$(tools/kustomize) edit add resource ./proxy-namespaces-rbac.yaml
+endif
 	cd $(OUTPUT_DIR) && $(ROOT_DIR)/$(tools/kustomize) edit add resource ./gatewayapi-crds.yaml
 	$(tools/kustomize) build $(OUTPUT_DIR) > $(OUTPUT_DIR)/install.yaml
 	@echo "\033[36m===========> Added: $(OUTPUT_DIR)/install.yaml\033[0m"